@@ -0,0 +1,120 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// import-manifest emits the Azure resource IDs and the suggested machine objects of the existing VMs of a machine
+// class, e.g. to restore the machine objects after the state of the machine controller manager was lost while the
+// Azure resources survived.
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	cp "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/spi"
+	"github.com/gardener/machine-controller-manager/pkg/client/clientset/versioned"
+	"github.com/spf13/pflag"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/component-base/cli/flag"
+	"k8s.io/component-base/logs"
+	"sigs.k8s.io/yaml"
+)
+
+func main() {
+	var (
+		machineClass string
+		outputFile   string
+	)
+	pflag.CommandLine.StringVar(&machineClass, "machine-class", machineClass, "Name of the machine class whose existing VMs are exported")
+	pflag.CommandLine.StringVar(&outputFile, "output", outputFile, "File the import manifest is written to as YAML. It is written to stdout if empty")
+
+	o := cp.NewDriverOptions()
+	o.AddFlags(pflag.CommandLine)
+	pflag.CommandLine.StringVar(&o.ControlKubeconfig, "control-kubeconfig", o.ControlKubeconfig, "Path to the kubeconfig of the cluster the machine classes are stored in")
+	pflag.CommandLine.StringVar(&o.Namespace, "namespace", o.Namespace, "Namespace of the machine classes in the control cluster")
+
+	flag.InitFlags()
+	spi.SyncLogFlags()
+	logs.InitLogs()
+	defer logs.FlushLogs()
+
+	if err := run(o, machineClass, outputFile); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(o *cp.DriverOptions, machineClassName, outputFile string) error {
+	if machineClassName == "" {
+		return fmt.Errorf("--machine-class is required")
+	}
+
+	// The background loops of the driver are stopped once the run is finished
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	o.StopCh = stopCh
+
+	driver := cp.NewAzureDriver(&spi.PluginSPIImpl{})
+	if err := o.ApplyTo(driver); err != nil {
+		return err
+	}
+
+	config, err := clientcmd.BuildConfigFromFlags("", o.ControlKubeconfig)
+	if err != nil {
+		return fmt.Errorf("Could not load control kubeconfig: %v", err)
+	}
+	machineClient, err := versioned.NewForConfig(config)
+	if err != nil {
+		return fmt.Errorf("Could not create machine client: %v", err)
+	}
+	client, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return fmt.Errorf("Could not create control cluster client: %v", err)
+	}
+
+	machineClass, err := machineClient.MachineV1alpha1().MachineClasses(o.Namespace).Get(machineClassName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("Could not get machine class %q: %v", machineClassName, err)
+	}
+	// The credentials secret takes precedence over the secret, as done by the machine controller manager
+	secret := &corev1.Secret{Data: map[string][]byte{}}
+	for _, ref := range []*corev1.SecretReference{machineClass.SecretRef, machineClass.CredentialsSecretRef} {
+		if ref == nil {
+			continue
+		}
+		s, err := client.CoreV1().Secrets(ref.Namespace).Get(ref.Name, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("Could not get secret %s/%s: %v", ref.Namespace, ref.Name, err)
+		}
+		for key, value := range s.Data {
+			secret.Data[key] = value
+		}
+	}
+
+	manifest, err := driver.ExportImportManifest(context.Background(), &cp.ImportManifestRequest{MachineClass: machineClass, Secret: secret})
+	if err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(manifest)
+	if err != nil {
+		return fmt.Errorf("Could not encode import manifest: %v", err)
+	}
+
+	if outputFile == "" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	if err := ioutil.WriteFile(outputFile, data, 0644); err != nil {
+		return fmt.Errorf("Could not write import manifest: %v", err)
+	}
+	spi.InfoS(context.Background(), "Import manifest was written", spi.LogKeyMachineClass, machineClassName, "machines", len(manifest.Machines), "file", outputFile)
+	return nil
+}
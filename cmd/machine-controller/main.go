@@ -33,11 +33,25 @@ func main() {
 	s := options.NewMCServer()
 	s.AddFlags(pflag.CommandLine)
 
+	o := cp.NewDriverOptions()
+	o.AddFlags(pflag.CommandLine)
+
 	flag.InitFlags()
+	spi.SyncLogFlags()
 	logs.InitLogs()
 	defer logs.FlushLogs()
 
 	driver := cp.NewAzureDriver(&spi.PluginSPIImpl{})
+	o.TargetKubeconfig = s.TargetKubeconfig
+	o.ControlKubeconfig = s.ControlKubeconfig
+	o.Namespace = s.Namespace
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	o.StopCh = stopCh
+	if err := o.ApplyTo(driver); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
 
 	if err := app.Run(s, driver); err != nil {
 		fmt.Fprintf(os.Stderr, "%v\n", err)
@@ -0,0 +1,162 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// reconcile-tags reconciles the tags of the existing machines of a machine class with the tags of its provider spec
+// in rate-limited batches. The progress is stored in a state file, so that an interrupted run is resumed.
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	cp "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/spi"
+	"github.com/gardener/machine-controller-manager/pkg/client/clientset/versioned"
+	"github.com/spf13/pflag"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/component-base/cli/flag"
+	"k8s.io/component-base/logs"
+)
+
+func main() {
+	var (
+		machineClass      string
+		stateFile         string
+		batchSize         = 50
+		requestsPerSecond = float32(1)
+	)
+	pflag.CommandLine.StringVar(&machineClass, "machine-class", machineClass, "Name of the machine class whose machines are reconciled")
+	pflag.CommandLine.StringVar(&stateFile, "state-file", stateFile, "File storing the continuation token after the last reconciled machine, from which an interrupted run is resumed. The run starts from the beginning if empty or missing")
+	pflag.CommandLine.IntVar(&batchSize, "batch-size", batchSize, "Number of machines reconciled per batch, after which the progress is stored")
+	pflag.CommandLine.Float32Var(&requestsPerSecond, "requests-per-second", requestsPerSecond, "Maximum rate of the Azure API requests. It is unlimited if zero")
+
+	o := cp.NewDriverOptions()
+	o.AddFlags(pflag.CommandLine)
+	pflag.CommandLine.StringVar(&o.ControlKubeconfig, "control-kubeconfig", o.ControlKubeconfig, "Path to the kubeconfig of the cluster the machine classes are stored in")
+	pflag.CommandLine.StringVar(&o.Namespace, "namespace", o.Namespace, "Namespace of the machine classes in the control cluster")
+
+	flag.InitFlags()
+	spi.SyncLogFlags()
+	logs.InitLogs()
+	defer logs.FlushLogs()
+
+	if err := run(o, machineClass, stateFile, batchSize, requestsPerSecond); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(o *cp.DriverOptions, machineClassName, stateFile string, batchSize int, requestsPerSecond float32) error {
+	if machineClassName == "" {
+		return fmt.Errorf("--machine-class is required")
+	}
+
+	// The background loops of the driver are stopped once the run is finished
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	o.StopCh = stopCh
+
+	driver := cp.NewAzureDriver(&spi.PluginSPIImpl{})
+	if err := o.ApplyTo(driver); err != nil {
+		return err
+	}
+
+	config, err := clientcmd.BuildConfigFromFlags("", o.ControlKubeconfig)
+	if err != nil {
+		return fmt.Errorf("Could not load control kubeconfig: %v", err)
+	}
+	machineClient, err := versioned.NewForConfig(config)
+	if err != nil {
+		return fmt.Errorf("Could not create machine client: %v", err)
+	}
+	client, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return fmt.Errorf("Could not create control cluster client: %v", err)
+	}
+
+	machineClass, err := machineClient.MachineV1alpha1().MachineClasses(o.Namespace).Get(machineClassName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("Could not get machine class %q: %v", machineClassName, err)
+	}
+	// The credentials secret takes precedence over the secret, as done by the machine controller manager
+	secret := &corev1.Secret{Data: map[string][]byte{}}
+	for _, ref := range []*corev1.SecretReference{machineClass.SecretRef, machineClass.CredentialsSecretRef} {
+		if ref == nil {
+			continue
+		}
+		s, err := client.CoreV1().Secrets(ref.Namespace).Get(ref.Name, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("Could not get secret %s/%s: %v", ref.Namespace, ref.Name, err)
+		}
+		for key, value := range s.Data {
+			secret.Data[key] = value
+		}
+	}
+
+	ctx := spi.WithLogFields(context.Background(), spi.LogKeyMachineClass, machineClassName)
+	continueAfter, err := readState(stateFile)
+	if err != nil {
+		return err
+	}
+	if continueAfter != "" {
+		spi.InfoS(ctx, "Resuming the reconciliation", "continue", continueAfter)
+	}
+
+	for {
+		response, err := driver.ReconcileTags(ctx, &cp.ReconcileTagsRequest{
+			MachineClass:      machineClass,
+			Secret:            secret,
+			Continue:          continueAfter,
+			BatchSize:         batchSize,
+			RequestsPerSecond: requestsPerSecond,
+		})
+		if response != nil {
+			for _, name := range response.Updated {
+				spi.InfoS(ctx, "Tags of machine were updated", "machine", name)
+			}
+			if stateErr := writeState(stateFile, response.Continue); stateErr != nil {
+				spi.ErrorS(ctx, stateErr, "Progress could not be stored", "file", stateFile)
+			}
+			continueAfter = response.Continue
+		}
+		if err != nil {
+			return err
+		}
+		if continueAfter == "" {
+			spi.InfoS(ctx, "Tags of all machines of machine class are reconciled")
+			return nil
+		}
+	}
+}
+
+// readState returns the continuation token from the state file, or an empty string if there is none
+func readState(stateFile string) (string, error) {
+	if stateFile == "" {
+		return "", nil
+	}
+	data, err := ioutil.ReadFile(stateFile)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("Could not read state file: %v", err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// writeState stores the continuation token in the state file
+func writeState(stateFile, continueAfter string) error {
+	if stateFile == "" {
+		return nil
+	}
+	return ioutil.WriteFile(stateFile, []byte(continueAfter), 0644)
+}
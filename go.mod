@@ -3,9 +3,9 @@ module github.com/gardener/machine-controller-manager-provider-azure
 go 1.13
 
 require (
-	github.com/Azure/azure-sdk-for-go v42.2.0+incompatible
-	github.com/Azure/go-autorest/autorest v0.10.1
-	github.com/Azure/go-autorest/autorest/adal v0.8.2
+	github.com/Azure/azure-sdk-for-go v68.0.0+incompatible
+	github.com/Azure/go-autorest/autorest v0.11.29
+	github.com/Azure/go-autorest/autorest/adal v0.9.23
 	github.com/Azure/go-autorest/autorest/to v0.3.0
 	github.com/gardener/machine-controller-manager v0.36.0
 	github.com/golang/mock v1.4.4
@@ -14,13 +14,13 @@ require (
 	github.com/onsi/gomega v1.9.0
 	github.com/prometheus/client_golang v1.5.1
 	github.com/spf13/pflag v1.0.5
-	github.com/stretchr/testify v1.5.1 // indirect
-	golang.org/x/net v0.0.0-20200202094626-16171245cfb2 // indirect
 	k8s.io/api v0.16.8
 	k8s.io/apimachinery v0.16.8
+	k8s.io/client-go v0.16.8
+	k8s.io/cluster-bootstrap v0.0.0-20190918163108-da9fdfce26bb
 	k8s.io/component-base v0.16.8
-	k8s.io/klog v1.0.0
-	sigs.k8s.io/yaml v1.2.0 // indirect
+	k8s.io/klog/v2 v2.10.0
+	sigs.k8s.io/yaml v1.2.0
 )
 
 replace (
@@ -0,0 +1,52 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-08-01/compute"
+	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/spi"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/codes"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/status"
+)
+
+// existingVM returns the VM with the name of the machine if it exists already and can be adopted, e.g. as a previous
+// creation request timed out after Azure accepted the creation, or nil if the VM needs to be created. VMs without
+// the cluster and role tags of the machine class, or owned by another instance, are a genuine conflict, which is
+// reported with AlreadyExists, so that they are never cleaned up after a failed creation. VMs which are still being
+// provisioned are reported with Aborted, so that the creation is retried shortly.
+func (d *MachinePlugin) existingVM(ctx context.Context, clients spi.AzureDriverClientsInterface, providerSpec *api.AzureProviderSpec, vmName string) (*compute.VirtualMachine, error) {
+	vm, err := clients.GetVM().Get(ctx, providerSpec.ResourceGroup, vmName, "")
+	if err != nil {
+		if spi.NotFound(err) {
+			return nil, nil
+		}
+		return nil, spi.OnARMAPIErrorFail(prometheusServiceVM, err, "VM.Get failed for %s", vmName)
+	}
+	spi.OnARMAPISuccess(prometheusServiceVM, "VM.Get")
+
+	if !matchesClassTags(vm.Tags, providerSpec.Tags) || d.ownedByOtherInstance(vm.Tags) {
+		return nil, status.Error(codes.AlreadyExists, fmt.Sprintf("VM %q already exists, but does not belong to the machine class", vmName))
+	}
+
+	var provisioningState string
+	if vm.VirtualMachineProperties != nil && vm.ProvisioningState != nil {
+		provisioningState = *vm.ProvisioningState
+	}
+	switch provisioningState {
+	case "Succeeded":
+		return &vm, nil
+	case "Failed":
+		// The creation is repeated, which lets Azure reconcile the failed VM
+		return nil, nil
+	default:
+		return nil, status.Error(codes.Aborted, fmt.Sprintf("VM %q exists already and is in provisioning state %q", vmName, provisioningState))
+	}
+}
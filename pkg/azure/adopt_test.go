@@ -0,0 +1,82 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-08-01/compute"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/mock"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/codes"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/status"
+	gomock "github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Adopt", func() {
+	var (
+		ctx          = context.Background()
+		driver       *MachinePlugin
+		clients      *mock.AzureDriverClients
+		providerSpec *api.AzureProviderSpec
+
+		vm = func(provisioningState string, tags map[string]*string) compute.VirtualMachine {
+			return compute.VirtualMachine{
+				Name:                     to.StringPtr("machine"),
+				Tags:                     tags,
+				VirtualMachineProperties: &compute.VirtualMachineProperties{ProvisioningState: to.StringPtr(provisioningState)},
+			}
+		}
+		expectCode = func(err error, code codes.Code) {
+			s, ok := status.FromError(err)
+			Expect(ok).To(BeTrue())
+			Expect(s.Code()).To(Equal(code))
+		}
+	)
+
+	BeforeEach(func() {
+		sp := mock.NewMockPluginSPIImpl(gomock.NewController(GinkgoT()))
+		_, secret := newProviderSpecCacheFixtures()
+		driverClients, err := sp.Setup(secret, nil)
+		Expect(err).NotTo(HaveOccurred())
+		clients = driverClients.(*mock.AzureDriverClients)
+		driver = NewAzureDriver(sp)
+		providerSpec = UnmarshalProviderSpec(mock.AzureProviderSpec)
+	})
+
+	It("should create VMs which don't exist", func() {
+		clients.VM.EXPECT().Get(ctx, providerSpec.ResourceGroup, "machine", compute.InstanceViewTypes("")).Return(compute.VirtualMachine{}, autorest.DetailedError{Response: &http.Response{StatusCode: http.StatusNotFound}})
+
+		Expect(driver.existingVM(ctx, clients, providerSpec, "machine")).To(BeNil())
+	})
+
+	It("should adopt provisioned VMs of the machine class", func() {
+		existing := vm("Succeeded", getAzureTags(providerSpec.Tags))
+		clients.VM.EXPECT().Get(ctx, providerSpec.ResourceGroup, "machine", compute.InstanceViewTypes("")).Return(existing, nil)
+
+		Expect(driver.existingVM(ctx, clients, providerSpec, "machine")).To(Equal(&existing))
+	})
+
+	It("should retry the creation of VMs which are still provisioned", func() {
+		clients.VM.EXPECT().Get(ctx, providerSpec.ResourceGroup, "machine", compute.InstanceViewTypes("")).Return(vm("Creating", getAzureTags(providerSpec.Tags)), nil)
+
+		_, err := driver.existingVM(ctx, clients, providerSpec, "machine")
+		expectCode(err, codes.Aborted)
+	})
+
+	It("should report VMs of other clusters as a conflict", func() {
+		clients.VM.EXPECT().Get(ctx, providerSpec.ResourceGroup, "machine", compute.InstanceViewTypes("")).Return(vm("Succeeded", map[string]*string{"kubernetes.io-cluster-other": to.StringPtr("1")}), nil)
+
+		_, err := driver.existingVM(ctx, clients, providerSpec, "machine")
+		expectCode(err, codes.AlreadyExists)
+	})
+})
@@ -6,6 +6,14 @@ SPDX-License-Identifier: Apache-2.0
 
 package api
 
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
 const (
 	// AzureClientID is a constant for a key name that is part of the Azure cloud credentials.
 	AzureClientID string = "azureClientId"
@@ -27,19 +35,238 @@ const (
 	// AzureAlternativeTenantID is a constant for a key name of a secret containing the Azure credentials (tenant id).
 	AzureAlternativeTenantID = "tenantID"
 
+	// AzureWorkloadIdentityTokenFile is a constant for a key name of a secret containing the path to a projected
+	// service account token which is exchanged for an AAD token instead of using a client secret.
+	AzureWorkloadIdentityTokenFile = "workloadIdentityTokenFile"
+	// AzureWorkloadIdentityToken is a constant for a key name of a secret containing a service account token which
+	// is exchanged for an AAD token instead of using a client secret.
+	AzureWorkloadIdentityToken = "workloadIdentityToken"
+	// AzureUseManagedIdentity is a constant for a key name of a secret which opts in to authenticating with the managed
+	// identity of the provider if set to "true". The secret must contain neither a client id, a client secret nor a
+	// workload identity token then.
+	AzureUseManagedIdentity = "useManagedIdentity"
+	// AzureManagedIdentityClientID is a constant for a key name of a secret containing the client id of the user
+	// assigned managed identity the provider authenticates with if the secret opts in to the managed identity. The
+	// system assigned managed identity is used if it is empty.
+	AzureManagedIdentityClientID = "managedIdentityClientID"
+
 	// MachineSetKindAvailabilitySet is the machine set kind for AvailabilitySet
 	MachineSetKindAvailabilitySet string = "availabilityset"
 	// MachineSetKindVMO is the machine set kind for VirtualMachineScaleSet Orchestration Mode VM (VMO)
 	MachineSetKindVMO string = "vmo"
+
+	// CloudNameAzurePublic is the name of the Azure public cloud
+	CloudNameAzurePublic string = "AzurePublic"
+	// CloudNameAzureChina is the name of the Azure China cloud
+	CloudNameAzureChina string = "AzureChina"
+	// CloudNameAzureGovernment is the name of the Azure US Government cloud
+	CloudNameAzureGovernment string = "AzureGovernment"
+	// CloudNameAzureStackHub is the name of Azure Stack Hub clouds, whose endpoints must be configured
+	CloudNameAzureStackHub string = "AzureStackHub"
+
+	// APIProfile20190301Hybrid is the API profile of Azure Stack Hub 1904 and later
+	APIProfile20190301Hybrid string = "2019-03-01-hybrid"
+	// APIProfile20200901Hybrid is the API profile of Azure Stack Hub 2102 and later
+	APIProfile20200901Hybrid string = "2020-09-01-hybrid"
+
+	// PrivateIPAllocationMethodDynamic lets Azure assign the private IP address of a network interface
+	PrivateIPAllocationMethodDynamic string = "Dynamic"
+	// PrivateIPAllocationMethodStatic assigns a fixed private IP address to a network interface
+	PrivateIPAllocationMethodStatic string = "Static"
+
+	// PublicIPSKUBasic is the basic SKU of public IP addresses
+	PublicIPSKUBasic string = "Basic"
+	// PublicIPSKUStandard is the standard SKU of public IP addresses
+	PublicIPSKUStandard string = "Standard"
+
+	// LicenseTypeWindowsServer applies the Azure Hybrid Benefit to Windows Server VMs
+	LicenseTypeWindowsServer string = "Windows_Server"
+	// LicenseTypeWindowsClient applies the Azure Hybrid Benefit to Windows client VMs
+	LicenseTypeWindowsClient string = "Windows_Client"
+	// LicenseTypeRHELBYOS marks Red Hat Enterprise Linux VMs using an own subscription
+	LicenseTypeRHELBYOS string = "RHEL_BYOS"
+	// LicenseTypeSLESBYOS marks SUSE Linux Enterprise Server VMs using an own subscription
+	LicenseTypeSLESBYOS string = "SLES_BYOS"
+
+	// DataDiskCreateOptionEmpty creates an empty data disk
+	DataDiskCreateOptionEmpty string = "Empty"
+	// DataDiskCreateOptionAttach attaches an existing managed disk as data disk
+	DataDiskCreateOptionAttach string = "Attach"
+	// DataDiskCreateOptionFromImage creates a data disk from the data disk of the image with the same LUN
+	DataDiskCreateOptionFromImage string = "FromImage"
+
+	// CachingNone disables the host caching of a disk
+	CachingNone string = "None"
+	// CachingReadOnly enables the host caching of the reads of a disk
+	CachingReadOnly string = "ReadOnly"
+	// CachingReadWrite enables the host caching of the reads and writes of a disk
+	CachingReadWrite string = "ReadWrite"
+
+	// DataDiskDeleteOptionDelete deletes the data disk along with the VM
+	DataDiskDeleteOptionDelete string = "Delete"
+	// DataDiskDeleteOptionDetach detaches the data disk and keeps it when the VM is deleted
+	DataDiskDeleteOptionDetach string = "Detach"
+
+	// OSTypeLinux is the OS type of Linux VMs
+	OSTypeLinux string = "Linux"
+	// OSTypeWindows is the OS type of Windows VMs
+	OSTypeWindows string = "Windows"
+	// WindowsAdminPasswordSecretKey is the default key of the machine class secret containing the password of the
+	// admin user of Windows VMs
+	WindowsAdminPasswordSecretKey = "adminPassword"
+	// WinRMProtocolHTTP is the protocol of unencrypted WinRM listeners
+	WinRMProtocolHTTP string = "Http"
+	// WinRMProtocolHTTPS is the protocol of encrypted WinRM listeners
+	WinRMProtocolHTTPS string = "Https"
+
+	// SecurityTypeTrustedLaunch protects the VM with secure boot and a virtual TPM
+	SecurityTypeTrustedLaunch string = "TrustedLaunch"
+	// SecurityTypeConfidentialVM runs the VM on confidential computing hardware
+	SecurityTypeConfidentialVM string = "ConfidentialVM"
+
+	// DiskControllerTypeSCSI attaches the disks of the VM with a SCSI controller
+	DiskControllerTypeSCSI string = "SCSI"
+	// DiskControllerTypeNVMe attaches the disks of the VM with an NVMe controller, which requires a VM size supporting it
+	DiskControllerTypeNVMe string = "NVMe"
+
+	// NamingStrategySuffix is the default naming strategy of the machine resources, appending their type to the VM
+	// name, e.g. <vm>-nic and <vm>-os-disk
+	NamingStrategySuffix string = "suffix"
+	// NamingStrategyPrefix is the naming strategy of the machine resources prepending their type to the VM name, e.g.
+	// nic-<vm> and osdisk-<vm>, following the abbreviations recommended by Azure
+	NamingStrategyPrefix string = "prefix"
+
+	// MachineAnnotationPrivateIPAddressPool is the annotation of a machine carrying a comma-separated list of
+	// private IP addresses. The primary network interface gets the first address which is not in use.
+	MachineAnnotationPrivateIPAddressPool = "azure.machine.sapcloud.io/private-ip-address-pool"
+	// MachineAnnotationIPHandoffGroup is the annotation of a machine naming its IP handoff group. When a machine of
+	// the group is deleted, the next machine of the group created within a short time takes over its private IP
+	// address and the DNS label of its public IP. The group must be a DNS label and is scoped to the machine class.
+	// The handoff is kept in memory by the controller only, so it requires replacing the machines without surge and
+	// within the same machine class, and addresses released before a restart of the controller are not handed off.
+	MachineAnnotationIPHandoffGroup = "azure.machine.sapcloud.io/ip-handoff-group"
+	// MachineAnnotationHandOverTo is the annotation of a machine naming the owner id of the machine controller
+	// instance the machine is handed over to. Deleting such a machine rewrites the owner tag of its resources
+	// instead of deleting them, so that the other instance adopts the VM without recreating it.
+	MachineAnnotationHandOverTo = "azure.machine.sapcloud.io/hand-over-to"
+	// MachineAnnotationPaused is the annotation of a machine pausing its reconciliation if set to true. The machine is
+	// neither created nor deleted while it is paused, but its status is still reported, so that its Azure resources
+	// can be repaired manually, e.g. during an incident.
+	MachineAnnotationPaused = "provider.azure/paused"
+	// MachineAnnotationForceDelete is the annotation of a machine or machine class forcing the deletion of the VMs if
+	// set to true. The VMs are deleted without graceful shutdown, including VMs stuck in a failed provisioning state,
+	// e.g. during incident recovery.
+	MachineAnnotationForceDelete = "provider.azure/force-delete"
+	// MachineClassAnnotationStrictDecoding is the annotation of a machine class rejecting the fields of its provider
+	// spec which are unknown, e.g. misspelled ones, if set to true, instead of ignoring them
+	MachineClassAnnotationStrictDecoding = "provider.azure/strict-decoding"
+
+	// ClusterTagPrefix is the prefix of the tag naming the cluster of the machines, e.g. kubernetes.io-cluster-<cluster>.
+	// ListMachines and the orphan collection only consider resources carrying the cluster and role tags of the machine
+	// class, so that resources of other clusters in the same resource group are never touched.
+	ClusterTagPrefix = "kubernetes.io-cluster-"
+	// RoleTagPrefix is the prefix of the tag naming the role of the machines, e.g. kubernetes.io-role-node
+	RoleTagPrefix = "kubernetes.io-role-"
+	// NodeRoleTag is the role tag of machine classes without one
+	NodeRoleTag = RoleTagPrefix + "node"
+	// ClusterTagValue is the value of the cluster and role tags filled in for machine classes without them
+	ClusterTagValue = "1"
+
+	// MaintenanceWindowTimeLayout is the layout of the begin and end of maintenance windows
+	MaintenanceWindowTimeLayout = "15:04"
+
+	// MachineLabelOSProfile is the label of a machine naming the alternative OS profile of the machine class the
+	// machine is created with. Machines without the label use the OS profile of the machine class.
+	MachineLabelOSProfile = "azure.machine.sapcloud.io/os-profile"
 )
 
+// APIProfileVersions are the API versions of the API profiles by lowercase resource provider and, where they differ,
+// by resource type of the provider
+var APIProfileVersions = map[string]map[string]string{
+	APIProfile20190301Hybrid: {
+		"microsoft.compute":       "2017-12-01",
+		"microsoft.compute/disks": "2017-03-30",
+		"microsoft.network":       "2017-10-01",
+		"microsoft.resources":     "2018-05-01",
+	},
+	APIProfile20200901Hybrid: {
+		"microsoft.compute":       "2020-06-01",
+		"microsoft.compute/disks": "2019-07-01",
+		"microsoft.network":       "2018-11-01",
+		"microsoft.resources":     "2019-10-01",
+	},
+}
+
 // AzureProviderSpec is the spec to be used while parsing the calls.
 type AzureProviderSpec struct {
 	Location      string                        `json:"location,omitempty"`
-	Tags          map[string]string             `json:"tags,omitempty"`
+	Tags          Tags                          `json:"tags,omitempty"`
 	Properties    AzureVirtualMachineProperties `json:"properties,omitempty"`
 	ResourceGroup string                        `json:"resourceGroup,omitempty"`
 	SubnetInfo    AzureSubnetInfo               `json:"subnetInfo,omitempty"`
+	// CloudConfiguration is the Azure cloud the machines are created in. Defaults to the Azure public cloud.
+	CloudConfiguration *CloudConfiguration `json:"cloudConfiguration,omitempty"`
+	// NamingStrategy is the name of the strategy deriving the names of the NICs, public IPs and disks of the machines
+	// from their VM names, either suffix or prefix. Defaults to the suffix strategy. It must not be changed while
+	// machines of the class exist.
+	NamingStrategy string `json:"namingStrategy,omitempty"`
+	// NICResourceGroup is the existing resource group the NICs and public IPs of the machines are created in, e.g. to
+	// segregate the network resources. Defaults to the resource group of the VMs.
+	NICResourceGroup *string `json:"nicResourceGroup,omitempty"`
+}
+
+// Tags are the tags of the machine resources. Besides strings, numbers and booleans are accepted as values and
+// converted to their string representation, as Azure only supports string values.
+type Tags map[string]string
+
+// UnmarshalJSON decodes tags with string, number, boolean or null values
+func (t *Tags) UnmarshalJSON(data []byte) error {
+	var raw map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&raw); err != nil {
+		return err
+	}
+	if raw == nil {
+		*t = nil
+		return nil
+	}
+
+	tags := make(Tags, len(raw))
+	for key, value := range raw {
+		switch v := value.(type) {
+		case string:
+			tags[key] = v
+		case json.Number:
+			tags[key] = v.String()
+		case bool:
+			tags[key] = strconv.FormatBool(v)
+		case nil:
+			tags[key] = ""
+		default:
+			return fmt.Errorf("value of tag %q must be a string, number or boolean", key)
+		}
+	}
+	*t = tags
+	return nil
+}
+
+// CloudConfiguration contains the information about the Azure cloud to talk to
+type CloudConfiguration struct {
+	// Name is the name of a well-known Azure cloud, e.g. AzurePublic, AzureChina or AzureGovernment.
+	Name string `json:"name,omitempty"`
+	// ResourceManagerEndpoint overrides the Azure Resource Manager endpoint of the cloud.
+	ResourceManagerEndpoint *string `json:"resourceManagerEndpoint,omitempty"`
+	// ActiveDirectoryEndpoint overrides the Azure Active Directory endpoint of the cloud.
+	ActiveDirectoryEndpoint *string `json:"activeDirectoryEndpoint,omitempty"`
+	// TokenAudience is the audience of the tokens acquired for the resource manager, e.g. the
+	// activeDirectoryServiceEndpointResourceId of the metadata of an Azure Stack Hub. Defaults to the resource manager
+	// endpoint.
+	TokenAudience *string `json:"tokenAudience,omitempty"`
+	// APIProfile is the API profile the API versions of all requests are taken from, e.g. 2020-09-01-hybrid for Azure
+	// Stack Hub. The API versions of the provider are used if empty. Fields requiring newer compute API versions than
+	// the one of the profile are rejected, and the force delete annotation is ignored.
+	APIProfile string `json:"apiProfile,omitempty"`
 }
 
 // AzureVirtualMachineProperties is describes the properties of a Virtual Machine.
@@ -48,10 +275,91 @@ type AzureVirtualMachineProperties struct {
 	StorageProfile  AzureStorageProfile    `json:"storageProfile,omitempty"`
 	OsProfile       AzureOSProfile         `json:"osProfile,omitempty"`
 	NetworkProfile  AzureNetworkProfile    `json:"networkProfile,omitempty"`
-	AvailabilitySet *AzureSubResource      `json:"availabilitySet,omitempty"`
+	AvailabilitySet *AzureAvailabilitySet  `json:"availabilitySet,omitempty"`
 	IdentityID      *string                `json:"identityID,omitempty"`
 	Zone            *int                   `json:"zone,omitempty"`
 	MachineSet      *AzureMachineSetConfig `json:"machineSet,omitempty"`
+	// Zones spreads the machines across the given zones. The zone of a machine is chosen deterministically from a
+	// hash of its name and tagged on its VM. It cannot be combined with zone.
+	Zones []int `json:"zones,omitempty"`
+	// Regional places the VM in the region without a zone, availability set or machine set. VM sizes which are only
+	// offered in zones of the location are rejected for regional machines.
+	Regional bool `json:"regional,omitempty"`
+	// LicenseType specifies that the image or disk is licensed on-premises, e.g. RHEL_BYOS or Windows_Server.
+	LicenseType *string `json:"licenseType,omitempty"`
+	// SecurityProfile enables Trusted Launch or confidential computing for the VM. It requires a Gen2 image.
+	SecurityProfile *AzureSecurityProfile `json:"securityProfile,omitempty"`
+	// UseUserData passes the user data via the userData property of the VM instead of the custom data of the OS
+	// profile. Unlike custom data, it can be retrieved from the instance metadata service and updated. Changes of the
+	// user data of the secret are applied to the existing VMs on GetMachineStatus, so that nodes can be re-bootstrapped
+	// without recreating them.
+	UseUserData bool `json:"useUserData,omitempty"`
+	// Extensions are installed on the VM in the given order after it is created. They require the VM agent.
+	Extensions []AzureVMExtension `json:"extensions,omitempty"`
+	// AlternativeOSProfiles are OS configurations selected per machine by the os-profile label of the machine, e.g.
+	// to run Windows and Linux machines in one machine deployment. They replace the OS profile, the image and the
+	// license type of the machine class.
+	AlternativeOSProfiles map[string]AzureAlternativeOSProfile `json:"alternativeOSProfiles,omitempty"`
+	// RestartPolicy configures the automatic recovery of the VMs of the machines.
+	RestartPolicy *AzureRestartPolicy `json:"restartPolicy,omitempty"`
+}
+
+// AzureRestartPolicy configures the automatic recovery of VMs. Azure only offers automatic repairs for scale sets,
+// which are not created by the provider, hence stopped VMs are restarted by the provider itself.
+type AzureRestartPolicy struct {
+	// RestartStopped starts VMs found stopped but not deallocated, e.g. after a crash or shutdown inside the guest,
+	// so that the capacity is restored without replacing the machine.
+	RestartStopped bool `json:"restartStopped,omitempty"`
+	// MaintenanceWindows are the daily windows in which stopped VMs are not restarted, e.g. as they are stopped for
+	// maintenance on purpose.
+	MaintenanceWindows []AzureMaintenanceWindow `json:"maintenanceWindows,omitempty"`
+}
+
+// AzureMaintenanceWindow is a daily time window. It ends on the next day if it ends before it begins.
+type AzureMaintenanceWindow struct {
+	// Begin is the UTC time of day the window begins at, formatted as HH:MM.
+	Begin string `json:"begin"`
+	// End is the UTC time of day the window ends at, formatted as HH:MM.
+	End string `json:"end"`
+}
+
+// AzureAlternativeOSProfile is an OS configuration of the machine class selected per machine.
+type AzureAlternativeOSProfile struct {
+	// OsProfile replaces the OS profile of the machine class.
+	OsProfile AzureOSProfile `json:"osProfile,omitempty"`
+	// ImageReference replaces the image of the machine class. It is required, as the image determines the OS.
+	ImageReference AzureImageReference `json:"imageReference,omitempty"`
+	// LicenseType replaces the license type of the machine class.
+	LicenseType *string `json:"licenseType,omitempty"`
+}
+
+// AzureVMExtension describes a VM extension, e.g. the AAD SSH login or the monitoring agent.
+type AzureVMExtension struct {
+	// Name is the name of the extension resource of the VM.
+	Name string `json:"name,omitempty"`
+	// Publisher is the publisher of the extension handler, e.g. Microsoft.Azure.ActiveDirectory.
+	Publisher string `json:"publisher,omitempty"`
+	// Type is the type of the extension handler, e.g. AADSSHLoginForLinux.
+	Type string `json:"type,omitempty"`
+	// TypeHandlerVersion is the version of the extension handler, e.g. 1.0.
+	TypeHandlerVersion string `json:"typeHandlerVersion,omitempty"`
+	// AutoUpgradeMinorVersion lets Azure use the latest minor version of the extension handler.
+	AutoUpgradeMinorVersion *bool `json:"autoUpgradeMinorVersion,omitempty"`
+	// Settings are the public settings of the extension.
+	Settings map[string]interface{} `json:"settings,omitempty"`
+	// ProtectedSettingsSecretKey is the key of the machine class secret containing the protected settings of the
+	// extension as a JSON object.
+	ProtectedSettingsSecretKey string `json:"protectedSettingsSecretKey,omitempty"`
+}
+
+// AzureSecurityProfile specifies the security features of the virtual machine.
+type AzureSecurityProfile struct {
+	// SecurityType is either TrustedLaunch or ConfidentialVM.
+	SecurityType string `json:"securityType,omitempty"`
+	// SecureBootEnabled enables secure boot of the VM.
+	SecureBootEnabled *bool `json:"secureBootEnabled,omitempty"`
+	// VTPMEnabled enables the virtual trusted platform module of the VM.
+	VTPMEnabled *bool `json:"vTPMEnabled,omitempty"`
 }
 
 // AzureHardwareProfile is specifies the hardware settings for the virtual machine.
@@ -71,6 +379,14 @@ type AzureStorageProfile struct {
 	ImageReference AzureImageReference `json:"imageReference,omitempty"`
 	OsDisk         AzureOSDisk         `json:"osDisk,omitempty"`
 	DataDisks      []AzureDataDisk     `json:"dataDisks,omitempty"`
+	// DataDiskLunOffset is the first LUN of the data disks. The LUNs below are reserved for disks attached later, e.g.
+	// by the Azure Disk CSI driver. Data disks without LUN are assigned consecutive LUNs starting at the offset. The
+	// LUN is part of the names of these disks, so changing the offset only applies to new machines. The disks of
+	// existing machines are deleted by the offset resolved from their VM.
+	DataDiskLunOffset int32 `json:"dataDiskLunOffset,omitempty"`
+	// DiskControllerType is the controller the disks are attached with, either SCSI or NVMe. Defaults to the controller
+	// Azure chooses for the VM size and image.
+	DiskControllerType string `json:"diskControllerType,omitempty"`
 }
 
 // AzureImageReference is specifies information about the image to use. You can specify information about platform images,
@@ -80,6 +396,13 @@ type AzureImageReference struct {
 	ID string `json:"id,omitempty"`
 	// Uniform Resource Name of the OS image to be used , it has the format 'publisher:offer:sku:version'
 	URN *string `json:"urn,omitempty"`
+	// SkipPlanIfAgreementDenied creates the VM without the marketplace plan of the image if its terms cannot be
+	// accepted, as purchases are denied by a policy of the tenant. It must only be set for images which can be used
+	// without plan.
+	SkipPlanIfAgreementDenied bool `json:"skipPlanIfAgreementDenied,omitempty"`
+	// DisableAgreementAcceptance fails the creation of machines whose image has a marketplace plan with terms not
+	// accepted for the subscription, instead of accepting them automatically, which may start billing for the plan.
+	DisableAgreementAcceptance bool `json:"disableAgreementAcceptance,omitempty"`
 }
 
 // AzureOSDisk is specifies information about the operating system disk used by the virtual machine. <br><br> For more
@@ -91,6 +414,11 @@ type AzureOSDisk struct {
 	ManagedDisk  AzureManagedDiskParameters `json:"managedDisk,omitempty"`
 	DiskSizeGB   int32                      `json:"diskSizeGB,omitempty"`
 	CreateOption string                     `json:"createOption,omitempty"`
+	// DiskEncryptionSetID is the resource ID of the disk encryption set used to encrypt the disk with a customer-managed key.
+	DiskEncryptionSetID *string `json:"diskEncryptionSetID,omitempty"`
+	// WriteAcceleratorEnabled enables the write accelerator of the disk. It is only supported by M-series VM sizes for
+	// premium disks with the caching None or ReadOnly.
+	WriteAcceleratorEnabled *bool `json:"writeAcceleratorEnabled,omitempty"`
 }
 
 // AzureDataDisk specifies information about the data disk used by the virtual machine.
@@ -100,6 +428,28 @@ type AzureDataDisk struct {
 	Caching            string `json:"caching,omitempty"`
 	StorageAccountType string `json:"storageAccountType,omitempty"`
 	DiskSizeGB         int32  `json:"diskSizeGB,omitempty"`
+	// DiskEncryptionSetID is the resource ID of the disk encryption set used to encrypt the disk with a customer-managed key.
+	DiskEncryptionSetID *string `json:"diskEncryptionSetID,omitempty"`
+	// CreateOption is how the data disk is created: Empty (default) creates an empty disk, Attach attaches the
+	// existing managed disk of managedDiskID and FromImage creates the disk from the data disk of the image with the
+	// same LUN. Attached disks are detached but not deleted with the VM, and neither tagged nor reconciled. As every
+	// machine of the class attaches the same disk, attached disks must be shared with maxShares greater than 1.
+	CreateOption string `json:"createOption,omitempty"`
+	// ManagedDiskID is the resource ID of the existing managed disk attached with the Attach create option.
+	ManagedDiskID *string `json:"managedDiskID,omitempty"`
+	// DeleteOption is what happens to the data disk when the machine is deleted: Delete (default) deletes it along
+	// with the VM and Detach keeps it, e.g. to re-attach a cache to another machine. Kept disks are tagged as retained
+	// and excluded from the orphan collection. Attached disks are always kept.
+	DeleteOption string `json:"deleteOption,omitempty"`
+	// MaxShares is the maximum number of VMs the data disk can be attached to at the same time. Disks with more than
+	// one share belong to the machine class, e.g. for clustered workloads: the first machine creates the disk named
+	// <machine class>-<name>-shared-data-disk and all machines of the class attach it. They are never deleted with a
+	// machine. They must be named, empty disks without host caching, and zone-redundant if the class has several zones.
+	// Attached disks with more than one share are not created, the existing disk must allow as many shares.
+	MaxShares *int32 `json:"maxShares,omitempty"`
+	// WriteAcceleratorEnabled enables the write accelerator of the disk. It is only supported by M-series VM sizes for
+	// premium disks with the caching None or ReadOnly.
+	WriteAcceleratorEnabled *bool `json:"writeAcceleratorEnabled,omitempty"`
 }
 
 // AzureManagedDiskParameters is the parameters of a managed disk.
@@ -115,6 +465,19 @@ type AzureOSProfile struct {
 	AdminPassword      string                  `json:"adminPassword,omitempty"`
 	CustomData         string                  `json:"customData,omitempty"`
 	LinuxConfiguration AzureLinuxConfiguration `json:"linuxConfiguration,omitempty"`
+	// OSType is the operating system of the VM. Either Linux (default) or Windows. The names of Windows machines
+	// must not exceed 15 characters, as they are used as computer names.
+	OSType string `json:"osType,omitempty"`
+	// WindowsConfiguration is the configuration of Windows VMs. It is only allowed for the Windows OS type.
+	WindowsConfiguration *AzureWindowsConfiguration `json:"windowsConfiguration,omitempty"`
+	// ComputerNameTemplate configures the computer name, i.e. the hostname, of the VM, which is the machine name by
+	// default. The node of the machine is expected to be named after the computer name.
+	ComputerNameTemplate *AzureComputerNameTemplate `json:"computerNameTemplate,omitempty"`
+	// AllowExtensionOperations specifies whether extension operations are allowed on the VM.
+	AllowExtensionOperations *bool `json:"allowExtensionOperations,omitempty"`
+	// ProvisionVMAgent specifies whether the VM agent is provisioned on the VM. Images running without
+	// the Azure Linux agent must set this to false to avoid provisioning timeouts.
+	ProvisionVMAgent *bool `json:"provisionVMAgent,omitempty"`
 }
 
 // AzureLinuxConfiguration is specifies the Linux operating system settings on the virtual machine. <br><br>For a list of
@@ -127,13 +490,93 @@ type AzureLinuxConfiguration struct {
 	SSH                           AzureSSHConfiguration `json:"ssh,omitempty"`
 }
 
+// AzureComputerNameTemplate describes how the computer name of a VM is derived from the machine name.
+type AzureComputerNameTemplate struct {
+	// Template is a Go template rendered into the computer name. The name of the machine is available as
+	// {{ .MachineName }}, e.g. "node-{{ .MachineName }}".
+	Template string `json:"template,omitempty"`
+	// MaxLength is the maximum length of the computer name. Longer names are truncated and suffixed with a hash of
+	// the full name to keep them unique. Defaults to 15 for Windows and 64 for Linux VMs.
+	MaxLength *int `json:"maxLength,omitempty"`
+	// Lowercase converts the computer name to lower case.
+	Lowercase bool `json:"lowercase,omitempty"`
+}
+
+// AzureWindowsConfiguration specifies the Windows operating system settings on the virtual machine.
+type AzureWindowsConfiguration struct {
+	// AdminPasswordSecretKey is the key of the machine class secret containing the password of the admin user.
+	// Defaults to adminPassword.
+	AdminPasswordSecretKey string `json:"adminPasswordSecretKey,omitempty"`
+	// EnableAutomaticUpdates specifies whether Windows Update is enabled. Defaults to true.
+	EnableAutomaticUpdates *bool `json:"enableAutomaticUpdates,omitempty"`
+	// TimeZone is the time zone of the VM, e.g. "Pacific Standard Time".
+	TimeZone *string `json:"timeZone,omitempty"`
+	// WinRM are the Windows Remote Management listeners of the VM.
+	WinRM []AzureWinRMListener `json:"winRM,omitempty"`
+}
+
+// AzureWinRMListener describes a Windows Remote Management listener.
+type AzureWinRMListener struct {
+	// Protocol is the protocol of the listener. Either Http or Https.
+	Protocol string `json:"protocol,omitempty"`
+	// CertificateURL is the Key Vault secret URL of the certificate of Https listeners.
+	CertificateURL *string `json:"certificateURL,omitempty"`
+}
+
 // AzureSSHConfiguration is SSH configuration for Linux based VMs running on Azure
 type AzureSSHConfiguration struct {
-	PublicKeys AzureSSHPublicKey `json:"publicKeys,omitempty"`
+	// PublicKeys are the public keys placed on the VM. A single key object is accepted for backward compatibility.
+	PublicKeys AzureSSHPublicKeys `json:"publicKeys,omitempty"`
+	// AdditionalPublicKeys are further public keys placed on the VM, e.g. in a separate file for a break-glass login.
+	// Their path is required and, like all paths, must be below the .ssh directory of the admin user.
+	AdditionalPublicKeys []AzureSSHPublicKey `json:"additionalPublicKeys,omitempty"`
+	// PublicKeysSecretKey is the key of the machine class secret containing further public keys in the authorized_keys
+	// format, which are placed in the authorized_keys file of the admin user. This allows rotating the keys without
+	// changing the machine class.
+	PublicKeysSecretKey string `json:"publicKeysSecretKey,omitempty"`
+}
+
+// AzureSSHPublicKeys are SSH public keys. Besides a list, a single key object is accepted, which was the format of
+// the public keys before multiple keys were supported.
+type AzureSSHPublicKeys []AzureSSHPublicKey
+
+// UnmarshalJSON decodes a list of public keys or a single public key
+func (k *AzureSSHPublicKeys) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '{' {
+		var key AzureSSHPublicKey
+		if err := json.Unmarshal(data, &key); err != nil {
+			return err
+		}
+		*k = AzureSSHPublicKeys{key}
+		return nil
+	}
+
+	var keys []AzureSSHPublicKey
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return err
+	}
+	*k = keys
+	return nil
+}
+
+// ParseAuthorizedKeys returns the public keys of the authorized_keys file content, skipping empty lines and comments.
+// The keys have no path, i.e. they are placed in the authorized_keys file of the admin user.
+func ParseAuthorizedKeys(data []byte) []AzureSSHPublicKey {
+	var keys []AzureSSHPublicKey
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		keys = append(keys, AzureSSHPublicKey{KeyData: line})
+	}
+	return keys
 }
 
 // AzureSSHPublicKey is contains information about SSH certificate public key and the path on the Linux VM where the public
-// key is placed.
+// key is placed. If the path is empty, the key is placed in the authorized_keys file of the admin user. Azure only accepts
+// paths below the .ssh directory of the admin user.
 type AzureSSHPublicKey struct {
 	Path    string `json:"path,omitempty"`
 	KeyData string `json:"keyData,omitempty"`
@@ -143,6 +586,91 @@ type AzureSSHPublicKey struct {
 type AzureNetworkProfile struct {
 	NetworkInterfaces     AzureNetworkInterfaceReference `json:"networkInterfaces,omitempty"`
 	AcceleratedNetworking *bool                          `json:"acceleratedNetworking,omitempty"`
+	// EnableIPForwarding enables IP forwarding on the single interface created if no interfaces are given. It defaults
+	// to true.
+	EnableIPForwarding *bool `json:"enableIPForwarding,omitempty"`
+	// Interfaces is the list of network interfaces created for the virtual machine. Exactly one of them must be primary.
+	// If empty, a single primary interface is created in the subnet of the provider spec's subnet info.
+	Interfaces []AzureNetworkInterface `json:"interfaces,omitempty"`
+	// PrivateIPAllocationMethod is the allocation method of the private IP address of the single interface
+	// created if no interfaces are given. Either Dynamic (default) or Static.
+	PrivateIPAllocationMethod string `json:"privateIPAllocationMethod,omitempty"`
+	// PrivateIPAddress is the static private IP address of the single interface created if no interfaces are given.
+	PrivateIPAddress *string `json:"privateIPAddress,omitempty"`
+	// PublicIP attaches a public IP address to the single interface created if no interfaces are given.
+	PublicIP *AzurePublicIP `json:"publicIP,omitempty"`
+	// NetworkSecurityGroup is an existing network security group associated with the single interface created if no
+	// interfaces are given.
+	NetworkSecurityGroup *AzureSubResource `json:"networkSecurityGroup,omitempty"`
+	// ApplicationSecurityGroups are existing application security groups the single interface created if no
+	// interfaces are given is a member of.
+	ApplicationSecurityGroups []AzureSubResource `json:"applicationSecurityGroups,omitempty"`
+	// IPv6 adds a secondary IPv6 IP configuration to the single interface created if no interfaces are given.
+	IPv6 *AzureIPv6Configuration `json:"ipv6,omitempty"`
+	// LoadBalancerBackendAddressPools are existing load balancer backend address pools the single interface created if
+	// no interfaces are given is added to when it is created.
+	LoadBalancerBackendAddressPools []AzureSubResource `json:"loadBalancerBackendAddressPools,omitempty"`
+	// ApplicationGatewayBackendAddressPools are existing application gateway backend address pools the single
+	// interface created if no interfaces are given is added to when it is created.
+	ApplicationGatewayBackendAddressPools []AzureSubResource `json:"applicationGatewayBackendAddressPools,omitempty"`
+}
+
+// AzureNetworkInterface is describes a network interface created for the virtual machine.
+type AzureNetworkInterface struct {
+	// Name is used to derive the name of a secondary network interface. The primary interface is always named after the VM.
+	Name string `json:"name,omitempty"`
+	// SubnetInfo is the subnet of the interface. The vnet defaults to the one of the provider spec's subnet info.
+	SubnetInfo AzureSubnetInfo `json:"subnetInfo,omitempty"`
+	// AcceleratedNetworking enables accelerated networking for the interface.
+	AcceleratedNetworking *bool `json:"acceleratedNetworking,omitempty"`
+	// EnableIPForwarding enables IP forwarding on the interface. It defaults to true.
+	EnableIPForwarding *bool `json:"enableIPForwarding,omitempty"`
+	// Primary marks the interface as the primary interface of the VM.
+	Primary bool `json:"primary,omitempty"`
+	// PrivateIPAllocationMethod is the allocation method of the private IP address. Either Dynamic (default) or Static.
+	PrivateIPAllocationMethod string `json:"privateIPAllocationMethod,omitempty"`
+	// PrivateIPAddress is the static private IP address of the interface. As it is fixed, it only suits machine
+	// classes with a single machine; use the private IP address pool annotation of the machine otherwise.
+	PrivateIPAddress *string `json:"privateIPAddress,omitempty"`
+	// PublicIP attaches a public IP address to the interface.
+	PublicIP *AzurePublicIP `json:"publicIP,omitempty"`
+	// NetworkSecurityGroup is an existing network security group associated with the interface.
+	NetworkSecurityGroup *AzureSubResource `json:"networkSecurityGroup,omitempty"`
+	// ApplicationSecurityGroups are existing application security groups the interface is a member of.
+	ApplicationSecurityGroups []AzureSubResource `json:"applicationSecurityGroups,omitempty"`
+	// IPv6 adds a secondary IPv6 IP configuration to the interface.
+	IPv6 *AzureIPv6Configuration `json:"ipv6,omitempty"`
+	// LoadBalancerBackendAddressPools are existing load balancer backend address pools the interface is added to when
+	// it is created, so that the node receives traffic before the cloud controller manager reconciles the pools.
+	LoadBalancerBackendAddressPools []AzureSubResource `json:"loadBalancerBackendAddressPools,omitempty"`
+	// ApplicationGatewayBackendAddressPools are existing application gateway backend address pools the interface is
+	// added to when it is created.
+	ApplicationGatewayBackendAddressPools []AzureSubResource `json:"applicationGatewayBackendAddressPools,omitempty"`
+}
+
+// AzureIPv6Configuration describes the secondary IPv6 IP configuration of a network interface, e.g. for dual-stack
+// Kubernetes clusters. The IPv4 IP configuration stays the primary one.
+type AzureIPv6Configuration struct {
+	// SubnetName is the subnet the IPv6 address is allocated from, in the vnet of the interface. It defaults to the
+	// subnet of the interface, which then needs an IPv6 address prefix.
+	SubnetName string `json:"subnetName,omitempty"`
+	// PrivateIPAllocationMethod is the allocation method of the private IPv6 address. Either Dynamic (default) or
+	// Static.
+	PrivateIPAllocationMethod string `json:"privateIPAllocationMethod,omitempty"`
+	// PrivateIPAddress is the static private IPv6 address of the interface.
+	PrivateIPAddress *string `json:"privateIPAddress,omitempty"`
+}
+
+// AzurePublicIP describes a public IP address which is created and deleted together with its network interface.
+type AzurePublicIP struct {
+	// SKU is the SKU of the public IP address. Either Basic or Standard (default).
+	SKU string `json:"sku,omitempty"`
+	// AllocationMethod is the allocation method of the public IP address. Either Dynamic or Static (default).
+	// Standard public IP addresses are always static.
+	AllocationMethod string `json:"allocationMethod,omitempty"`
+	// DNSLabelTemplate is a Go template rendered into the DNS label of the public IP address. The name of the
+	// machine is available as {{ .MachineName }}.
+	DNSLabelTemplate *string `json:"dnsLabelTemplate,omitempty"`
 }
 
 // AzureNetworkInterfaceReference is describes a network interface reference.
@@ -156,6 +684,23 @@ type AzureNetworkInterfaceReferenceProperties struct {
 	Primary bool `json:"primary,omitempty"`
 }
 
+// AzureAvailabilitySet references the availability set of the VM, either by its ID or by its name. An availability set
+// referenced by name is created in the resource group of the VM if it doesn't exist.
+type AzureAvailabilitySet struct {
+	// ID is the ID of an existing availability set.
+	ID string `json:"id,omitempty"`
+	// Name is the name of the availability set in the resource group of the VM. It cannot be combined with id.
+	Name string `json:"name,omitempty"`
+	// PlatformFaultDomainCount is the number of fault domains of a created availability set, between 1 and 3. Defaults
+	// to 2.
+	PlatformFaultDomainCount *int32 `json:"platformFaultDomainCount,omitempty"`
+	// PlatformUpdateDomainCount is the number of update domains of a created availability set, between 1 and 20.
+	// Defaults to 5.
+	PlatformUpdateDomainCount *int32 `json:"platformUpdateDomainCount,omitempty"`
+	// DeleteWhenEmpty deletes the availability set referenced by name once its last VM is deleted.
+	DeleteWhenEmpty bool `json:"deleteWhenEmpty,omitempty"`
+}
+
 // AzureSubResource is the Sub Resource definition.
 type AzureSubResource struct {
 	ID string `json:"id,omitempty"`
@@ -165,5 +710,8 @@ type AzureSubResource struct {
 type AzureSubnetInfo struct {
 	VnetName          string  `json:"vnetName,omitempty"`
 	VnetResourceGroup *string `json:"vnetResourceGroup,omitempty"`
-	SubnetName        string  `json:"subnetName,omitempty"`
+	// VnetSubscriptionID is the subscription of the vnet, e.g. the central networking subscription of a hub-and-spoke
+	// topology. Defaults to the subscription of the VM.
+	VnetSubscriptionID *string `json:"vnetSubscriptionID,omitempty"`
+	SubnetName         string  `json:"subnetName,omitempty"`
 }
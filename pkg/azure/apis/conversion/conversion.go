@@ -0,0 +1,81 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package conversion decodes the raw provider specs of all versions into the internal provider spec and encodes the
+// internal provider spec in a given version
+package conversion
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis/v1alpha1"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis/v1beta1"
+)
+
+// typeMeta is the discriminator of the versions of the raw provider specs
+type typeMeta struct {
+	APIVersion string `json:"apiVersion,omitempty"`
+}
+
+// DecodeProviderSpec decodes the raw provider spec of the version named by its apiVersion into the internal provider
+// spec. Provider specs without an apiVersion are decoded as azure/v1alpha1.
+func DecodeProviderSpec(raw []byte) (*api.AzureProviderSpec, error) {
+	return decodeProviderSpec(raw, false)
+}
+
+// DecodeProviderSpecStrict decodes the raw provider spec like DecodeProviderSpec, but rejects fields which are not
+// fields of its version, e.g. misspelled ones, with an error naming their paths
+func DecodeProviderSpecStrict(raw []byte) (*api.AzureProviderSpec, error) {
+	return decodeProviderSpec(raw, true)
+}
+
+func decodeProviderSpec(raw []byte, strict bool) (*api.AzureProviderSpec, error) {
+	var meta typeMeta
+	if err := json.Unmarshal(raw, &meta); err != nil {
+		return nil, err
+	}
+
+	var spec interface {
+		ConvertToInternal() *api.AzureProviderSpec
+	}
+	switch meta.APIVersion {
+	case "", v1alpha1.APIVersion:
+		spec = &v1alpha1.AzureProviderSpec{}
+	case v1beta1.APIVersion:
+		spec = &v1beta1.AzureProviderSpec{}
+	default:
+		return nil, fmt.Errorf("unsupported provider spec apiVersion %q, supported are %s and %s", meta.APIVersion, v1alpha1.APIVersion, v1beta1.APIVersion)
+	}
+	if err := json.Unmarshal(raw, spec); err != nil {
+		return nil, err
+	}
+	if strict {
+		paths, err := unknownFields(raw, reflect.TypeOf(spec))
+		if err != nil {
+			return nil, err
+		}
+		if len(paths) > 0 {
+			return nil, fmt.Errorf("unknown fields in provider spec: %s", strings.Join(paths, ", "))
+		}
+	}
+	return spec.ConvertToInternal(), nil
+}
+
+// EncodeProviderSpec encodes the internal provider spec as raw provider spec of the given version
+func EncodeProviderSpec(spec *api.AzureProviderSpec, apiVersion string) ([]byte, error) {
+	switch apiVersion {
+	case v1alpha1.APIVersion:
+		return json.Marshal(v1alpha1.ConvertFromInternal(spec))
+	case v1beta1.APIVersion:
+		return json.Marshal(v1beta1.ConvertFromInternal(spec))
+	default:
+		return nil, fmt.Errorf("unsupported provider spec apiVersion %q, supported are %s and %s", apiVersion, v1alpha1.APIVersion, v1beta1.APIVersion)
+	}
+}
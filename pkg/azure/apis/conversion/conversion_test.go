@@ -0,0 +1,90 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package conversion
+
+import (
+	"reflect"
+
+	"github.com/Azure/go-autorest/autorest/to"
+	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis/v1alpha1"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis/v1beta1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Conversion", func() {
+	var spec *api.AzureProviderSpec
+
+	BeforeEach(func() {
+		spec = &api.AzureProviderSpec{
+			Location:      "westeurope",
+			Tags:          api.Tags{"kubernetes.io-role-mcm": "1"},
+			ResourceGroup: "shoot",
+			SubnetInfo: api.AzureSubnetInfo{
+				VnetName:           "vnet",
+				VnetResourceGroup:  to.StringPtr("network"),
+				VnetSubscriptionID: to.StringPtr("00000000-0000-0000-0000-000000000000"),
+				SubnetName:         "nodes",
+			},
+			CloudConfiguration: &api.CloudConfiguration{Name: "AzureChina"},
+			NamingStrategy:     "prefix",
+			NICResourceGroup:   to.StringPtr("nics"),
+		}
+		spec.Properties.HardwareProfile.VMSize = "Standard_D2s_v3"
+	})
+
+	It("should decode provider specs without apiVersion as v1alpha1", func() {
+		decoded, err := DecodeProviderSpec([]byte(`{"location":"westeurope","subnetInfo":{"vnetName":"vnet","subnetName":"nodes"}}`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(decoded.Location).To(Equal("westeurope"))
+		Expect(decoded.SubnetInfo).To(Equal(api.AzureSubnetInfo{VnetName: "vnet", SubnetName: "nodes"}))
+	})
+
+	It("should decode the network of v1beta1 provider specs into the subnet info", func() {
+		decoded, err := DecodeProviderSpec([]byte(`{"apiVersion":"azure/v1beta1","location":"westeurope","network":{"virtualNetwork":"vnet","subnet":"nodes"}}`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(decoded.SubnetInfo).To(Equal(api.AzureSubnetInfo{VnetName: "vnet", SubnetName: "nodes"}))
+	})
+
+	It("should encode and decode the provider spec in all versions without loss", func() {
+		// All fields are set, so that fields added to the internal provider spec but not to a version are detected
+		value := reflect.ValueOf(*spec)
+		for i := 0; i < value.NumField(); i++ {
+			Expect(value.Field(i).IsZero()).To(BeFalse(), "field %s of the provider spec is not set", value.Type().Field(i).Name)
+		}
+
+		for _, apiVersion := range []string{v1alpha1.APIVersion, v1beta1.APIVersion} {
+			raw, err := EncodeProviderSpec(spec, apiVersion)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(raw)).To(ContainSubstring(`"apiVersion":"` + apiVersion + `"`))
+
+			decoded, err := DecodeProviderSpec(raw)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(decoded).To(Equal(spec))
+		}
+	})
+
+	It("should reject unknown fields in strict mode only", func() {
+		raw := []byte(`{"location":"westeurope","properties":{"storageProfile":{"osDisk":{"diskSizeGb":50},"dataDisks":[{"lun":0},{"size":10}]}},"tags":{"Any":"1"},"zones":[1]}`)
+
+		_, err := DecodeProviderSpec(raw)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = DecodeProviderSpecStrict(raw)
+		Expect(err).To(MatchError("unknown fields in provider spec: properties.storageProfile.dataDisks[1].size, properties.storageProfile.osDisk.diskSizeGb, zones"))
+
+		_, err = DecodeProviderSpecStrict([]byte(`{"apiVersion":"azure/v1beta1","network":{"subnet":"nodes"},"properties":{"storageProfile":{"osDisk":{"diskSizeGB":50}}}}`))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should reject unknown versions", func() {
+		_, err := DecodeProviderSpec([]byte(`{"apiVersion":"azure/v2"}`))
+		Expect(err).To(MatchError(ContainSubstring(`unsupported provider spec apiVersion "azure/v2"`)))
+
+		_, err = EncodeProviderSpec(spec, "azure/v2")
+		Expect(err).To(HaveOccurred())
+	})
+})
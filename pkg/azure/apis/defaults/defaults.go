@@ -0,0 +1,138 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package defaults fills the defaults into decoded Azure provider specs, so that incomplete specs are completed or
+// rejected by the validation instead of failing with confusing errors of the Azure API
+package defaults
+
+import (
+	"strings"
+
+	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+)
+
+const (
+	// osDiskCreateOptionFromImage creates the OS disk from the image of the VM
+	osDiskCreateOptionFromImage = "FromImage"
+	// storageAccountTypeStandardLRS is the storage account type Azure uses for managed disks without one
+	storageAccountTypeStandardLRS = "Standard_LRS"
+)
+
+// storageAccountTypes are the storage account types of managed disks in their canonical spelling
+var storageAccountTypes = []string{"Standard_LRS", "Premium_LRS", "StandardSSD_LRS", "UltraSSD_LRS", "Premium_ZRS", "StandardSSD_ZRS"}
+
+// cachingTypes are the host caching types of disks in their canonical spelling
+var cachingTypes = []string{api.CachingNone, api.CachingReadOnly, api.CachingReadWrite}
+
+// SetDefaults fills the defaults into the provider spec:
+// - The OS disk is created from the image with read-write caching on standard storage unless configured otherwise.
+// - Data disks are created without caching unless configured otherwise. Their storage account type is not defaulted.
+// - Caching and storage account types are spelled canonically, as Azure rejects other spellings.
+// - Zones listed twice and zones equal to the single zone of the machine are dropped.
+// - The whitespace around tag keys and values is trimmed and tags with empty keys are dropped.
+// - The cluster tag is derived from the resource group and the node role tag is added if the tags lack them.
+func SetDefaults(spec *api.AzureProviderSpec) {
+	if spec == nil {
+		return
+	}
+	setStorageProfileDefaults(&spec.Properties.StorageProfile)
+	normalizeZones(&spec.Properties)
+	spec.Tags = canonicalTags(spec.Tags)
+	setClusterTagDefaults(spec)
+}
+
+func setStorageProfileDefaults(storageProfile *api.AzureStorageProfile) {
+	osDisk := &storageProfile.OsDisk
+	if osDisk.CreateOption == "" {
+		osDisk.CreateOption = osDiskCreateOptionFromImage
+	}
+	osDisk.Caching = canonical(osDisk.Caching, cachingTypes, api.CachingReadWrite)
+	osDisk.ManagedDisk.StorageAccountType = canonical(osDisk.ManagedDisk.StorageAccountType, storageAccountTypes, storageAccountTypeStandardLRS)
+
+	for i := range storageProfile.DataDisks {
+		dataDisk := &storageProfile.DataDisks[i]
+		dataDisk.Caching = canonical(dataDisk.Caching, cachingTypes, api.CachingNone)
+		// The storage account type of data disks is not defaulted, as empty data disks are required to choose one
+		// instead of silently becoming standard HDDs, and the other disks keep the one of the existing disk
+		dataDisk.StorageAccountType = canonical(dataDisk.StorageAccountType, storageAccountTypes, "")
+	}
+}
+
+// normalizeZones drops duplicate zones and the zones of machines assigned to a single zone which only repeat it.
+// The order of the zones is kept, as the zones of the machines are chosen by their index.
+func normalizeZones(properties *api.AzureVirtualMachineProperties) {
+	if len(properties.Zones) == 0 {
+		return
+	}
+
+	var (
+		zones []int
+		seen  = map[int]bool{}
+	)
+	for _, zone := range properties.Zones {
+		if !seen[zone] {
+			seen[zone] = true
+			zones = append(zones, zone)
+		}
+	}
+	if properties.Zone != nil && len(zones) == 1 && zones[0] == *properties.Zone {
+		zones = nil
+	}
+	properties.Zones = zones
+}
+
+// setClusterTagDefaults adds the cluster and role tags the machines are listed and their orphaned resources are
+// collected by if the tags lack them. The cluster is named after the resource group, which is the convention of the
+// Gardener provider extension, and is only added if the resource group is set.
+func setClusterTagDefaults(spec *api.AzureProviderSpec) {
+	var hasClusterTag, hasRoleTag bool
+	for key := range spec.Tags {
+		hasClusterTag = hasClusterTag || strings.HasPrefix(key, api.ClusterTagPrefix)
+		hasRoleTag = hasRoleTag || strings.HasPrefix(key, api.RoleTagPrefix)
+	}
+
+	if hasClusterTag && hasRoleTag {
+		return
+	}
+	if spec.Tags == nil {
+		spec.Tags = api.Tags{}
+	}
+	if !hasClusterTag && spec.ResourceGroup != "" {
+		spec.Tags[api.ClusterTagPrefix+spec.ResourceGroup] = api.ClusterTagValue
+	}
+	if !hasRoleTag {
+		spec.Tags[api.NodeRoleTag] = api.ClusterTagValue
+	}
+}
+
+// canonicalTags returns the tags with trimmed keys and values, without the tags with empty keys
+func canonicalTags(tags map[string]string) map[string]string {
+	if tags == nil {
+		return nil
+	}
+	result := make(map[string]string, len(tags))
+	for key, value := range tags {
+		if key = strings.TrimSpace(key); key != "" {
+			result[key] = strings.TrimSpace(value)
+		}
+	}
+	return result
+}
+
+// canonical returns the canonical spelling of the value among the known values, the default if the value is empty or
+// the value as is if it is unknown, so that the validation or Azure can reject it
+func canonical(value string, known []string, defaultValue string) string {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return defaultValue
+	}
+	for _, k := range known {
+		if strings.EqualFold(k, value) {
+			return k
+		}
+	}
+	return value
+}
@@ -0,0 +1,88 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package defaults
+
+import (
+	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Defaults", func() {
+	It("should default the disks of the storage profile", func() {
+		spec := &api.AzureProviderSpec{}
+		spec.Properties.StorageProfile.DataDisks = []api.AzureDataDisk{
+			{Name: "empty"},
+			{Name: "image", CreateOption: api.DataDiskCreateOptionFromImage},
+			{Name: "existing", CreateOption: api.DataDiskCreateOptionAttach},
+		}
+
+		SetDefaults(spec)
+
+		osDisk := spec.Properties.StorageProfile.OsDisk
+		Expect(osDisk.CreateOption).To(Equal("FromImage"))
+		Expect(osDisk.Caching).To(Equal(api.CachingReadWrite))
+		Expect(osDisk.ManagedDisk.StorageAccountType).To(Equal("Standard_LRS"))
+		dataDisks := spec.Properties.StorageProfile.DataDisks
+		Expect(dataDisks[0].Caching).To(Equal(api.CachingNone))
+		Expect(dataDisks[0].StorageAccountType).To(BeEmpty())
+		Expect(dataDisks[1].StorageAccountType).To(BeEmpty())
+		Expect(dataDisks[2].StorageAccountType).To(BeEmpty())
+	})
+
+	It("should spell caching and storage account types canonically", func() {
+		spec := &api.AzureProviderSpec{}
+		spec.Properties.StorageProfile.OsDisk.Caching = "readonly"
+		spec.Properties.StorageProfile.OsDisk.ManagedDisk.StorageAccountType = " premium_lrs"
+		spec.Properties.StorageProfile.DataDisks = []api.AzureDataDisk{{Caching: "Cached", StorageAccountType: "standardssd_lrs"}}
+
+		SetDefaults(spec)
+
+		Expect(spec.Properties.StorageProfile.OsDisk.Caching).To(Equal(api.CachingReadOnly))
+		Expect(spec.Properties.StorageProfile.OsDisk.ManagedDisk.StorageAccountType).To(Equal("Premium_LRS"))
+		Expect(spec.Properties.StorageProfile.DataDisks[0].Caching).To(Equal("Cached"))
+		Expect(spec.Properties.StorageProfile.DataDisks[0].StorageAccountType).To(Equal("StandardSSD_LRS"))
+	})
+
+	It("should drop duplicate zones and keep their order", func() {
+		spec := &api.AzureProviderSpec{}
+		spec.Properties.Zones = []int{3, 1, 3, 2, 1}
+
+		SetDefaults(spec)
+		Expect(spec.Properties.Zones).To(Equal([]int{3, 1, 2}))
+	})
+
+	It("should drop the zones which only repeat the zone of the machine", func() {
+		zone := 2
+		spec := &api.AzureProviderSpec{}
+		spec.Properties.Zone = &zone
+		spec.Properties.Zones = []int{2, 2}
+
+		SetDefaults(spec)
+		Expect(spec.Properties.Zones).To(BeNil())
+		Expect(*spec.Properties.Zone).To(Equal(2))
+	})
+
+	It("should trim the tags and drop tags without key", func() {
+		spec := &api.AzureProviderSpec{Tags: map[string]string{" kubernetes.io-role-mcm ": " 1 ", " ": "empty"}}
+
+		SetDefaults(spec)
+		Expect(spec.Tags).To(Equal(api.Tags{"kubernetes.io-role-mcm": "1"}))
+	})
+
+	It("should add the cluster tag derived from the resource group and the node role tag", func() {
+		spec := &api.AzureProviderSpec{ResourceGroup: "shoot--foo--bar"}
+
+		SetDefaults(spec)
+		Expect(spec.Tags).To(Equal(api.Tags{"kubernetes.io-cluster-shoot--foo--bar": "1", "kubernetes.io-role-node": "1"}))
+	})
+
+	It("should keep the cluster and role tags of the provider spec", func() {
+		spec := &api.AzureProviderSpec{ResourceGroup: "rg", Tags: api.Tags{"kubernetes.io-cluster-shoot--foo--bar": "1", "kubernetes.io-role-mcm": "1"}}
+
+		SetDefaults(spec)
+		Expect(spec.Tags).To(Equal(api.Tags{"kubernetes.io-cluster-shoot--foo--bar": "1", "kubernetes.io-role-mcm": "1"}))
+	})
+})
@@ -0,0 +1,34 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package v1alpha1 contains the azure/v1alpha1 version of the provider spec, which is the schema of the provider specs
+// without an apiVersion
+package v1alpha1
+
+import (
+	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+)
+
+// APIVersion is the apiVersion of the provider specs of this version
+const APIVersion = "azure/v1alpha1"
+
+// AzureProviderSpec is the azure/v1alpha1 provider spec. Its fields are the ones of the internal provider spec.
+type AzureProviderSpec struct {
+	// APIVersion is the version of the provider spec, which is optional for this version
+	APIVersion string `json:"apiVersion,omitempty"`
+	api.AzureProviderSpec
+}
+
+// ConvertToInternal converts the provider spec to the internal provider spec
+func (s *AzureProviderSpec) ConvertToInternal() *api.AzureProviderSpec {
+	spec := s.AzureProviderSpec
+	return &spec
+}
+
+// ConvertFromInternal converts the internal provider spec to the provider spec of this version
+func ConvertFromInternal(spec *api.AzureProviderSpec) *AzureProviderSpec {
+	return &AzureProviderSpec{APIVersion: APIVersion, AzureProviderSpec: *spec}
+}
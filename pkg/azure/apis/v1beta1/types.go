@@ -0,0 +1,90 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package v1beta1 contains the azure/v1beta1 version of the provider spec, which groups the network of the machines
+// instead of the subnet info of azure/v1alpha1
+package v1beta1
+
+import (
+	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+)
+
+// APIVersion is the apiVersion of the provider specs of this version
+const APIVersion = "azure/v1beta1"
+
+// AzureProviderSpec is the azure/v1beta1 provider spec
+type AzureProviderSpec struct {
+	// APIVersion is the version of the provider spec, which is required for this version
+	APIVersion    string                            `json:"apiVersion"`
+	Location      string                            `json:"location,omitempty"`
+	Tags          api.Tags                          `json:"tags,omitempty"`
+	Properties    api.AzureVirtualMachineProperties `json:"properties,omitempty"`
+	ResourceGroup string                            `json:"resourceGroup,omitempty"`
+	// Network is the network the machines are connected to. It replaces the subnetInfo of azure/v1alpha1.
+	Network AzureNetwork `json:"network,omitempty"`
+	// CloudConfiguration is the Azure cloud the machines are created in. Defaults to the Azure public cloud.
+	CloudConfiguration *api.CloudConfiguration `json:"cloudConfiguration,omitempty"`
+	// NamingStrategy is the name of the strategy deriving the names of the NICs, public IPs and disks of the machines
+	// from their VM names, either suffix or prefix. Defaults to the suffix strategy. It must not be changed while
+	// machines of the class exist.
+	NamingStrategy string `json:"namingStrategy,omitempty"`
+	// NICResourceGroup is the existing resource group the NICs and public IPs of the machines are created in. Defaults
+	// to the resource group of the machines.
+	NICResourceGroup *string `json:"nicResourceGroup,omitempty"`
+}
+
+// AzureNetwork is the network the machines are connected to
+type AzureNetwork struct {
+	// VirtualNetwork is the name of the virtual network
+	VirtualNetwork string `json:"virtualNetwork,omitempty"`
+	// VirtualNetworkResourceGroup is the resource group of the virtual network. Defaults to the resource group of the
+	// machines.
+	VirtualNetworkResourceGroup *string `json:"virtualNetworkResourceGroup,omitempty"`
+	// VirtualNetworkSubscriptionID is the subscription of the virtual network. Defaults to the subscription of the
+	// machines.
+	VirtualNetworkSubscriptionID *string `json:"virtualNetworkSubscriptionID,omitempty"`
+	// Subnet is the name of the subnet of the primary network interfaces
+	Subnet string `json:"subnet,omitempty"`
+}
+
+// ConvertToInternal converts the provider spec to the internal provider spec
+func (s *AzureProviderSpec) ConvertToInternal() *api.AzureProviderSpec {
+	return &api.AzureProviderSpec{
+		Location:      s.Location,
+		Tags:          s.Tags,
+		Properties:    s.Properties,
+		ResourceGroup: s.ResourceGroup,
+		SubnetInfo: api.AzureSubnetInfo{
+			VnetName:           s.Network.VirtualNetwork,
+			VnetResourceGroup:  s.Network.VirtualNetworkResourceGroup,
+			VnetSubscriptionID: s.Network.VirtualNetworkSubscriptionID,
+			SubnetName:         s.Network.Subnet,
+		},
+		CloudConfiguration: s.CloudConfiguration,
+		NamingStrategy:     s.NamingStrategy,
+		NICResourceGroup:   s.NICResourceGroup,
+	}
+}
+
+// ConvertFromInternal converts the internal provider spec to the provider spec of this version
+func ConvertFromInternal(spec *api.AzureProviderSpec) *AzureProviderSpec {
+	return &AzureProviderSpec{
+		APIVersion:    APIVersion,
+		Location:      spec.Location,
+		Tags:          spec.Tags,
+		Properties:    spec.Properties,
+		ResourceGroup: spec.ResourceGroup,
+		Network: AzureNetwork{
+			VirtualNetwork:               spec.SubnetInfo.VnetName,
+			VirtualNetworkResourceGroup:  spec.SubnetInfo.VnetResourceGroup,
+			VirtualNetworkSubscriptionID: spec.SubnetInfo.VnetSubscriptionID,
+			Subnet:                       spec.SubnetInfo.SubnetName,
+		},
+		CloudConfiguration: spec.CloudConfiguration,
+		NamingStrategy:     spec.NamingStrategy,
+		NICResourceGroup:   spec.NICResourceGroup,
+	}
+}
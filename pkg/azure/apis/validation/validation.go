@@ -8,10 +8,18 @@ SPDX-License-Identifier: Apache-2.0
 package validation
 
 import (
+	"encoding/json"
 	"fmt"
+	"net"
+	"net/url"
+	"path"
 	"regexp"
+	"sort"
 	"strings"
+	"text/template"
+	"time"
 
+	"github.com/Azure/go-autorest/autorest/azure"
 	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
 
 	corev1 "k8s.io/api/core/v1"
@@ -23,8 +31,28 @@ const nameFmt string = `[-a-z0-9]+`
 
 var nameRegexp = regexp.MustCompile("^" + nameFmt + "$")
 
+// invalidTagNameCharacters are the characters Azure rejects in tag names
+const invalidTagNameCharacters = `<>%&\?/`
+
+// subscriptionIDRegexp matches the GUIDs of Azure subscriptions, e.g. 00000000-0000-0000-0000-000000000000
+var subscriptionIDRegexp = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
 // ValidateAzureSpecNSecret validates Azure provider spec
 func ValidateAzureSpecNSecret(spec *api.AzureProviderSpec, secrets *corev1.Secret) []error {
+	if secrets == nil {
+		return append(validateAzureSpec(spec, nil), fmt.Errorf("secret is required"))
+	}
+	return validateAzureSpec(spec, secrets)
+}
+
+// ValidateAzureProviderSpec validates the Azure provider spec without the secret it is used with, e.g. the provider
+// spec of a machine class generated for a migration. The checks of the secret keys referenced by the spec are skipped.
+func ValidateAzureProviderSpec(spec *api.AzureProviderSpec) []error {
+	return validateAzureSpec(spec, nil)
+}
+
+// validateAzureSpec validates the provider spec and, unless nil, the secret
+func validateAzureSpec(spec *api.AzureProviderSpec, secrets *corev1.Secret) []error {
 	var allErrs []error
 
 	if "" == spec.Location {
@@ -33,15 +61,114 @@ func ValidateAzureSpecNSecret(spec *api.AzureProviderSpec, secrets *corev1.Secre
 	if "" == spec.ResourceGroup {
 		allErrs = append(allErrs, fmt.Errorf("Resource Group Name is required field"))
 	}
+	if spec.NICResourceGroup != nil && *spec.NICResourceGroup == "" {
+		allErrs = append(allErrs, field.Required(field.NewPath("nicResourceGroup"), "NIC resource group must not be empty if set"))
+	}
 
+	allErrs = append(allErrs, validateCloudConfiguration(spec.CloudConfiguration)...)
+	allErrs = append(allErrs, validateAPIProfileSupport(spec)...)
 	allErrs = append(allErrs, validateSpecSubnetInfo(spec.SubnetInfo)...)
+	allErrs = append(allErrs, validateNetworkInterfaces(spec.Properties.NetworkProfile.Interfaces)...)
+	allErrs = append(allErrs, validatePrivateIPAddress(field.NewPath("properties.networkProfile"), spec.Properties.NetworkProfile.PrivateIPAllocationMethod, spec.Properties.NetworkProfile.PrivateIPAddress)...)
+	allErrs = append(allErrs, validatePublicIP(field.NewPath("properties.networkProfile.publicIP"), spec.Properties.NetworkProfile.PublicIP)...)
+	allErrs = append(allErrs, validateSecurityGroups(field.NewPath("properties.networkProfile"), spec.Properties.NetworkProfile.NetworkSecurityGroup, spec.Properties.NetworkProfile.ApplicationSecurityGroups)...)
+	allErrs = append(allErrs, validateIPv6Configuration(field.NewPath("properties.networkProfile.ipv6"), spec.Properties.NetworkProfile.IPv6)...)
+	allErrs = append(allErrs, validateBackendAddressPools(field.NewPath("properties.networkProfile"), spec.Properties.NetworkProfile.LoadBalancerBackendAddressPools, spec.Properties.NetworkProfile.ApplicationGatewayBackendAddressPools)...)
 	allErrs = append(allErrs, validateSpecProperties(spec.Properties)...)
-	allErrs = append(allErrs, validateSecrets(secrets)...)
+	allErrs = append(allErrs, validateLicenseType(field.NewPath("properties.licenseType"), spec.Properties.LicenseType)...)
+	allErrs = append(allErrs, validateIdentityID(field.NewPath("properties.identityID"), spec.Properties.IdentityID)...)
+	allErrs = append(allErrs, validateSecurityProfile(field.NewPath("properties.securityProfile"), spec.Properties.SecurityProfile)...)
+	allErrs = append(allErrs, validateExtensions(field.NewPath("properties.extensions"), spec.Properties, secrets)...)
+	allErrs = append(allErrs, validateWindowsConfiguration(field.NewPath("properties.osProfile"), spec.Properties.OsProfile, secrets)...)
+	allErrs = append(allErrs, validateSSHPublicKeysSecretKey(field.NewPath("properties.osProfile.linuxConfiguration.ssh"), spec.Properties.OsProfile, secrets)...)
+	allErrs = append(allErrs, validateComputerNameTemplate(field.NewPath("properties.osProfile.computerNameTemplate"), spec.Properties.OsProfile)...)
+	allErrs = append(allErrs, validateAlternativeOSProfiles(field.NewPath("properties.alternativeOSProfiles"), spec.Properties, secrets)...)
+	allErrs = append(allErrs, validateRestartPolicy(field.NewPath("properties.restartPolicy"), spec.Properties.RestartPolicy)...)
+	if secrets != nil {
+		allErrs = append(allErrs, validateSecrets(secrets)...)
+	}
 	allErrs = append(allErrs, validateSpecTags(spec.Tags)...)
 
 	return allErrs
 }
 
+func validateCloudConfiguration(cloudConfiguration *api.CloudConfiguration) []error {
+	var allErrs []error
+
+	if cloudConfiguration == nil {
+		return allErrs
+	}
+
+	fldPath := field.NewPath("cloudConfiguration")
+	switch strings.ToLower(cloudConfiguration.Name) {
+	case "", strings.ToLower(api.CloudNameAzurePublic), strings.ToLower(api.CloudNameAzureChina), strings.ToLower(api.CloudNameAzureGovernment):
+	case strings.ToLower(api.CloudNameAzureStackHub):
+		if cloudConfiguration.ResourceManagerEndpoint == nil {
+			allErrs = append(allErrs, field.Required(fldPath.Child("resourceManagerEndpoint"), "the resource manager endpoint is required for Azure Stack Hub"))
+		}
+		if cloudConfiguration.ActiveDirectoryEndpoint == nil {
+			allErrs = append(allErrs, field.Required(fldPath.Child("activeDirectoryEndpoint"), "the active directory endpoint is required for Azure Stack Hub"))
+		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("name"), cloudConfiguration.Name, []string{api.CloudNameAzurePublic, api.CloudNameAzureChina, api.CloudNameAzureGovernment, api.CloudNameAzureStackHub}))
+	}
+	if cloudConfiguration.ResourceManagerEndpoint != nil && !isHTTPSURL(*cloudConfiguration.ResourceManagerEndpoint) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("resourceManagerEndpoint"), *cloudConfiguration.ResourceManagerEndpoint, "must be an https URL"))
+	}
+	if cloudConfiguration.ActiveDirectoryEndpoint != nil && !isHTTPSURL(*cloudConfiguration.ActiveDirectoryEndpoint) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("activeDirectoryEndpoint"), *cloudConfiguration.ActiveDirectoryEndpoint, "must be an https URL"))
+	}
+	if cloudConfiguration.TokenAudience != nil && !isHTTPSURL(*cloudConfiguration.TokenAudience) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("tokenAudience"), *cloudConfiguration.TokenAudience, "must be an https URL"))
+	}
+	switch cloudConfiguration.APIProfile {
+	case "", api.APIProfile20190301Hybrid, api.APIProfile20200901Hybrid:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("apiProfile"), cloudConfiguration.APIProfile, []string{api.APIProfile20190301Hybrid, api.APIProfile20200901Hybrid}))
+	}
+
+	return allErrs
+}
+
+// validateAPIProfileSupport rejects the fields of the provider spec which are not supported by the compute API version
+// of the API profile, as Azure would reject or silently ignore them
+func validateAPIProfileSupport(spec *api.AzureProviderSpec) []error {
+	var allErrs []error
+
+	if spec.CloudConfiguration == nil {
+		return allErrs
+	}
+	profile := spec.CloudConfiguration.APIProfile
+	version, ok := api.APIProfileVersions[profile]["microsoft.compute"]
+	if !ok {
+		return allErrs
+	}
+
+	requires := func(fldPath *field.Path, minVersion string) {
+		// The API versions are dates, hence they are ordered lexically
+		if version < minVersion {
+			allErrs = append(allErrs, field.Forbidden(fldPath, fmt.Sprintf("requires the compute API version %s or later, but the API profile %s uses %s", minVersion, profile, version)))
+		}
+	}
+	fldPath := field.NewPath("properties")
+	if spec.Properties.UseUserData {
+		requires(fldPath.Child("useUserData"), "2021-03-01")
+	}
+	if spec.Properties.SecurityProfile != nil {
+		requires(fldPath.Child("securityProfile"), "2020-12-01")
+	}
+	if spec.Properties.StorageProfile.DiskControllerType != "" {
+		requires(fldPath.Child("storageProfile", "diskControllerType"), "2022-08-01")
+	}
+
+	return allErrs
+}
+
+func isHTTPSURL(value string) bool {
+	u, err := url.Parse(value)
+	return err == nil && u.Scheme == "https" && u.Host != ""
+}
+
 func validateSpecSubnetInfo(subnetInfo api.AzureSubnetInfo) []error {
 	var allErrs []error
 
@@ -51,10 +178,394 @@ func validateSpecSubnetInfo(subnetInfo api.AzureSubnetInfo) []error {
 	if "" == subnetInfo.SubnetName {
 		allErrs = append(allErrs, fmt.Errorf("Subnet name is required for subnet info"))
 	}
+	allErrs = append(allErrs, validateVnetSubscriptionID(field.NewPath("subnetInfo.vnetSubscriptionID"), subnetInfo.VnetSubscriptionID)...)
+
+	return allErrs
+}
+
+// validateVnetSubscriptionID validates the subscription of a vnet, which is optional
+func validateVnetSubscriptionID(fldPath *field.Path, subscriptionID *string) []error {
+	if subscriptionID != nil && !subscriptionIDRegexp.MatchString(*subscriptionID) {
+		return []error{field.Invalid(fldPath, *subscriptionID, "Subscription ID must be a GUID")}
+	}
+	return nil
+}
+
+func validateNetworkInterfaces(interfaces []api.AzureNetworkInterface) []error {
+	var (
+		allErrs []error
+		fldPath = field.NewPath("properties.networkProfile.interfaces")
+		names   = map[string]bool{}
+		primary int
+	)
+
+	if len(interfaces) == 0 {
+		return allErrs
+	}
+
+	for i, nic := range interfaces {
+		idxPath := fldPath.Index(i)
+		if nic.Primary {
+			primary++
+		} else if nic.Name == "" {
+			allErrs = append(allErrs, field.Required(idxPath.Child("name"), "Name is required for secondary network interfaces"))
+		} else if !nameRegexp.MatchString(nic.Name) {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("name"), nic.Name, fmt.Sprintf("Name must match %s", nameFmt)))
+		} else if names[nic.Name] {
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("name"), nic.Name))
+		}
+		names[nic.Name] = true
+
+		if nic.SubnetInfo.SubnetName == "" {
+			allErrs = append(allErrs, field.Required(idxPath.Child("subnetInfo.subnetName"), "Subnet name is required for network interfaces"))
+		}
+		allErrs = append(allErrs, validateVnetSubscriptionID(idxPath.Child("subnetInfo.vnetSubscriptionID"), nic.SubnetInfo.VnetSubscriptionID)...)
+		allErrs = append(allErrs, validatePrivateIPAddress(idxPath, nic.PrivateIPAllocationMethod, nic.PrivateIPAddress)...)
+		allErrs = append(allErrs, validatePublicIP(idxPath.Child("publicIP"), nic.PublicIP)...)
+		allErrs = append(allErrs, validateSecurityGroups(idxPath, nic.NetworkSecurityGroup, nic.ApplicationSecurityGroups)...)
+		allErrs = append(allErrs, validateIPv6Configuration(idxPath.Child("ipv6"), nic.IPv6)...)
+		allErrs = append(allErrs, validateBackendAddressPools(idxPath, nic.LoadBalancerBackendAddressPools, nic.ApplicationGatewayBackendAddressPools)...)
+	}
+
+	if primary != 1 {
+		allErrs = append(allErrs, field.Invalid(fldPath, primary, "Exactly one network interface must be primary"))
+	}
+
+	return allErrs
+}
+
+// validatePrivateIPAddress validates the private IP allocation of a network interface. A static allocation without
+// an address is allowed, as the address may be taken from the private IP address pool annotation of the machine.
+func validatePrivateIPAddress(fldPath *field.Path, allocationMethod string, address *string) []error {
+	var allErrs []error
+
+	switch allocationMethod {
+	case "", api.PrivateIPAllocationMethodDynamic, api.PrivateIPAllocationMethodStatic:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("privateIPAllocationMethod"), allocationMethod, []string{api.PrivateIPAllocationMethodDynamic, api.PrivateIPAllocationMethodStatic}))
+	}
+
+	if address != nil {
+		if net.ParseIP(*address) == nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("privateIPAddress"), *address, "must be a valid IP address"))
+		}
+		if allocationMethod != api.PrivateIPAllocationMethodStatic {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("privateIPAllocationMethod"), allocationMethod, "must be Static if a private IP address is given"))
+		}
+	}
+
+	return allErrs
+}
+
+func validateIPv6Configuration(fldPath *field.Path, ipv6 *api.AzureIPv6Configuration) []error {
+	var allErrs []error
+
+	if ipv6 == nil {
+		return allErrs
+	}
+
+	allErrs = append(allErrs, validatePrivateIPAddress(fldPath, ipv6.PrivateIPAllocationMethod, ipv6.PrivateIPAddress)...)
+	if ipv6.PrivateIPAddress != nil {
+		if ip := net.ParseIP(*ipv6.PrivateIPAddress); ip != nil && ip.To4() != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("privateIPAddress"), *ipv6.PrivateIPAddress, "must be an IPv6 address"))
+		}
+	} else if ipv6.PrivateIPAllocationMethod == api.PrivateIPAllocationMethodStatic {
+		allErrs = append(allErrs, field.Required(fldPath.Child("privateIPAddress"), "Private IPv6 address is required for the static allocation"))
+	}
+
+	return allErrs
+}
+
+func validatePublicIP(fldPath *field.Path, publicIP *api.AzurePublicIP) []error {
+	var allErrs []error
+
+	if publicIP == nil {
+		return allErrs
+	}
+
+	switch publicIP.SKU {
+	case "", api.PublicIPSKUBasic, api.PublicIPSKUStandard:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("sku"), publicIP.SKU, []string{api.PublicIPSKUBasic, api.PublicIPSKUStandard}))
+	}
+
+	switch publicIP.AllocationMethod {
+	case "", api.PrivateIPAllocationMethodStatic:
+	case api.PrivateIPAllocationMethodDynamic:
+		if publicIP.SKU != api.PublicIPSKUBasic {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("allocationMethod"), publicIP.AllocationMethod, "Standard public IP addresses must be allocated statically"))
+		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("allocationMethod"), publicIP.AllocationMethod, []string{api.PrivateIPAllocationMethodDynamic, api.PrivateIPAllocationMethodStatic}))
+	}
+
+	if publicIP.DNSLabelTemplate != nil {
+		if _, err := template.New("dnsLabel").Parse(*publicIP.DNSLabelTemplate); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("dnsLabelTemplate"), *publicIP.DNSLabelTemplate, err.Error()))
+		}
+	}
+
+	return allErrs
+}
+
+func validateSecurityGroups(fldPath *field.Path, networkSecurityGroup *api.AzureSubResource, applicationSecurityGroups []api.AzureSubResource) []error {
+	var allErrs []error
+
+	if networkSecurityGroup != nil && !isResourceID(networkSecurityGroup.ID, "Microsoft.Network", "networkSecurityGroups") {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("networkSecurityGroup", "id"), networkSecurityGroup.ID, "must be the resource ID of a network security group"))
+	}
+
+	ids := map[string]bool{}
+	for i, applicationSecurityGroup := range applicationSecurityGroups {
+		idxPath := fldPath.Child("applicationSecurityGroups").Index(i).Child("id")
+		if !isResourceID(applicationSecurityGroup.ID, "Microsoft.Network", "applicationSecurityGroups") {
+			allErrs = append(allErrs, field.Invalid(idxPath, applicationSecurityGroup.ID, "must be the resource ID of an application security group"))
+		} else if ids[strings.ToLower(applicationSecurityGroup.ID)] {
+			allErrs = append(allErrs, field.Duplicate(idxPath, applicationSecurityGroup.ID))
+		}
+		ids[strings.ToLower(applicationSecurityGroup.ID)] = true
+	}
 
 	return allErrs
 }
 
+func validateBackendAddressPools(fldPath *field.Path, loadBalancerPools, applicationGatewayPools []api.AzureSubResource) []error {
+	var (
+		allErrs []error
+		ids     = map[string]bool{}
+	)
+
+	for i, pool := range loadBalancerPools {
+		idxPath := fldPath.Child("loadBalancerBackendAddressPools").Index(i).Child("id")
+		if !isChildResourceID(pool.ID, "Microsoft.Network", "loadBalancers", "backendAddressPools") {
+			allErrs = append(allErrs, field.Invalid(idxPath, pool.ID, "must be the resource ID of a load balancer backend address pool"))
+		} else if ids[strings.ToLower(pool.ID)] {
+			allErrs = append(allErrs, field.Duplicate(idxPath, pool.ID))
+		}
+		ids[strings.ToLower(pool.ID)] = true
+	}
+
+	for i, pool := range applicationGatewayPools {
+		idxPath := fldPath.Child("applicationGatewayBackendAddressPools").Index(i).Child("id")
+		if !isChildResourceID(pool.ID, "Microsoft.Network", "applicationGateways", "backendAddressPools") {
+			allErrs = append(allErrs, field.Invalid(idxPath, pool.ID, "must be the resource ID of an application gateway backend address pool"))
+		} else if ids[strings.ToLower(pool.ID)] {
+			allErrs = append(allErrs, field.Duplicate(idxPath, pool.ID))
+		}
+		ids[strings.ToLower(pool.ID)] = true
+	}
+
+	return allErrs
+}
+
+func validateLicenseType(fldPath *field.Path, licenseType *string) []error {
+	if licenseType == nil {
+		return nil
+	}
+	switch *licenseType {
+	case api.LicenseTypeWindowsServer, api.LicenseTypeWindowsClient, api.LicenseTypeRHELBYOS, api.LicenseTypeSLESBYOS:
+		return nil
+	}
+	return []error{field.NotSupported(fldPath, *licenseType, []string{api.LicenseTypeWindowsServer, api.LicenseTypeWindowsClient, api.LicenseTypeRHELBYOS, api.LicenseTypeSLESBYOS})}
+}
+
+func validateIdentityID(fldPath *field.Path, identityID *string) []error {
+	if identityID != nil && *identityID != "" && !isResourceID(*identityID, "Microsoft.ManagedIdentity", "userAssignedIdentities") {
+		return []error{field.Invalid(fldPath, *identityID, "must be the resource ID of a user-assigned identity")}
+	}
+	return nil
+}
+
+func validateSecurityProfile(fldPath *field.Path, securityProfile *api.AzureSecurityProfile) []error {
+	var allErrs []error
+
+	if securityProfile == nil {
+		return allErrs
+	}
+
+	switch securityProfile.SecurityType {
+	case api.SecurityTypeTrustedLaunch:
+	case api.SecurityTypeConfidentialVM:
+		if securityProfile.VTPMEnabled != nil && !*securityProfile.VTPMEnabled {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("vTPMEnabled"), *securityProfile.VTPMEnabled, "confidential VMs require a virtual TPM"))
+		}
+	case "":
+		allErrs = append(allErrs, field.Required(fldPath.Child("securityType"), "security type is required"))
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("securityType"), securityProfile.SecurityType, []string{api.SecurityTypeTrustedLaunch, api.SecurityTypeConfidentialVM}))
+	}
+
+	return allErrs
+}
+
+func validateRestartPolicy(fldPath *field.Path, restartPolicy *api.AzureRestartPolicy) []error {
+	var allErrs []error
+
+	if restartPolicy == nil {
+		return allErrs
+	}
+
+	for i, window := range restartPolicy.MaintenanceWindows {
+		idxPath := fldPath.Child("maintenanceWindows").Index(i)
+		if _, err := time.Parse(api.MaintenanceWindowTimeLayout, window.Begin); err != nil {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("begin"), window.Begin, "must be a time of day formatted as HH:MM"))
+		}
+		if _, err := time.Parse(api.MaintenanceWindowTimeLayout, window.End); err != nil {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("end"), window.End, "must be a time of day formatted as HH:MM"))
+		}
+	}
+
+	return allErrs
+}
+
+func validateExtensions(fldPath *field.Path, properties api.AzureVirtualMachineProperties, secret *corev1.Secret) []error {
+	var allErrs []error
+
+	if len(properties.Extensions) == 0 {
+		return allErrs
+	}
+	if properties.OsProfile.ProvisionVMAgent != nil && !*properties.OsProfile.ProvisionVMAgent {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "extensions cannot be installed if the VM agent is not provisioned"))
+	}
+	if properties.OsProfile.AllowExtensionOperations != nil && !*properties.OsProfile.AllowExtensionOperations {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "extensions cannot be installed if extension operations are not allowed"))
+	}
+
+	names := map[string]bool{}
+	for i, extension := range properties.Extensions {
+		idxPath := fldPath.Index(i)
+		if extension.Name == "" {
+			allErrs = append(allErrs, field.Required(idxPath.Child("name"), "name is required"))
+		} else if names[strings.ToLower(extension.Name)] {
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("name"), extension.Name))
+		}
+		names[strings.ToLower(extension.Name)] = true
+		if extension.Publisher == "" {
+			allErrs = append(allErrs, field.Required(idxPath.Child("publisher"), "publisher is required"))
+		}
+		if extension.Type == "" {
+			allErrs = append(allErrs, field.Required(idxPath.Child("type"), "type is required"))
+		}
+		if extension.TypeHandlerVersion == "" {
+			allErrs = append(allErrs, field.Required(idxPath.Child("typeHandlerVersion"), "type handler version is required"))
+		}
+		if key := extension.ProtectedSettingsSecretKey; key != "" && secret != nil {
+			var protectedSettings map[string]interface{}
+			if err := json.Unmarshal(secret.Data[key], &protectedSettings); err != nil {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("protectedSettingsSecretKey"), key, "secret key must contain the protected settings as a JSON object"))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+func validateWindowsConfiguration(fldPath *field.Path, osProfile api.AzureOSProfile, secret *corev1.Secret) []error {
+	var allErrs []error
+
+	switch osProfile.OSType {
+	case "", api.OSTypeLinux:
+		if osProfile.WindowsConfiguration != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("windowsConfiguration"), "windows configuration is only allowed for the Windows OS type"))
+		}
+		return allErrs
+	case api.OSTypeWindows:
+	default:
+		return append(allErrs, field.NotSupported(fldPath.Child("osType"), osProfile.OSType, []string{api.OSTypeLinux, api.OSTypeWindows}))
+	}
+
+	config := osProfile.WindowsConfiguration
+	if config == nil {
+		config = &api.AzureWindowsConfiguration{}
+	}
+	passwordKey := config.AdminPasswordSecretKey
+	if passwordKey == "" {
+		passwordKey = api.WindowsAdminPasswordSecretKey
+	}
+	if secret != nil && len(secret.Data[passwordKey]) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("windowsConfiguration.adminPasswordSecretKey").Key(passwordKey), "the secret key with the admin password is required for Windows VMs"))
+	}
+
+	for i, listener := range config.WinRM {
+		idxPath := fldPath.Child("windowsConfiguration.winRM").Index(i)
+		switch listener.Protocol {
+		case api.WinRMProtocolHTTP:
+		case api.WinRMProtocolHTTPS:
+			if listener.CertificateURL == nil || !isHTTPSURL(*listener.CertificateURL) {
+				allErrs = append(allErrs, field.Required(idxPath.Child("certificateURL"), "Https listeners require the HTTPS URL of a Key Vault certificate"))
+			}
+		default:
+			allErrs = append(allErrs, field.NotSupported(idxPath.Child("protocol"), listener.Protocol, []string{api.WinRMProtocolHTTP, api.WinRMProtocolHTTPS}))
+		}
+	}
+
+	return allErrs
+}
+
+func validateComputerNameTemplate(fldPath *field.Path, osProfile api.AzureOSProfile) []error {
+	var allErrs []error
+
+	nameTemplate := osProfile.ComputerNameTemplate
+	if nameTemplate == nil {
+		return allErrs
+	}
+	if _, err := template.New("computerName").Parse(nameTemplate.Template); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("template"), nameTemplate.Template, err.Error()))
+	}
+
+	maxLength := 64
+	if osProfile.OSType == api.OSTypeWindows {
+		maxLength = 15
+	}
+	if nameTemplate.MaxLength != nil && (*nameTemplate.MaxLength < 7 || *nameTemplate.MaxLength > maxLength) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxLength"), *nameTemplate.MaxLength, fmt.Sprintf("must be between 7 and %d", maxLength)))
+	}
+
+	return allErrs
+}
+
+func validateDiskEncryptionSetID(fldPath *field.Path, diskEncryptionSetID *string) []error {
+	if diskEncryptionSetID != nil && !isResourceID(*diskEncryptionSetID, "Microsoft.Compute", "diskEncryptionSets") {
+		return []error{field.Invalid(fldPath, *diskEncryptionSetID, "must be the resource ID of a disk encryption set")}
+	}
+	return nil
+}
+
+// validateWriteAccelerator rejects write accelerated disks of VM sizes other than the M-series, of non-premium
+// storage and with host caching other than None or ReadOnly, which Azure rejects. The storage account type is not
+// checked if it is inherited from an image or an existing disk.
+func validateWriteAccelerator(fldPath *field.Path, enabled *bool, vmSize, caching, storageAccountType string) []error {
+	var allErrs []error
+	if enabled == nil || !*enabled {
+		return nil
+	}
+	if !strings.HasPrefix(strings.ToLower(vmSize), "standard_m") {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("writeAcceleratorEnabled"), "write accelerator is only supported by M-series VM sizes"))
+	}
+	if caching != api.CachingNone && caching != api.CachingReadOnly {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("caching"), caching, []string{api.CachingNone, api.CachingReadOnly}))
+	}
+	if storageAccountType != "" && !strings.HasPrefix(storageAccountType, "Premium") {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("writeAcceleratorEnabled"), "write accelerator is only supported for premium storage"))
+	}
+	return allErrs
+}
+
+func isResourceID(id, provider, resourceType string) bool {
+	resource, err := azure.ParseResourceID(id)
+	return err == nil && strings.EqualFold(resource.Provider, provider) && strings.EqualFold(resource.ResourceType, resourceType)
+}
+
+// isChildResourceID returns true if the ID is the resource ID of a child resource of the given type, e.g. a backend
+// address pool of a load balancer
+func isChildResourceID(id, provider, resourceType, childType string) bool {
+	if !isResourceID(id, provider, resourceType) {
+		return false
+	}
+	segments := strings.Split(strings.Trim(id, "/"), "/")
+	return len(segments) == 10 && strings.EqualFold(segments[8], childType) && segments[9] != ""
+}
+
 func validateSpecProperties(properties api.AzureVirtualMachineProperties) []error {
 	var allErrs []error
 
@@ -66,22 +577,7 @@ func validateSpecProperties(properties api.AzureVirtualMachineProperties) []erro
 		allErrs = append(allErrs, fmt.Errorf("VMSize is required"))
 	}
 
-	imageRef := properties.StorageProfile.ImageReference
-	if ((imageRef.URN == nil || *imageRef.URN == "") && imageRef.ID == "") ||
-		(imageRef.URN != nil && *imageRef.URN != "" && imageRef.ID != "") {
-		allErrs = append(allErrs, field.Required(fldPath.Child("storageProfile.imageReference"), "must specify either a image id or an urn"))
-	} else if imageRef.URN != nil && *imageRef.URN != "" {
-		splits := strings.Split(*imageRef.URN, ":")
-		if len(splits) != 4 {
-			allErrs = append(allErrs, field.Required(fldPath.Child("storageProfile.imageReference.urn"), "Invalid urn format"))
-		} else {
-			for _, s := range splits {
-				if len(s) == 0 {
-					allErrs = append(allErrs, field.Required(fldPath.Child("storageProfile.imageReference.urn"), "Invalid urn format, empty field"))
-				}
-			}
-		}
-	}
+	allErrs = append(allErrs, validateImageReference(fldPath.Child("storageProfile.imageReference"), properties.StorageProfile.ImageReference)...)
 
 	if properties.StorageProfile.OsDisk.DiskSizeGB <= 0 {
 		allErrs = append(allErrs, field.Required(fldPath.Child("storageProfile.osDisk.diskSizeGB"), "OSDisk size must be positive"))
@@ -89,6 +585,14 @@ func validateSpecProperties(properties api.AzureVirtualMachineProperties) []erro
 	if properties.StorageProfile.OsDisk.CreateOption == "" {
 		allErrs = append(allErrs, field.Required(fldPath.Child("storageProfile.osDisk.createOption"), "OSDisk create option is required"))
 	}
+	allErrs = append(allErrs, validateDiskEncryptionSetID(fldPath.Child("storageProfile.osDisk.diskEncryptionSetID"), properties.StorageProfile.OsDisk.DiskEncryptionSetID)...)
+	switch properties.StorageProfile.DiskControllerType {
+	case "", api.DiskControllerTypeSCSI, api.DiskControllerTypeNVMe:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("storageProfile.diskControllerType"), properties.StorageProfile.DiskControllerType, []string{api.DiskControllerTypeSCSI, api.DiskControllerTypeNVMe}))
+	}
+	osDisk := properties.StorageProfile.OsDisk
+	allErrs = append(allErrs, validateWriteAccelerator(fldPath.Child("storageProfile.osDisk"), osDisk.WriteAcceleratorEnabled, properties.HardwareProfile.VMSize, osDisk.Caching, osDisk.ManagedDisk.StorageAccountType)...)
 
 	if properties.StorageProfile.DataDisks != nil {
 
@@ -96,17 +600,29 @@ func validateSpecProperties(properties api.AzureVirtualMachineProperties) []erro
 			allErrs = append(allErrs, field.TooMany(fldPath.Child("storageProfile.dataDisks"), len(properties.StorageProfile.DataDisks), 64))
 		}
 
+		lunOffset := properties.StorageProfile.DataDiskLunOffset
+		if lunOffset < 0 || lunOffset > 63 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("storageProfile.dataDiskLunOffset"), lunOffset, utilvalidation.InclusiveRangeError(0, 63)))
+		}
+
 		luns := map[int32]int{}
 		for i, dataDisk := range properties.StorageProfile.DataDisks {
 			idxPath := fldPath.Child("storageProfile.dataDisks").Index(i)
 
 			lun := dataDisk.Lun
+			if lun == nil && lunOffset > 0 {
+				// Data disks without LUN are assigned consecutive LUNs starting at the offset
+				assigned := lunOffset + int32(i)
+				lun = &assigned
+			}
 
 			if lun == nil {
 				allErrs = append(allErrs, field.Required(idxPath.Child("lun"), "DataDisk Lun is required"))
 			} else {
 				if *lun < 0 || *lun > 63 {
 					allErrs = append(allErrs, field.Invalid(idxPath.Child("lun"), *lun, utilvalidation.InclusiveRangeError(0, 63)))
+				} else if *lun < lunOffset {
+					allErrs = append(allErrs, field.Invalid(idxPath.Child("lun"), *lun, fmt.Sprintf("LUNs below the data disk LUN offset %d are reserved", lunOffset)))
 				}
 				if _, keyExist := luns[*lun]; keyExist {
 					luns[*lun]++
@@ -115,12 +631,73 @@ func validateSpecProperties(properties api.AzureVirtualMachineProperties) []erro
 				}
 			}
 
-			if dataDisk.DiskSizeGB <= 0 {
-				allErrs = append(allErrs, field.Required(idxPath.Child("diskSizeGB"), "DataDisk size must be positive"))
+			switch dataDisk.CreateOption {
+			case "", api.DataDiskCreateOptionEmpty:
+				if dataDisk.DiskSizeGB <= 0 {
+					allErrs = append(allErrs, field.Required(idxPath.Child("diskSizeGB"), "DataDisk size must be positive"))
+				}
+				if dataDisk.StorageAccountType == "" {
+					allErrs = append(allErrs, field.Required(idxPath.Child("storageAccountType"), "DataDisk storage account type is required"))
+				}
+			case api.DataDiskCreateOptionAttach:
+				if dataDisk.ManagedDiskID == nil {
+					allErrs = append(allErrs, field.Required(idxPath.Child("managedDiskID"), "managed disk ID is required for attached data disks"))
+				} else if !isResourceID(*dataDisk.ManagedDiskID, "Microsoft.Compute", "disks") {
+					allErrs = append(allErrs, field.Invalid(idxPath.Child("managedDiskID"), *dataDisk.ManagedDiskID, "must be the resource ID of a managed disk"))
+				}
+				if dataDisk.MaxShares == nil || *dataDisk.MaxShares <= 1 {
+					allErrs = append(allErrs, field.Required(idxPath.Child("maxShares"), "attached data disks are attached by all machines of the class and must be shared"))
+				}
+			case api.DataDiskCreateOptionFromImage:
+				if dataDisk.DiskSizeGB < 0 {
+					allErrs = append(allErrs, field.Invalid(idxPath.Child("diskSizeGB"), dataDisk.DiskSizeGB, "DataDisk size must not be negative"))
+				}
+			default:
+				allErrs = append(allErrs, field.NotSupported(idxPath.Child("createOption"), dataDisk.CreateOption, []string{api.DataDiskCreateOptionEmpty, api.DataDiskCreateOptionAttach, api.DataDiskCreateOptionFromImage}))
 			}
-			if dataDisk.StorageAccountType == "" {
-				allErrs = append(allErrs, field.Required(idxPath.Child("storageAccountType"), "DataDisk storage account type is required"))
+			if dataDisk.ManagedDiskID != nil && dataDisk.CreateOption != api.DataDiskCreateOptionAttach {
+				allErrs = append(allErrs, field.Forbidden(idxPath.Child("managedDiskID"), "managed disk ID is only allowed for attached data disks"))
 			}
+			switch dataDisk.DeleteOption {
+			case "", api.DataDiskDeleteOptionDetach:
+			case api.DataDiskDeleteOptionDelete:
+				if dataDisk.CreateOption == api.DataDiskCreateOptionAttach {
+					allErrs = append(allErrs, field.Forbidden(idxPath.Child("deleteOption"), "attached data disks are never deleted with the VM"))
+				}
+			default:
+				allErrs = append(allErrs, field.NotSupported(idxPath.Child("deleteOption"), dataDisk.DeleteOption, []string{api.DataDiskDeleteOptionDelete, api.DataDiskDeleteOptionDetach}))
+			}
+			allErrs = append(allErrs, validateDiskEncryptionSetID(idxPath.Child("diskEncryptionSetID"), dataDisk.DiskEncryptionSetID)...)
+			if dataDisk.MaxShares != nil {
+				if *dataDisk.MaxShares < 1 {
+					allErrs = append(allErrs, field.Invalid(idxPath.Child("maxShares"), *dataDisk.MaxShares, "must be positive"))
+				} else if *dataDisk.MaxShares > 1 {
+					switch dataDisk.CreateOption {
+					case "", api.DataDiskCreateOptionEmpty:
+						if dataDisk.Name == "" {
+							allErrs = append(allErrs, field.Required(idxPath.Child("name"), "shared data disks are named after the machine class and their name"))
+						}
+						if len(properties.Zones) > 1 && !strings.HasSuffix(dataDisk.StorageAccountType, "_ZRS") {
+							allErrs = append(allErrs, field.Forbidden(idxPath.Child("storageAccountType"), "shared data disks of machines spread across zones must be zone-redundant"))
+						}
+					case api.DataDiskCreateOptionAttach:
+					default:
+						allErrs = append(allErrs, field.Forbidden(idxPath.Child("maxShares"), "only empty or attached data disks can be shared"))
+					}
+					if dataDisk.Caching != "" && dataDisk.Caching != api.CachingNone {
+						allErrs = append(allErrs, field.Forbidden(idxPath.Child("caching"), "shared data disks do not support host caching"))
+					}
+					if dataDisk.WriteAcceleratorEnabled != nil && *dataDisk.WriteAcceleratorEnabled {
+						allErrs = append(allErrs, field.Forbidden(idxPath.Child("writeAcceleratorEnabled"), "shared data disks do not support the write accelerator"))
+					}
+				}
+			}
+			caching := dataDisk.Caching
+			if caching == "" {
+				// Data disks are created without caching by default
+				caching = api.CachingNone
+			}
+			allErrs = append(allErrs, validateWriteAccelerator(idxPath, dataDisk.WriteAcceleratorEnabled, properties.HardwareProfile.VMSize, caching, dataDisk.StorageAccountType)...)
 		}
 
 		for lun, number := range luns {
@@ -129,19 +706,40 @@ func validateSpecProperties(properties api.AzureVirtualMachineProperties) []erro
 			}
 		}
 	}
-	if properties.OsProfile.AdminUsername == "" {
-		allErrs = append(allErrs, field.Required(fldPath.Child("osProfile.adminUsername"), "AdminUsername is required"))
-	}
+	allErrs = append(allErrs, validateOSProfile(fldPath.Child("osProfile"), properties.OsProfile)...)
 
-	if properties.Zone == nil && properties.MachineSet == nil && properties.AvailabilitySet == nil {
-		allErrs = append(allErrs, field.Forbidden(fldPath.Child("zone|.machineSet|.availabilitySet"), "Machine need to be assigned to a zone, a MachineSet or an AvailabilitySet"))
+	zoned := properties.Zone != nil || len(properties.Zones) > 0
+	if properties.Regional {
+		if zoned || properties.MachineSet != nil || properties.AvailabilitySet != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("regional"), "Regional machine cannot be assigned to a zone, a MachineSet or an AvailabilitySet"))
+		}
+	} else if !zoned && properties.MachineSet == nil && properties.AvailabilitySet == nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("zone|.machineSet|.availabilitySet|.regional"), "Machine need to be assigned to a zone, a MachineSet or an AvailabilitySet, or be regional"))
 	}
 
-	if properties.Zone != nil && (properties.MachineSet != nil || properties.AvailabilitySet != nil) {
+	if zoned && (properties.MachineSet != nil || properties.AvailabilitySet != nil) {
 		allErrs = append(allErrs, field.Forbidden(fldPath.Child("zone|.machineSet|.availabilitySet"), "Machine cannot be assigned to a zone, a MachineSet and an AvailabilitySet in parallel"))
 	}
 
-	if properties.Zone == nil {
+	if properties.Zone != nil && len(properties.Zones) > 0 {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("zones"), "Machine cannot be assigned to a zone and spread across zones in parallel"))
+	}
+	zones := map[int]bool{}
+	for i, zone := range properties.Zones {
+		if zone < 1 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("zones").Index(i), zone, "must be a positive zone number"))
+		}
+		if zones[zone] {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("zones").Index(i), zone))
+		}
+		zones[zone] = true
+	}
+
+	if properties.AvailabilitySet != nil {
+		allErrs = append(allErrs, validateAvailabilitySet(properties.AvailabilitySet, fldPath.Child("availabilitySet"))...)
+	}
+
+	if !zoned {
 		if properties.MachineSet != nil && properties.AvailabilitySet != nil {
 			allErrs = append(allErrs, field.Forbidden(fldPath.Child("machineSet|.availabilitySet"), "Machine cannot be assigned a MachineSet and an AvailabilitySet in parallel"))
 		}
@@ -153,41 +751,281 @@ func validateSpecProperties(properties api.AzureVirtualMachineProperties) []erro
 	return allErrs
 }
 
+func validateImageReference(fldPath *field.Path, imageRef api.AzureImageReference) []error {
+	var allErrs []error
+
+	if ((imageRef.URN == nil || *imageRef.URN == "") && imageRef.ID == "") ||
+		(imageRef.URN != nil && *imageRef.URN != "" && imageRef.ID != "") {
+		allErrs = append(allErrs, field.Required(fldPath, "must specify either a image id or an urn"))
+	} else if imageRef.URN != nil && *imageRef.URN != "" {
+		splits := strings.Split(*imageRef.URN, ":")
+		if len(splits) != 4 {
+			allErrs = append(allErrs, field.Required(fldPath.Child("urn"), "Invalid urn format"))
+		} else {
+			for _, s := range splits {
+				if len(s) == 0 {
+					allErrs = append(allErrs, field.Required(fldPath.Child("urn"), "Invalid urn format, empty field"))
+				}
+			}
+		}
+	}
+
+	return allErrs
+}
+
+func validateOSProfile(fldPath *field.Path, osProfile api.AzureOSProfile) []error {
+	var allErrs []error
+
+	if osProfile.AdminUsername == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("adminUsername"), "AdminUsername is required"))
+	}
+	if osProfile.OSType != api.OSTypeWindows {
+		allErrs = append(allErrs, validateSSHPublicKeys(osProfile.LinuxConfiguration.SSH, osProfile.AdminUsername, fldPath.Child("linuxConfiguration.ssh"))...)
+	}
+	if osProfile.ProvisionVMAgent != nil && !*osProfile.ProvisionVMAgent &&
+		osProfile.AllowExtensionOperations != nil && *osProfile.AllowExtensionOperations {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("allowExtensionOperations"), "Extension operations cannot be allowed if the VM agent is not provisioned"))
+	}
+
+	return allErrs
+}
+
+func validateAlternativeOSProfiles(fldPath *field.Path, properties api.AzureVirtualMachineProperties, secret *corev1.Secret) []error {
+	var allErrs []error
+
+	for name, alternative := range properties.AlternativeOSProfiles {
+		keyPath := fldPath.Key(name)
+		if errs := utilvalidation.IsValidLabelValue(name); len(errs) > 0 || name == "" {
+			allErrs = append(allErrs, field.Invalid(keyPath, name, "must be a non-empty label value"))
+		}
+		allErrs = append(allErrs, validateImageReference(keyPath.Child("imageReference"), alternative.ImageReference)...)
+		allErrs = append(allErrs, validateLicenseType(keyPath.Child("licenseType"), alternative.LicenseType)...)
+		allErrs = append(allErrs, validateOSProfile(keyPath.Child("osProfile"), alternative.OsProfile)...)
+		allErrs = append(allErrs, validateWindowsConfiguration(keyPath.Child("osProfile"), alternative.OsProfile, secret)...)
+		allErrs = append(allErrs, validateSSHPublicKeysSecretKey(keyPath.Child("osProfile.linuxConfiguration.ssh"), alternative.OsProfile, secret)...)
+		allErrs = append(allErrs, validateComputerNameTemplate(keyPath.Child("osProfile.computerNameTemplate"), alternative.OsProfile)...)
+		if len(properties.Extensions) == 0 {
+			continue
+		}
+		if alternative.OsProfile.ProvisionVMAgent != nil && !*alternative.OsProfile.ProvisionVMAgent {
+			allErrs = append(allErrs, field.Forbidden(keyPath.Child("osProfile.provisionVMAgent"), "extensions cannot be installed if the VM agent is not provisioned"))
+		}
+		if alternative.OsProfile.AllowExtensionOperations != nil && !*alternative.OsProfile.AllowExtensionOperations {
+			allErrs = append(allErrs, field.Forbidden(keyPath.Child("osProfile.allowExtensionOperations"), "extensions cannot be installed if extension operations are not allowed"))
+		}
+	}
+
+	return allErrs
+}
+
+func validateAvailabilitySet(availabilitySet *api.AzureAvailabilitySet, fldPath *field.Path) []error {
+	var allErrs []error
+
+	if availabilitySet.ID == "" && availabilitySet.Name == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("id|.name"), "AvailabilitySet needs to be referenced by ID or by name"))
+	}
+	if availabilitySet.ID != "" && availabilitySet.Name != "" {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("id|.name"), "AvailabilitySet cannot be referenced by ID and by name in parallel"))
+	}
+	if availabilitySet.Name == "" {
+		if availabilitySet.PlatformFaultDomainCount != nil || availabilitySet.PlatformUpdateDomainCount != nil || availabilitySet.DeleteWhenEmpty {
+			allErrs = append(allErrs, field.Forbidden(fldPath, "Domain counts and deletion when empty are only supported for an AvailabilitySet referenced by name"))
+		}
+	}
+	if count := availabilitySet.PlatformFaultDomainCount; count != nil && (*count < 1 || *count > 3) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("platformFaultDomainCount"), *count, "must be between 1 and 3"))
+	}
+	if count := availabilitySet.PlatformUpdateDomainCount; count != nil && (*count < 1 || *count > 20) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("platformUpdateDomainCount"), *count, "must be between 1 and 20"))
+	}
+
+	return allErrs
+}
+
+// validateSSHPublicKeys validates the public keys of the SSH configuration. Azure only places keys under the .ssh
+// directory of the admin user, so keys for further users have to be placed by the user data.
+func validateSSHPublicKeys(ssh api.AzureSSHConfiguration, adminUsername string, fldPath *field.Path) []error {
+	var allErrs []error
+
+	paths := map[string]int{}
+	for i, key := range ssh.PublicKeys {
+		if key.KeyData == "" && key.Path == "" {
+			// An empty key object is ignored for backward compatibility
+			continue
+		}
+		allErrs = append(allErrs, validateSSHPublicKey(fldPath.Child("publicKeys").Index(i), key, adminUsername)...)
+		paths[key.Path+"/"+key.KeyData]++
+	}
+	for i, key := range ssh.AdditionalPublicKeys {
+		keyPath := fldPath.Child("additionalPublicKeys").Index(i)
+		if key.Path == "" {
+			allErrs = append(allErrs, field.Required(keyPath.Child("path"), "Path is required for additional public keys"))
+		}
+		allErrs = append(allErrs, validateSSHPublicKey(keyPath, key, adminUsername)...)
+		paths[key.Path+"/"+key.KeyData]++
+	}
+
+	for _, number := range paths {
+		if number > 1 {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("publicKeys|additionalPublicKeys"), "Public keys must be unique per path"))
+			break
+		}
+	}
+
+	return allErrs
+}
+
+// validateSSHPublicKeysSecretKey validates the public keys of the machine class secret, which are only validated if
+// the secret is given
+func validateSSHPublicKeysSecretKey(fldPath *field.Path, osProfile api.AzureOSProfile, secret *corev1.Secret) []error {
+	var allErrs []error
+
+	secretKey := osProfile.LinuxConfiguration.SSH.PublicKeysSecretKey
+	if secretKey == "" {
+		return nil
+	}
+	if osProfile.OSType == api.OSTypeWindows {
+		return append(allErrs, field.Forbidden(fldPath.Child("publicKeysSecretKey"), "public keys are only allowed for Linux VMs"))
+	}
+	if secret == nil {
+		return nil
+	}
+	keys := api.ParseAuthorizedKeys(secret.Data[secretKey])
+	if len(keys) == 0 {
+		return append(allErrs, fmt.Errorf("secret %s is required for the public keys of %s", secretKey, fldPath.Child("publicKeysSecretKey")))
+	}
+	for i, key := range keys {
+		allErrs = append(allErrs, validateSSHPublicKey(fldPath.Child("publicKeysSecretKey").Key(secretKey).Index(i), key, osProfile.AdminUsername)...)
+	}
+	return allErrs
+}
+
+func validateSSHPublicKey(fldPath *field.Path, key api.AzureSSHPublicKey, adminUsername string) []error {
+	var allErrs []error
+
+	if key.KeyData == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("keyData"), "KeyData is required"))
+	}
+	if key.Path == "" {
+		return allErrs
+	}
+	sshDir := fmt.Sprintf("/home/%s/.ssh/", adminUsername)
+	if !path.IsAbs(key.Path) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("path"), key.Path, "Path must be absolute"))
+	} else if adminUsername != "" && !strings.HasPrefix(path.Clean(key.Path), sshDir) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("path"), key.Path, fmt.Sprintf("Path must be below %s, as Azure only places public keys for the admin user", sshDir)))
+	}
+	return allErrs
+}
+
+// validateSpecTags validates the cluster and role tags, as ListMachines and the orphan collection only consider the
+// resources carrying them. Resources of machine classes without them could neither be listed nor collected.
 func validateSpecTags(tags map[string]string) []error {
 
 	var fldPath *field.Path
 	var allErrs []error
 
 	fldPath = field.NewPath("providerSpec")
-	clusterName := ""
-	nodeRole := ""
+	var clusterTags, roleTags []string
 
 	for key := range tags {
-		if strings.Contains(key, "kubernetes.io-cluster-") {
-			clusterName = key
-		} else if strings.Contains(key, "kubernetes.io-role-") {
-			nodeRole = key
+		if strings.HasPrefix(key, api.ClusterTagPrefix) {
+			clusterTags = append(clusterTags, key)
+		} else if strings.HasPrefix(key, api.RoleTagPrefix) {
+			roleTags = append(roleTags, key)
 		}
 	}
+	sort.Strings(clusterTags)
 
-	if clusterName == "" {
+	if len(clusterTags) == 0 {
 		allErrs = append(allErrs, field.Required(fldPath.Child("kubernetes.io-cluster-"), "Tag required of the form kubernetes.io-cluster-****"))
+	} else if len(clusterTags) > 1 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("tags"), strings.Join(clusterTags, ", "), "only one tag of the form kubernetes.io-cluster-**** is allowed"))
 	}
-	if nodeRole == "" {
+	if len(roleTags) == 0 {
 		allErrs = append(allErrs, field.Required(fldPath.Child("kubernetes.io-role-"), "Tag required of the form kubernetes.io-role-****"))
 	}
 
+	for _, key := range append(clusterTags, roleTags...) {
+		switch {
+		case key == api.ClusterTagPrefix || key == api.RoleTagPrefix:
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("tags"), key, "tag must name the cluster or role after its prefix"))
+		case strings.ContainsAny(key, invalidTagNameCharacters):
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("tags"), key, fmt.Sprintf("tag must not contain any of the characters %s", invalidTagNameCharacters)))
+		case tags[key] == "":
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("tags").Key(key), tags[key], "tag must have a value"))
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateReservedTags rejects tags reserved by the operator, e.g. cost center tags which are enforced by governance and
+// must not be overridden by the authors of machine classes, see ReservedTags
+func ValidateReservedTags(tags map[string]string, reservedKeys []string) []error {
+	var (
+		allErrs []error
+		fldPath = field.NewPath("providerSpec", "tags")
+	)
+
+	for _, key := range ReservedTags(tags, reservedKeys) {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Key(key), "tag is reserved and must not be set by the machine class"))
+	}
 	return allErrs
 }
 
+// ReservedTags returns the sorted keys of the tags which are reserved by the operator. A reserved key ending with a *
+// reserves all keys with the prefix before it. Keys are compared case-insensitively, as Azure does.
+func ReservedTags(tags map[string]string, reservedKeys []string) []string {
+	var keys []string
+	for key := range tags {
+		if isReservedTagKey(key, reservedKeys) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func isReservedTagKey(key string, reservedKeys []string) bool {
+	key = strings.ToLower(key)
+	for _, reservedKey := range reservedKeys {
+		reservedKey = strings.ToLower(reservedKey)
+		if prefix := strings.TrimSuffix(reservedKey, "*"); prefix != reservedKey {
+			if strings.HasPrefix(key, prefix) {
+				return true
+			}
+		} else if key == reservedKey {
+			return true
+		}
+	}
+	return false
+}
+
 func validateSecrets(secret *corev1.Secret) []error {
 	var allErrs []error
 
-	if "" == string(secret.Data[api.AzureClientID]) && "" == string(secret.Data[api.AzureAlternativeClientID]) {
-		allErrs = append(allErrs, fmt.Errorf("secret %s or %s is required field", api.AzureClientID, api.AzureAlternativeClientID))
-	}
-	if "" == string(secret.Data[api.AzureClientSecret]) && "" == string(secret.Data[api.AzureAlternativeClientSecret]) {
-		allErrs = append(allErrs, fmt.Errorf("secret %s or %s is required field", api.AzureClientSecret, api.AzureAlternativeClientSecret))
+	var (
+		missingClientID     = "" == string(secret.Data[api.AzureClientID]) && "" == string(secret.Data[api.AzureAlternativeClientID])
+		missingClientSecret = "" == string(secret.Data[api.AzureClientSecret]) && "" == string(secret.Data[api.AzureAlternativeClientSecret]) &&
+			"" == string(secret.Data[api.AzureWorkloadIdentityTokenFile]) && "" == string(secret.Data[api.AzureWorkloadIdentityToken])
+		useManagedIdentity = "true" == string(secret.Data[api.AzureUseManagedIdentity])
+	)
+	// Only secrets opting in explicitly authenticate with the managed identity of the provider
+	if useManagedIdentity {
+		if !missingClientID || !missingClientSecret {
+			allErrs = append(allErrs, fmt.Errorf("secret %s is only allowed without client credentials", api.AzureUseManagedIdentity))
+		}
+	} else {
+		if missingClientID {
+			allErrs = append(allErrs, fmt.Errorf("secret %s or %s is required field unless %s is true", api.AzureClientID, api.AzureAlternativeClientID, api.AzureUseManagedIdentity))
+		}
+		if missingClientSecret {
+			allErrs = append(allErrs, fmt.Errorf("secret %s or %s is required field unless a workload identity token (%s or %s) is provided or %s is true", api.AzureClientSecret, api.AzureAlternativeClientSecret, api.AzureWorkloadIdentityTokenFile, api.AzureWorkloadIdentityToken, api.AzureUseManagedIdentity))
+		}
+		if "" != string(secret.Data[api.AzureManagedIdentityClientID]) {
+			allErrs = append(allErrs, fmt.Errorf("secret %s is only allowed if %s is true", api.AzureManagedIdentityClientID, api.AzureUseManagedIdentity))
+		}
 	}
 	if "" == string(secret.Data[api.AzureTenantID]) && "" == string(secret.Data[api.AzureAlternativeTenantID]) {
 		allErrs = append(allErrs, fmt.Errorf("secret %s or %s is required field", api.AzureTenantID, api.AzureAlternativeTenantID))
@@ -0,0 +1,198 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"github.com/Azure/go-autorest/autorest/to"
+	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const diskID = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg/providers/Microsoft.Compute/disks/shared"
+
+var _ = Describe("Validation", func() {
+	Describe("#validateSpecTags", func() {
+		It("should accept a cluster and a role tag", func() {
+			Expect(validateSpecTags(map[string]string{"kubernetes.io-cluster-shoot--foo--bar": "1", "kubernetes.io-role-node": "1", "Name": "bar"})).To(BeEmpty())
+		})
+
+		It("should require the cluster and role tags", func() {
+			Expect(validateSpecTags(map[string]string{"Name": "bar"})).To(HaveLen(2))
+		})
+
+		It("should only accept tags which carry the prefix at their beginning", func() {
+			Expect(validateSpecTags(map[string]string{"foo.kubernetes.io-cluster-bar": "1", "kubernetes.io-role-node": "1"})).To(HaveLen(1))
+		})
+
+		It("should reject several cluster tags", func() {
+			Expect(validateSpecTags(map[string]string{"kubernetes.io-cluster-foo": "1", "kubernetes.io-cluster-bar": "1", "kubernetes.io-role-node": "1"})).To(HaveLen(1))
+		})
+
+		It("should reject tags without a name, with invalid characters or without a value", func() {
+			Expect(validateSpecTags(map[string]string{"kubernetes.io-cluster-": "1", "kubernetes.io-role-node": "1"})).To(HaveLen(1))
+			Expect(validateSpecTags(map[string]string{"kubernetes.io-cluster-foo/bar": "1", "kubernetes.io-role-node": "1"})).To(HaveLen(1))
+			Expect(validateSpecTags(map[string]string{"kubernetes.io-cluster-foo": "", "kubernetes.io-role-node": "1"})).To(HaveLen(1))
+		})
+	})
+
+	Describe("#validateAPIProfileSupport", func() {
+		var spec *api.AzureProviderSpec
+
+		BeforeEach(func() {
+			spec = &api.AzureProviderSpec{CloudConfiguration: &api.CloudConfiguration{APIProfile: api.APIProfile20200901Hybrid}}
+			spec.Properties.UseUserData = true
+			spec.Properties.SecurityProfile = &api.AzureSecurityProfile{SecurityType: "TrustedLaunch"}
+			spec.Properties.StorageProfile.DiskControllerType = api.DiskControllerTypeNVMe
+		})
+
+		It("should reject the fields unsupported by the compute API version of the API profile", func() {
+			Expect(validateAPIProfileSupport(spec)).To(ConsistOf(
+				MatchError(ContainSubstring("properties.useUserData")),
+				MatchError(ContainSubstring("properties.securityProfile")),
+				MatchError(ContainSubstring("properties.storageProfile.diskControllerType")),
+			))
+		})
+
+		It("should accept all fields without an API profile", func() {
+			spec.CloudConfiguration.APIProfile = ""
+			Expect(validateAPIProfileSupport(spec)).To(BeEmpty())
+		})
+	})
+
+	DescribeTable("#validateSpecProperties data disks",
+		func(dataDisk api.AzureDataDisk, matcher OmegaMatcher) {
+			properties := api.AzureVirtualMachineProperties{}
+			properties.StorageProfile.DataDisks = []api.AzureDataDisk{dataDisk}
+			Expect(validateSpecProperties(properties)).To(matcher)
+		},
+		Entry("shared attached disk", api.AzureDataDisk{Lun: to.Int32Ptr(0), CreateOption: api.DataDiskCreateOptionAttach, ManagedDiskID: to.StringPtr(diskID), MaxShares: to.Int32Ptr(3)},
+			Not(ContainElement(MatchError(ContainSubstring("dataDisks[0]"))))),
+		Entry("attached disk without shares", api.AzureDataDisk{Lun: to.Int32Ptr(0), CreateOption: api.DataDiskCreateOptionAttach, ManagedDiskID: to.StringPtr(diskID)},
+			ContainElement(MatchError(ContainSubstring("dataDisks[0].maxShares")))),
+		Entry("attached disk with a single share", api.AzureDataDisk{Lun: to.Int32Ptr(0), CreateOption: api.DataDiskCreateOptionAttach, ManagedDiskID: to.StringPtr(diskID), MaxShares: to.Int32Ptr(1)},
+			ContainElement(MatchError(ContainSubstring("dataDisks[0].maxShares")))),
+		Entry("shared disk from the image", api.AzureDataDisk{Lun: to.Int32Ptr(0), CreateOption: api.DataDiskCreateOptionFromImage, MaxShares: to.Int32Ptr(3)},
+			ContainElement(MatchError(ContainSubstring("dataDisks[0].maxShares")))),
+		Entry("empty disk without storage account type", api.AzureDataDisk{Lun: to.Int32Ptr(0), DiskSizeGB: 10},
+			ContainElement(MatchError(ContainSubstring("dataDisks[0].storageAccountType")))),
+		Entry("empty disk with storage account type", api.AzureDataDisk{Lun: to.Int32Ptr(0), DiskSizeGB: 10, StorageAccountType: "Premium_LRS"},
+			Not(ContainElement(MatchError(ContainSubstring("dataDisks[0]"))))),
+	)
+
+	DescribeTable("#ValidateReservedTags",
+		func(reservedKeys []string, errors int) {
+			tags := map[string]string{"CostCenter": "42", "governance.example.com/owner": "team", "team": "a"}
+			Expect(ValidateReservedTags(tags, reservedKeys)).To(HaveLen(errors))
+		},
+		Entry("no reserved keys", nil, 0),
+		Entry("reserved key compared case-insensitively", []string{"costcenter"}, 1),
+		Entry("reserved key which is only a prefix", []string{"cost"}, 0),
+		Entry("reserved prefix", []string{"Governance.example.com/*"}, 1),
+		Entry("reserved keys and prefixes", []string{"costcenter", "governance.example.com/*", "team"}, 3),
+	)
+
+	DescribeTable("#validateOSProfile",
+		func(provisionVMAgent, allowExtensionOperations *bool, errors int) {
+			osProfile := api.AzureOSProfile{AdminUsername: "core", ProvisionVMAgent: provisionVMAgent, AllowExtensionOperations: allowExtensionOperations}
+			osProfile.LinuxConfiguration.SSH.PublicKeys = api.AzureSSHPublicKeys{{KeyData: "ssh-rsa a"}}
+			Expect(validateOSProfile(field.NewPath("osProfile"), osProfile)).To(HaveLen(errors))
+		},
+		Entry("defaults", nil, nil, 0),
+		Entry("VM agent and extension operations disabled", to.BoolPtr(false), to.BoolPtr(false), 0),
+		Entry("VM agent disabled", to.BoolPtr(false), nil, 0),
+		Entry("extension operations allowed without VM agent", to.BoolPtr(false), to.BoolPtr(true), 1),
+		Entry("extension operations allowed with VM agent", to.BoolPtr(true), to.BoolPtr(true), 0),
+	)
+
+	Describe("#validateWindowsConfiguration", func() {
+		It("should require the admin password in the secret", func() {
+			osProfile := api.AzureOSProfile{OSType: api.OSTypeWindows, WindowsConfiguration: &api.AzureWindowsConfiguration{AdminPasswordSecretKey: "windowsPassword"}}
+
+			Expect(validateWindowsConfiguration(field.NewPath("osProfile"), osProfile, &corev1.Secret{Data: map[string][]byte{"windowsPassword": []byte("password")}})).To(BeEmpty())
+			Expect(validateWindowsConfiguration(field.NewPath("osProfile"), osProfile, &corev1.Secret{})).To(ConsistOf(
+				MatchError(ContainSubstring("osProfile.windowsConfiguration.adminPasswordSecretKey[windowsPassword]: Required value")),
+			))
+		})
+	})
+
+	DescribeTable("#validateSSHPublicKeys",
+		func(ssh api.AzureSSHConfiguration, errors int) {
+			Expect(validateSSHPublicKeys(ssh, "core", field.NewPath("ssh"))).To(HaveLen(errors))
+		},
+		Entry("key with the default path", api.AzureSSHConfiguration{PublicKeys: api.AzureSSHPublicKeys{{KeyData: "ssh-rsa a"}}}, 0),
+		Entry("empty key object", api.AzureSSHConfiguration{PublicKeys: api.AzureSSHPublicKeys{{}}}, 0),
+		Entry("key without data", api.AzureSSHConfiguration{PublicKeys: api.AzureSSHPublicKeys{{Path: "/home/core/.ssh/authorized_keys"}}}, 1),
+		Entry("key below the .ssh directory of the admin user", api.AzureSSHConfiguration{PublicKeys: api.AzureSSHPublicKeys{{Path: "/home/core/.ssh/authorized_keys", KeyData: "ssh-rsa a"}}}, 0),
+		Entry("relative path", api.AzureSSHConfiguration{PublicKeys: api.AzureSSHPublicKeys{{Path: "core/.ssh/authorized_keys", KeyData: "ssh-rsa a"}}}, 1),
+		Entry("path of another user", api.AzureSSHConfiguration{PublicKeys: api.AzureSSHPublicKeys{{Path: "/home/admin/.ssh/authorized_keys", KeyData: "ssh-rsa a"}}}, 1),
+		Entry("path escaping the .ssh directory", api.AzureSSHConfiguration{PublicKeys: api.AzureSSHPublicKeys{{Path: "/home/core/.ssh/../.bashrc", KeyData: "ssh-rsa a"}}}, 1),
+		Entry("additional key", api.AzureSSHConfiguration{AdditionalPublicKeys: []api.AzureSSHPublicKey{{Path: "/home/core/.ssh/break_glass_keys", KeyData: "ssh-rsa a"}}}, 0),
+		Entry("additional key without path", api.AzureSSHConfiguration{AdditionalPublicKeys: []api.AzureSSHPublicKey{{KeyData: "ssh-rsa a"}}}, 1),
+		Entry("additional key of another user", api.AzureSSHConfiguration{AdditionalPublicKeys: []api.AzureSSHPublicKey{{Path: "/home/breakglass/.ssh/authorized_keys", KeyData: "ssh-rsa a"}}}, 1),
+		Entry("duplicate key", api.AzureSSHConfiguration{
+			PublicKeys:           api.AzureSSHPublicKeys{{Path: "/home/core/.ssh/authorized_keys", KeyData: "ssh-rsa a"}},
+			AdditionalPublicKeys: []api.AzureSSHPublicKey{{Path: "/home/core/.ssh/authorized_keys", KeyData: "ssh-rsa a"}},
+		}, 1),
+	)
+
+	Describe("#validateSSHPublicKeysSecretKey", func() {
+		var osProfile api.AzureOSProfile
+
+		BeforeEach(func() {
+			osProfile = api.AzureOSProfile{AdminUsername: "core"}
+			osProfile.LinuxConfiguration.SSH.PublicKeysSecretKey = "sshKeys"
+		})
+
+		It("should accept the keys of the secret", func() {
+			secret := &corev1.Secret{Data: map[string][]byte{"sshKeys": []byte("# rotated\nssh-rsa first\nssh-ed25519 second\n")}}
+			Expect(validateSSHPublicKeysSecretKey(field.NewPath("ssh"), osProfile, secret)).To(BeEmpty())
+		})
+
+		It("should require a key in the secret", func() {
+			secret := &corev1.Secret{Data: map[string][]byte{"sshKeys": []byte("# no keys\n\n")}}
+			Expect(validateSSHPublicKeysSecretKey(field.NewPath("ssh"), osProfile, secret)).To(HaveLen(1))
+		})
+
+		It("should reject the secret key for Windows VMs", func() {
+			osProfile.OSType = api.OSTypeWindows
+			Expect(validateSSHPublicKeysSecretKey(field.NewPath("ssh"), osProfile, nil)).To(HaveLen(1))
+		})
+	})
+
+	Describe("#validateSecrets", func() {
+		newSecret := func(data map[string]string) *corev1.Secret {
+			secret := &corev1.Secret{Data: map[string][]byte{
+				api.AzureTenantID:       []byte("tenant"),
+				api.AzureSubscriptionID: []byte("subscription"),
+				"userData":              []byte("data"),
+			}}
+			for key, value := range data {
+				secret.Data[key] = []byte(value)
+			}
+			return secret
+		}
+
+		It("should accept client credentials", func() {
+			Expect(validateSecrets(newSecret(map[string]string{api.AzureClientID: "client", api.AzureClientSecret: "secret"}))).To(BeEmpty())
+		})
+
+		It("should require client credentials unless the managed identity is opted in", func() {
+			Expect(validateSecrets(newSecret(nil))).To(HaveLen(2))
+			Expect(validateSecrets(newSecret(map[string]string{api.AzureManagedIdentityClientID: "identity"}))).To(HaveLen(3))
+		})
+
+		It("should accept the managed identity if opted in", func() {
+			Expect(validateSecrets(newSecret(map[string]string{api.AzureUseManagedIdentity: "true", api.AzureManagedIdentityClientID: "identity"}))).To(BeEmpty())
+		})
+
+		It("should reject opting in to the managed identity with client credentials", func() {
+			Expect(validateSecrets(newSecret(map[string]string{api.AzureUseManagedIdentity: "true", api.AzureClientID: "client", api.AzureClientSecret: "secret"}))).To(HaveLen(1))
+		})
+	})
+})
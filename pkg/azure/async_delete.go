@@ -0,0 +1,213 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-04-01/network"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/spi"
+)
+
+const provisioningStateDeleting = "Deleting"
+
+// asyncDeletionResources are the NICs and data disks of a VM resolved from its model
+type asyncDeletionResources struct {
+	networkInterfaces []networkInterface
+	dataDiskNames     []string
+}
+
+// asyncDeletions keeps the resources of the VMs whose deletion was issued asynchronously, as the VM model they are
+// resolved from is gone by the time they are deleted, and when their power off was issued. The state is kept in memory
+// only, so that after a restart only the resources following the naming convention are deleted, like for VMs deleted
+// before, and the power off is issued again.
+type asyncDeletions struct {
+	mutex     sync.Mutex
+	resources map[string]asyncDeletionResources
+	shutdowns map[string]time.Time
+}
+
+func newAsyncDeletions() *asyncDeletions {
+	return &asyncDeletions{resources: map[string]asyncDeletionResources{}, shutdowns: map[string]time.Time{}}
+}
+
+func (a *asyncDeletions) storeShutdown(key string, issuedAt time.Time) {
+	if a == nil {
+		return
+	}
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	a.shutdowns[key] = issuedAt
+}
+
+func (a *asyncDeletions) loadShutdown(key string) (time.Time, bool) {
+	if a == nil {
+		return time.Time{}, false
+	}
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	issuedAt, ok := a.shutdowns[key]
+	return issuedAt, ok
+}
+
+func (a *asyncDeletions) store(key string, resources asyncDeletionResources) {
+	if a == nil {
+		return
+	}
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	a.resources[key] = resources
+}
+
+func (a *asyncDeletions) load(key string) (asyncDeletionResources, bool) {
+	if a == nil {
+		return asyncDeletionResources{}, false
+	}
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	resources, ok := a.resources[key]
+	return resources, ok
+}
+
+func (a *asyncDeletions) forget(key string) {
+	if a == nil {
+		return
+	}
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	delete(a.resources, key)
+	delete(a.shutdowns, key)
+}
+
+// deleteVMNicDisksAsync issues the power off and the deletion of the VM and afterwards the deletion of its NICs, public
+// IPs and disks without waiting for the long running operations. It has to be called repeatedly and returns true once
+// all resources are gone.
+// Resources which are already being deleted are not deleted again. The NICs and disks of the VM model are resolved
+// before the VM deletion is issued and deleted as well, see vmResources.
+func (d *MachinePlugin) deleteVMNicDisksAsync(ctx context.Context, clients spi.AzureDriverClientsInterface, resourceGroupName string, VMName string, networkInterfaces []networkInterface, diskName string, dataDiskNames []string) (bool, error) {
+	key := strings.ToLower(resourceGroupName + "/" + VMName)
+
+	// The NICs and disks can only be deleted once they are no longer attached to the VM
+	vm, err := clients.GetVM().Get(ctx, resourceGroupName, VMName, "")
+	if err == nil {
+		resolvedNICs, resolvedDataDiskNames := vmResources(ctx, vm, resourceGroupName, networkInterfaces, diskName, dataDiskNames)
+		d.asyncDeletions.store(key, asyncDeletionResources{networkInterfaces: resolvedNICs, dataDiskNames: resolvedDataDiskNames})
+		if vm.VirtualMachineProperties == nil || !isDeleting(vm.ProvisioningState) {
+			if !d.shutDownVMAsync(ctx, clients, key, resourceGroupName, VMName) {
+				return false, nil
+			}
+			if _, err := clients.GetVM().Delete(ctx, resourceGroupName, VMName, spi.ForceDeletionParameter(ctx)); err != nil {
+				return false, spi.OnARMAPIErrorFail(prometheusServiceVM, err, "vm.Delete")
+			}
+			spi.OnARMAPISuccess(prometheusServiceVM, "VM deletion was issued for %s", VMName)
+		}
+		return false, nil
+	} else if !spi.NotFound(err) {
+		return false, spi.OnARMAPIErrorFail(prometheusServiceVM, err, "vm.Get")
+	}
+
+	if resources, ok := d.asyncDeletions.load(key); ok {
+		networkInterfaces, dataDiskNames = resources.networkInterfaces, resources.dataDiskNames
+	}
+
+	deleted := true
+	for _, nic := range networkInterfaces {
+		nicDeleted, err := d.deleteNICAsync(ctx, clients, resourceGroupName, nic)
+		if err != nil {
+			return false, err
+		}
+		deleted = deleted && nicDeleted
+	}
+
+	for _, name := range append([]string{diskName}, dataDiskNames...) {
+		diskDeleted, err := deleteDiskAsync(ctx, clients, resourceGroupName, name)
+		if err != nil {
+			return false, err
+		}
+		deleted = deleted && diskDeleted
+	}
+
+	if deleted {
+		d.asyncDeletions.forget(key)
+	}
+	return deleted, nil
+}
+
+// deleteNICAsync issues the deletion of the NIC and, once it is gone, of its public IP
+func (d *MachinePlugin) deleteNICAsync(ctx context.Context, clients spi.AzureDriverClientsInterface, resourceGroupName string, nic networkInterface) (bool, error) {
+	resourceGroupName = nic.resourceGroupName(resourceGroupName)
+	NIC, err := clients.GetNic().Get(ctx, resourceGroupName, nic.name, "")
+	if err == nil {
+		if NIC.InterfacePropertiesFormat == nil || NIC.ProvisioningState != network.Deleting {
+			if err := removeForeignReferences(ctx, clients, resourceGroupName, NIC, nic); err != nil {
+				return false, err
+			}
+			if err := d.deleteReservedNIC(ctx, clients, resourceGroupName, nic.name, func() error {
+				if _, err := clients.GetNic().Delete(ctx, resourceGroupName, nic.name); err != nil {
+					return spi.OnARMAPIErrorFail(prometheusServiceNIC, err, "nic.Delete")
+				}
+				return nil
+			}); err != nil {
+				return false, err
+			}
+			spi.OnARMAPISuccess(prometheusServiceNIC, "NIC deletion was issued for %s", nic.name)
+		}
+		return false, nil
+	} else if !spi.NotFound(err) {
+		return false, spi.OnARMAPIErrorFail(prometheusServiceNIC, err, "nic.Get")
+	}
+	d.forgetReservedNIC(resourceGroupName, nic.name)
+
+	// The public IP is deleted even if the provider spec does not configure one anymore, as it may have been created
+	// before the public IP was switched off
+	if nic.publicIPName == "" {
+		return true, nil
+	}
+	publicIP, err := clients.GetPublicIP().Get(ctx, resourceGroupName, nic.publicIPName, "")
+	if err == nil {
+		if publicIP.PublicIPAddressPropertiesFormat == nil || publicIP.ProvisioningState != network.Deleting {
+			if _, err := clients.GetPublicIP().Delete(ctx, resourceGroupName, nic.publicIPName); err != nil {
+				return false, spi.OnARMAPIErrorFail(prometheusServicePublicIP, err, "PublicIP.Delete")
+			}
+			spi.OnARMAPISuccess(prometheusServicePublicIP, "PublicIP deletion was issued for %s", nic.publicIPName)
+		}
+		return false, nil
+	} else if !spi.NotFound(err) {
+		return false, spi.OnARMAPIErrorFail(prometheusServicePublicIP, err, "PublicIP.Get")
+	}
+	return true, nil
+}
+
+// deleteDiskAsync issues the deletion of the disk, unless it is still attached to a VM
+func deleteDiskAsync(ctx context.Context, clients spi.AzureDriverClientsInterface, resourceGroupName, diskName string) (bool, error) {
+	disk, err := clients.GetDisk().Get(ctx, resourceGroupName, diskName)
+	if err != nil {
+		if spi.NotFound(err) {
+			return true, nil
+		}
+		return false, spi.OnARMAPIErrorFail(prometheusServiceDisk, err, "disk.Get")
+	}
+
+	if disk.ManagedBy != nil {
+		spi.V(2).InfoS(ctx, "Disk is still attached to a VM, waiting for its detachment", "disk", diskName, "vm", disk.ManagedBy)
+		return false, nil
+	}
+	if disk.DiskProperties == nil || !isDeleting(disk.ProvisioningState) {
+		if _, err := clients.GetDisk().Delete(ctx, resourceGroupName, diskName); err != nil {
+			return false, spi.OnARMAPIErrorFail(prometheusServiceDisk, err, "disk.Delete")
+		}
+		spi.OnARMAPISuccess(prometheusServiceDisk, "Disk deletion was issued for %s", diskName)
+	}
+	return false, nil
+}
+
+func isDeleting(provisioningState *string) bool {
+	return provisioningState != nil && strings.EqualFold(*provisioningState, provisioningStateDeleting)
+}
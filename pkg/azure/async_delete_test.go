@@ -0,0 +1,181 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-08-01/compute"
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-04-01/network"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/mock"
+	gomock "github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var _ = Describe("AsyncDeletion", func() {
+	var (
+		ctx               = context.Background()
+		resourceGroupName = "rg"
+		vmName            = "machine-0"
+		notFound          = autorest.DetailedError{Response: &http.Response{StatusCode: http.StatusNotFound}}
+
+		driver            *MachinePlugin
+		clients           *mock.AzureDriverClients
+		networkInterfaces []networkInterface
+	)
+
+	BeforeEach(func() {
+		spi := mock.NewMockPluginSPIImpl(gomock.NewController(GinkgoT()))
+		driverClients, err := spi.Setup(&corev1.Secret{}, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		driver = NewAzureDriver(spi)
+		clients = driverClients.(*mock.AzureDriverClients)
+		networkInterfaces = getNetworkInterfaces(&api.AzureProviderSpec{}, vmName)
+	})
+
+	It("should issue the deletion of the VM first", func() {
+		clients.VM.EXPECT().Get(ctx, resourceGroupName, vmName, compute.InstanceViewTypes("")).Return(compute.VirtualMachine{
+			VirtualMachineProperties: &compute.VirtualMachineProperties{ProvisioningState: to.StringPtr("Succeeded")},
+		}, nil)
+		clients.VM.EXPECT().Delete(ctx, resourceGroupName, vmName, nil).Return(compute.VirtualMachinesDeleteFuture{}, nil)
+
+		deleted, err := driver.deleteVMNicDisksAsync(ctx, clients, resourceGroupName, vmName, networkInterfaces, "machine-0-os-disk", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(deleted).To(BeFalse())
+	})
+
+	It("should issue the power off of the VM without waiting for it before its deletion", func() {
+		driver.gracefulShutdownTimeout = time.Minute
+		running := compute.VirtualMachine{VirtualMachineProperties: &compute.VirtualMachineProperties{ProvisioningState: to.StringPtr("Succeeded")}}
+
+		clients.VM.EXPECT().Get(ctx, resourceGroupName, vmName, compute.InstanceViewTypes("")).Return(running, nil).Times(3)
+		clients.VM.EXPECT().PowerOff(ctx, resourceGroupName, vmName, to.BoolPtr(false)).Return(compute.VirtualMachinesPowerOffFuture{}, nil)
+		deleted, err := driver.deleteVMNicDisksAsync(ctx, clients, resourceGroupName, vmName, networkInterfaces, "machine-0-os-disk", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(deleted).To(BeFalse())
+
+		clients.VM.EXPECT().InstanceView(ctx, resourceGroupName, vmName).Return(compute.VirtualMachineInstanceView{}, nil)
+		deleted, err = driver.deleteVMNicDisksAsync(ctx, clients, resourceGroupName, vmName, networkInterfaces, "machine-0-os-disk", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(deleted).To(BeFalse())
+
+		clients.VM.EXPECT().InstanceView(ctx, resourceGroupName, vmName).Return(compute.VirtualMachineInstanceView{
+			Statuses: &[]compute.InstanceViewStatus{{Code: to.StringPtr(powerStateStopped)}},
+		}, nil)
+		clients.VM.EXPECT().Delete(ctx, resourceGroupName, vmName, nil).Return(compute.VirtualMachinesDeleteFuture{}, nil)
+		deleted, err = driver.deleteVMNicDisksAsync(ctx, clients, resourceGroupName, vmName, networkInterfaces, "machine-0-os-disk", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(deleted).To(BeFalse())
+	})
+
+	It("should delete the VM once the graceful shutdown timed out", func() {
+		driver.gracefulShutdownTimeout = time.Minute
+		driver.asyncDeletions.storeShutdown(resourceGroupName+"/"+vmName, time.Now().Add(-time.Hour))
+
+		clients.VM.EXPECT().Get(ctx, resourceGroupName, vmName, compute.InstanceViewTypes("")).Return(compute.VirtualMachine{
+			VirtualMachineProperties: &compute.VirtualMachineProperties{ProvisioningState: to.StringPtr("Succeeded")},
+		}, nil)
+		clients.VM.EXPECT().Delete(ctx, resourceGroupName, vmName, nil).Return(compute.VirtualMachinesDeleteFuture{}, nil)
+
+		deleted, err := driver.deleteVMNicDisksAsync(ctx, clients, resourceGroupName, vmName, networkInterfaces, "machine-0-os-disk", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(deleted).To(BeFalse())
+	})
+
+	It("should not delete a VM which is already being deleted again", func() {
+		clients.VM.EXPECT().Get(ctx, resourceGroupName, vmName, compute.InstanceViewTypes("")).Return(compute.VirtualMachine{
+			VirtualMachineProperties: &compute.VirtualMachineProperties{ProvisioningState: to.StringPtr("Deleting")},
+		}, nil)
+
+		deleted, err := driver.deleteVMNicDisksAsync(ctx, clients, resourceGroupName, vmName, networkInterfaces, "machine-0-os-disk", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(deleted).To(BeFalse())
+	})
+
+	It("should issue the deletion of the NIC and the disk once the VM is gone", func() {
+		clients.VM.EXPECT().Get(ctx, resourceGroupName, vmName, compute.InstanceViewTypes("")).Return(compute.VirtualMachine{}, notFound)
+		clients.NIC.EXPECT().Get(ctx, resourceGroupName, "machine-0-nic", "").Return(network.Interface{
+			InterfacePropertiesFormat: &network.InterfacePropertiesFormat{ProvisioningState: network.Succeeded},
+		}, nil)
+		clients.NIC.EXPECT().Delete(ctx, resourceGroupName, "machine-0-nic").Return(network.InterfacesDeleteFuture{}, nil)
+		clients.Disk.EXPECT().Get(ctx, resourceGroupName, "machine-0-os-disk").Return(compute.Disk{
+			DiskProperties: &compute.DiskProperties{ProvisioningState: to.StringPtr("Deleting")},
+		}, nil)
+
+		deleted, err := driver.deleteVMNicDisksAsync(ctx, clients, resourceGroupName, vmName, networkInterfaces, "machine-0-os-disk", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(deleted).To(BeFalse())
+	})
+
+	It("should report the deletion as completed once all resources are gone", func() {
+		clients.VM.EXPECT().Get(ctx, resourceGroupName, vmName, compute.InstanceViewTypes("")).Return(compute.VirtualMachine{}, notFound)
+		clients.NIC.EXPECT().Get(ctx, resourceGroupName, "machine-0-nic", "").Return(network.Interface{}, notFound)
+		clients.PublicIP.EXPECT().Get(ctx, resourceGroupName, "machine-0-pip", "").Return(network.PublicIPAddress{}, notFound)
+		clients.Disk.EXPECT().Get(ctx, resourceGroupName, "machine-0-os-disk").Return(compute.Disk{}, notFound)
+
+		deleted, err := driver.deleteVMNicDisksAsync(ctx, clients, resourceGroupName, vmName, networkInterfaces, "machine-0-os-disk", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(deleted).To(BeTrue())
+	})
+
+	It("should delete the public IP of the naming convention even if the provider spec configures none", func() {
+		clients.VM.EXPECT().Get(ctx, resourceGroupName, vmName, compute.InstanceViewTypes("")).Return(compute.VirtualMachine{}, notFound)
+		clients.NIC.EXPECT().Get(ctx, resourceGroupName, "machine-0-nic", "").Return(network.Interface{}, notFound)
+		clients.PublicIP.EXPECT().Get(ctx, resourceGroupName, "machine-0-pip", "").Return(network.PublicIPAddress{
+			PublicIPAddressPropertiesFormat: &network.PublicIPAddressPropertiesFormat{ProvisioningState: network.Succeeded},
+		}, nil)
+		clients.PublicIP.EXPECT().Delete(ctx, resourceGroupName, "machine-0-pip").Return(network.PublicIPAddressesDeleteFuture{}, nil)
+		clients.Disk.EXPECT().Get(ctx, resourceGroupName, "machine-0-os-disk").Return(compute.Disk{}, notFound)
+
+		Expect(networkInterfaces[0].publicIP).To(BeNil())
+		deleted, err := driver.deleteVMNicDisksAsync(ctx, clients, resourceGroupName, vmName, networkInterfaces, "machine-0-os-disk", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(deleted).To(BeFalse())
+	})
+
+	It("should delete the NICs and disks of the VM model which do not follow the naming convention once the VM is gone", func() {
+		clients.VM.EXPECT().Get(ctx, resourceGroupName, vmName, compute.InstanceViewTypes("")).Return(compute.VirtualMachine{
+			Name: to.StringPtr(vmName),
+			VirtualMachineProperties: &compute.VirtualMachineProperties{
+				ProvisioningState: to.StringPtr("Succeeded"),
+				StorageProfile: &compute.StorageProfile{DataDisks: &[]compute.DataDisk{{
+					CreateOption: compute.DiskCreateOptionTypesEmpty,
+					ManagedDisk:  &compute.ManagedDiskParameters{ID: to.StringPtr("/subscriptions/s/resourceGroups/rg/providers/Microsoft.Compute/disks/legacy-disk")},
+				}}},
+				NetworkProfile: &compute.NetworkProfile{NetworkInterfaces: &[]compute.NetworkInterfaceReference{{
+					ID: to.StringPtr("/subscriptions/s/resourceGroups/rg/providers/Microsoft.Network/networkInterfaces/legacy-nic"),
+				}}},
+			},
+		}, nil)
+		clients.VM.EXPECT().Delete(ctx, resourceGroupName, vmName, nil).Return(compute.VirtualMachinesDeleteFuture{}, nil)
+
+		deleted, err := driver.deleteVMNicDisksAsync(ctx, clients, resourceGroupName, vmName, networkInterfaces, "machine-0-os-disk", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(deleted).To(BeFalse())
+
+		clients.VM.EXPECT().Get(ctx, resourceGroupName, vmName, compute.InstanceViewTypes("")).Return(compute.VirtualMachine{}, notFound)
+		clients.NIC.EXPECT().Get(ctx, resourceGroupName, "machine-0-nic", "").Return(network.Interface{}, notFound)
+		clients.NIC.EXPECT().Get(ctx, resourceGroupName, "legacy-nic", "").Return(network.Interface{}, notFound)
+		clients.PublicIP.EXPECT().Get(ctx, resourceGroupName, "machine-0-pip", "").Return(network.PublicIPAddress{}, notFound)
+		clients.Disk.EXPECT().Get(ctx, resourceGroupName, "machine-0-os-disk").Return(compute.Disk{}, notFound)
+		clients.Disk.EXPECT().Get(ctx, resourceGroupName, "legacy-disk").Return(compute.Disk{}, notFound)
+
+		deleted, err = driver.deleteVMNicDisksAsync(ctx, clients, resourceGroupName, vmName, networkInterfaces, "machine-0-os-disk", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(deleted).To(BeTrue())
+		_, ok := driver.asyncDeletions.load("rg/machine-0")
+		Expect(ok).To(BeFalse())
+	})
+})
@@ -0,0 +1,103 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-08-01/compute"
+	"github.com/Azure/go-autorest/autorest/to"
+	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/spi"
+)
+
+const (
+	prometheusServiceAvailabilitySet = "availability_sets"
+
+	// defaultPlatformFaultDomainCount is the number of fault domains of created availability sets, which is supported
+	// by all regions
+	defaultPlatformFaultDomainCount int32 = 2
+	// defaultPlatformUpdateDomainCount is the number of update domains of created availability sets, as defaulted by Azure
+	defaultPlatformUpdateDomainCount int32 = 5
+)
+
+// ensureAvailabilitySet returns the ID of the availability set of the VM, or nil if it has none. An availability set
+// referenced by name is created in the resource group of the VM if it doesn't exist yet.
+func ensureAvailabilitySet(ctx context.Context, clients spi.AzureDriverClientsInterface, providerSpec *api.AzureProviderSpec) (*string, error) {
+	availabilitySet := providerSpec.Properties.AvailabilitySet
+	if availabilitySet == nil {
+		return nil, nil
+	}
+	if availabilitySet.Name == "" {
+		return to.StringPtr(availabilitySet.ID), nil
+	}
+
+	existing, err := clients.GetAvailabilitySets().Get(ctx, providerSpec.ResourceGroup, availabilitySet.Name)
+	if err == nil {
+		spi.OnARMAPISuccess(prometheusServiceAvailabilitySet, "AvailabilitySets.Get")
+		return existing.ID, nil
+	}
+	if !spi.NotFound(err) {
+		return nil, spi.OnARMAPIErrorFail(prometheusServiceAvailabilitySet, err, "AvailabilitySets.Get failed for %s", availabilitySet.Name)
+	}
+
+	faultDomainCount, updateDomainCount := defaultPlatformFaultDomainCount, defaultPlatformUpdateDomainCount
+	if availabilitySet.PlatformFaultDomainCount != nil {
+		faultDomainCount = *availabilitySet.PlatformFaultDomainCount
+	}
+	if availabilitySet.PlatformUpdateDomainCount != nil {
+		updateDomainCount = *availabilitySet.PlatformUpdateDomainCount
+	}
+
+	created, err := clients.GetAvailabilitySets().CreateOrUpdate(ctx, providerSpec.ResourceGroup, availabilitySet.Name, compute.AvailabilitySet{
+		Location: &providerSpec.Location,
+		Tags:     getAzureTags(providerSpec.Tags),
+		// Aligned availability sets are required for VMs with managed disks
+		Sku: &compute.Sku{Name: to.StringPtr(string(compute.Aligned))},
+		AvailabilitySetProperties: &compute.AvailabilitySetProperties{
+			PlatformFaultDomainCount:  &faultDomainCount,
+			PlatformUpdateDomainCount: &updateDomainCount,
+		},
+	})
+	if err != nil {
+		return nil, spi.OnARMAPIErrorFail(prometheusServiceAvailabilitySet, err, "AvailabilitySets.CreateOrUpdate failed for %s", availabilitySet.Name)
+	}
+	spi.OnARMAPISuccess(prometheusServiceAvailabilitySet, "AvailabilitySets.CreateOrUpdate")
+	spi.InfoS(ctx, "Availability set created", "availabilitySet", availabilitySet.Name, spi.LogKeyResourceGroup, providerSpec.ResourceGroup)
+
+	return created.ID, nil
+}
+
+// deleteEmptyAvailabilitySet deletes the availability set referenced by name if it is configured to be deleted when
+// empty and no VM is left in it. Azure refuses to delete availability sets which still contain VMs, hence a VM
+// created concurrently keeps the set.
+func deleteEmptyAvailabilitySet(ctx context.Context, clients spi.AzureDriverClientsInterface, providerSpec *api.AzureProviderSpec) error {
+	availabilitySet := providerSpec.Properties.AvailabilitySet
+	if availabilitySet == nil || availabilitySet.Name == "" || !availabilitySet.DeleteWhenEmpty {
+		return nil
+	}
+
+	existing, err := clients.GetAvailabilitySets().Get(ctx, providerSpec.ResourceGroup, availabilitySet.Name)
+	if err != nil {
+		if spi.NotFound(err) {
+			return nil
+		}
+		return spi.OnARMAPIErrorFail(prometheusServiceAvailabilitySet, err, "AvailabilitySets.Get failed for %s", availabilitySet.Name)
+	}
+	spi.OnARMAPISuccess(prometheusServiceAvailabilitySet, "AvailabilitySets.Get")
+	if existing.AvailabilitySetProperties != nil && existing.VirtualMachines != nil && len(*existing.VirtualMachines) > 0 {
+		return nil
+	}
+
+	if _, err := clients.GetAvailabilitySets().Delete(ctx, providerSpec.ResourceGroup, availabilitySet.Name); err != nil {
+		return spi.OnARMAPIErrorFail(prometheusServiceAvailabilitySet, err, "AvailabilitySets.Delete failed for %s", availabilitySet.Name)
+	}
+	spi.OnARMAPISuccess(prometheusServiceAvailabilitySet, "AvailabilitySets.Delete")
+	spi.InfoS(ctx, "Empty availability set deleted", "availabilitySet", availabilitySet.Name, spi.LogKeyResourceGroup, providerSpec.ResourceGroup)
+
+	return nil
+}
@@ -0,0 +1,97 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-08-01/compute"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/mock"
+	gomock "github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var _ = Describe("AvailabilitySet", func() {
+	var (
+		ctx      = context.Background()
+		id       = "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Compute/availabilitySets/as"
+		notFound = autorest.DetailedError{Response: &http.Response{StatusCode: http.StatusNotFound}}
+
+		clients      *mock.AzureDriverClients
+		providerSpec *api.AzureProviderSpec
+	)
+
+	BeforeEach(func() {
+		spi := mock.NewMockPluginSPIImpl(gomock.NewController(GinkgoT()))
+		driverClients, err := spi.Setup(&corev1.Secret{}, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		clients = driverClients.(*mock.AzureDriverClients)
+		providerSpec = &api.AzureProviderSpec{Location: "westeurope", ResourceGroup: "rg"}
+		providerSpec.Properties.AvailabilitySet = &api.AzureAvailabilitySet{Name: "as", PlatformFaultDomainCount: to.Int32Ptr(3)}
+	})
+
+	Describe("#ensureAvailabilitySet", func() {
+		It("should return the ID of an availability set referenced by ID", func() {
+			providerSpec.Properties.AvailabilitySet = &api.AzureAvailabilitySet{ID: id}
+
+			Expect(ensureAvailabilitySet(ctx, clients, providerSpec)).To(Equal(&id))
+		})
+
+		It("should return the ID of an existing availability set", func() {
+			clients.AvailabilitySets.EXPECT().Get(ctx, "rg", "as").Return(compute.AvailabilitySet{ID: &id}, nil)
+
+			Expect(ensureAvailabilitySet(ctx, clients, providerSpec)).To(Equal(&id))
+		})
+
+		It("should create a missing availability set", func() {
+			clients.AvailabilitySets.EXPECT().Get(ctx, "rg", "as").Return(compute.AvailabilitySet{}, notFound)
+			clients.AvailabilitySets.EXPECT().CreateOrUpdate(ctx, "rg", "as", gomock.Any()).DoAndReturn(
+				func(_ context.Context, _, _ string, availabilitySet compute.AvailabilitySet) (compute.AvailabilitySet, error) {
+					Expect(*availabilitySet.Sku.Name).To(Equal("Aligned"))
+					Expect(*availabilitySet.PlatformFaultDomainCount).To(Equal(int32(3)))
+					Expect(*availabilitySet.PlatformUpdateDomainCount).To(Equal(defaultPlatformUpdateDomainCount))
+					return compute.AvailabilitySet{ID: &id}, nil
+				})
+
+			Expect(ensureAvailabilitySet(ctx, clients, providerSpec)).To(Equal(&id))
+		})
+	})
+
+	Describe("#deleteEmptyAvailabilitySet", func() {
+		BeforeEach(func() {
+			providerSpec.Properties.AvailabilitySet.DeleteWhenEmpty = true
+		})
+
+		It("should delete an empty availability set", func() {
+			clients.AvailabilitySets.EXPECT().Get(ctx, "rg", "as").Return(compute.AvailabilitySet{ID: &id, AvailabilitySetProperties: &compute.AvailabilitySetProperties{}}, nil)
+			clients.AvailabilitySets.EXPECT().Delete(ctx, "rg", "as").Return(autorest.Response{}, nil)
+
+			Expect(deleteEmptyAvailabilitySet(ctx, clients, providerSpec)).To(Succeed())
+		})
+
+		It("should keep an availability set which still contains VMs", func() {
+			clients.AvailabilitySets.EXPECT().Get(ctx, "rg", "as").Return(compute.AvailabilitySet{ID: &id, AvailabilitySetProperties: &compute.AvailabilitySetProperties{
+				VirtualMachines: &[]compute.SubResource{{ID: to.StringPtr("vm")}},
+			}}, nil)
+
+			Expect(deleteEmptyAvailabilitySet(ctx, clients, providerSpec)).To(Succeed())
+		})
+
+		It("should keep the availability set if not configured", func() {
+			providerSpec.Properties.AvailabilitySet.DeleteWhenEmpty = false
+
+			Expect(deleteEmptyAvailabilitySet(ctx, clients, providerSpec)).To(Succeed())
+		})
+	})
+})
@@ -0,0 +1,391 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-08-01/compute"
+	"github.com/Azure/go-autorest/autorest/to"
+	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/spi"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/codes"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/status"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const (
+	prometheusServiceResourceSkus = "resource_skus"
+
+	// capabilityMatrixTTL is the duration after which the capabilities of a location are listed again
+	capabilityMatrixTTL = 6 * time.Hour
+
+	capabilityAcceleratedNetworking = "AcceleratedNetworkingEnabled"
+	capabilityPremiumIO             = "PremiumIO"
+	capabilityMaxDataDiskCount      = "MaxDataDiskCount"
+	capabilityHyperVGenerations     = "HyperVGenerations"
+	capabilityTrustedLaunchDisabled = "TrustedLaunchDisabled"
+	capabilityVCPUs                 = "vCPUs"
+	capabilityMaxWriteAccelerator   = "MaxWriteAcceleratorDisksAllowed"
+	capabilityDiskControllerTypes   = "DiskControllerTypes"
+)
+
+// vmCapabilities are the capabilities of a VM size in a location, as reported by the resource SKUs API
+type vmCapabilities struct {
+	acceleratedNetworking bool
+	premiumIO             bool
+	maxDataDiskCount      *int
+	hyperVGenerations     []string
+	trustedLaunchDisabled bool
+	restricted            bool
+	family                string
+	vCPUs                 *int
+	// maxWriteAcceleratorDisks is the number of write accelerated disks, which is nil for VM sizes without support
+	maxWriteAcceleratorDisks *int
+	// diskControllerTypes are the disk controllers of the VM size, which is nil for VM sizes only supporting SCSI
+	diskControllerTypes []string
+	// zones are the zones of the location the VM size is offered in for the subscription
+	zones []string
+	// zonesListed is true if the resource SKUs API lists the zones of the VM size in the location
+	zonesListed bool
+}
+
+// capabilityMatrix caches the capabilities of the VM sizes per subscription and location, as the resource SKUs API
+// reports the restrictions of the subscription
+type capabilityMatrix struct {
+	ttl time.Duration
+
+	mutex     sync.Mutex
+	locations map[string]*locationCapabilities
+}
+
+// locationCapabilities are the capabilities of the VM sizes of a location. Its mutex is held while the resource SKUs
+// are listed, so that concurrent lookups of the same location share a single listing without blocking other locations.
+type locationCapabilities struct {
+	mutex     sync.Mutex
+	expiresAt time.Time
+	sizes     map[string]vmCapabilities
+}
+
+func newCapabilityMatrix(ttl time.Duration) *capabilityMatrix {
+	return &capabilityMatrix{
+		ttl:       ttl,
+		locations: map[string]*locationCapabilities{},
+	}
+}
+
+// get returns the capabilities of the VM size in the location of the subscription. The second return value is false
+// if the resource SKUs API does not know the VM size in the location.
+func (m *capabilityMatrix) get(ctx context.Context, clients spi.AzureDriverClientsInterface, subscriptionID, location, vmSize string) (vmCapabilities, bool, error) {
+	location = strings.ToLower(location)
+	key := strings.ToLower(subscriptionID) + "/" + location
+
+	m.mutex.Lock()
+	entry, ok := m.locations[key]
+	if !ok {
+		entry = &locationCapabilities{}
+		m.locations[key] = entry
+	}
+	m.mutex.Unlock()
+
+	entry.mutex.Lock()
+	defer entry.mutex.Unlock()
+
+	if entry.sizes == nil || time.Now().After(entry.expiresAt) {
+		sizes, err := listVMCapabilities(ctx, clients, location)
+		if err != nil {
+			return vmCapabilities{}, false, err
+		}
+		if len(sizes) == 0 {
+			return vmCapabilities{}, false, fmt.Errorf("no VM sizes are listed for location %q", location)
+		}
+		entry.sizes, entry.expiresAt = sizes, time.Now().Add(m.ttl)
+	}
+
+	capabilities, ok := entry.sizes[strings.ToLower(vmSize)]
+	return capabilities, ok, nil
+}
+
+func listVMCapabilities(ctx context.Context, clients spi.AzureDriverClientsInterface, location string) (map[string]vmCapabilities, error) {
+	var (
+		items  []compute.ResourceSku
+		filter = fmt.Sprintf("location eq '%s'", location)
+	)
+
+	result, err := clients.GetResourceSkus().List(ctx, filter, "")
+	if err != nil {
+		return nil, spi.OnARMAPIErrorFail(prometheusServiceResourceSkus, err, "ResourceSkus.List")
+	}
+	items = append(items, result.Values()...)
+	for result.NotDone() {
+		if err = result.NextWithContext(ctx); err != nil {
+			return nil, spi.OnARMAPIErrorFail(prometheusServiceResourceSkus, err, "ResourceSkus.List")
+		}
+		items = append(items, result.Values()...)
+	}
+	spi.OnARMAPISuccess(prometheusServiceResourceSkus, "ResourceSkus.List")
+
+	sizes := map[string]vmCapabilities{}
+	for _, sku := range items {
+		if sku.ResourceType == nil || *sku.ResourceType != "virtualMachines" || sku.Name == nil {
+			continue
+		}
+		sizes[strings.ToLower(*sku.Name)] = newVMCapabilities(sku, location)
+	}
+	return sizes, nil
+}
+
+func newVMCapabilities(sku compute.ResourceSku, location string) vmCapabilities {
+	var capabilities vmCapabilities
+	if sku.Family != nil {
+		capabilities.family = *sku.Family
+	}
+
+	if sku.Capabilities != nil {
+		for _, capability := range *sku.Capabilities {
+			if capability.Name == nil || capability.Value == nil {
+				continue
+			}
+			switch *capability.Name {
+			case capabilityAcceleratedNetworking:
+				capabilities.acceleratedNetworking = strings.EqualFold(*capability.Value, "True")
+			case capabilityPremiumIO:
+				capabilities.premiumIO = strings.EqualFold(*capability.Value, "True")
+			case capabilityMaxDataDiskCount:
+				if count, err := strconv.Atoi(*capability.Value); err == nil {
+					capabilities.maxDataDiskCount = &count
+				}
+			case capabilityHyperVGenerations:
+				capabilities.hyperVGenerations = strings.Split(*capability.Value, ",")
+			case capabilityTrustedLaunchDisabled:
+				capabilities.trustedLaunchDisabled = strings.EqualFold(*capability.Value, "True")
+			case capabilityVCPUs:
+				if count, err := strconv.Atoi(*capability.Value); err == nil {
+					capabilities.vCPUs = &count
+				}
+			case capabilityDiskControllerTypes:
+				capabilities.diskControllerTypes = strings.Split(*capability.Value, ",")
+			case capabilityMaxWriteAccelerator:
+				if count, err := strconv.Atoi(*capability.Value); err == nil {
+					capabilities.maxWriteAcceleratorDisks = &count
+				}
+			}
+		}
+	}
+
+	restrictedZones := map[string]bool{}
+	if sku.Restrictions != nil {
+		for _, restriction := range *sku.Restrictions {
+			switch {
+			case restriction.Type == compute.Location && restriction.Values != nil:
+				for _, value := range *restriction.Values {
+					if strings.EqualFold(value, location) {
+						capabilities.restricted = true
+					}
+				}
+			case restriction.Type == compute.Zone && restriction.RestrictionInfo != nil && restriction.RestrictionInfo.Zones != nil:
+				if restriction.RestrictionInfo.Locations != nil && !containsFold(*restriction.RestrictionInfo.Locations, location) {
+					continue
+				}
+				for _, zone := range *restriction.RestrictionInfo.Zones {
+					restrictedZones[zone] = true
+				}
+			}
+		}
+	}
+
+	if sku.LocationInfo != nil {
+		for _, info := range *sku.LocationInfo {
+			if info.Location == nil || !strings.EqualFold(*info.Location, location) {
+				continue
+			}
+			capabilities.zonesListed = true
+			if info.Zones == nil {
+				continue
+			}
+			for _, zone := range *info.Zones {
+				if !restrictedZones[zone] {
+					capabilities.zones = append(capabilities.zones, zone)
+				}
+			}
+		}
+		sort.Strings(capabilities.zones)
+	}
+
+	return capabilities
+}
+
+// checkVMCapabilities rejects provider specs which request features the VM size does not support in the location.
+// If the resource SKUs cannot be listed, the check is skipped and the creation is left to Azure.
+func (d *MachinePlugin) checkVMCapabilities(ctx context.Context, clients spi.AzureDriverClientsInterface, subscriptionID string, providerSpec *api.AzureProviderSpec) error {
+	if d.capabilities == nil {
+		return nil
+	}
+
+	vmSize := providerSpec.Properties.HardwareProfile.VMSize
+	capabilities, ok, err := d.capabilities.get(ctx, clients, subscriptionID, providerSpec.Location, vmSize)
+	if err != nil {
+		spi.WarningS(ctx, "Skipping capability check of VM size", "vmSize", vmSize, "err", err)
+		return nil
+	}
+	if !ok {
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("VM size %q is not offered in location %q", vmSize, providerSpec.Location))
+	}
+
+	if errs := validateVMCapabilities(providerSpec, capabilities); len(errs) > 0 {
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("Provider spec is not supported by VM size %q in location %q: %s", vmSize, providerSpec.Location, joinErrors(errs)))
+	}
+	return nil
+}
+
+// joinErrors returns the messages of the errors separated by semicolons, so that each rejected field of the provider
+// spec is named on its own
+func joinErrors(errs []error) string {
+	messages := make([]string, 0, len(errs))
+	for _, err := range errs {
+		messages = append(messages, err.Error())
+	}
+	return strings.Join(messages, "; ")
+}
+
+func validateVMCapabilities(providerSpec *api.AzureProviderSpec, capabilities vmCapabilities) []error {
+	var (
+		allErrs    []error
+		fldPath    = field.NewPath("properties")
+		properties = providerSpec.Properties
+	)
+
+	allErrs = append(allErrs, validateVMZones(providerSpec, capabilities)...)
+
+	if !capabilities.acceleratedNetworking {
+		if to.Bool(properties.NetworkProfile.AcceleratedNetworking) {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("networkProfile", "acceleratedNetworking"), "VM size does not support accelerated networking"))
+		}
+		for i, nic := range properties.NetworkProfile.Interfaces {
+			if to.Bool(nic.AcceleratedNetworking) {
+				allErrs = append(allErrs, field.Forbidden(fldPath.Child("networkProfile", "interfaces").Index(i).Child("acceleratedNetworking"), "VM size does not support accelerated networking"))
+			}
+		}
+	}
+
+	if !capabilities.premiumIO {
+		if isPremiumStorage(properties.StorageProfile.OsDisk.ManagedDisk.StorageAccountType) {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("storageProfile", "osDisk", "managedDisk", "storageAccountType"), "VM size does not support premium storage"))
+		}
+		for i, dataDisk := range properties.StorageProfile.DataDisks {
+			if isPremiumStorage(dataDisk.StorageAccountType) {
+				allErrs = append(allErrs, field.Forbidden(fldPath.Child("storageProfile", "dataDisks").Index(i).Child("storageAccountType"), "VM size does not support premium storage"))
+			}
+		}
+	}
+
+	if capabilities.maxDataDiskCount != nil && len(properties.StorageProfile.DataDisks) > *capabilities.maxDataDiskCount {
+		allErrs = append(allErrs, field.TooMany(fldPath.Child("storageProfile", "dataDisks"), len(properties.StorageProfile.DataDisks), *capabilities.maxDataDiskCount))
+	}
+
+	if count := writeAcceleratedDisks(properties.StorageProfile); count > 0 {
+		if capabilities.maxWriteAcceleratorDisks == nil || *capabilities.maxWriteAcceleratorDisks == 0 {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("storageProfile"), "VM size does not support write accelerated disks"))
+		} else if count > *capabilities.maxWriteAcceleratorDisks {
+			allErrs = append(allErrs, field.TooMany(fldPath.Child("storageProfile"), count, *capabilities.maxWriteAcceleratorDisks))
+		}
+	}
+
+	if controller := properties.StorageProfile.DiskControllerType; controller != "" {
+		supported := capabilities.diskControllerTypes
+		if supported == nil {
+			supported = []string{api.DiskControllerTypeSCSI}
+		}
+		if !containsFold(supported, controller) {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("storageProfile", "diskControllerType"), fmt.Sprintf("VM size does not support the %s disk controller", controller)))
+		}
+	}
+
+	if properties.SecurityProfile != nil {
+		if capabilities.hyperVGenerations != nil && !containsFold(capabilities.hyperVGenerations, string(compute.HyperVGenerationTypesV2)) {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("securityProfile"), "VM size does not support Gen2 VMs"))
+		}
+		if properties.SecurityProfile.SecurityType == api.SecurityTypeTrustedLaunch && capabilities.trustedLaunchDisabled {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("securityProfile", "securityType"), "VM size does not support Trusted Launch"))
+		}
+	}
+
+	return allErrs
+}
+
+// validateVMZones rejects VM sizes restricted for the subscription in the location, whether deployed regionally or in
+// zones, and zones the VM size is not offered in. The errors name the valid zones, as Azure fails the creation with a
+// generic allocation error.
+func validateVMZones(providerSpec *api.AzureProviderSpec, capabilities vmCapabilities) []error {
+	var (
+		allErrs    []error
+		fldPath    = field.NewPath("properties")
+		properties = providerSpec.Properties
+	)
+
+	// The location restriction applies to the zones of the location as well, so it is checked first
+	if capabilities.restricted {
+		return append(allErrs, field.Forbidden(fldPath.Child("hardwareProfile", "vmSize"), "VM size is restricted for the subscription in this location"))
+	}
+	if properties.Zone == nil && len(properties.Zones) == 0 {
+		return nil
+	}
+	if !capabilities.zonesListed {
+		return nil
+	}
+
+	checkZone := func(zonePath *field.Path, zone int) {
+		if containsFold(capabilities.zones, strconv.Itoa(zone)) {
+			return
+		}
+		if len(capabilities.zones) == 0 {
+			allErrs = append(allErrs, field.Forbidden(zonePath, "VM size is not offered in any zone of this location, use a regional deployment instead"))
+			return
+		}
+		allErrs = append(allErrs, field.NotSupported(zonePath, zone, capabilities.zones))
+	}
+	if properties.Zone != nil {
+		checkZone(fldPath.Child("zone"), *properties.Zone)
+	}
+	for i, zone := range properties.Zones {
+		checkZone(fldPath.Child("zones").Index(i), zone)
+	}
+	return allErrs
+}
+
+// writeAcceleratedDisks returns the number of disks of the storage profile with the write accelerator enabled
+func writeAcceleratedDisks(storageProfile api.AzureStorageProfile) int {
+	count := 0
+	if to.Bool(storageProfile.OsDisk.WriteAcceleratorEnabled) {
+		count++
+	}
+	for _, dataDisk := range storageProfile.DataDisks {
+		if to.Bool(dataDisk.WriteAcceleratorEnabled) {
+			count++
+		}
+	}
+	return count
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(strings.TrimSpace(v), value) {
+			return true
+		}
+	}
+	return false
+}
+
+func isPremiumStorage(storageAccountType string) bool {
+	return strings.HasPrefix(storageAccountType, "Premium") || storageAccountType == string(compute.StorageAccountTypesUltraSSDLRS)
+}
@@ -0,0 +1,285 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-08-01/compute"
+	"github.com/Azure/go-autorest/autorest/to"
+	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/mock"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/spi"
+	"github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/codes"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/status"
+	gomock "github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var _ = Describe("Capabilities", func() {
+
+	Describe("#newVMCapabilities", func() {
+		It("should parse the capabilities and location restrictions of the SKU", func() {
+			capabilities := newVMCapabilities(compute.ResourceSku{
+				Capabilities: &[]compute.ResourceSkuCapabilities{
+					{Name: to.StringPtr("AcceleratedNetworkingEnabled"), Value: to.StringPtr("True")},
+					{Name: to.StringPtr("PremiumIO"), Value: to.StringPtr("False")},
+					{Name: to.StringPtr("MaxDataDiskCount"), Value: to.StringPtr("4")},
+				},
+				Restrictions: &[]compute.ResourceSkuRestrictions{
+					{Type: compute.Location, Values: &[]string{"westeurope"}},
+				},
+			}, "westeurope")
+
+			Expect(capabilities.acceleratedNetworking).To(BeTrue())
+			Expect(capabilities.premiumIO).To(BeFalse())
+			Expect(capabilities.maxDataDiskCount).To(Equal(to.IntPtr(4)))
+			Expect(capabilities.restricted).To(BeTrue())
+		})
+
+		It("should parse the zones of the location which are not restricted", func() {
+			capabilities := newVMCapabilities(compute.ResourceSku{
+				LocationInfo: &[]compute.ResourceSkuLocationInfo{
+					{Location: to.StringPtr("northeurope"), Zones: &[]string{"1"}},
+					{Location: to.StringPtr("WestEurope"), Zones: &[]string{"3", "1", "2"}},
+				},
+				Restrictions: &[]compute.ResourceSkuRestrictions{
+					{Type: compute.Zone, RestrictionInfo: &compute.ResourceSkuRestrictionInfo{Locations: &[]string{"westeurope"}, Zones: &[]string{"2"}}},
+				},
+			}, "westeurope")
+
+			Expect(capabilities.zonesListed).To(BeTrue())
+			Expect(capabilities.zones).To(Equal([]string{"1", "3"}))
+		})
+	})
+
+	Describe("#validateVMZones", func() {
+		var providerSpec *api.AzureProviderSpec
+
+		BeforeEach(func() {
+			providerSpec = &api.AzureProviderSpec{}
+		})
+
+		It("should reject VM sizes restricted in the location for regional and zonal deployments", func() {
+			capabilities := vmCapabilities{restricted: true, zonesListed: true, zones: []string{"1", "3"}}
+
+			providerSpec.Properties.Regional = true
+			errs := validateVMZones(providerSpec, capabilities)
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Error()).To(ContainSubstring("restricted for the subscription in this location"))
+
+			providerSpec.Properties.Regional = false
+			providerSpec.Properties.Zones = []int{1, 3}
+			errs = validateVMZones(providerSpec, capabilities)
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Error()).To(ContainSubstring("properties.hardwareProfile.vmSize"))
+		})
+
+		It("should accept regional deployments of unrestricted VM sizes", func() {
+			providerSpec.Properties.Regional = true
+
+			Expect(validateVMZones(providerSpec, vmCapabilities{zonesListed: true, zones: []string{"1", "3"}})).To(BeEmpty())
+		})
+
+		It("should reject zones the VM size is not offered in and name the valid zones", func() {
+			providerSpec.Properties.Zones = []int{1, 2}
+
+			errs := validateVMZones(providerSpec, vmCapabilities{zonesListed: true, zones: []string{"1", "3"}})
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Error()).To(ContainSubstring("properties.zones[1]"))
+			Expect(errs[0].Error()).To(ContainSubstring(`"1", "3"`))
+
+			providerSpec.Properties.Zones = nil
+			providerSpec.Properties.Zone = to.IntPtr(1)
+			Expect(validateVMZones(providerSpec, vmCapabilities{zonesListed: true, zones: []string{"1", "3"}})).To(BeEmpty())
+		})
+
+		It("should reject zonal deployments of VM sizes without zones in the location", func() {
+			providerSpec.Properties.Zone = to.IntPtr(1)
+
+			errs := validateVMZones(providerSpec, vmCapabilities{zonesListed: true})
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Error()).To(ContainSubstring("not offered in any zone"))
+		})
+
+		It("should not check the zones if the location info is not listed", func() {
+			providerSpec.Properties.Zone = to.IntPtr(1)
+
+			Expect(validateVMZones(providerSpec, vmCapabilities{})).To(BeEmpty())
+			Expect(validateVMZones(providerSpec, vmCapabilities{restricted: true})).To(HaveLen(1))
+		})
+	})
+
+	Describe("#validateVMCapabilities", func() {
+		var providerSpec *api.AzureProviderSpec
+
+		BeforeEach(func() {
+			providerSpec = &api.AzureProviderSpec{}
+			providerSpec.Properties.NetworkProfile.AcceleratedNetworking = to.BoolPtr(true)
+			providerSpec.Properties.StorageProfile.OsDisk.ManagedDisk.StorageAccountType = "Premium_LRS"
+			providerSpec.Properties.StorageProfile.DataDisks = []api.AzureDataDisk{
+				{StorageAccountType: "Standard_LRS"},
+				{StorageAccountType: "Premium_LRS"},
+			}
+		})
+
+		It("should accept a spec supported by the VM size", func() {
+			Expect(validateVMCapabilities(providerSpec, vmCapabilities{acceleratedNetworking: true, premiumIO: true})).To(BeEmpty())
+		})
+
+		It("should reject features the VM size does not support", func() {
+			errs := validateVMCapabilities(providerSpec, vmCapabilities{maxDataDiskCount: to.IntPtr(1)})
+
+			Expect(errs).To(HaveLen(4))
+			Expect(errs[0].Error()).To(ContainSubstring("properties.networkProfile.acceleratedNetworking"))
+			Expect(errs[1].Error()).To(ContainSubstring("properties.storageProfile.osDisk.managedDisk.storageAccountType"))
+			Expect(errs[2].Error()).To(ContainSubstring("properties.storageProfile.dataDisks[1].storageAccountType"))
+			Expect(errs[3].Error()).To(ContainSubstring("properties.storageProfile.dataDisks"))
+		})
+
+		It("should reject accelerated networking of interfaces on VM sizes without support", func() {
+			providerSpec.Properties.NetworkProfile.AcceleratedNetworking = nil
+			providerSpec.Properties.NetworkProfile.Interfaces = []api.AzureNetworkInterface{
+				{Primary: true, AcceleratedNetworking: to.BoolPtr(false)},
+				{Name: "secondary", AcceleratedNetworking: to.BoolPtr(true)},
+			}
+
+			Expect(validateVMCapabilities(providerSpec, vmCapabilities{acceleratedNetworking: true, premiumIO: true})).To(BeEmpty())
+			errs := validateVMCapabilities(providerSpec, vmCapabilities{premiumIO: true})
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Error()).To(ContainSubstring("properties.networkProfile.interfaces[1].acceleratedNetworking"))
+		})
+
+		It("should reject security profiles on VM sizes without Gen2 or Trusted Launch support", func() {
+			providerSpec.Properties.SecurityProfile = &api.AzureSecurityProfile{SecurityType: api.SecurityTypeTrustedLaunch}
+			capabilities := vmCapabilities{acceleratedNetworking: true, premiumIO: true, hyperVGenerations: []string{"V1"}, trustedLaunchDisabled: true}
+
+			errs := validateVMCapabilities(providerSpec, capabilities)
+
+			Expect(errs).To(HaveLen(2))
+			Expect(errs[0].Error()).To(ContainSubstring("Gen2"))
+			Expect(errs[1].Error()).To(ContainSubstring("Trusted Launch"))
+
+			capabilities.hyperVGenerations = []string{"V1", "V2"}
+			capabilities.trustedLaunchDisabled = false
+			Expect(validateVMCapabilities(providerSpec, capabilities)).To(BeEmpty())
+		})
+
+		It("should reject the NVMe disk controller on VM sizes without support", func() {
+			providerSpec.Properties.StorageProfile.DiskControllerType = api.DiskControllerTypeNVMe
+			capabilities := vmCapabilities{acceleratedNetworking: true, premiumIO: true}
+
+			errs := validateVMCapabilities(providerSpec, capabilities)
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Error()).To(Equal("properties.storageProfile.diskControllerType: Forbidden: VM size does not support the NVMe disk controller"))
+
+			capabilities.diskControllerTypes = newVMCapabilities(compute.ResourceSku{
+				Capabilities: &[]compute.ResourceSkuCapabilities{{Name: to.StringPtr("DiskControllerTypes"), Value: to.StringPtr("SCSI, NVMe")}},
+			}, "westeurope").diskControllerTypes
+			Expect(validateVMCapabilities(providerSpec, capabilities)).To(BeEmpty())
+
+			providerSpec.Properties.StorageProfile.DiskControllerType = api.DiskControllerTypeSCSI
+			Expect(validateVMCapabilities(providerSpec, vmCapabilities{acceleratedNetworking: true, premiumIO: true})).To(BeEmpty())
+		})
+
+		It("should reject write accelerated disks beyond the limit of the VM size", func() {
+			providerSpec.Properties.StorageProfile.OsDisk.WriteAcceleratorEnabled = to.BoolPtr(true)
+			providerSpec.Properties.StorageProfile.DataDisks[1].WriteAcceleratorEnabled = to.BoolPtr(true)
+			capabilities := vmCapabilities{acceleratedNetworking: true, premiumIO: true}
+
+			errs := validateVMCapabilities(providerSpec, capabilities)
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Error()).To(ContainSubstring("does not support write accelerated disks"))
+
+			capabilities.maxWriteAcceleratorDisks = to.IntPtr(1)
+			errs = validateVMCapabilities(providerSpec, capabilities)
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Error()).To(ContainSubstring("must have at most 1 items"))
+
+			capabilities.maxWriteAcceleratorDisks = to.IntPtr(8)
+			Expect(validateVMCapabilities(providerSpec, capabilities)).To(BeEmpty())
+		})
+	})
+
+	Context("with the resource SKUs API", func() {
+		var (
+			ctx = context.Background()
+
+			sp           spi.SessionProviderInterface
+			machineClass *v1alpha1.MachineClass
+			secret       *corev1.Secret
+			clients      *mock.AzureDriverClients
+			sku          compute.ResourceSku
+		)
+
+		BeforeEach(func() {
+			sp = mock.NewMockPluginSPIImpl(gomock.NewController(GinkgoT()))
+			machineClass, secret = newProviderSpecCacheFixtures()
+			driverClients, err := sp.Setup(secret, nil)
+			Expect(err).NotTo(HaveOccurred())
+			clients = driverClients.(*mock.AzureDriverClients)
+			sku = compute.ResourceSku{ResourceType: to.StringPtr("virtualMachines"), Name: to.StringPtr("Standard_DS2_v2")}
+		})
+
+		Describe("#capabilityMatrix", func() {
+			It("should list the capabilities once per subscription and location", func() {
+				clients.Skus.EXPECT().List(gomock.Any(), "location eq 'westeurope'", "").Return(newResourceSkusPage(ctx, sku), nil).Times(2)
+
+				matrix := newCapabilityMatrix(capabilityMatrixTTL)
+				for _, subscription := range []string{"subscription-a", "subscription-b", "Subscription-A"} {
+					_, ok, err := matrix.get(ctx, clients, subscription, "WestEurope", "standard_ds2_v2")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(ok).To(BeTrue())
+				}
+			})
+		})
+
+		Describe("#checkVMCapabilities", func() {
+			It("should reject the provider spec with an error naming each unsupported field", func() {
+				providerSpec := UnmarshalProviderSpec(machineClass.ProviderSpec.Raw)
+				providerSpec.Properties.NetworkProfile.AcceleratedNetworking = to.BoolPtr(true)
+				providerSpec.Properties.StorageProfile.OsDisk.ManagedDisk.StorageAccountType = "Premium_LRS"
+				clients.Skus.EXPECT().List(gomock.Any(), "location eq 'westeurope'", "").Return(newResourceSkusPage(ctx, sku), nil)
+
+				err := NewAzureDriver(sp).checkVMCapabilities(ctx, clients, "subscription", providerSpec)
+				s, ok := status.FromError(err)
+				Expect(ok).To(BeTrue())
+				Expect(s.Code()).To(Equal(codes.InvalidArgument))
+				Expect(s.Message()).To(Equal(`Provider spec is not supported by VM size "Standard_DS2_v2" in location "westeurope": ` +
+					"properties.networkProfile.acceleratedNetworking: Forbidden: VM size does not support accelerated networking; " +
+					"properties.storageProfile.osDisk.managedDisk.storageAccountType: Forbidden: VM size does not support premium storage"))
+			})
+		})
+
+		Describe("#decodeAndValidateProviderSpec", func() {
+			It("should reject a machine class whose VM size is not offered in the location", func() {
+				clients.Skus.EXPECT().List(gomock.Any(), "location eq 'westeurope'", "").Return(newResourceSkusPage(ctx, compute.ResourceSku{
+					ResourceType: to.StringPtr("virtualMachines"),
+					Name:         to.StringPtr("Standard_D2s_v5"),
+				}), nil)
+
+				_, _, err := NewAzureDriver(sp).decodeAndValidateProviderSpec(ctx, machineClass, secret)
+				s, ok := status.FromError(err)
+				Expect(ok).To(BeTrue())
+				Expect(s.Code()).To(Equal(codes.InvalidArgument))
+				Expect(s.Message()).To(Equal(`VM size "Standard_DS2_v2" is not offered in location "westeurope"`))
+			})
+
+			It("should return the provider spec and clients of a valid machine class", func() {
+				clients.Skus.EXPECT().List(gomock.Any(), "location eq 'westeurope'", "").Return(newResourceSkusPage(ctx, sku), nil)
+
+				providerSpec, driverClients, err := NewAzureDriver(sp).decodeAndValidateProviderSpec(ctx, machineClass, secret)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(providerSpec.Properties.HardwareProfile.VMSize).To(Equal("Standard_DS2_v2"))
+				Expect(driverClients).To(Equal(clients))
+			})
+		})
+	})
+})
@@ -0,0 +1,129 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package client provides a small Go API to manage machine-shaped Azure resources, i.e. a VM with its NICs and disks,
+// with the conventions of the provider, e.g. for bastion controllers or test frameworks of other Gardener extensions.
+// It is a facade of the driver, hence the resources are named, tagged, created and deleted like the ones of machines.
+package client
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/azure"
+	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/spi"
+	"github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/driver"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/codes"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/status"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// Client manages the resources of machines with the conventions of the provider. It is safe for concurrent use.
+type Client struct {
+	driver *azure.MachinePlugin
+}
+
+// Machine describes the resources of a machine
+type Machine struct {
+	// Name is the name of the machine and of its VM
+	Name string
+	// ProviderID is the provider ID of the machine, i.e. azure:///<location>/<name>
+	ProviderID string
+	// NodeName is the name of the node the machine is expected to register, i.e. the computer name of its VM
+	NodeName string
+}
+
+// New returns a client talking to Azure with the credentials of the secrets passed to its methods
+func New() *Client {
+	return NewForDriver(azure.NewAzureDriver(&spi.PluginSPIImpl{}))
+}
+
+// NewForDriver returns a client managing the resources with the given driver, e.g. a driver configured with
+// azure.DriverOptions
+func NewForDriver(driver *azure.MachinePlugin) *Client {
+	return &Client{driver: driver}
+}
+
+// NewMachineClass returns a machine class with the given name and provider spec, so that callers can use the typed
+// provider spec instead of building the machine class themselves
+func NewMachineClass(name string, providerSpec *api.AzureProviderSpec) (*v1alpha1.MachineClass, error) {
+	raw, err := json.Marshal(providerSpec)
+	if err != nil {
+		return nil, err
+	}
+	return &v1alpha1.MachineClass{
+		ObjectMeta:   metav1.ObjectMeta{Name: name},
+		ProviderSpec: runtime.RawExtension{Raw: raw},
+		Provider:     azure.ProviderName,
+	}, nil
+}
+
+// Create creates the VM of the machine with its NICs and disks. The secret contains the credentials and the user data
+// of the VM in the key userData. Resources created before a failure are deleted again.
+func (c *Client) Create(ctx context.Context, name string, machineClass *v1alpha1.MachineClass, secret *corev1.Secret) (*Machine, error) {
+	resp, err := c.requestDriver().CreateMachine(ctx, &driver.CreateMachineRequest{
+		Machine:      newMachine(name),
+		MachineClass: machineClass,
+		Secret:       secret,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Machine{Name: name, ProviderID: resp.ProviderID, NodeName: resp.NodeName}, nil
+}
+
+// Get returns the machine if its VM exists. A missing VM is reported with an error for which IsNotFound is true.
+func (c *Client) Get(ctx context.Context, name string, machineClass *v1alpha1.MachineClass, secret *corev1.Secret) (*Machine, error) {
+	resp, err := c.requestDriver().GetMachineStatus(ctx, &driver.GetMachineStatusRequest{
+		Machine:      newMachine(name),
+		MachineClass: machineClass,
+		Secret:       secret,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Machine{Name: name, ProviderID: resp.ProviderID, NodeName: resp.NodeName}, nil
+}
+
+// Delete deletes the VM of the machine with its NICs and disks. Resources which don't exist are skipped, hence
+// deleting a machine which is gone succeeds.
+func (c *Client) Delete(ctx context.Context, name string, machineClass *v1alpha1.MachineClass, secret *corev1.Secret) error {
+	_, err := c.requestDriver().DeleteMachine(ctx, &driver.DeleteMachineRequest{
+		Machine:      newMachine(name),
+		MachineClass: machineClass,
+		Secret:       secret,
+	})
+	if IsNotFound(err) {
+		// The resource group of the machine is gone
+		return nil
+	}
+	return err
+}
+
+// IsNotFound returns true if the error reports that the machine does not exist
+func IsNotFound(err error) bool {
+	if err == nil {
+		return false
+	}
+	s, ok := status.FromError(err)
+	return ok && s.Code() == codes.NotFound
+}
+
+// requestDriver returns a copy of the driver for a single request. The driver keeps the provider spec and secret of
+// the request it serves, while its caches guard themselves and are shared by all copies.
+func (c *Client) requestDriver() *azure.MachinePlugin {
+	driver := *c.driver
+	return &driver
+}
+
+// newMachine returns the machine object of the requests of the driver
+func newMachine(name string) *v1alpha1.Machine {
+	return &v1alpha1.Machine{ObjectMeta: metav1.ObjectMeta{Name: name}}
+}
@@ -0,0 +1,119 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-05-01/resources"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+	cp "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure"
+	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/mock"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/codes"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/status"
+	gomock "github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var _ = Describe("Client", func() {
+	var (
+		ctx          = context.Background()
+		providerSpec *api.AzureProviderSpec
+		secret       *corev1.Secret
+		clients      *mock.AzureDriverClients
+		client       *Client
+	)
+
+	BeforeEach(func() {
+		providerSpec = &api.AzureProviderSpec{}
+		Expect(json.Unmarshal(mock.AzureProviderSpec, providerSpec)).To(Succeed())
+		secret = &corev1.Secret{Data: map[string][]byte{
+			api.AzureClientID:       []byte("client"),
+			api.AzureClientSecret:   []byte("secret"),
+			api.AzureSubscriptionID: []byte("subscription"),
+			api.AzureTenantID:       []byte("tenant"),
+			"userData":              []byte("data"),
+		}}
+
+		sp := mock.NewMockPluginSPIImpl(gomock.NewController(GinkgoT()))
+		driverClients, err := sp.Setup(secret, nil)
+		Expect(err).NotTo(HaveOccurred())
+		clients = driverClients.(*mock.AzureDriverClients)
+		client = NewForDriver(cp.NewAzureDriver(sp))
+	})
+
+	It("should build machine classes from the provider spec", func() {
+		machineClass, err := NewMachineClass("bastion", providerSpec)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(machineClass.Name).To(Equal("bastion"))
+
+		decoded := &api.AzureProviderSpec{}
+		Expect(json.Unmarshal(machineClass.ProviderSpec.Raw, decoded)).To(Succeed())
+		Expect(decoded).To(Equal(providerSpec))
+	})
+
+	It("should delete machines whose resource group is gone", func() {
+		machineClass, err := NewMachineClass("bastion", providerSpec)
+		Expect(err).NotTo(HaveOccurred())
+		clients.Group.EXPECT().Get(gomock.Any(), providerSpec.ResourceGroup).Return(resources.Group{}, autorest.DetailedError{
+			Response: &http.Response{StatusCode: http.StatusNotFound},
+			Original: &azure.RequestError{ServiceError: &azure.ServiceError{Code: "ResourceGroupNotFound"}},
+		})
+
+		Expect(client.Delete(ctx, "bastion", machineClass, secret)).To(Succeed())
+	})
+
+	It("should process concurrent requests concurrently", func() {
+		machineClass, err := NewMachineClass("bastion", providerSpec)
+		Expect(err).NotTo(HaveOccurred())
+
+		var arrived sync.WaitGroup
+		arrived.Add(2)
+		clients.Group.EXPECT().Get(gomock.Any(), providerSpec.ResourceGroup).DoAndReturn(func(_ context.Context, _ string) (resources.Group, error) {
+			arrived.Done()
+			// Both requests must reach Azure before either of them completes
+			done := make(chan struct{})
+			go func() {
+				arrived.Wait()
+				close(done)
+			}()
+			select {
+			case <-done:
+			case <-time.After(10 * time.Second):
+				return resources.Group{}, errors.New("requests were serialized")
+			}
+			return resources.Group{}, autorest.DetailedError{
+				Response: &http.Response{StatusCode: http.StatusNotFound},
+				Original: &azure.RequestError{ServiceError: &azure.ServiceError{Code: "ResourceGroupNotFound"}},
+			}
+		}).Times(2)
+
+		errs := make(chan error, 2)
+		for _, name := range []string{"bastion-0", "bastion-1"} {
+			go func(name string) {
+				errs <- client.Delete(ctx, name, machineClass, secret)
+			}(name)
+		}
+		Expect(<-errs).To(Succeed())
+		Expect(<-errs).To(Succeed())
+	})
+
+	It("should detect not found errors", func() {
+		Expect(IsNotFound(status.Error(codes.NotFound, "gone"))).To(BeTrue())
+		Expect(IsNotFound(status.Error(codes.Unknown, "failed"))).To(BeFalse())
+		Expect(IsNotFound(nil)).To(BeFalse())
+	})
+})
@@ -0,0 +1,83 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-08-01/compute"
+	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/codes"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/status"
+)
+
+const (
+	// linuxComputerNameMaxLength is the maximum length of the computer name of Linux VMs
+	linuxComputerNameMaxLength = 64
+	// computerNameHashLength is the number of hex characters of the hash appended to truncated computer names
+	computerNameHashLength = 5
+)
+
+// computerNameMaxLength returns the maximum length of the computer name of VMs with the OS profile
+func computerNameMaxLength(osProfile api.AzureOSProfile) int {
+	if osProfile.ComputerNameTemplate != nil && osProfile.ComputerNameTemplate.MaxLength != nil {
+		return *osProfile.ComputerNameTemplate.MaxLength
+	}
+	if isWindows(osProfile) {
+		return windowsComputerNameMaxLength
+	}
+	return linuxComputerNameMaxLength
+}
+
+// getComputerName returns the computer name of the VM. Without a template it is the VM name. Rendered names exceeding
+// the maximum length are truncated and suffixed with a hash of the full name, so that distinct names stay distinct.
+func getComputerName(osProfile api.AzureOSProfile, vmName string) (string, error) {
+	nameTemplate := osProfile.ComputerNameTemplate
+	if nameTemplate == nil || nameTemplate.Template == "" {
+		return vmName, nil
+	}
+
+	tmpl, err := template.New("computerName").Parse(nameTemplate.Template)
+	if err != nil {
+		return "", status.Error(codes.InvalidArgument, fmt.Sprintf("Invalid computer name template: %v", err))
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, struct{ MachineName string }{MachineName: vmName}); err != nil {
+		return "", status.Error(codes.InvalidArgument, fmt.Sprintf("Could not render computer name template: %v", err))
+	}
+
+	computerName := buf.String()
+	if nameTemplate.Lowercase {
+		computerName = strings.ToLower(computerName)
+	}
+	if computerName == "" {
+		return "", status.Error(codes.InvalidArgument, fmt.Sprintf("Computer name template renders an empty name for VM %q", vmName))
+	}
+
+	maxLength := computerNameMaxLength(osProfile)
+	if len(computerName) > maxLength {
+		if maxLength <= computerNameHashLength+1 {
+			return "", status.Error(codes.InvalidArgument, fmt.Sprintf("Computer name %q exceeds %d characters", computerName, maxLength))
+		}
+		sum := sha256.Sum256([]byte(computerName))
+		computerName = strings.TrimRight(computerName[:maxLength-computerNameHashLength-1], "-") + "-" + hex.EncodeToString(sum[:])[:computerNameHashLength]
+	}
+	return computerName, nil
+}
+
+// getNodeName returns the name of the node the VM registers with, which is named after the lower-cased computer name
+func getNodeName(vm compute.VirtualMachine) string {
+	if vm.VirtualMachineProperties != nil && vm.OsProfile != nil && vm.OsProfile.ComputerName != nil && *vm.OsProfile.ComputerName != "" {
+		return strings.ToLower(*vm.OsProfile.ComputerName)
+	}
+	return *vm.Name
+}
@@ -0,0 +1,59 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-08-01/compute"
+	"github.com/Azure/go-autorest/autorest/to"
+	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ComputerName", func() {
+	It("should use the VM name without a template", func() {
+		Expect(getComputerName(api.AzureOSProfile{}, "shoot--project--worker-z1-abcde")).To(Equal("shoot--project--worker-z1-abcde"))
+	})
+
+	It("should render the template and enforce lower case", func() {
+		osProfile := api.AzureOSProfile{ComputerNameTemplate: &api.AzureComputerNameTemplate{Template: "Node-{{ .MachineName }}", Lowercase: true}}
+		Expect(getComputerName(osProfile, "worker-a")).To(Equal("node-worker-a"))
+	})
+
+	It("should truncate long names to distinct names within the default Windows limit", func() {
+		osProfile := api.AzureOSProfile{OSType: api.OSTypeWindows, ComputerNameTemplate: &api.AzureComputerNameTemplate{Template: "{{ .MachineName }}"}}
+		first, err := getComputerName(osProfile, "shoot--project--worker-z1-abcde")
+		Expect(err).NotTo(HaveOccurred())
+		second, err := getComputerName(osProfile, "shoot--project--worker-z1-fghij")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(first).To(HaveLen(windowsComputerNameMaxLength))
+		Expect(first).To(HavePrefix("shoot--pr"))
+		Expect(first).NotTo(Equal(second))
+		Expect(checkWindowsComputerName(osProfile, first)).To(Succeed())
+	})
+
+	It("should truncate to the configured maximum length", func() {
+		osProfile := api.AzureOSProfile{ComputerNameTemplate: &api.AzureComputerNameTemplate{Template: "{{ .MachineName }}", MaxLength: to.IntPtr(10)}}
+		Expect(getComputerName(osProfile, "worker-abcdefgh")).To(HaveLen(10))
+	})
+
+	It("should reject invalid templates", func() {
+		osProfile := api.AzureOSProfile{ComputerNameTemplate: &api.AzureComputerNameTemplate{Template: "{{ .MachineName"}}
+		_, err := getComputerName(osProfile, "worker-a")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should name the node after the lower-cased computer name", func() {
+		vm := compute.VirtualMachine{
+			Name:                     to.StringPtr("worker-a"),
+			VirtualMachineProperties: &compute.VirtualMachineProperties{OsProfile: &compute.OSProfile{ComputerName: to.StringPtr("Node-A")}},
+		}
+		Expect(getNodeName(vm)).To(Equal("node-a"))
+		Expect(getNodeName(compute.VirtualMachine{Name: to.StringPtr("worker-a")})).To(Equal("worker-a"))
+	})
+})
@@ -9,18 +9,24 @@ package azure
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
+	"time"
 
-	"github.com/Azure/azure-sdk-for-go/profiles/latest/compute/mgmt/compute"
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-08-01/compute"
 	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/bootstrap"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/eventgrid"
 	"github.com/gardener/machine-controller-manager-provider-azure/pkg/spi"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/spot"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/userdata"
 	"github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
 	"github.com/gardener/machine-controller-manager/pkg/util/provider/driver"
 	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/codes"
 	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/status"
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/klog"
+	"k8s.io/client-go/tools/record"
 )
 
 // NOTE
@@ -55,15 +61,138 @@ type MachinePlugin struct {
 	SPI               spi.SessionProviderInterface
 	AzureProviderSpec *api.AzureProviderSpec
 	Secret            *corev1.Secret
+
+	// Publisher optionally publishes machine lifecycle events to an Event Grid topic
+	Publisher eventgrid.Publisher
+
+	// TokenIssuer optionally issues bootstrap tokens which are rendered into the user data
+	TokenIssuer bootstrap.TokenIssuer
+
+	// UserDataTransformers optionally transform the user data before it is passed to Azure
+	UserDataTransformers userdata.Chain
+
+	// Recorder optionally records events on the machine objects
+	Recorder record.EventRecorder
+
+	// orphanCollector optionally garbage collects NICs and disks not attached to any VM
+	orphanCollector *orphanCollector
+
+	// capabilities caches the capabilities of the VM sizes per subscription and location
+	capabilities *capabilityMatrix
+
+	// ipHandoffs keeps the addresses of deleted machines for their successors
+	ipHandoffs *ipHandoffs
+
+	// vnetLocations caches the locations of the virtual networks of the machines
+	vnetLocations *vnetLocations
+
+	// nicReservations tracks NICs Azure keeps reserved for deleted VMs
+	nicReservations *nicReservations
+
+	// marketplaceAgreements caches the accepted marketplace agreements per subscription
+	marketplaceAgreements *marketplaceAgreements
+
+	// ownerID identifies this machine controller instance in the owner tag of the machine resources
+	ownerID string
+
+	// checkIdentityExistence verifies that the user-assigned identity exists before a machine is created
+	checkIdentityExistence bool
+
+	// tagValuePolicy determines how tag values exceeding the Azure limit are handled
+	tagValuePolicy string
+
+	// reservedTagKeys are the tag keys machine classes must not set
+	reservedTagKeys []string
+
+	// networkDiagnostics records the effective network configuration of machines whose node failed to join
+	networkDiagnostics bool
+	networkDiagnoses   *networkDiagnoses
+
+	// creationBudget caps the concurrent machine creations per resource group, it is nil if they are not capped
+	creationBudget *creationBudget
+
+	// nicCreateTimeout, vmCreateTimeout and deleteTimeout bound the Azure operations of the machine resources.
+	// The operations are only bounded by the request context if zero.
+	nicCreateTimeout time.Duration
+	vmCreateTimeout  time.Duration
+	deleteTimeout    time.Duration
+
+	// gracefulShutdownTimeout bounds the shutdown of a VM through its OS before it is deleted. VMs are deleted without
+	// shutdown if zero.
+	gracefulShutdownTimeout time.Duration
+
+	// guestAgentReadyTimeout optionally bounds the wait for the guest agent of a created VM to report ready, which is
+	// polled in the guestAgentPollInterval. The VM is not waited for if zero.
+	guestAgentReadyTimeout time.Duration
+	guestAgentPollInterval time.Duration
+
+	// imageCanaries optionally validates new images with a canary machine before other machines are created with them
+	imageCanaries *imageCanaries
+
+	// asyncDeletion issues the deletion of the machine resources without waiting for their completion.
+	// The deletion is verified by the subsequent delete requests.
+	asyncDeletion  bool
+	asyncDeletions *asyncDeletions
+
+	// strictDecoding rejects unknown fields of the provider specs of all machine classes
+	strictDecoding bool
+
+	// tagSyncs optionally synchronizes the tags of the machine classes to the resources of their machines on
+	// GetMachineStatus
+	tagSyncs *tagSyncs
+
+	// userDataSyncs updates the user data of the VMs passed the user data via their userData property on
+	// GetMachineStatus
+	userDataSyncs *userDataSyncs
+
+	// volumeSessions remembers the credentials per subscription to read the tags of the disks of volumes
+	volumeSessions *volumeSessions
+
+	// vmInventory optionally caches the VMs per resource group to determine the status of machines
+	vmInventory *vmInventory
+
+	// vmWatcher optionally pushes the VM changes of the Activity Log into the VM inventory
+	vmWatcher *vmWatcher
+
+	// regionHealth optionally detects region-wide issues and adds failover hints to the errors
+	regionHealth *regionHealth
+
+	// spotTracker optionally tracks the spot signals of the VM sizes of all listed machine classes
+	spotTracker *spot.Tracker
+
+	// providerSpecs caches the decoded and validated provider specs of the machine classes
+	providerSpecs *providerSpecCache
+
+	// tagListings keeps the VMs listed by the tag reconciliations for their next batches
+	tagListings *tagListings
 }
 
 // AzureMachineClassKind for Azure Machine Class
 const AzureMachineClassKind = "AzureMachineClass"
 
+// ProviderName is the provider of the machine classes served by this driver
+const ProviderName = "Azure"
+
+// AzureDiskCSIDriverName is the name of the CSI driver of Azure disks
+const AzureDiskCSIDriverName = "disk.csi.azure.com"
+
 // NewAzureDriver returns an empty AzureDriver object
 func NewAzureDriver(spi spi.SessionProviderInterface) *MachinePlugin {
 	return &MachinePlugin{
-		SPI: spi,
+		SPI:              spi,
+		asyncDeletions:   newAsyncDeletions(),
+		capabilities:     newCapabilityMatrix(capabilityMatrixTTL),
+		ipHandoffs:       newIPHandoffs(ipHandoffTTL),
+		networkDiagnoses: newNetworkDiagnoses(networkDiagnosesTTL),
+		nicReservations:  newNICReservations(nicReservationEscalationPeriod, nicReservationAlertPeriod),
+		providerSpecs:    newProviderSpecCache(),
+		tagListings:      newTagListings(tagListingTTL),
+		userDataSyncs:    newUserDataSyncs(),
+		volumeSessions:   newVolumeSessions(),
+		vnetLocations:    newVNetLocations(vnetLocationTTL),
+
+		guestAgentPollInterval: guestAgentPollInterval,
+		marketplaceAgreements:  newMarketplaceAgreements(marketplaceAgreementTTL),
 	}
 }
 
@@ -74,19 +203,39 @@ func NewAzureDriver(spi spi.SessionProviderInterface) *MachinePlugin {
 //
 func (d *MachinePlugin) CreateMachine(ctx context.Context, req *driver.CreateMachineRequest) (*driver.CreateMachineResponse, error) {
 	// Log messages to track request
-	klog.V(2).Infof("Machine creation request has been recieved for %q", req.Machine.Name)
-	defer klog.V(2).Infof("Machine creation request has been processed for %q", req.Machine.Name)
+	ctx = withMachineLogFields(ctx, "CreateMachine", req.Machine.Name, req.MachineClass)
+	spi.V(2).InfoS(ctx, "Machine creation request has been recieved")
+	defer spi.V(2).InfoS(ctx, "Machine creation request has been processed")
+	if err := checkPaused(ctx, req.Machine, "creation"); err != nil {
+		return nil, err
+	}
 
 	d.Secret = req.Secret
-	virtualMachine, err := d.createVMNicDisk(req)
+	virtualMachine, err := d.createVMNicDisk(ctx, req)
+	code := errorCode(err)
+	detail := newLastOperationDetail(lastOperationPhaseCreate, code, err)
+	if s, ok := err.(*status.Status); ok {
+		// Errors which are not worth retrying already carry their code
+		err = errors.New(s.Message())
+	}
+	// The provider spec of the request is used, as concurrent requests of other machine classes replace the shared one
+	providerSpec, decodeErr := d.decodeProviderSpecAndSecret(req.MachineClass, req.Secret)
+	if decodeErr == nil {
+		err = d.recordRegionHealth(ctx, providerSpec.Location, req.MachineClass.Name, err)
+	} else {
+		providerSpec = nil
+	}
 	if err != nil {
-		return nil, status.Error(codes.Unknown, err.Error())
+		d.publishMachineEvent(ctx, operationCreate, req.Machine, req.MachineClass, providerSpec, "", err)
+		return nil, lastOperationError(code, operationErrorMessage(err), detail)
 	}
 
 	providerID := encodeMachineID(*virtualMachine.Location, *virtualMachine.Name)
-	klog.Infof("Provider ID: %s\nNodeName: %s\n", providerID, *virtualMachine.Name)
+	nodeName := getNodeName(*virtualMachine)
+	spi.InfoS(ctx, "Machine created", spi.LogKeyResourceGroup, providerSpec.ResourceGroup, "providerID", providerID, "nodeName", nodeName)
+	d.publishMachineEvent(ctx, operationCreate, req.Machine, req.MachineClass, providerSpec, providerID, nil)
 
-	return &driver.CreateMachineResponse{ProviderID: providerID, NodeName: *virtualMachine.Name}, nil
+	return &driver.CreateMachineResponse{ProviderID: providerID, NodeName: nodeName}, nil
 }
 
 // DeleteMachine handles a machine deletion request
@@ -102,44 +251,118 @@ func (d *MachinePlugin) CreateMachine(ctx context.Context, req *driver.CreateMac
 //
 func (d *MachinePlugin) DeleteMachine(ctx context.Context, req *driver.DeleteMachineRequest) (*driver.DeleteMachineResponse, error) {
 	// Log messages to track delete request
-	klog.V(2).Infof("Machine deletion request has been recieved for %q", req.Machine.Name)
-	defer klog.V(2).Infof("Machine deletion request has been processed for %q", req.Machine.Name)
+	ctx = withMachineLogFields(ctx, "DeleteMachine", req.Machine.Name, req.MachineClass)
+	spi.V(2).InfoS(ctx, "Machine deletion request has been recieved")
+	defer spi.V(2).InfoS(ctx, "Machine deletion request has been processed")
+	if err := checkPaused(ctx, req.Machine, "deletion"); err != nil {
+		return nil, err
+	}
+	ctx = spi.WithMachineClass(ctx, req.MachineClass.Name)
 
-	providerSpec, err := decodeProviderSpecAndSecret(req.MachineClass, req.Secret)
+	providerSpec, err := d.decodeProviderSpecAndSecret(req.MachineClass, req.Secret)
 	if err != nil {
 		return nil, status.Error(codes.Unknown, err.Error())
 	}
 	d.AzureProviderSpec = providerSpec
 	d.Secret = req.Secret
+	ctx = spi.WithLogFields(ctx, spi.LogKeyResourceGroup, providerSpec.ResourceGroup)
+	ctx = withForceDeletion(ctx, req.Machine, req.MachineClass, providerSpec)
 
 	var (
 		vmName            = strings.ToLower(req.Machine.Name)
 		resourceGroupName = providerSpec.ResourceGroup
-		nicName           = dependencyNameFromVMName(vmName, nicSuffix)
-		diskName          = dependencyNameFromVMName(vmName, diskSuffix)
+		networkInterfaces = getNetworkInterfaces(providerSpec, vmName)
+		diskName          = namingStrategyOf(providerSpec).OSDiskName(vmName)
 		dataDiskNames     []string
 	)
 
-	clients, err := d.SPI.Setup(d.Secret)
+	clients, err := d.SPI.Setup(d.Secret, providerSpec.CloudConfiguration)
 	if err != nil {
 		return nil, status.Error(codes.Unknown, err.Error())
 	}
 
-	// Check if the underlying resource group still exists. If not, skip the deletion, as all resources are gone.
+	// Check if the underlying resource group still exists. If not, only the NICs in another resource group are
+	// deleted, as all other resources are gone.
 	if _, err := clients.GetGroup().Get(ctx, resourceGroupName); err != nil {
-		if spi.NotFound(err) {
-			return nil, status.Error(codes.NotFound, err.Error())
+		if resourceGroupNotFound(err) {
+			if err := d.deleteNICsOfDeletedResourceGroup(ctx, clients, resourceGroupName, networkInterfaces); err != nil {
+				return nil, deletionError(err)
+			}
+			d.forgetMachineOfDeletedResourceGroup(ctx, req.Machine, req.MachineClass, resourceGroupName, vmName)
+			return &driver.DeleteMachineResponse{}, nil
 		}
-		return nil, status.Error(codes.Unknown, err.Error())
+		return nil, deletionError(err)
 	}
 
+	// The data disks are named by the LUN offset the VM was created with, which is not necessarily the one of the class
+	lunOffset, err := resolveDataDiskLunOffset(ctx, clients, resourceGroupName, vmName, namingStrategyOf(providerSpec), providerSpec.Properties.StorageProfile.DataDisks, providerSpec.Properties.StorageProfile.DataDiskLunOffset)
+	if err != nil {
+		return nil, deletionError(err)
+	}
 	if providerSpec.Properties.StorageProfile.DataDisks != nil && len(providerSpec.Properties.StorageProfile.DataDisks) > 0 {
-		dataDiskNames = getAzureDataDiskNames(providerSpec.Properties.StorageProfile.DataDisks, vmName, dataDiskSuffix)
+		dataDiskNames = getAzureDataDiskNames(namingStrategyOf(providerSpec), providerSpec.Properties.StorageProfile.DataDisks, lunOffset, vmName)
 	}
 
-	err = d.deleteVMNicDisks(ctx, clients, resourceGroupName, vmName, nicName, diskName, dataDiskNames)
-	if err != nil {
-		return nil, status.Error(codes.Unknown, err.Error())
+	// Machines handed over to another machine controller instance keep their resources
+	if owner := req.Machine.Annotations[api.MachineAnnotationHandOverTo]; owner != "" {
+		if err := handOverMachine(ctx, clients, resourceGroupName, vmName, networkInterfaces, append([]string{diskName}, dataDiskNames...), owner); err != nil {
+			return nil, deletionError(err)
+		}
+		spi.InfoS(ctx, "Machine was handed over, its resources are kept", "owner", owner)
+		return &driver.DeleteMachineResponse{}, nil
+	}
+	if d.ownerID != "" {
+		// The resources are only deleted once the VM is known to be owned by this instance or to be gone, so that
+		// a failed lookup never deletes the resources of a VM of another instance
+		vm, err := clients.GetVM().Get(ctx, resourceGroupName, vmName, "")
+		if err != nil && !spi.NotFound(err) {
+			return nil, deletionError(spi.OnARMAPIErrorFail(prometheusServiceVM, err, "VM.Get failed for %s", vmName))
+		}
+		if err == nil && d.ownedByOtherInstance(vm.Tags) {
+			spi.InfoS(ctx, "VM is owned by another instance, its resources are kept", "owner", ownerOf(vm.Tags))
+			return &driver.DeleteMachineResponse{}, nil
+		}
+	}
+
+	// Data disks with the detach delete option are kept and must not be deleted even if found in the VM model
+	if retainedDiskNames := getRetainedDataDiskNames(namingStrategyOf(providerSpec), providerSpec.Properties.StorageProfile.DataDisks, lunOffset, vmName); len(retainedDiskNames) > 0 {
+		if dataDiskNames, err = retainDataDisks(ctx, clients, resourceGroupName, dataDiskNames, retainedDiskNames); err != nil {
+			return nil, deletionError(err)
+		}
+		ctx = withRetainedDisks(ctx, retainedDiskNames)
+	}
+
+	d.reportNetworkDiagnostics(ctx, clients, resourceGroupName, req.Machine, networkInterfaces)
+	d.holdIPHandoff(ctx, clients, resourceGroupName, req.Machine, req.MachineClass, networkInterfaces)
+
+	if d.asyncDeletion {
+		deleted, err := d.deleteVMNicDisksAsync(ctx, clients, resourceGroupName, vmName, networkInterfaces, diskName, dataDiskNames)
+		if err != nil {
+			d.publishMachineEvent(ctx, operationDelete, req.Machine, req.MachineClass, providerSpec, req.Machine.Spec.ProviderID, err)
+			return nil, deletionError(err)
+		}
+		if !deleted {
+			return nil, status.Error(codes.Unavailable, fmt.Sprintf("Deletion of the resources of machine %q is in progress", req.Machine.Name))
+		}
+	} else if err := d.deleteVMNicDisks(ctx, clients, resourceGroupName, vmName, networkInterfaces, diskName, dataDiskNames); err != nil {
+		d.publishMachineEvent(ctx, operationDelete, req.Machine, req.MachineClass, providerSpec, req.Machine.Spec.ProviderID, err)
+		return nil, deletionError(err)
+	}
+	d.releaseIPHandoff(req.Machine, req.MachineClass)
+	if err := deleteEmptyAvailabilitySet(ctx, clients, providerSpec); err != nil {
+		spi.WarningS(ctx, "Empty availability set of machine could not be deleted", "err", err)
+	}
+	if d.vmInventory != nil {
+		d.vmInventory.remove(inventoryKey(d.Secret, resourceGroupName), vmName)
+	}
+	d.tagSyncs.forget(req.Machine.Name)
+	d.userDataSyncs.forget(req.Machine.Name)
+	d.publishMachineEvent(ctx, operationDelete, req.Machine, req.MachineClass, providerSpec, req.Machine.Spec.ProviderID, nil)
+
+	if d.TokenIssuer != nil {
+		if err := d.TokenIssuer.Revoke(ctx, req.Machine.Name); err != nil {
+			spi.WarningS(ctx, "Bootstrap token of machine could not be revoked", "err", err)
+		}
 	}
 
 	return &driver.DeleteMachineResponse{}, nil
@@ -163,11 +386,22 @@ func (d *MachinePlugin) DeleteMachine(ctx context.Context, req *driver.DeleteMac
 // The request should return a NOT_FOUND (5) status error code if the machine is not existing
 func (d *MachinePlugin) GetMachineStatus(ctx context.Context, req *driver.GetMachineStatusRequest) (*driver.GetMachineStatusResponse, error) {
 	// Log messages to track start and end of request
-	klog.V(2).Infof("Get request has been recieved for %q", req.Machine.Name)
-	defer klog.V(2).Infof("Machine get request has been processed successfully for %q", req.Machine.Name)
+	ctx = withMachineLogFields(ctx, "GetMachineStatus", req.Machine.Name, req.MachineClass)
+	spi.V(2).InfoS(ctx, "Get request has been recieved")
+	defer spi.V(2).InfoS(ctx, "Machine get request has been processed successfully")
 
 	var machineStatusResponse = &driver.GetMachineStatusResponse{}
 
+	if d.vmInventory != nil {
+		machineStatusResponse, err := d.getMachineStatusFromInventory(ctx, req)
+		if err == nil {
+			d.restartStoppedVM(ctx, req, time.Now())
+			d.syncMachineTags(ctx, req, time.Now())
+			d.syncUserData(ctx, req)
+		}
+		return machineStatusResponse, err
+	}
+
 	listMachineRequest := &driver.ListMachinesRequest{MachineClass: req.MachineClass, Secret: req.Secret}
 
 	machines, err := d.ListMachines(ctx, listMachineRequest)
@@ -177,7 +411,18 @@ func (d *MachinePlugin) GetMachineStatus(ctx context.Context, req *driver.GetMac
 	for providerID, VMName := range machines.MachineList {
 		if VMName == req.Machine.Name {
 			machineStatusResponse.NodeName = VMName
+			// The provider spec of the request is used, as concurrent requests of other machine classes replace the shared one
+			if providerSpec, err := d.decodeProviderSpecAndSecret(req.MachineClass, req.Secret); err == nil {
+				if providerSpec, err := selectOSProfile(providerSpec, req.Machine); err == nil {
+					if computerName, err := getComputerName(providerSpec.Properties.OsProfile, VMName); err == nil {
+						machineStatusResponse.NodeName = strings.ToLower(computerName)
+					}
+				}
+			}
 			machineStatusResponse.ProviderID = providerID
+			d.restartStoppedVM(ctx, req, time.Now())
+			d.syncMachineTags(ctx, req, time.Now())
+			d.syncUserData(ctx, req)
 			return machineStatusResponse, nil
 		}
 	}
@@ -200,39 +445,72 @@ func (d *MachinePlugin) GetMachineStatus(ctx context.Context, req *driver.GetMac
 //
 func (d *MachinePlugin) ListMachines(ctx context.Context, req *driver.ListMachinesRequest) (*driver.ListMachinesResponse, error) {
 	// Log messages to track start and end of request
-	klog.V(2).Infof("List machines request has been recieved for %q", req.MachineClass.Name)
-	defer klog.V(2).Infof("List machines request has been recieved for %q", req.MachineClass.Name)
+	ctx = withMachineLogFields(ctx, "ListMachines", "", req.MachineClass)
+	spi.V(2).InfoS(ctx, "List machines request has been recieved")
+	defer spi.V(2).InfoS(ctx, "List machines request has been processed")
+	ctx = spi.WithMachineClass(ctx, req.MachineClass.Name)
 
-	providerSpec, err := decodeProviderSpecAndSecret(req.MachineClass, req.Secret)
+	providerSpec, err := d.decodeProviderSpecAndSecret(req.MachineClass, req.Secret)
+	if err != nil {
+		return nil, err
+	}
 	d.AzureProviderSpec = providerSpec
 
 	var (
 		resourceGroupName = providerSpec.ResourceGroup
 		items             []compute.VirtualMachine
-		result            compute.VirtualMachineListResultPage
 		listOfVMs         = make(map[string]string)
 	)
 
-	clients, err := d.SPI.Setup(req.Secret)
+	clients, err := d.SPI.Setup(req.Secret, providerSpec.CloudConfiguration)
 	if err != nil {
 		return nil, status.Error(codes.Unknown, err.Error())
 	}
 
-	result, err = clients.GetVM().List(ctx, resourceGroupName)
-	items = append(items, result.Values()...)
-	for result.NotDone() {
-		err = result.NextWithContext(ctx)
-		if err != nil {
-			return nil, spi.OnARMAPIErrorFail(prometheusServiceVM, err, "VM.List")
+	// The VMs are iterated page by page and only the VMs carrying the cluster and role tags of the machine class are
+	// kept, so that resource groups with thousands of VMs of other clusters do not need to be held in memory
+	iterator, err := clients.GetVM().ListComplete(ctx, resourceGroupName, "")
+	for err == nil && iterator.NotDone() {
+		if item := iterator.Value(); matchesClassTags(item.Tags, providerSpec.Tags) {
+			items = append(items, item)
 		}
-		items = append(items, result.Values()...)
+		err = iterator.NextWithContext(ctx)
+	}
+	if err != nil {
+		return nil, machineError(spi.OnARMAPIErrorFail(prometheusServiceVM, err, "VM.List"))
 	}
 
 	for _, item := range items {
+		// VMs handed over to another machine controller instance are not listed, so that they are not considered orphaned
+		if d.ownedByOtherInstance(item.Tags) {
+			continue
+		}
 		listOfVMs[encodeMachineID(*item.Location, *item.Name)] = *item.Name
 	}
 
 	spi.OnARMAPISuccess(prometheusServiceVM, "VM.List")
+
+	if d.vmInventory != nil {
+		d.vmInventory.update(inventoryKey(req.Secret, resourceGroupName), items)
+	}
+
+	if d.vmWatcher != nil {
+		if env, err := spi.GetEnvironment(providerSpec.CloudConfiguration); err == nil {
+			d.vmWatcher.watch(inventoryKey(req.Secret, resourceGroupName), subscriptionID(req.Secret), resourceGroupName, clients.GetClient(), env.ResourceManagerEndpoint)
+		}
+	}
+
+	if d.spotTracker != nil {
+		if env, err := spi.GetEnvironment(providerSpec.CloudConfiguration); err == nil {
+			d.spotTracker.Observe(providerSpec.Properties.HardwareProfile.VMSize, providerSpec.Location, req.Secret.Namespace+"/"+req.Secret.Name, clients.GetClient(), env.ResourceManagerEndpoint)
+		}
+	}
+
+	if d.orphanCollector != nil {
+		if err := d.orphanCollector.collect(ctx, clients, providerSpec); err != nil {
+			spi.WarningS(ctx, "Orphaned resources of machine class could not be collected", "err", err)
+		}
+	}
 	return &driver.ListMachinesResponse{MachineList: listOfVMs}, nil
 }
 
@@ -246,19 +524,35 @@ func (d *MachinePlugin) ListMachines(ctx context.Context, req *driver.ListMachin
 //
 func (d *MachinePlugin) GetVolumeIDs(ctx context.Context, req *driver.GetVolumeIDsRequest) (*driver.GetVolumeIDsResponse, error) {
 	// Log messages to track start and end of request
-	klog.V(2).Infof("GetVolumeIDs request has been recieved for %q", req.PVSpecs)
-	defer klog.V(2).Infof("GetVolumeIDs request has been processed successfully for %q", req.PVSpecs)
+	ctx = spi.WithLogFields(ctx, spi.LogKeyOperation, "GetVolumeIDs")
+	spi.V(2).InfoS(ctx, "GetVolumeIDs request has been recieved", "pvSpecs", len(req.PVSpecs))
+	defer spi.V(2).InfoS(ctx, "GetVolumeIDs request has been processed successfully")
 
 	names := []string{}
 	specs := req.PVSpecs
 
 	for i := range specs {
 		spec := specs[i]
-		if spec.AzureDisk == nil {
+		var name string
+		switch {
+		case spec.AzureDisk != nil:
+			// The disk URI is the resource ID of managed disks and the blob URI of unmanaged disks
+			name = spec.AzureDisk.DataDiskURI
+			if name == "" {
+				name = spec.AzureDisk.DiskName
+			}
+		case spec.CSI != nil && spec.CSI.Driver == AzureDiskCSIDriverName:
+			// The volume handle of the CSI driver is the resource ID of the managed disk
+			name = spec.CSI.VolumeHandle
+		default:
 			// Not an azure volume
 			continue
 		}
-		name := spec.AzureDisk.DiskName
+		if d.isMachineDisk(ctx, name) {
+			// Disks created by the provider stay attached until the VM is deleted, hence the drain must not wait
+			// for their detachment
+			continue
+		}
 		names = append(names, name)
 	}
 
@@ -286,16 +580,23 @@ func (d *MachinePlugin) GetVolumeIDs(ctx context.Context, req *driver.GetVolumeI
 //
 func (d *MachinePlugin) GenerateMachineClassForMigration(ctx context.Context, req *driver.GenerateMachineClassForMigrationRequest) (*driver.GenerateMachineClassForMigrationResponse, error) {
 	// Log messages to track start and end of request
-	klog.V(2).Infof("MigrateMachineClass request has been recieved for %q", req.ClassSpec)
-	defer klog.V(2).Infof("MigrateMachineClass request has been processed successfully for %q", req.ClassSpec)
-
-	azureMachineClass := req.ProviderSpecificMachineClass.(*v1alpha1.AzureMachineClass)
+	ctx = spi.WithLogFields(ctx, spi.LogKeyOperation, "GenerateMachineClassForMigration", spi.LogKeyMachineClass, req.ClassSpec.Name)
+	spi.V(2).InfoS(ctx, "MigrateMachineClass request has been recieved", "kind", req.ClassSpec.Kind)
+	defer spi.V(2).InfoS(ctx, "MigrateMachineClass request has been processed successfully")
 
 	// Check if incoming CR is valid CR for migration
 	// In this case, the MachineClassKind to be matching
 	if req.ClassSpec.Kind != AzureMachineClassKind {
 		return nil, status.Error(codes.Internal, "Migration cannot be done for this machineClass kind")
 	}
+	azureMachineClass, ok := req.ProviderSpecificMachineClass.(*v1alpha1.AzureMachineClass)
+	if !ok {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("Migration cannot be done for machine class of type %T", req.ProviderSpecificMachineClass))
+	}
 
-	return &driver.GenerateMachineClassForMigrationResponse{}, fillUpMachineClass(azureMachineClass, req.MachineClass)
+	if err := fillUpMachineClass(azureMachineClass, req.MachineClass); err != nil {
+		return nil, err
+	}
+	spi.InfoS(ctx, "Machine class was generated for migration", "azureMachineClass", azureMachineClass.Name)
+	return &driver.GenerateMachineClassForMigrationResponse{}, nil
 }
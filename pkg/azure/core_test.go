@@ -9,12 +9,19 @@ package azure
 import (
 	"context"
 	"encoding/json"
+	"net/http"
 	"strings"
+	"time"
 
-	"github.com/Azure/azure-sdk-for-go/profiles/latest/network/mgmt/network"
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-08-01/compute"
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-04-01/network"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
 
 	apis "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
 	mock "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/mock"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/bootstrap"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/spi"
 	v1alpha1 "github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
 	"github.com/gardener/machine-controller-manager/pkg/util/provider/driver"
 	gomock "github.com/golang/mock/gomock"
@@ -24,6 +31,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
 )
 
 var _ = Describe("MachineController", func() {
@@ -94,7 +102,7 @@ var _ = Describe("MachineController", func() {
 				mockDriver := NewAzureDriver(mockPluginSPIImpl)
 
 				// call setup before the create machine
-				mockDriverClients, err := mockPluginSPIImpl.Setup(machineRequest.Secret)
+				mockDriverClients, err := mockPluginSPIImpl.Setup(machineRequest.Secret, providerSpec.CloudConfiguration)
 
 				// Define all the client expectations here and then proceed with the function call
 				fakeClients := mockDriverClients.(*mock.AzureDriverClients)
@@ -107,7 +115,7 @@ var _ = Describe("MachineController", func() {
 					// vnetResourceGroup = resourceGroupName
 					subnetName = providerSpec.SubnetInfo.SubnetName
 					// nicName           = dependencyNameFromVMName(vmName, nicSuffix)
-					// diskName          = dependencyNameFromVMName(vmName, diskSuffix)
+					// diskName          = suffixNamingStrategy{}.OSDiskName(vmName)
 					// vmImageRef        *compute.VirtualMachineImage
 				)
 
@@ -115,15 +123,39 @@ var _ = Describe("MachineController", func() {
 
 				nicFuture := UnmarshalNICFuture([]byte("{\"method\":\"PUT\",\"pollingMethod\":\"RequestURI\",\"pollingURI\":\"https://management.azure.com/subscriptions/00d2caa5-cd29-46f7-845a-2f8ee0360ef5/resourceGroups/shoot--i538135--seed-az/providers/Microsoft.Network/networkInterfaces/test-machine-deployment-oot-748df-95bhn-nic?api-version=2020-04-01\",\"lroState\":\"Succeeded\",\"resultURI\":\"https://management.azure.com/subscriptions/00d2caa5-cd29-46f7-845a-2f8ee0360ef5/resourceGroups/shoot--i538135--seed-az/providers/Microsoft.Network/networkInterfaces/test-machine-deployment-oot-748df-95bhn-nic?api-version=2020-04-01\"}"))
 
-				fakeClients.Subnet.EXPECT().Get(context.Background(),
+				// The machine class is validated with the context of the request, the resources are created with its machine class and resource group on top
+				machineCtx := withMachineLogFields(ctx, "CreateMachine", machineRequest.Machine.Name, machineRequest.MachineClass)
+				requestCtx := spi.WithLogFields(spi.WithMachineClass(machineCtx, machineRequest.MachineClass.Name), spi.LogKeyResourceGroup, resourceGroupName)
+
+				fakeClients.Subnet.EXPECT().Get(requestCtx,
 					resourceGroupName,
 					vnetName,
 					subnetName,
 					"").Return(subnet, nil)
 
+				fakeClients.Skus.EXPECT().List(machineCtx, "location eq '"+providerSpec.Location+"'", "").Return(newResourceSkusPage(ctx, compute.ResourceSku{
+					ResourceType: to.StringPtr("virtualMachines"),
+					Name:         to.StringPtr(providerSpec.Properties.HardwareProfile.VMSize),
+				}), nil)
+
+				fakeClients.VirtualNetworks.EXPECT().Get(requestCtx, providerSpec.ResourceGroup, providerSpec.SubnetInfo.VnetName, "").Return(network.VirtualNetwork{Location: to.StringPtr(providerSpec.Location)}, nil)
+
 				mockDriver.AzureProviderSpec = UnmarshalProviderSpec(mock.AzureProviderSpec)
-				NICParameters := mockDriver.getNICParameters(vmName, &subnet)
-				fakeClients.NIC.EXPECT().CreateOrUpdate(ctx, resourceGroupName, *NICParameters.Name, NICParameters).Return(nicFuture, nil)
+				NICParameters := mockDriver.getNICParameters(getNetworkInterfaces(providerSpec, vmName)[0], &subnet, nil, "", nil)
+				fakeClients.NIC.EXPECT().CreateOrUpdate(requestCtx, resourceGroupName, *NICParameters.Name, NICParameters).Return(nicFuture, nil)
+				fakeClients.NIC.EXPECT().Get(requestCtx, resourceGroupName, *NICParameters.Name, "").Return(network.Interface{
+					ID:   to.StringPtr("/subscriptions/00d2caa5-cd29-46f7-845a-2f8ee0360ef5/resourceGroups/" + resourceGroupName + "/providers/Microsoft.Network/networkInterfaces/" + *NICParameters.Name),
+					Name: NICParameters.Name,
+				}, nil)
+
+				fakeClients.VM.EXPECT().Get(requestCtx, resourceGroupName, vmName, compute.InstanceViewTypes("")).Return(compute.VirtualMachine{}, autorest.DetailedError{Response: &http.Response{StatusCode: http.StatusNotFound}})
+				fakeClients.Images.EXPECT().Get(requestCtx, providerSpec.Location, "sap", "gardenlinux", "greatest", "27.1.0").Return(compute.VirtualMachineImage{VirtualMachineImageProperties: &compute.VirtualMachineImageProperties{}}, nil)
+				fakeClients.VM.EXPECT().CreateOrUpdate(requestCtx, resourceGroupName, vmName, gomock.Any()).Return(UnmarshalVMFuture([]byte(succeededFuture)), nil)
+				fakeClients.VM.EXPECT().Get(requestCtx, resourceGroupName, vmName, compute.InstanceViewTypes("")).Return(compute.VirtualMachine{
+					Name:     to.StringPtr(vmName),
+					Location: to.StringPtr(providerSpec.Location),
+				}, nil)
+				fakeClients.Disk.EXPECT().Update(requestCtx, resourceGroupName, suffixNamingStrategy{}.OSDiskName(vmName), gomock.Any()).Return(UnmarshalDiskUpdateFuture([]byte(succeededFuture)), nil)
 
 				// if there is no variation in the machine class (various scenarios) call the
 				// machineRequest.MachineClass = newAzureMachineClass(providerSpec)
@@ -154,9 +186,227 @@ var _ = Describe("MachineController", func() {
 				"",
 			),
 		)
+
+		It("should not issue a bootstrap token for an adopted VM", func() {
+			var (
+				ctx                  = context.Background()
+				mockPluginSPIImpl    = mock.NewMockPluginSPIImpl(gomock.NewController(GinkgoT()))
+				plugin               = NewAzureDriver(mockPluginSPIImpl)
+				machineClass, secret = newProviderSpecCacheFixtures()
+				providerSpec         = UnmarshalProviderSpec(mock.AzureProviderSpec)
+				targetClient         = fake.NewSimpleClientset()
+			)
+			plugin.TokenIssuer = bootstrap.NewTokenIssuer(targetClient, time.Hour)
+			secret.Data["userData"] = []byte("token: " + bootstrap.TokenPlaceholder)
+
+			mockDriverClients, err := mockPluginSPIImpl.Setup(secret, nil)
+			Expect(err).NotTo(HaveOccurred())
+			fakeClients := mockDriverClients.(*mock.AzureDriverClients)
+			fakeClients.Subnet.EXPECT().Get(gomock.Any(), providerSpec.ResourceGroup, providerSpec.SubnetInfo.VnetName, providerSpec.SubnetInfo.SubnetName, "").Return(network.Subnet{}, nil)
+			fakeClients.Skus.EXPECT().List(gomock.Any(), "location eq '"+providerSpec.Location+"'", "").Return(newResourceSkusPage(ctx, compute.ResourceSku{
+				ResourceType: to.StringPtr("virtualMachines"),
+				Name:         to.StringPtr(providerSpec.Properties.HardwareProfile.VMSize),
+			}), nil)
+			fakeClients.VirtualNetworks.EXPECT().Get(gomock.Any(), providerSpec.ResourceGroup, providerSpec.SubnetInfo.VnetName, "").Return(network.VirtualNetwork{Location: to.StringPtr(providerSpec.Location)}, nil)
+			fakeClients.VM.EXPECT().Get(gomock.Any(), providerSpec.ResourceGroup, "machine", compute.InstanceViewTypes("")).Return(compute.VirtualMachine{
+				Name:                     to.StringPtr("machine"),
+				Location:                 to.StringPtr(providerSpec.Location),
+				Tags:                     getAzureTags(providerSpec.Tags),
+				VirtualMachineProperties: &compute.VirtualMachineProperties{ProvisioningState: to.StringPtr("Succeeded")},
+			}, nil).AnyTimes()
+			fakeClients.Disk.EXPECT().Update(gomock.Any(), providerSpec.ResourceGroup, gomock.Any(), gomock.Any()).Return(UnmarshalDiskUpdateFuture([]byte(succeededFuture)), nil).AnyTimes()
+
+			_, err = plugin.CreateMachine(ctx, &driver.CreateMachineRequest{Machine: newMachine("machine"), MachineClass: machineClass, Secret: secret})
+			Expect(err).NotTo(HaveOccurred())
+
+			secrets, err := targetClient.CoreV1().Secrets(metav1.NamespaceSystem).List(metav1.ListOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(secrets.Items).To(BeEmpty())
+		})
+	})
+
+	Describe("#Get Volume IDs", func() {
+		It("should return the disk URIs of in-tree and CSI volumes", func() {
+			var (
+				diskURI    = "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Compute/disks/pv-0"
+				csiDiskURI = "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Compute/disks/pv-1"
+			)
+
+			response, err := NewAzureDriver(nil).GetVolumeIDs(context.Background(), &driver.GetVolumeIDsRequest{PVSpecs: []*corev1.PersistentVolumeSpec{
+				{PersistentVolumeSource: corev1.PersistentVolumeSource{AzureDisk: &corev1.AzureDiskVolumeSource{DiskName: "pv-0", DataDiskURI: diskURI}}},
+				{PersistentVolumeSource: corev1.PersistentVolumeSource{CSI: &corev1.CSIPersistentVolumeSource{Driver: AzureDiskCSIDriverName, VolumeHandle: csiDiskURI}}},
+				{PersistentVolumeSource: corev1.PersistentVolumeSource{CSI: &corev1.CSIPersistentVolumeSource{Driver: "file.csi.azure.com", VolumeHandle: "rg#account#share"}}},
+			}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.VolumeIDs).To(Equal([]string{diskURI, csiDiskURI}))
+		})
+
+		It("should not return the disks created by the provider along with the VM", func() {
+			var (
+				sp          = mock.NewMockPluginSPIImpl(gomock.NewController(GinkgoT()))
+				plugin      = NewAzureDriver(sp)
+				pvDiskURI   = "/subscriptions/subscription/resourceGroups/rg/providers/Microsoft.Compute/disks/machine-0-logs-data-disk"
+				dataDiskURI = "/subscriptions/subscription/resourceGroups/rg/providers/Microsoft.Compute/disks/data"
+				unknownURI  = "/subscriptions/other/resourceGroups/rg/providers/Microsoft.Compute/disks/machine-0-os-disk"
+			)
+			machineClass, secret := newProviderSpecCacheFixtures()
+			_, err := plugin.decodeProviderSpecAndSecret(machineClass, secret)
+			Expect(err).NotTo(HaveOccurred())
+			driverClients, err := sp.Setup(secret, nil)
+			Expect(err).NotTo(HaveOccurred())
+			clients := driverClients.(*mock.AzureDriverClients)
+
+			// The disks are told apart by their tags, not by their names
+			clients.Disk.EXPECT().Get(gomock.Any(), "rg", "machine-0-logs-data-disk").Return(compute.Disk{Tags: map[string]*string{"kubernetes.io-created-for-pv-name": to.StringPtr("pv")}}, nil)
+			clients.Disk.EXPECT().Get(gomock.Any(), "rg", "data").Return(compute.Disk{Tags: map[string]*string{spi.DiskManagedByTagKey: to.StringPtr(spi.DiskManagedByTagValue)}}, nil)
+
+			response, err := plugin.GetVolumeIDs(context.Background(), &driver.GetVolumeIDsRequest{PVSpecs: []*corev1.PersistentVolumeSpec{
+				{PersistentVolumeSource: corev1.PersistentVolumeSource{CSI: &corev1.CSIPersistentVolumeSource{Driver: AzureDiskCSIDriverName, VolumeHandle: pvDiskURI}}},
+				{PersistentVolumeSource: corev1.PersistentVolumeSource{CSI: &corev1.CSIPersistentVolumeSource{Driver: AzureDiskCSIDriverName, VolumeHandle: dataDiskURI}}},
+				{PersistentVolumeSource: corev1.PersistentVolumeSource{CSI: &corev1.CSIPersistentVolumeSource{Driver: AzureDiskCSIDriverName, VolumeHandle: unknownURI}}},
+			}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.VolumeIDs).To(Equal([]string{pvDiskURI, unknownURI}))
+		})
+	})
+
+	Describe("#List Machines", func() {
+		var (
+			ctx           = context.Background()
+			resourceGroup = "shoot--i538135--seed-az"
+		)
+
+		It("should only list the VMs with the cluster and role tags of the machine class", func() {
+			sp := mock.NewMockPluginSPIImpl(gomock.NewController(GinkgoT()))
+			machineClass, secret := newProviderSpecCacheFixtures()
+			driverClients, err := sp.Setup(secret, nil)
+			Expect(err).NotTo(HaveOccurred())
+			clients := driverClients.(*mock.AzureDriverClients)
+
+			var (
+				ownTags   = map[string]*string{"kubernetes.io-cluster-shoot--i538135--seed-az": to.StringPtr("1"), "kubernetes.io-role-mcm": to.StringPtr("1")}
+				otherTags = map[string]*string{"kubernetes.io-cluster-shoot--other": to.StringPtr("1"), "kubernetes.io-role-mcm": to.StringPtr("1")}
+			)
+			clients.VM.EXPECT().ListComplete(gomock.Any(), resourceGroup, "").Return(newVMListIterator(ctx,
+				[]compute.VirtualMachine{{Name: to.StringPtr("machine-0"), Location: to.StringPtr("westeurope"), Tags: ownTags}, {Name: to.StringPtr("other-0"), Location: to.StringPtr("westeurope"), Tags: otherTags}},
+				[]compute.VirtualMachine{{Name: to.StringPtr("machine-1"), Location: to.StringPtr("westeurope"), Tags: ownTags}, {Name: to.StringPtr("untagged"), Location: to.StringPtr("westeurope")}},
+			), nil)
+
+			response, err := NewAzureDriver(sp).ListMachines(ctx, &driver.ListMachinesRequest{MachineClass: machineClass, Secret: secret})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.MachineList).To(Equal(map[string]string{
+				"azure:///westeurope/machine-0": "machine-0",
+				"azure:///westeurope/machine-1": "machine-1",
+			}))
+		})
+	})
+	Describe("#Get Machine Status", func() {
+		It("should derive the node name from the machine class of the request", func() {
+			ctx := context.Background()
+			sp := mock.NewMockPluginSPIImpl(gomock.NewController(GinkgoT()))
+			machineClass, secret := newProviderSpecCacheFixtures()
+			driverClients, err := sp.Setup(secret, nil)
+			Expect(err).NotTo(HaveOccurred())
+			clients := driverClients.(*mock.AzureDriverClients)
+
+			providerSpec := UnmarshalProviderSpec(machineClass.ProviderSpec.Raw)
+			providerSpec.Properties.OsProfile.ComputerNameTemplate = &apis.AzureComputerNameTemplate{Template: "node-{{ .MachineName }}"}
+			machineClass.ProviderSpec.Raw, err = json.Marshal(providerSpec)
+			Expect(err).NotTo(HaveOccurred())
+
+			clients.VM.EXPECT().ListComplete(gomock.Any(), "shoot--i538135--seed-az", "").Return(newVMListIterator(ctx,
+				[]compute.VirtualMachine{{Name: to.StringPtr("machine"), Location: to.StringPtr("westeurope"), Tags: getAzureTags(providerSpec.Tags)}},
+			), nil)
+
+			plugin := NewAzureDriver(sp)
+			// The provider spec of another machine class, which concurrent requests may leave behind
+			plugin.AzureProviderSpec = &apis.AzureProviderSpec{}
+			plugin.AzureProviderSpec.Properties.OsProfile.ComputerNameTemplate = &apis.AzureComputerNameTemplate{Template: "other-{{ .MachineName }}"}
+			response, err := plugin.GetMachineStatus(ctx, &driver.GetMachineStatusRequest{Machine: newMachine("machine"), MachineClass: machineClass, Secret: secret})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.NodeName).To(Equal("node-machine"))
+		})
+	})
+
+	Describe("#Generate Machine Class For Migration", func() {
+		var (
+			azureMachineClass *v1alpha1.AzureMachineClass
+			machineClass      *v1alpha1.MachineClass
+			classSpec         *v1alpha1.ClassSpec
+		)
+
+		BeforeEach(func() {
+			azureMachineClass = &v1alpha1.AzureMachineClass{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "class",
+					Labels:      map[string]string{"worker": "pool"},
+					Annotations: map[string]string{"owner": "azure-machine-class"},
+				},
+			}
+			Expect(json.Unmarshal(mock.AzureProviderSpec, &azureMachineClass.Spec)).To(Succeed())
+			azureMachineClass.Spec.SecretRef = &corev1.SecretReference{Name: "secret", Namespace: "default"}
+			machineClass = &v1alpha1.MachineClass{ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{"owner": "machine-class", "migrated": "true"},
+			}}
+			classSpec = &v1alpha1.ClassSpec{Kind: AzureMachineClassKind, Name: "class"}
+		})
+
+		It("should fill up the machine class from the AzureMachineClass", func() {
+			_, err := NewAzureDriver(nil).GenerateMachineClassForMigration(context.Background(), &driver.GenerateMachineClassForMigrationRequest{
+				ProviderSpecificMachineClass: azureMachineClass,
+				MachineClass:                 machineClass,
+				ClassSpec:                    classSpec,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(machineClass.Name).To(Equal("class"))
+			Expect(machineClass.Provider).To(Equal(ProviderName))
+			Expect(machineClass.SecretRef).To(Equal(azureMachineClass.Spec.SecretRef))
+			Expect(machineClass.Labels).To(Equal(map[string]string{"worker": "pool"}))
+			Expect(machineClass.Annotations).To(Equal(map[string]string{"owner": "azure-machine-class", "migrated": "true"}))
+			providerSpec := UnmarshalProviderSpec(machineClass.ProviderSpec.Raw)
+			Expect(providerSpec.Location).To(Equal(azureMachineClass.Spec.Location))
+			Expect(providerSpec.Properties.HardwareProfile.VMSize).To(Equal(azureMachineClass.Spec.Properties.HardwareProfile.VMSize))
+		})
+
+		It("should reject AzureMachineClasses with an invalid provider spec", func() {
+			azureMachineClass.Spec.Location = ""
+
+			_, err := NewAzureDriver(nil).GenerateMachineClassForMigration(context.Background(), &driver.GenerateMachineClassForMigrationRequest{
+				ProviderSpecificMachineClass: azureMachineClass,
+				MachineClass:                 machineClass,
+				ClassSpec:                    classSpec,
+			})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Region is required field"))
+			Expect(machineClass.ProviderSpec.Raw).To(BeNil())
+		})
+
+		It("should reject other machine class kinds", func() {
+			_, err := NewAzureDriver(nil).GenerateMachineClassForMigration(context.Background(), &driver.GenerateMachineClassForMigrationRequest{
+				ProviderSpecificMachineClass: &v1alpha1.AWSMachineClass{},
+				MachineClass:                 machineClass,
+				ClassSpec:                    &v1alpha1.ClassSpec{Kind: AzureMachineClassKind, Name: "class"},
+			})
+			Expect(err).To(HaveOccurred())
+		})
 	})
 })
 
+// newVMListIterator returns an iterator over the given pages of VMs
+func newVMListIterator(ctx context.Context, pages ...[]compute.VirtualMachine) compute.VirtualMachineListResultIterator {
+	page := compute.NewVirtualMachineListResultPage(compute.VirtualMachineListResult{}, func(_ context.Context, last compute.VirtualMachineListResult) (compute.VirtualMachineListResult, error) {
+		if len(pages) == 0 {
+			return compute.VirtualMachineListResult{}, nil
+		}
+		next := pages[0]
+		pages = pages[1:]
+		return compute.VirtualMachineListResult{Value: &next, NextLink: to.StringPtr("next")}, nil
+	})
+	_ = page.NextWithContext(ctx)
+	return compute.NewVirtualMachineListResultIterator(page)
+}
+
 // UnmarshalSubnet converts byte JSON to Subnet Struct
 func UnmarshalSubnet(bytesSubnet []byte) network.Subnet {
 	var subnet network.Subnet
@@ -167,12 +417,41 @@ func UnmarshalSubnet(bytesSubnet []byte) network.Subnet {
 	return subnet
 }
 
+// succeededFuture is a long running operation which already succeeded
+const succeededFuture = "{\"method\":\"PUT\",\"pollingMethod\":\"RequestURI\",\"pollingURI\":\"https://management.azure.com/operation\",\"lroState\":\"Succeeded\",\"resultURI\":\"https://management.azure.com/result\"}"
+
+// UnmarshalVMFuture converts byte JSON to a VM create or update future
+func UnmarshalVMFuture(bytesVMFuture []byte) compute.VirtualMachinesCreateOrUpdateFuture {
+	var vmFuture compute.VirtualMachinesCreateOrUpdateFuture
+	_ = json.Unmarshal(bytesVMFuture, &vmFuture)
+	return vmFuture
+}
+
+// UnmarshalDiskUpdateFuture converts byte JSON to a disk update future
+func UnmarshalDiskUpdateFuture(bytesDiskFuture []byte) compute.DisksUpdateFuture {
+	var diskFuture compute.DisksUpdateFuture
+	_ = json.Unmarshal(bytesDiskFuture, &diskFuture)
+	return diskFuture
+}
+
 func UnmarshalNICFuture(bytesNICFuture []byte) network.InterfacesCreateOrUpdateFuture {
 	var nicFuture network.InterfacesCreateOrUpdateFuture
 	_ = json.Unmarshal(bytesNICFuture, &nicFuture)
 	return nicFuture
 }
 
+// newResourceSkusPage returns a single result page containing the given resource SKUs
+func newResourceSkusPage(ctx context.Context, skus ...compute.ResourceSku) compute.ResourceSkusResultPage {
+	page := compute.NewResourceSkusResultPage(compute.ResourceSkusResult{}, func(_ context.Context, last compute.ResourceSkusResult) (compute.ResourceSkusResult, error) {
+		if last.Value != nil {
+			return compute.ResourceSkusResult{}, nil
+		}
+		return compute.ResourceSkusResult{Value: &skus}, nil
+	})
+	_ = page.NextWithContext(ctx)
+	return page
+}
+
 // UnmarshalProviderSpec converts byte JSON to AzureProviderSpec Struct
 func UnmarshalProviderSpec(bytesProviderSpec []byte) *apis.AzureProviderSpec {
 	var providerSpec apis.AzureProviderSpec
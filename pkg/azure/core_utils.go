@@ -8,11 +8,15 @@ SPDX-License-Identifier: Apache-2.0
 package azure
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
+	"time"
 
 	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis/conversion"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis/defaults"
 	"github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis/validation"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/spi"
 	"github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
 	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/codes"
 	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/status"
@@ -21,25 +25,95 @@ import (
 
 // decodeProviderSpecAndSecret unmarshals the raw providerspec into api.AzureProviderSpec structure
 func decodeProviderSpecAndSecret(machineClass *v1alpha1.MachineClass, secret *corev1.Secret) (*api.AzureProviderSpec, error) {
-	var providerSpec *api.AzureProviderSpec
+	return decodeProviderSpec(machineClass, secret, false)
+}
+
+// decodeProviderSpec unmarshals the raw providerspec like decodeProviderSpecAndSecret. Unknown fields are rejected if
+// strict or if the machine class is annotated for strict decoding.
+func decodeProviderSpec(machineClass *v1alpha1.MachineClass, secret *corev1.Secret, strict bool) (*api.AzureProviderSpec, error) {
+	decode := conversion.DecodeProviderSpec
+	if strict || machineClass.Annotations[api.MachineClassAnnotationStrictDecoding] == "true" {
+		decode = conversion.DecodeProviderSpecStrict
+	}
 
-	// Extract providerSpec
-	err := json.Unmarshal(machineClass.ProviderSpec.Raw, &providerSpec)
+	// Extract providerSpec of the version named by its apiVersion
+	providerSpec, err := decode(machineClass.ProviderSpec.Raw)
 	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
+	// Fill in the defaults, so that incomplete specs are completed or rejected by the validation instead of by Azure
+	defaults.SetDefaults(providerSpec)
+
 	//Validate the Spec and Secrets
 	ValidationErr := validation.ValidateAzureSpecNSecret(providerSpec, secret)
 	if ValidationErr != nil {
 		err = fmt.Errorf("Error while validating ProviderSpec %v", ValidationErr)
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := validateNamingStrategy(providerSpec); err != nil {
+		return nil, err
 	}
 
 	return providerSpec, nil
 }
 
+// decodeAndValidateProviderSpec decodes and validates the provider spec of the machine class like
+// decodeProviderSpecAndSecret, rejects the tags reserved by the operator and validates it against the Azure API with
+// the clients of the secret, which are returned with it. The machine controller does not validate machine classes
+// with the provider, hence the checks which require the Azure API, i.e. that the VM size is offered in the location
+// and supports the requested features, are performed whenever a machine is created from the class.
+func (d *MachinePlugin) decodeAndValidateProviderSpec(ctx context.Context, machineClass *v1alpha1.MachineClass, secret *corev1.Secret) (*api.AzureProviderSpec, spi.AzureDriverClientsInterface, error) {
+	providerSpec, err := d.decodeProviderSpecAndSecret(machineClass, secret)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := d.validateReservedTags(providerSpec); err != nil {
+		return nil, nil, err
+	}
+
+	clients, err := d.SPI.Setup(secret, providerSpec.CloudConfiguration)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := d.checkVMCapabilities(ctx, clients, subscriptionID(secret), providerSpec); err != nil {
+		return nil, nil, err
+	}
+	return providerSpec, clients, nil
+}
+
 // encodeMachineID generates the providerID for the nodes
 func encodeMachineID(location, vmName string) string {
 	return fmt.Sprintf("azure:///%s/%s", location, vmName)
 }
+
+// withOperationTimeout returns a context for an Azure operation which is cancelled after the timeout. The operation is
+// only bounded by the given context if the timeout is zero.
+func withOperationTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// rollbackTimeout bounds the deletion of the resources of a failed machine creation if no deletion timeout is configured
+const rollbackTimeout = 10 * time.Minute
+
+// detachedContext carries the values of its parent context, e.g. the log fields, but is neither cancelled nor bounded
+// by the deadline of its parent
+type detachedContext struct {
+	context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+func (detachedContext) Done() <-chan struct{}       { return nil }
+func (detachedContext) Err() error                  { return nil }
+
+// operationTimeoutError returns a deadline exceeded error if the context of the failed operation timed out, so that
+// the operation is retried by the machine controller. Other errors are returned as they are.
+func operationTimeoutError(ctx context.Context, err error) error {
+	if err != nil && ctx.Err() == context.DeadlineExceeded {
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	}
+	return err
+}
@@ -0,0 +1,91 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/codes"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/status"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// inFlightCreationsGauge is the number of machine creations of this process in flight per resource group
+var inFlightCreationsGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: "mcm",
+	Subsystem: "azure",
+	Name:      "creations_in_flight",
+	Help:      "Number of machine creations of this machine controller per resource group which were started but did not complete yet, i.e. whose VM is not created or whose guest agent is not ready yet.",
+}, []string{"subscription", "resource_group"})
+
+func init() {
+	prometheus.MustRegister(inFlightCreationsGauge)
+}
+
+// creationBudget caps the number of concurrent machine creations per resource group, i.e. per shoot, so that a
+// misbehaving autoscaler cannot exhaust the quota or cause Azure to throttle all requests of the subscription. A
+// creation is in flight until its VM is created and, if waited for, its guest agent is ready. The state is kept in
+// memory only, hence the cap applies per machine controller process. Creations of other processes managing the same
+// resource group, e.g. of a second instance during a handover, are not counted.
+type creationBudget struct {
+	max int
+
+	mutex    sync.Mutex
+	inFlight map[string]map[string]bool
+}
+
+func newCreationBudget(max int) *creationBudget {
+	return &creationBudget{
+		max:      max,
+		inFlight: map[string]map[string]bool{},
+	}
+}
+
+// acquire records the creation of the machine in the resource group of the subscription as in flight and returns the
+// function ending it. It returns a resource exhausted error if the budget of the resource group is used up by other
+// machines.
+func (b *creationBudget) acquire(subscriptionID, resourceGroup, machineName string) (func(), error) {
+	if b == nil {
+		return func() {}, nil
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	key := strings.ToLower(subscriptionID + "/" + resourceGroup)
+	machines, ok := b.inFlight[key]
+	if !ok {
+		machines = map[string]bool{}
+		b.inFlight[key] = machines
+	}
+	if machines[machineName] {
+		// The machine is created by a concurrent request already, which ends the creation
+		return func() {}, nil
+	}
+	if len(machines) >= b.max {
+		return nil, status.Error(codes.ResourceExhausted, fmt.Sprintf("%d machine creations are in flight in resource group %s, which is the maximum", len(machines), resourceGroup))
+	}
+
+	machines[machineName] = true
+	inFlightCreationsGauge.WithLabelValues(subscriptionID, resourceGroup).Inc()
+	return func() { b.release(key, subscriptionID, resourceGroup, machineName) }, nil
+}
+
+// release ends the creation of the machine
+func (b *creationBudget) release(key, subscriptionID, resourceGroup, machineName string) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if machines := b.inFlight[key]; machines[machineName] {
+		delete(machines, machineName)
+		if len(machines) == 0 {
+			delete(b.inFlight, key)
+		}
+		inFlightCreationsGauge.WithLabelValues(subscriptionID, resourceGroup).Dec()
+	}
+}
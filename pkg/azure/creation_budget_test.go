@@ -0,0 +1,83 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/codes"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/status"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+var _ = Describe("CreationBudget", func() {
+	var budget *creationBudget
+
+	BeforeEach(func() {
+		budget = newCreationBudget(2)
+	})
+
+	It("should reject creations exceeding the budget of the resource group until one ends", func() {
+		endFirst, err := budget.acquire("s", "rg", "machine-1")
+		Expect(err).NotTo(HaveOccurred())
+		_, err = budget.acquire("s", "rg", "machine-2")
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = budget.acquire("s", "rg", "machine-3")
+		s, ok := status.FromError(err)
+		Expect(ok).To(BeTrue())
+		Expect(s.Code()).To(Equal(codes.ResourceExhausted))
+
+		endFirst()
+		_, err = budget.acquire("s", "rg", "machine-3")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should count a machine created by concurrent requests once", func() {
+		endFirst, err := budget.acquire("s", "rg", "machine-1")
+		Expect(err).NotTo(HaveOccurred())
+		endSecond, err := budget.acquire("s", "rg", "machine-1")
+		Expect(err).NotTo(HaveOccurred())
+		endSecond()
+
+		_, err = budget.acquire("s", "rg", "machine-2")
+		Expect(err).NotTo(HaveOccurred())
+		_, err = budget.acquire("s", "rg", "machine-3")
+		Expect(err).To(HaveOccurred())
+		endFirst()
+	})
+
+	It("should budget resource groups independently", func() {
+		for _, machine := range []string{"machine-1", "machine-2"} {
+			_, err := budget.acquire("s", "rg-a", machine)
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		_, err := budget.acquire("s", "rg-b", "machine-3")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should budget the resource groups of different subscriptions independently and label them by both", func() {
+		for _, machine := range []string{"machine-1", "machine-2"} {
+			_, err := budget.acquire("s", "rg", machine)
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		end, err := budget.acquire("t", "rg", "machine-3")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(testutil.ToFloat64(inFlightCreationsGauge.WithLabelValues("t", "rg"))).To(Equal(float64(1)))
+		end()
+		Expect(testutil.ToFloat64(inFlightCreationsGauge.WithLabelValues("t", "rg"))).To(BeZero())
+	})
+
+	It("should not cap creations if disabled", func() {
+		var disabled *creationBudget
+		end, err := disabled.acquire("s", "rg", "machine")
+		Expect(err).NotTo(HaveOccurred())
+		end()
+	})
+})
@@ -0,0 +1,272 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-04-01/network"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/spi"
+	"github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// networkDiagnosticsMaxLength is the maximum length of the network diagnostics summary recorded in an event
+	networkDiagnosticsMaxLength = 1024
+	// networkDiagnosticsTimeout bounds the fetching of the network diagnostics, so that it does not delay the deletion
+	networkDiagnosticsTimeout = time.Minute
+	// networkDiagnosesTTL is the duration for which a machine is remembered as diagnosed, which covers the retries of
+	// its deletion
+	networkDiagnosesTTL = time.Hour
+)
+
+// networkDiagnoses remembers the machines whose network was diagnosed, so that the retries of their deletion do not
+// fetch the diagnostics again. They are kept in memory only, so a machine may be diagnosed again after a restart.
+type networkDiagnoses struct {
+	ttl time.Duration
+
+	mutex     sync.Mutex
+	diagnosed map[string]time.Time
+}
+
+func newNetworkDiagnoses(ttl time.Duration) *networkDiagnoses {
+	return &networkDiagnoses{
+		ttl:       ttl,
+		diagnosed: map[string]time.Time{},
+	}
+}
+
+// first marks the machine as diagnosed and returns true unless it was diagnosed already
+func (n *networkDiagnoses) first(machine *v1alpha1.Machine) bool {
+	key := machine.Namespace + "/" + machine.Name
+
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	now := time.Now()
+	for k, expiresAt := range n.diagnosed {
+		if now.After(expiresAt) {
+			delete(n.diagnosed, k)
+		}
+	}
+	if _, ok := n.diagnosed[key]; ok {
+		return false
+	}
+	n.diagnosed[key] = now.Add(n.ttl)
+	return true
+}
+
+// NetworkDiagnostics summarizes the effective network configuration of a network interface, i.e. the configuration
+// resulting from all network security groups and route tables of the interface and its subnet
+type NetworkDiagnostics struct {
+	// SecurityRules are the effective deny rules of the network security groups, ordered by their priority
+	SecurityRules []string
+	// Routes are the active effective routes which are not system routes of the vnet, and the default routes
+	Routes []string
+}
+
+// String returns the summary of the diagnostics
+func (n NetworkDiagnostics) String() string {
+	var (
+		securityRules = "none"
+		routes        = "none"
+	)
+	if len(n.SecurityRules) > 0 {
+		securityRules = strings.Join(n.SecurityRules, "; ")
+	}
+	if len(n.Routes) > 0 {
+		routes = strings.Join(n.Routes, "; ")
+	}
+	return fmt.Sprintf("Effective deny rules: %s. Effective routes: %s", securityRules, routes)
+}
+
+// failedToJoin returns true if the machine failed without its node ever reporting a condition, i.e. the node did not
+// register within the creation timeout
+func failedToJoin(machine *v1alpha1.Machine) bool {
+	return machine.Status.CurrentStatus.Phase == v1alpha1.MachineFailed && len(machine.Status.Conditions) == 0
+}
+
+// reportNetworkDiagnostics records the network diagnostics of the primary network interface of a machine whose node
+// failed to join with a warning event on the machine, before its resources are deleted. The diagnostics are fetched
+// once per machine and within their own timeout. Failures are only logged, as the diagnostics must not block the
+// deletion.
+func (d *MachinePlugin) reportNetworkDiagnostics(ctx context.Context, clients spi.AzureDriverClientsInterface, resourceGroupName string, machine *v1alpha1.Machine, networkInterfaces []networkInterface) {
+	if !d.networkDiagnostics || d.Recorder == nil || !failedToJoin(machine) || !d.networkDiagnoses.first(machine) {
+		return
+	}
+
+	diagnosticsCtx, cancel := context.WithTimeout(ctx, networkDiagnosticsTimeout)
+	defer cancel()
+
+	nic := primaryNIC(networkInterfaces)
+	nicName := nic.name
+	diagnostics, err := getNetworkDiagnostics(diagnosticsCtx, clients, nic.resourceGroupName(resourceGroupName), nicName)
+	if err != nil {
+		spi.WarningS(ctx, "Network diagnostics of machine could not be fetched", "nic", nicName, "err", err)
+		return
+	}
+
+	message := fmt.Sprintf("Node of the machine failed to join. NIC %s: %s", nicName, diagnostics)
+	if len(message) > networkDiagnosticsMaxLength {
+		message = message[:networkDiagnosticsMaxLength-3] + "..."
+	}
+	spi.InfoS(ctx, "Network diagnostics of machine whose node failed to join", "nic", nicName, "diagnostics", diagnostics)
+	d.Recorder.Event(machine, corev1.EventTypeWarning, "NetworkDiagnostics", message)
+}
+
+// primaryNIC returns the primary network interface
+func primaryNIC(networkInterfaces []networkInterface) networkInterface {
+	for _, nic := range networkInterfaces {
+		if nic.primary {
+			return nic
+		}
+	}
+	return networkInterfaces[0]
+}
+
+// getNetworkDiagnostics fetches the effective security rules and routes of the network interface in parallel
+func getNetworkDiagnostics(ctx context.Context, clients spi.AzureDriverClientsInterface, resourceGroupName, nicName string) (*NetworkDiagnostics, error) {
+	var (
+		securityGroups network.EffectiveNetworkSecurityGroupListResult
+		routes         network.EffectiveRouteListResult
+	)
+
+	err := spi.RunInParallel([]func() error{
+		func() error {
+			future, err := clients.GetNic().ListEffectiveNetworkSecurityGroups(ctx, resourceGroupName, nicName)
+			if err != nil {
+				return spi.OnARMAPIErrorFail(prometheusServiceNIC, err, "NIC.ListEffectiveNetworkSecurityGroups failed for %s", nicName)
+			}
+			if err := getFutureResult(ctx, clients, future.FutureAPI, &securityGroups); err != nil {
+				return spi.OnARMAPIErrorFail(prometheusServiceNIC, err, "NIC.ListEffectiveNetworkSecurityGroups result failed for %s", nicName)
+			}
+			spi.OnARMAPISuccess(prometheusServiceNIC, "NIC.ListEffectiveNetworkSecurityGroups")
+			return nil
+		},
+		func() error {
+			future, err := clients.GetNic().GetEffectiveRouteTable(ctx, resourceGroupName, nicName)
+			if err != nil {
+				return spi.OnARMAPIErrorFail(prometheusServiceNIC, err, "NIC.GetEffectiveRouteTable failed for %s", nicName)
+			}
+			if err := getFutureResult(ctx, clients, future.FutureAPI, &routes); err != nil {
+				return spi.OnARMAPIErrorFail(prometheusServiceNIC, err, "NIC.GetEffectiveRouteTable result failed for %s", nicName)
+			}
+			spi.OnARMAPISuccess(prometheusServiceNIC, "NIC.GetEffectiveRouteTable")
+			return nil
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &NetworkDiagnostics{
+		SecurityRules: summarizeSecurityRules(securityGroups),
+		Routes:        summarizeRoutes(routes),
+	}, nil
+}
+
+// getFutureResult waits for the completion of the long running operation and unmarshals its result
+func getFutureResult(ctx context.Context, clients spi.AzureDriverClientsInterface, future azure.FutureAPI, result interface{}) error {
+	client := clients.GetClient()
+	if err := future.WaitForCompletionRef(ctx, client); err != nil {
+		return err
+	}
+	resp, err := future.GetResult(client)
+	if err != nil {
+		return err
+	}
+	return autorest.Respond(resp, azure.WithErrorUnlessStatusCode(http.StatusOK), autorest.ByUnmarshallingJSON(result), autorest.ByClosing())
+}
+
+// summarizeSecurityRules returns the effective deny rules of the network security groups ordered by their priority
+func summarizeSecurityRules(result network.EffectiveNetworkSecurityGroupListResult) []string {
+	type rule struct {
+		priority int32
+		summary  string
+	}
+
+	var rules []rule
+	if result.Value != nil {
+		for _, group := range *result.Value {
+			if group.EffectiveSecurityRules == nil {
+				continue
+			}
+			groupName := "unknown"
+			if group.NetworkSecurityGroup != nil && group.NetworkSecurityGroup.ID != nil {
+				groupName = resourceNameFromID(*group.NetworkSecurityGroup.ID)
+			}
+			for _, securityRule := range *group.EffectiveSecurityRules {
+				if securityRule.Access != network.SecurityRuleAccessDeny {
+					continue
+				}
+				rules = append(rules, rule{
+					priority: to.Int32(securityRule.Priority),
+					summary: fmt.Sprintf("%s/%s %s %s to %s:%s (priority %d)", groupName, to.String(securityRule.Name), securityRule.Direction,
+						securityRule.Protocol, addressPrefix(securityRule.DestinationAddressPrefix, securityRule.DestinationAddressPrefixes),
+						addressPrefix(securityRule.DestinationPortRange, securityRule.DestinationPortRanges), to.Int32(securityRule.Priority)),
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(rules, func(i, j int) bool { return rules[i].priority < rules[j].priority })
+	summaries := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		summaries = append(summaries, rule.summary)
+	}
+	return summaries
+}
+
+// summarizeRoutes returns the active routes which are not system routes of the vnet, e.g. user-defined routes sending
+// the traffic to a firewall, and the default routes
+func summarizeRoutes(result network.EffectiveRouteListResult) []string {
+	var summaries []string
+	if result.Value == nil {
+		return summaries
+	}
+	for _, route := range *result.Value {
+		if route.State != network.Active || route.AddressPrefix == nil {
+			continue
+		}
+		prefixes := *route.AddressPrefix
+		if route.Source == network.EffectiveRouteSourceDefault && !containsFold(prefixes, "0.0.0.0/0") {
+			continue
+		}
+		summary := fmt.Sprintf("%s via %s", strings.Join(prefixes, ","), route.NextHopType)
+		if route.NextHopIPAddress != nil && len(*route.NextHopIPAddress) > 0 {
+			summary += " " + strings.Join(*route.NextHopIPAddress, ",")
+		}
+		summaries = append(summaries, fmt.Sprintf("%s (%s)", summary, route.Source))
+	}
+	return summaries
+}
+
+// addressPrefix returns the single prefix or range of a security rule, or its list
+func addressPrefix(single *string, list *[]string) string {
+	if single != nil && *single != "" {
+		return *single
+	}
+	if list != nil && len(*list) > 0 {
+		return strings.Join(*list, ",")
+	}
+	return "*"
+}
+
+// resourceNameFromID returns the last segment of an Azure resource ID
+func resourceNameFromID(id string) string {
+	return id[strings.LastIndex(id, "/")+1:]
+}
@@ -0,0 +1,136 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-04-01/network"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/mock"
+	"github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
+	gomock "github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+var _ = Describe("Diagnostics", func() {
+	Describe("#summarizeSecurityRules", func() {
+		It("should return the deny rules ordered by their priority", func() {
+			result := network.EffectiveNetworkSecurityGroupListResult{Value: &[]network.EffectiveNetworkSecurityGroup{
+				{
+					NetworkSecurityGroup: &network.SubResource{ID: to.StringPtr("/subscriptions/s/resourceGroups/rg/providers/Microsoft.Network/networkSecurityGroups/nodes")},
+					EffectiveSecurityRules: &[]network.EffectiveNetworkSecurityRule{
+						{Name: to.StringPtr("DenyAllOutBound"), Access: network.SecurityRuleAccessDeny, Direction: network.SecurityRuleDirectionOutbound, Protocol: network.EffectiveSecurityRuleProtocolAll, DestinationAddressPrefix: to.StringPtr("0.0.0.0/0"), DestinationPortRange: to.StringPtr("0-65535"), Priority: to.Int32Ptr(65500)},
+						{Name: to.StringPtr("AllowVnetOutBound"), Access: network.SecurityRuleAccessAllow, Priority: to.Int32Ptr(65000)},
+						{Name: to.StringPtr("deny-apiserver"), Access: network.SecurityRuleAccessDeny, Direction: network.SecurityRuleDirectionOutbound, Protocol: network.EffectiveSecurityRuleProtocolTCP, DestinationAddressPrefixes: &[]string{"10.0.0.1/32", "10.0.0.2/32"}, DestinationPortRanges: &[]string{"443-443"}, Priority: to.Int32Ptr(100)},
+					},
+				},
+			}}
+
+			Expect(summarizeSecurityRules(result)).To(Equal([]string{
+				"nodes/deny-apiserver Outbound Tcp to 10.0.0.1/32,10.0.0.2/32:443-443 (priority 100)",
+				"nodes/DenyAllOutBound Outbound All to 0.0.0.0/0:0-65535 (priority 65500)",
+			}))
+		})
+	})
+
+	Describe("#summarizeRoutes", func() {
+		It("should return the active user-defined routes and the default routes", func() {
+			result := network.EffectiveRouteListResult{Value: &[]network.EffectiveRoute{
+				{Source: network.EffectiveRouteSourceDefault, State: network.Active, AddressPrefix: &[]string{"10.250.0.0/16"}, NextHopType: network.RouteNextHopTypeVnetLocal},
+				{Source: network.EffectiveRouteSourceDefault, State: network.Invalid, AddressPrefix: &[]string{"0.0.0.0/0"}, NextHopType: network.RouteNextHopTypeInternet},
+				{Source: network.EffectiveRouteSourceUser, State: network.Active, AddressPrefix: &[]string{"0.0.0.0/0"}, NextHopType: network.RouteNextHopTypeVirtualAppliance, NextHopIPAddress: &[]string{"10.0.0.4"}},
+			}}
+
+			Expect(summarizeRoutes(result)).To(Equal([]string{"0.0.0.0/0 via VirtualAppliance 10.0.0.4 (User)"}))
+		})
+	})
+
+	Describe("#String", func() {
+		It("should summarize the diagnostics", func() {
+			Expect(NetworkDiagnostics{Routes: []string{"a", "b"}}.String()).To(Equal("Effective deny rules: none. Effective routes: a; b"))
+		})
+	})
+
+	Describe("#reportNetworkDiagnostics", func() {
+		var (
+			ctx      = context.Background()
+			driver   *MachinePlugin
+			clients  *mock.AzureDriverClients
+			recorder *record.FakeRecorder
+			machine  *v1alpha1.Machine
+			nics     = []networkInterface{{name: "machine-nic", primary: true}}
+		)
+
+		BeforeEach(func() {
+			sp := mock.NewMockPluginSPIImpl(gomock.NewController(GinkgoT()))
+			driverClients, err := sp.Setup(&corev1.Secret{}, nil)
+			Expect(err).NotTo(HaveOccurred())
+			clients = driverClients.(*mock.AzureDriverClients)
+
+			recorder = record.NewFakeRecorder(1)
+			driver = &MachinePlugin{Recorder: recorder, networkDiagnostics: true, networkDiagnoses: newNetworkDiagnoses(time.Hour)}
+			machine = newMachine("machine")
+			machine.Status.CurrentStatus.Phase = v1alpha1.MachineFailed
+		})
+
+		It("should not diagnose machines whose node joined", func() {
+			machine.Status.Conditions = []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionFalse}}
+
+			driver.reportNetworkDiagnostics(ctx, clients, "rg", machine, nics)
+			Expect(recorder.Events).To(BeEmpty())
+		})
+
+		It("should not diagnose machines if disabled", func() {
+			driver.networkDiagnostics = false
+
+			driver.reportNetworkDiagnostics(ctx, clients, "rg", machine, nics)
+			Expect(recorder.Events).To(BeEmpty())
+		})
+
+		It("should not record an event if the diagnostics cannot be fetched", func() {
+			clients.NIC.EXPECT().ListEffectiveNetworkSecurityGroups(gomock.Any(), "rg", "machine-nic").Return(network.InterfacesListEffectiveNetworkSecurityGroupsFuture{}, errors.New("failed"))
+			clients.NIC.EXPECT().GetEffectiveRouteTable(gomock.Any(), "rg", "machine-nic").Return(network.InterfacesGetEffectiveRouteTableFuture{}, errors.New("failed"))
+
+			driver.reportNetworkDiagnostics(ctx, clients, "rg", machine, nics)
+			Expect(recorder.Events).To(BeEmpty())
+		})
+
+		It("should record the diagnostics once per machine", func() {
+			var securityGroupsFuture network.InterfacesListEffectiveNetworkSecurityGroupsFuture
+			Expect(json.Unmarshal([]byte(succeededFuture), &securityGroupsFuture)).To(Succeed())
+			var routesFuture network.InterfacesGetEffectiveRouteTableFuture
+			Expect(json.Unmarshal([]byte(strings.Replace(succeededFuture, "/result", "/routes", 1)), &routesFuture)).To(Succeed())
+			clients.NIC.EXPECT().ListEffectiveNetworkSecurityGroups(gomock.Any(), "rg", "machine-nic").Return(securityGroupsFuture, nil)
+			clients.NIC.EXPECT().GetEffectiveRouteTable(gomock.Any(), "rg", "machine-nic").Return(routesFuture, nil)
+			clients.Client = autorest.Client{Sender: autorest.SenderFunc(func(req *http.Request) (*http.Response, error) {
+				body := `{"value":[{"networkSecurityGroup":{"id":"/subscriptions/s/resourceGroups/rg/providers/Microsoft.Network/networkSecurityGroups/nodes"},"effectiveSecurityRules":[{"name":"deny-apiserver","access":"Deny","direction":"Outbound","protocol":"Tcp","destinationAddressPrefix":"10.0.0.1/32","destinationPortRange":"443","priority":100}]}]}`
+				if strings.HasSuffix(req.URL.Path, "/routes") {
+					body = `{"value":[{"source":"User","state":"Active","addressPrefix":["0.0.0.0/0"],"nextHopType":"VirtualAppliance","nextHopIpAddress":["10.0.0.4"]}]}`
+				}
+				return &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(strings.NewReader(body)), Request: req}, nil
+			})}
+
+			driver.reportNetworkDiagnostics(ctx, clients, "rg", machine, nics)
+			Expect(recorder.Events).To(Receive(Equal("Warning NetworkDiagnostics Node of the machine failed to join. NIC machine-nic: " +
+				"Effective deny rules: nodes/deny-apiserver Outbound Tcp to 10.0.0.1/32:443 (priority 100). Effective routes: 0.0.0.0/0 via VirtualAppliance 10.0.0.4 (User)")))
+
+			driver.reportNetworkDiagnostics(ctx, clients, "rg", machine, nics)
+			Expect(recorder.Events).To(BeEmpty())
+		})
+	})
+})
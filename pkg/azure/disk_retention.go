@@ -0,0 +1,93 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Azure/go-autorest/autorest/to"
+	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/spi"
+)
+
+// retainedTagKey is the tag of the data disks which are kept when the VM of their machine is deleted
+const retainedTagKey = "machine-controller-manager-retained"
+
+type retainedDisksKey struct{}
+
+// getRetainedDataDiskNames returns the names of the data disks created along with the VM which are detached and kept
+// when the machine is deleted
+func getRetainedDataDiskNames(naming NamingStrategy, azureDataDisks []api.AzureDataDisk, lunOffset int32, vmname string) []string {
+	var names []string
+	for i, disk := range azureDataDisks {
+		if disk.CreateOption == api.DataDiskCreateOptionAttach || disk.DeleteOption != api.DataDiskDeleteOptionDetach || isSharedDataDisk(disk) {
+			continue
+		}
+		names = append(names, naming.DataDiskName(vmname, disk.Name, *getDataDiskLun(disk, i, lunOffset)))
+	}
+	return names
+}
+
+// withRetainedDisks returns a context marking the disks with the given names as retained, so that the deletion of
+// the VM does not delete them even if they are found in the VM model
+func withRetainedDisks(ctx context.Context, names []string) context.Context {
+	if len(names) == 0 {
+		return ctx
+	}
+	retained := make(map[string]bool, len(names))
+	for _, name := range names {
+		retained[strings.ToLower(name)] = true
+	}
+	return context.WithValue(ctx, retainedDisksKey{}, retained)
+}
+
+// isRetainedDisk returns true if the disk with the given name is marked as retained in the context
+func isRetainedDisk(ctx context.Context, name string) bool {
+	retained, _ := ctx.Value(retainedDisksKey{}).(map[string]bool)
+	return retained[strings.ToLower(name)]
+}
+
+// isRetained returns true if the disk is tagged as retained
+func isRetained(tags map[string]*string) bool {
+	_, ok := tags[retainedTagKey]
+	return ok
+}
+
+// retainDataDisks tags the disks with the given names as retained and returns the remaining data disk names to be
+// deleted. Disks which do not exist are skipped.
+func retainDataDisks(ctx context.Context, clients spi.AzureDriverClientsInterface, resourceGroupName string, dataDiskNames, retainedDiskNames []string) ([]string, error) {
+	for _, diskName := range retainedDiskNames {
+		disk, err := clients.GetDisk().Get(ctx, resourceGroupName, diskName)
+		if err != nil {
+			if spi.NotFound(err) {
+				continue
+			}
+			return nil, spi.OnARMAPIErrorFail(prometheusServiceDisk, err, "Disk.Get failed for %s", diskName)
+		}
+		if !isRetained(disk.Tags) {
+			tags := make(map[string]*string, len(disk.Tags)+1)
+			for key, value := range disk.Tags {
+				tags[key] = value
+			}
+			tags[retainedTagKey] = to.StringPtr("true")
+			if err := spi.UpdateDiskTags(ctx, clients, resourceGroupName, diskName, tags); err != nil {
+				return nil, err
+			}
+		}
+		spi.InfoS(ctx, "Data disk is detached and kept", "disk", diskName)
+	}
+
+	retainedCtx := withRetainedDisks(ctx, retainedDiskNames)
+	remaining := make([]string, 0, len(dataDiskNames))
+	for _, name := range dataDiskNames {
+		if !isRetainedDisk(retainedCtx, name) {
+			remaining = append(remaining, name)
+		}
+	}
+	return remaining, nil
+}
@@ -0,0 +1,53 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-08-01/compute"
+	"github.com/Azure/go-autorest/autorest/to"
+	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DiskRetention", func() {
+	It("should retain the data disks with the detach delete option", func() {
+		dataDisks := []api.AzureDataDisk{
+			{Name: "cache", DeleteOption: api.DataDiskDeleteOptionDetach},
+			{Name: "logs", DeleteOption: api.DataDiskDeleteOptionDelete},
+			{Name: "scratch"},
+			{Name: "existing", CreateOption: api.DataDiskCreateOptionAttach, DeleteOption: api.DataDiskDeleteOptionDetach},
+		}
+
+		Expect(getRetainedDataDiskNames(suffixNamingStrategy{}, dataDisks, 0, "machine")).To(Equal([]string{"machine-cache-0-data-disk"}))
+	})
+
+	It("should skip the retained disks found in the VM model", func() {
+		vm := compute.VirtualMachine{
+			Name: to.StringPtr("machine"),
+			VirtualMachineProperties: &compute.VirtualMachineProperties{
+				StorageProfile: &compute.StorageProfile{
+					DataDisks: &[]compute.DataDisk{
+						{CreateOption: compute.DiskCreateOptionTypesEmpty, ManagedDisk: &compute.ManagedDiskParameters{ID: to.StringPtr("/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Compute/disks/Machine-Cache-0-Data-Disk")}},
+					},
+				},
+			},
+		}
+		ctx := withRetainedDisks(context.Background(), []string{"machine-cache-0-data-disk"})
+
+		_, dataDiskNames := vmResources(ctx, vm, "rg", nil, "machine-os-disk", nil)
+		Expect(dataDiskNames).To(BeEmpty())
+	})
+
+	It("should detect disks tagged as retained", func() {
+		Expect(isRetained(map[string]*string{retainedTagKey: to.StringPtr("true")})).To(BeTrue())
+		Expect(isRetained(nil)).To(BeFalse())
+		Expect(isRetainedDisk(context.Background(), "machine-cache-0-data-disk")).To(BeFalse())
+	})
+})
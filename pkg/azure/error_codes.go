@@ -0,0 +1,146 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/spi"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/codes"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/status"
+)
+
+// quotaErrorCodes are the Azure error codes of requests exceeding a quota of the subscription
+var quotaErrorCodes = map[string]bool{
+	"QuotaExceeded":                 true,
+	"SubscriptionQuotaExceeded":     true,
+	"PublicIPCountLimitReached":     true,
+	"NetworkInterfaceCountExceeded": true,
+}
+
+// permissionErrorCodes are the Azure error codes of requests which are not authenticated or authorized
+var permissionErrorCodes = map[string]bool{
+	"AuthorizationFailed":              true,
+	"LinkedAuthorizationFailed":        true,
+	"AuthenticationFailed":             true,
+	"InvalidAuthenticationToken":       true,
+	"InvalidAuthenticationTokenTenant": true,
+	"ExpiredAuthenticationToken":       true,
+	"SubscriptionNotFound":             true,
+}
+
+// notFoundErrorCodes are the Azure error codes of requests referring to resources which don't exist
+var notFoundErrorCodes = map[string]bool{
+	"NotFound":              true,
+	"ResourceNotFound":      true,
+	"ResourceGroupNotFound": true,
+}
+
+// conflictErrorCodes are the Azure error codes of requests conflicting with another operation on the same resource
+var conflictErrorCodes = map[string]bool{
+	"Conflict":                   true,
+	"AnotherOperationInProgress": true,
+	"OperationPreempted":         true,
+}
+
+// invalidErrorCodes are the Azure error codes of requests which are invalid, usually due to the provider spec
+var invalidErrorCodes = map[string]bool{
+	"InvalidParameter":      true,
+	"InvalidRequestContent": true,
+	"InvalidRequestFormat":  true,
+	"InvalidResourceName":   true,
+	"BadRequest":            true,
+}
+
+// errorCode returns the machine code of the error of an Azure operation, so that the machine controller manager
+// applies the proper backoff. Errors which carry a status already keep their code.
+func errorCode(err error) codes.Code {
+	if err == nil {
+		return codes.OK
+	}
+	if s, ok := err.(*status.Status); ok {
+		return s.Code()
+	}
+	if err == context.DeadlineExceeded {
+		return codes.DeadlineExceeded
+	}
+
+	code := serviceErrorCode(err)
+	switch {
+	case spi.IsThrottled(err):
+		return codes.ResourceExhausted
+	case isUnavailable(err):
+		return codes.Unavailable
+	case isQuotaExceeded(err):
+		return codes.ResourceExhausted
+	case permissionErrorCodes[code] || hasStatusCode(err, http.StatusUnauthorized, http.StatusForbidden):
+		return codes.PermissionDenied
+	case notFoundErrorCodes[code] || hasStatusCode(err, http.StatusNotFound):
+		return codes.NotFound
+	case conflictErrorCodes[code] || hasStatusCode(err, http.StatusConflict):
+		return codes.Aborted
+	case invalidErrorCodes[code] || hasStatusCode(err, http.StatusBadRequest):
+		return codes.InvalidArgument
+	}
+	return codes.Unknown
+}
+
+// machineError returns the error of an Azure operation with its machine code and the Azure error code in its message.
+// Errors which carry a status already are returned as they are.
+func machineError(err error) error {
+	if _, ok := err.(*status.Status); ok || err == nil {
+		return err
+	}
+	return status.Error(errorCode(err), operationErrorMessage(err))
+}
+
+// isQuotaExceeded returns true if the error indicates that a quota of the subscription is exceeded. Azure reports
+// exceeded core quotas with the generic code OperationNotAllowed, hence its message is checked as well.
+func isQuotaExceeded(err error) bool {
+	serviceErr := serviceError(err)
+	if serviceErr == nil {
+		return false
+	}
+	if quotaErrorCodes[serviceErr.Code] {
+		return true
+	}
+	return serviceErr.Code == "OperationNotAllowed" && strings.Contains(strings.ToLower(serviceErr.Message), "quota")
+}
+
+// hasStatusCode returns true if the error is the response of an Azure API request with one of the given status codes
+func hasStatusCode(err error, statusCodes ...int) bool {
+	detailedErr, ok := err.(autorest.DetailedError)
+	if !ok || detailedErr.Response == nil {
+		return false
+	}
+	for _, statusCode := range statusCodes {
+		if detailedErr.Response.StatusCode == statusCode {
+			return true
+		}
+	}
+	return false
+}
+
+// deletionError returns the error of the deletion of machine resources with its machine code. Resources which are
+// gone are skipped by the deletion, hence a remaining not found error refers to another resource. It is not reported
+// as NotFound, which would let the machine controller manager consider the VM deleted. The message is followed by the
+// detail of the failed Azure operation.
+func deletionError(err error) error {
+	code := errorCode(err)
+	detail := newLastOperationDetail(lastOperationPhaseDelete, code, err)
+	if code == codes.NotFound {
+		code = codes.Unknown
+	}
+	message := operationErrorMessage(err)
+	if s, ok := err.(*status.Status); ok {
+		message = s.Message()
+	}
+	return lastOperationError(code, message, detail)
+}
@@ -0,0 +1,62 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/codes"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/status"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ErrorCodes", func() {
+	azureError := func(statusCode int, code, message string) error {
+		return autorest.DetailedError{
+			Original: &azure.RequestError{ServiceError: &azure.ServiceError{Code: code, Message: message}},
+			Response: &http.Response{StatusCode: statusCode},
+		}
+	}
+
+	DescribeTable("#errorCode",
+		func(err error, code codes.Code) {
+			Expect(errorCode(err)).To(Equal(code))
+		},
+		Entry("no error", nil, codes.OK),
+		Entry("status", status.Error(codes.FailedPrecondition, "denied"), codes.FailedPrecondition),
+		Entry("throttled", azureError(http.StatusTooManyRequests, "TooManyRequests", ""), codes.ResourceExhausted),
+		Entry("allocation failure", azureError(http.StatusOK, "ZonalAllocationFailed", ""), codes.Unavailable),
+		Entry("quota", azureError(http.StatusConflict, "QuotaExceeded", ""), codes.ResourceExhausted),
+		Entry("core quota", azureError(http.StatusConflict, "OperationNotAllowed", "Operation results in exceeding approved standardDSv3Family Cores quota"), codes.ResourceExhausted),
+		Entry("authorization", azureError(http.StatusForbidden, "AuthorizationFailed", ""), codes.PermissionDenied),
+		Entry("authentication", autorest.DetailedError{Response: &http.Response{StatusCode: http.StatusUnauthorized}}, codes.PermissionDenied),
+		Entry("not found", azureError(http.StatusNotFound, "ResourceNotFound", ""), codes.NotFound),
+		Entry("conflict", azureError(http.StatusConflict, "AnotherOperationInProgress", ""), codes.Aborted),
+		Entry("other operation not allowed", azureError(http.StatusConflict, "OperationNotAllowed", "The VM is deallocating"), codes.Aborted),
+		Entry("invalid", azureError(http.StatusBadRequest, "InvalidParameter", ""), codes.InvalidArgument),
+		Entry("unknown", errors.New("connection reset"), codes.Unknown),
+	)
+
+	Describe("#deletionError", func() {
+		It("should not report not found errors as not found", func() {
+			s, ok := status.FromError(deletionError(azureError(http.StatusNotFound, "ResourceNotFound", "subnet not found")))
+			Expect(ok).To(BeTrue())
+			Expect(s.Code()).To(Equal(codes.Unknown))
+		})
+
+		It("should keep the code of other errors", func() {
+			s, ok := status.FromError(deletionError(azureError(http.StatusConflict, "Conflict", "")))
+			Expect(ok).To(BeTrue())
+			Expect(s.Code()).To(Equal(codes.Aborted))
+		})
+	})
+})
@@ -0,0 +1,68 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-08-01/compute"
+	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/spi"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const prometheusServiceVMExtension = "virtual_machine_extension"
+
+// getVMExtensionParameters returns the parameters of the VM extension. The protected settings are read from the
+// machine class secret.
+func getVMExtensionParameters(extension api.AzureVMExtension, location string, tags map[string]*string, secret *corev1.Secret) (compute.VirtualMachineExtension, error) {
+	properties := &compute.VirtualMachineExtensionProperties{
+		Publisher:               &extension.Publisher,
+		Type:                    &extension.Type,
+		TypeHandlerVersion:      &extension.TypeHandlerVersion,
+		AutoUpgradeMinorVersion: extension.AutoUpgradeMinorVersion,
+	}
+	if extension.Settings != nil {
+		properties.Settings = extension.Settings
+	}
+	if extension.ProtectedSettingsSecretKey != "" {
+		var protectedSettings map[string]interface{}
+		if err := json.Unmarshal(secret.Data[extension.ProtectedSettingsSecretKey], &protectedSettings); err != nil {
+			return compute.VirtualMachineExtension{}, err
+		}
+		properties.ProtectedSettings = protectedSettings
+	}
+
+	return compute.VirtualMachineExtension{
+		Location:                          &location,
+		Tags:                              tags,
+		VirtualMachineExtensionProperties: properties,
+	}, nil
+}
+
+// installVMExtensions installs the extensions of the provider spec on the VM in their order and waits for each of
+// them to be provisioned. The extensions are child resources of the VM and are deleted together with it.
+func (d *MachinePlugin) installVMExtensions(ctx context.Context, clients spi.AzureDriverClientsInterface, resourceGroupName, vmName string) error {
+	for _, extension := range d.AzureProviderSpec.Properties.Extensions {
+		parameters, err := getVMExtensionParameters(extension, d.AzureProviderSpec.Location, getAzureTags(d.AzureProviderSpec.Tags), d.Secret)
+		if err != nil {
+			return err
+		}
+
+		future, err := clients.GetVMExtensions().CreateOrUpdate(ctx, resourceGroupName, vmName, extension.Name, parameters)
+		if err != nil {
+			return spi.OnARMAPIErrorFail(prometheusServiceVMExtension, err, "VMExtension.CreateOrUpdate failed for %s of %s", extension.Name, vmName)
+		}
+		if err := future.WaitForCompletionRef(ctx, clients.GetClient()); err != nil {
+			return spi.OnARMAPIErrorFail(prometheusServiceVMExtension, err, "VMExtension.WaitForCompletionRef failed for %s of %s", extension.Name, vmName)
+		}
+		spi.OnARMAPISuccess(prometheusServiceVMExtension, "VMExtension.CreateOrUpdate")
+		spi.V(2).InfoS(ctx, "Extension was installed on VM", "extension", extension.Name, "vm", vmName)
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var _ = Describe("Extensions", func() {
+	Describe("#getVMExtensionParameters", func() {
+		var extension api.AzureVMExtension
+
+		BeforeEach(func() {
+			extension = api.AzureVMExtension{
+				Name:                       "monitoring",
+				Publisher:                  "Microsoft.Azure.Monitor",
+				Type:                       "AzureMonitorLinuxAgent",
+				TypeHandlerVersion:         "1.0",
+				Settings:                   map[string]interface{}{"workspaceId": "id"},
+				ProtectedSettingsSecretKey: "monitoringSettings",
+			}
+		})
+
+		It("should read the protected settings from the secret", func() {
+			secret := &corev1.Secret{Data: map[string][]byte{"monitoringSettings": []byte(`{"workspaceKey":"key"}`)}}
+
+			parameters, err := getVMExtensionParameters(extension, "westeurope", nil, secret)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*parameters.Location).To(Equal("westeurope"))
+			Expect(*parameters.Publisher).To(Equal("Microsoft.Azure.Monitor"))
+			Expect(*parameters.VirtualMachineExtensionProperties.Type).To(Equal("AzureMonitorLinuxAgent"))
+			Expect(parameters.Settings).To(Equal(map[string]interface{}{"workspaceId": "id"}))
+			Expect(parameters.ProtectedSettings).To(Equal(map[string]interface{}{"workspaceKey": "key"}))
+		})
+
+		It("should fail if the protected settings are no JSON object", func() {
+			secret := &corev1.Secret{Data: map[string][]byte{"monitoringSettings": []byte(`key`)}}
+
+			_, err := getVMExtensionParameters(extension, "westeurope", nil, secret)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
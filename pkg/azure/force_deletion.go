@@ -0,0 +1,34 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"context"
+	"strconv"
+
+	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/spi"
+	"github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
+)
+
+// withForceDeletion returns a context forcing the deletion of the VM if the machine or its machine class is annotated
+// with the force delete annotation. Azure has no forced deletion of NICs and disks, hence they are deleted as usual.
+// The compute API versions of the API profiles have no forced deletion either, hence the annotation is ignored if the
+// provider spec selects an API profile.
+func withForceDeletion(ctx context.Context, machine *v1alpha1.Machine, machineClass *v1alpha1.MachineClass, providerSpec *api.AzureProviderSpec) context.Context {
+	for _, annotations := range []map[string]string{machine.Annotations, machineClass.Annotations} {
+		if force, err := strconv.ParseBool(annotations[api.MachineAnnotationForceDelete]); err == nil && force {
+			if providerSpec.CloudConfiguration != nil && providerSpec.CloudConfiguration.APIProfile != "" {
+				spi.WarningS(ctx, "Forced deletion of the VM is not supported by the API profile, the VM is deleted as usual", "annotation", api.MachineAnnotationForceDelete, "apiProfile", providerSpec.CloudConfiguration.APIProfile)
+				return ctx
+			}
+			spi.InfoS(ctx, "Deletion of the VM is forced", "annotation", api.MachineAnnotationForceDelete)
+			return spi.WithForceDeletion(ctx)
+		}
+	}
+	return ctx
+}
@@ -0,0 +1,52 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"context"
+
+	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/spi"
+	"github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ForceDeletion", func() {
+	var (
+		ctx          = context.Background()
+		machine      *v1alpha1.Machine
+		machineClass *v1alpha1.MachineClass
+	)
+
+	BeforeEach(func() {
+		machine = newMachine("machine")
+		machineClass, _ = newProviderSpecCacheFixtures()
+	})
+
+	It("should force the deletion of VMs of annotated machines or machine classes", func() {
+		machine.Annotations = map[string]string{api.MachineAnnotationForceDelete: "true"}
+		Expect(spi.ForceDeletion(withForceDeletion(ctx, machine, machineClass, &api.AzureProviderSpec{}))).To(BeTrue())
+
+		machine.Annotations = nil
+		machineClass.Annotations = map[string]string{api.MachineAnnotationForceDelete: "true"}
+		Expect(spi.ForceDeletion(withForceDeletion(ctx, machine, machineClass, &api.AzureProviderSpec{}))).To(BeTrue())
+	})
+
+	It("should not force the deletion without annotation or if it is not true", func() {
+		Expect(spi.ForceDeletion(withForceDeletion(ctx, machine, machineClass, &api.AzureProviderSpec{}))).To(BeFalse())
+
+		machine.Annotations = map[string]string{api.MachineAnnotationForceDelete: "no"}
+		Expect(spi.ForceDeletion(withForceDeletion(ctx, machine, machineClass, &api.AzureProviderSpec{}))).To(BeFalse())
+	})
+
+	It("should not force the deletion with an API profile", func() {
+		machine.Annotations = map[string]string{api.MachineAnnotationForceDelete: "true"}
+		providerSpec := &api.AzureProviderSpec{CloudConfiguration: &api.CloudConfiguration{APIProfile: api.APIProfile20200901Hybrid}}
+		Expect(spi.ForceDeletion(withForceDeletion(ctx, machine, machineClass, providerSpec))).To(BeFalse())
+	})
+})
@@ -0,0 +1,95 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-08-01/compute"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/spi"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/codes"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/status"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	// guestAgentPollInterval is the interval in which the instance view of a created VM is polled until its guest
+	// agent reports ready
+	guestAgentPollInterval = 10 * time.Second
+	// guestAgentReadyStatus is the display status of a guest agent which is ready
+	guestAgentReadyStatus = "Ready"
+)
+
+// waitForGuestAgent waits until the guest agent of the VM reports ready in the instance view of the VM. A guest agent
+// which does not become ready within the timeout usually indicates an image with broken provisioning, e.g. cloud-init,
+// whose VM would never join the cluster. The wait is skipped if the timeout is zero.
+func (d *MachinePlugin) waitForGuestAgent(ctx context.Context, clients spi.AzureDriverClientsInterface, resourceGroupName, vmName string) error {
+	return d.waitForGuestAgentWithin(ctx, clients, resourceGroupName, vmName, d.guestAgentReadyTimeout)
+}
+
+// waitForGuestAgentWithin waits until the guest agent of the VM reports ready within the given timeout. The wait is
+// skipped if the timeout is zero.
+func (d *MachinePlugin) waitForGuestAgentWithin(ctx context.Context, clients spi.AzureDriverClientsInterface, resourceGroupName, vmName string, timeout time.Duration) error {
+	if timeout <= 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	lastStatus := "unknown"
+	err := wait.PollImmediateUntil(d.guestAgentPollInterval, func() (bool, error) {
+		instanceView, err := clients.GetVM().InstanceView(ctx, resourceGroupName, vmName)
+		if err != nil {
+			if spi.NotFound(err) {
+				return false, spi.OnARMAPIErrorFail(prometheusServiceVM, err, "VM.InstanceView failed for %s", vmName)
+			}
+			// The instance view may not be available right after the creation, hence other errors are retried
+			spi.V(3).InfoS(ctx, "Instance view of VM could not be retrieved", "vm", vmName, "err", err)
+			return false, nil
+		}
+		spi.OnARMAPISuccess(prometheusServiceVM, "VM.InstanceView")
+
+		ready, agentStatus := guestAgentStatus(instanceView)
+		if agentStatus != "" {
+			lastStatus = agentStatus
+		}
+		return ready, nil
+	}, ctx.Done())
+	if err == wait.ErrWaitTimeout {
+		return status.Error(codes.DeadlineExceeded, fmt.Sprintf("Guest agent of VM %q did not report ready within %s, last status: %s", vmName, timeout, lastStatus))
+	}
+	if err != nil {
+		return err
+	}
+
+	spi.V(2).InfoS(ctx, "Guest agent of VM is ready", "vm", vmName)
+	return nil
+}
+
+// guestAgentStatus returns true if the guest agent in the instance view is ready, along with its reported status
+func guestAgentStatus(instanceView compute.VirtualMachineInstanceView) (bool, string) {
+	if instanceView.VMAgent == nil || instanceView.VMAgent.Statuses == nil {
+		return false, ""
+	}
+	var agentStatus string
+	for _, s := range *instanceView.VMAgent.Statuses {
+		if s.DisplayStatus == nil {
+			continue
+		}
+		if *s.DisplayStatus == guestAgentReadyStatus {
+			return true, *s.DisplayStatus
+		}
+		agentStatus = *s.DisplayStatus
+		if s.Message != nil {
+			agentStatus = fmt.Sprintf("%s (%s)", agentStatus, *s.Message)
+		}
+	}
+	return false, agentStatus
+}
@@ -0,0 +1,77 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-08-01/compute"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/mock"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/codes"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/status"
+	gomock "github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("GuestAgent", func() {
+	var (
+		ctx           = context.Background()
+		resourceGroup = "rg"
+
+		driver  *MachinePlugin
+		clients *mock.AzureDriverClients
+
+		instanceView = func(displayStatus string) compute.VirtualMachineInstanceView {
+			return compute.VirtualMachineInstanceView{VMAgent: &compute.VirtualMachineAgentInstanceView{
+				Statuses: &[]compute.InstanceViewStatus{{DisplayStatus: to.StringPtr(displayStatus), Message: to.StringPtr("Guest Agent is running")}},
+			}}
+		}
+	)
+
+	BeforeEach(func() {
+		sp := mock.NewMockPluginSPIImpl(gomock.NewController(GinkgoT()))
+		_, secret := newProviderSpecCacheFixtures()
+		driverClients, err := sp.Setup(secret, nil)
+		Expect(err).NotTo(HaveOccurred())
+		clients = driverClients.(*mock.AzureDriverClients)
+
+		driver = NewAzureDriver(sp)
+		driver.guestAgentReadyTimeout = time.Second
+		driver.guestAgentPollInterval = time.Millisecond
+	})
+
+	It("should wait until the guest agent is ready", func() {
+		gomock.InOrder(
+			clients.VM.EXPECT().InstanceView(gomock.Any(), resourceGroup, "machine").Return(compute.VirtualMachineInstanceView{}, errors.New("not yet available")),
+			clients.VM.EXPECT().InstanceView(gomock.Any(), resourceGroup, "machine").Return(instanceView("Not Ready"), nil),
+			clients.VM.EXPECT().InstanceView(gomock.Any(), resourceGroup, "machine").Return(instanceView(guestAgentReadyStatus), nil),
+		)
+
+		Expect(driver.waitForGuestAgent(ctx, clients, resourceGroup, "machine")).To(Succeed())
+	})
+
+	It("should fail with deadline exceeded if the guest agent does not become ready", func() {
+		driver.guestAgentReadyTimeout = 20 * time.Millisecond
+		clients.VM.EXPECT().InstanceView(gomock.Any(), resourceGroup, "machine").Return(instanceView("Not Ready"), nil).MinTimes(1)
+
+		err := driver.waitForGuestAgent(ctx, clients, resourceGroup, "machine")
+		s, ok := status.FromError(err)
+		Expect(ok).To(BeTrue())
+		Expect(s.Code()).To(Equal(codes.DeadlineExceeded))
+		Expect(s.Message()).To(ContainSubstring("Not Ready (Guest Agent is running)"))
+	})
+
+	It("should not wait if disabled", func() {
+		driver.guestAgentReadyTimeout = 0
+
+		Expect(driver.waitForGuestAgent(ctx, clients, resourceGroup, "machine")).To(Succeed())
+	})
+})
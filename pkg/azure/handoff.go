@@ -0,0 +1,204 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/spi"
+	"github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/codes"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/status"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// ipHandoffTTL is the duration for which the addresses of a deleted machine are reserved for its successor
+const ipHandoffTTL = 15 * time.Minute
+
+// ipHandoff are the addresses a deleted machine hands off to its successor
+type ipHandoff struct {
+	privateIPAddress string
+	dnsLabel         *string
+	expiresAt        time.Time
+}
+
+// ipHandoffs keeps the addresses released by deleted machines per handoff group until a successor claims them.
+// The handoffs are kept in memory only, so they do not survive a restart of the controller.
+type ipHandoffs struct {
+	ttl time.Duration
+
+	mutex sync.Mutex
+	// groups are the released handoffs, by machine class and handoff group
+	groups map[string][]ipHandoff
+	// held are the handoffs of machines whose deletion is in progress, by machine class and machine name
+	held map[string]ipHandoff
+}
+
+// ipHandoffKey returns the key of a handoff group or machine, which is scoped to the machine class so that equally
+// named groups or machines of different classes do not collide
+func ipHandoffKey(machineClass *v1alpha1.MachineClass, name string) string {
+	return strings.ToLower(machineClass.Namespace + "/" + machineClass.Name + "/" + name)
+}
+
+// validateIPHandoffGroup validates the handoff group annotated on the machine. The handoff is kept in memory by the
+// controller instance only and is scoped to the machine class. It therefore requires the machines of a group to be
+// replaced without surge and within the same machine class; addresses released before a restart of the controller or
+// by a machine of another class are not handed off.
+func validateIPHandoffGroup(machine *v1alpha1.Machine) error {
+	group, ok := machine.Annotations[api.MachineAnnotationIPHandoffGroup]
+	if !ok {
+		return nil
+	}
+	if errs := validation.IsDNS1123Label(group); len(errs) > 0 {
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("Invalid handoff group %q in annotation %s of machine %q: %s", group, api.MachineAnnotationIPHandoffGroup, machine.Name, strings.Join(errs, ", ")))
+	}
+	return nil
+}
+
+func newIPHandoffs(ttl time.Duration) *ipHandoffs {
+	return &ipHandoffs{
+		ttl:    ttl,
+		groups: map[string][]ipHandoff{},
+		held:   map[string]ipHandoff{},
+	}
+}
+
+// hold keeps the handoff of a machine until its deletion is completed
+func (h *ipHandoffs) hold(machineName string, handoff ipHandoff) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.held[machineName] = handoff
+}
+
+// unhold returns and forgets the held handoff of a machine, if any
+func (h *ipHandoffs) unhold(machineName string) (ipHandoff, bool) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	handoff, ok := h.held[machineName]
+	delete(h.held, machineName)
+	return handoff, ok
+}
+
+// release reserves the addresses for the next machine of the group
+func (h *ipHandoffs) release(group string, handoff ipHandoff) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	handoff.expiresAt = time.Now().Add(h.ttl)
+	h.groups[group] = append(h.groups[group], handoff)
+}
+
+// claim returns the oldest unexpired handoff of the group, if any
+func (h *ipHandoffs) claim(group string) (ipHandoff, bool) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	now := time.Now()
+	for len(h.groups[group]) > 0 {
+		handoff := h.groups[group][0]
+		h.groups[group] = h.groups[group][1:]
+		if now.Before(handoff.expiresAt) {
+			return handoff, true
+		}
+	}
+	delete(h.groups, group)
+	return ipHandoff{}, false
+}
+
+// holdIPHandoff reads the addresses of the primary network interface of a machine which is about to be deleted
+// and holds them until releaseIPHandoff is called after the deletion. Machines which are not part of a handoff group,
+// or whose addresses cannot be determined, e.g. because the interface is already deleted, are skipped.
+func (d *MachinePlugin) holdIPHandoff(ctx context.Context, clients spi.AzureDriverClientsInterface, resourceGroupName string, machine *v1alpha1.Machine, machineClass *v1alpha1.MachineClass, networkInterfaces []networkInterface) {
+	if d.ipHandoffs == nil || machine.Annotations[api.MachineAnnotationIPHandoffGroup] == "" {
+		return
+	}
+	if handoff, ok := captureIPHandoff(ctx, clients, resourceGroupName, networkInterfaces); ok {
+		d.ipHandoffs.hold(ipHandoffKey(machineClass, machine.Name), handoff)
+	}
+}
+
+// releaseIPHandoff hands off the held addresses of a deleted machine to the next machine of its handoff group
+func (d *MachinePlugin) releaseIPHandoff(machine *v1alpha1.Machine, machineClass *v1alpha1.MachineClass) {
+	if d.ipHandoffs == nil {
+		return
+	}
+	if handoff, ok := d.ipHandoffs.unhold(ipHandoffKey(machineClass, machine.Name)); ok {
+		d.ipHandoffs.release(ipHandoffKey(machineClass, machine.Annotations[api.MachineAnnotationIPHandoffGroup]), handoff)
+	}
+}
+
+func captureIPHandoff(ctx context.Context, clients spi.AzureDriverClientsInterface, resourceGroupName string, networkInterfaces []networkInterface) (ipHandoff, bool) {
+	for _, nic := range networkInterfaces {
+		if !nic.primary {
+			continue
+		}
+		resourceGroupName := nic.resourceGroupName(resourceGroupName)
+
+		NIC, err := clients.GetNic().Get(ctx, resourceGroupName, nic.name, "")
+		if spi.NotFound(err) {
+			return ipHandoff{}, false
+		} else if err != nil {
+			spi.WarningS(ctx, "Could not capture addresses of NIC for handoff", "nic", nic.name, "err", err)
+			return ipHandoff{}, false
+		}
+		if NIC.IPConfigurations == nil || len(*NIC.IPConfigurations) == 0 || (*NIC.IPConfigurations)[0].PrivateIPAddress == nil {
+			return ipHandoff{}, false
+		}
+		handoff := ipHandoff{privateIPAddress: *(*NIC.IPConfigurations)[0].PrivateIPAddress}
+
+		if nic.publicIP != nil {
+			publicIP, err := clients.GetPublicIP().Get(ctx, resourceGroupName, nic.publicIPName, "")
+			if err != nil && !spi.NotFound(err) {
+				spi.WarningS(ctx, "Could not capture DNS label of public IP for handoff", "publicIP", nic.publicIPName, "err", err)
+			} else if err == nil && publicIP.DNSSettings != nil {
+				handoff.dnsLabel = publicIP.DNSSettings.DomainNameLabel
+			}
+		}
+		return handoff, true
+	}
+	return ipHandoff{}, false
+}
+
+// applyIPHandoff assigns the addresses handed off by a predecessor in the machine's handoff group to the primary
+// network interface. The handed off private IP address is tried first, followed by any other configured address.
+// The returned function hands the addresses back if the creation fails.
+func (d *MachinePlugin) applyIPHandoff(ctx context.Context, networkInterfaces []networkInterface, machine *v1alpha1.Machine, machineClass *v1alpha1.MachineClass) (func(), error) {
+	if err := validateIPHandoffGroup(machine); err != nil {
+		return nil, err
+	}
+	group := machine.Annotations[api.MachineAnnotationIPHandoffGroup]
+	if d.ipHandoffs == nil || group == "" {
+		return func() {}, nil
+	}
+
+	key := ipHandoffKey(machineClass, group)
+	handoff, ok := d.ipHandoffs.claim(key)
+	if !ok {
+		return func() {}, nil
+	}
+	spi.V(2).InfoS(ctx, "Machine takes over private IP address of handoff group", "privateIPAddress", handoff.privateIPAddress, "handoffGroup", group)
+
+	for i := range networkInterfaces {
+		if !networkInterfaces[i].primary {
+			continue
+		}
+		networkInterfaces[i].staticPrivateIP = true
+		networkInterfaces[i].privateIPAddresses = append([]string{handoff.privateIPAddress}, networkInterfaces[i].privateIPAddresses...)
+		networkInterfaces[i].dnsLabel = handoff.dnsLabel
+	}
+
+	return func() {
+		d.ipHandoffs.release(key, handoff)
+	}, nil
+}
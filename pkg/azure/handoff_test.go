@@ -0,0 +1,104 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"context"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest/to"
+	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+	"github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/codes"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/status"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("IPHandoff", func() {
+	var (
+		driver       *MachinePlugin
+		providerSpec *api.AzureProviderSpec
+		machine      *v1alpha1.Machine
+		machineClass *v1alpha1.MachineClass
+		group        string
+	)
+
+	BeforeEach(func() {
+		driver = &MachinePlugin{ipHandoffs: newIPHandoffs(time.Minute)}
+		providerSpec = &api.AzureProviderSpec{}
+		providerSpec.Properties.NetworkProfile.PublicIP = &api.AzurePublicIP{}
+		machine = &v1alpha1.Machine{}
+		machine.Name = "machine-1"
+		machine.Annotations = map[string]string{api.MachineAnnotationIPHandoffGroup: "ingress"}
+		machineClass = &v1alpha1.MachineClass{}
+		machineClass.Namespace, machineClass.Name = "shoot--foo--bar", "worker"
+		group = ipHandoffKey(machineClass, "ingress")
+	})
+
+	It("should hand off the addresses of a deleted machine to its successor", func() {
+		driver.ipHandoffs.release(group, ipHandoff{privateIPAddress: "10.250.0.4", dnsLabel: to.StringPtr("ingress")})
+
+		networkInterfaces := getNetworkInterfaces(providerSpec, machine.Name)
+		_, err := driver.applyIPHandoff(context.Background(), networkInterfaces, machine, machineClass)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(networkInterfaces[0].staticPrivateIP).To(BeTrue())
+		Expect(networkInterfaces[0].privateIPAddresses).To(Equal([]string{"10.250.0.4"}))
+		Expect(networkInterfaces[0].dnsLabel).To(Equal(to.StringPtr("ingress")))
+
+		_, ok := driver.ipHandoffs.claim(group)
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should hand the addresses back if the creation fails", func() {
+		driver.ipHandoffs.release(group, ipHandoff{privateIPAddress: "10.250.0.4"})
+
+		restore, err := driver.applyIPHandoff(context.Background(), getNetworkInterfaces(providerSpec, machine.Name), machine, machineClass)
+		Expect(err).NotTo(HaveOccurred())
+		restore()
+
+		handoff, ok := driver.ipHandoffs.claim(group)
+		Expect(ok).To(BeTrue())
+		Expect(handoff.privateIPAddress).To(Equal("10.250.0.4"))
+	})
+
+	It("should not hand off expired addresses", func() {
+		driver.ipHandoffs = newIPHandoffs(-time.Minute)
+		driver.ipHandoffs.release(group, ipHandoff{privateIPAddress: "10.250.0.4"})
+
+		networkInterfaces := getNetworkInterfaces(providerSpec, machine.Name)
+		_, err := driver.applyIPHandoff(context.Background(), networkInterfaces, machine, machineClass)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(networkInterfaces[0].staticPrivateIP).To(BeFalse())
+	})
+
+	It("should not hand off the addresses of an equally named group of another machine class", func() {
+		otherClass := machineClass.DeepCopy()
+		otherClass.Name = "other"
+		driver.ipHandoffs.hold(ipHandoffKey(otherClass, machine.Name), ipHandoff{privateIPAddress: "10.250.0.4"})
+		driver.releaseIPHandoff(machine, machineClass)
+		driver.releaseIPHandoff(machine, otherClass)
+
+		networkInterfaces := getNetworkInterfaces(providerSpec, "machine-2")
+		_, err := driver.applyIPHandoff(context.Background(), networkInterfaces, machine, machineClass)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(networkInterfaces[0].staticPrivateIP).To(BeFalse())
+
+		_, ok := driver.ipHandoffs.claim(ipHandoffKey(otherClass, "ingress"))
+		Expect(ok).To(BeTrue())
+	})
+
+	It("should reject an invalid handoff group", func() {
+		machine.Annotations[api.MachineAnnotationIPHandoffGroup] = "Ingress/Public"
+
+		_, err := driver.applyIPHandoff(context.Background(), getNetworkInterfaces(providerSpec, machine.Name), machine, machineClass)
+		Expect(err).To(HaveOccurred())
+		Expect(err.(*status.Status).Code()).To(Equal(codes.InvalidArgument))
+	})
+})
@@ -0,0 +1,109 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-08-01/compute"
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-04-01/network"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/spi"
+)
+
+// ownerTagKey is the tag of the machine resources naming the machine controller instance owning them
+const ownerTagKey = "machine-controller-manager-owner"
+
+// ownerOf returns the owner of a resource, or an empty string if it is not tagged with an owner
+func ownerOf(tags map[string]*string) string {
+	if owner, ok := tags[ownerTagKey]; ok && owner != nil {
+		return *owner
+	}
+	return ""
+}
+
+// ownedByOtherInstance returns true if the resource is tagged with an owner other than this instance
+func (d *MachinePlugin) ownedByOtherInstance(tags map[string]*string) bool {
+	owner := ownerOf(tags)
+	return owner != "" && owner != d.ownerID
+}
+
+// withOwnerTag returns the tags with the owner tag set to the given owner
+func withOwnerTag(tags map[string]*string, owner string) map[string]*string {
+	result := make(map[string]*string, len(tags)+1)
+	for key, value := range tags {
+		result[key] = value
+	}
+	result[ownerTagKey] = to.StringPtr(owner)
+	return result
+}
+
+// handOverMachine rewrites the owner tag of the NICs, public IPs, disks and finally the VM of a machine, so that
+// the machine controller instance with the given owner id adopts them. Resources which do not exist are skipped.
+func handOverMachine(ctx context.Context, clients spi.AzureDriverClientsInterface, resourceGroupName, vmName string, networkInterfaces []networkInterface, diskNames []string, owner string) error {
+	for _, nic := range networkInterfaces {
+		nicResourceGroup := nic.resourceGroupName(resourceGroupName)
+		NIC, err := clients.GetNic().Get(ctx, nicResourceGroup, nic.name, "")
+		if err == nil {
+			if _, err := clients.GetNic().UpdateTags(ctx, nicResourceGroup, nic.name, network.TagsObject{Tags: withOwnerTag(NIC.Tags, owner)}); err != nil {
+				return spi.OnARMAPIErrorFail(prometheusServiceNIC, err, "NIC.UpdateTags failed for %s", nic.name)
+			}
+			spi.OnARMAPISuccess(prometheusServiceNIC, "NIC.UpdateTags")
+		} else if !spi.NotFound(err) {
+			return spi.OnARMAPIErrorFail(prometheusServiceNIC, err, "NIC.Get failed for %s", nic.name)
+		}
+
+		if nic.publicIP == nil {
+			continue
+		}
+		publicIP, err := clients.GetPublicIP().Get(ctx, nicResourceGroup, nic.publicIPName, "")
+		if err == nil {
+			if _, err := clients.GetPublicIP().UpdateTags(ctx, nicResourceGroup, nic.publicIPName, network.TagsObject{Tags: withOwnerTag(publicIP.Tags, owner)}); err != nil {
+				return spi.OnARMAPIErrorFail(prometheusServicePublicIP, err, "PublicIP.UpdateTags failed for %s", nic.publicIPName)
+			}
+			spi.OnARMAPISuccess(prometheusServicePublicIP, "PublicIP.UpdateTags")
+		} else if !spi.NotFound(err) {
+			return spi.OnARMAPIErrorFail(prometheusServicePublicIP, err, "PublicIP.Get failed for %s", nic.publicIPName)
+		}
+	}
+
+	for _, diskName := range diskNames {
+		disk, err := clients.GetDisk().Get(ctx, resourceGroupName, diskName)
+		if err != nil {
+			if spi.NotFound(err) {
+				continue
+			}
+			return spi.OnARMAPIErrorFail(prometheusServiceDisk, err, "Disk.Get failed for %s", diskName)
+		}
+		future, err := clients.GetDisk().Update(ctx, resourceGroupName, diskName, compute.DiskUpdate{Tags: withOwnerTag(disk.Tags, owner)})
+		if err != nil {
+			return spi.OnARMAPIErrorFail(prometheusServiceDisk, err, "Disk.Update failed for %s", diskName)
+		}
+		if err := future.WaitForCompletionRef(ctx, clients.GetClient()); err != nil {
+			return spi.OnARMAPIErrorFail(prometheusServiceDisk, err, "Disk.WaitForCompletionRef failed for %s", diskName)
+		}
+		spi.OnARMAPISuccess(prometheusServiceDisk, "Disk.Update")
+	}
+
+	// The VM is handed over last, so that it is still listed by this instance until all its resources are handed over
+	vm, err := clients.GetVM().Get(ctx, resourceGroupName, vmName, "")
+	if err != nil {
+		if spi.NotFound(err) {
+			return nil
+		}
+		return spi.OnARMAPIErrorFail(prometheusServiceVM, err, "VM.Get failed for %s", vmName)
+	}
+	future, err := clients.GetVM().Update(ctx, resourceGroupName, vmName, compute.VirtualMachineUpdate{Tags: withOwnerTag(vm.Tags, owner)})
+	if err != nil {
+		return spi.OnARMAPIErrorFail(prometheusServiceVM, err, "VM.Update failed for %s", vmName)
+	}
+	if err := future.WaitForCompletionRef(ctx, clients.GetClient()); err != nil {
+		return spi.OnARMAPIErrorFail(prometheusServiceVM, err, "VM.WaitForCompletionRef failed for %s", vmName)
+	}
+	spi.OnARMAPISuccess(prometheusServiceVM, "VM.Update")
+	return nil
+}
@@ -0,0 +1,66 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-08-01/compute"
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-05-01/resources"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/mock"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/driver"
+	gomock "github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Handover", func() {
+	Describe("#ownedByOtherInstance", func() {
+		driver := &MachinePlugin{ownerID: "seed-a"}
+
+		It("should treat untagged resources and own resources as owned", func() {
+			Expect(driver.ownedByOtherInstance(nil)).To(BeFalse())
+			Expect(driver.ownedByOtherInstance(map[string]*string{ownerTagKey: to.StringPtr("seed-a")})).To(BeFalse())
+		})
+
+		It("should detect resources handed over to another instance", func() {
+			Expect(driver.ownedByOtherInstance(withOwnerTag(map[string]*string{"Name": to.StringPtr("shoot")}, "seed-b"))).To(BeTrue())
+		})
+	})
+
+	Describe("#withOwnerTag", func() {
+		It("should not modify the given tags", func() {
+			tags := map[string]*string{ownerTagKey: to.StringPtr("seed-a")}
+
+			Expect(ownerOf(withOwnerTag(tags, "seed-b"))).To(Equal("seed-b"))
+			Expect(ownerOf(tags)).To(Equal("seed-a"))
+		})
+	})
+
+	Describe("#DeleteMachine", func() {
+		It("should keep the resources if the owner of the VM cannot be determined", func() {
+			ctx := context.Background()
+			sp := mock.NewMockPluginSPIImpl(gomock.NewController(GinkgoT()))
+			machineClass, secret := newProviderSpecCacheFixtures()
+			clients, err := sp.Setup(secret, nil)
+			Expect(err).NotTo(HaveOccurred())
+			fakeClients := clients.(*mock.AzureDriverClients)
+
+			plugin := NewAzureDriver(sp)
+			plugin.ownerID = "seed-a"
+			fakeClients.Group.EXPECT().Get(gomock.Any(), "shoot--i538135--seed-az").Return(resources.Group{}, nil)
+			// The mock fails the test on any deletion of the resources
+			fakeClients.VM.EXPECT().Get(gomock.Any(), "shoot--i538135--seed-az", "machine", compute.InstanceViewTypes("")).Return(compute.VirtualMachine{}, autorest.DetailedError{Response: &http.Response{StatusCode: http.StatusTooManyRequests}})
+
+			_, err = plugin.DeleteMachine(ctx, &driver.DeleteMachineRequest{Machine: newMachine("machine"), MachineClass: machineClass, Secret: secret})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
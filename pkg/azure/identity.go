@@ -0,0 +1,50 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/spi"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/codes"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/status"
+)
+
+const (
+	prometheusServiceIdentity = "identity"
+
+	// userAssignedIdentityAPIVersion is the API version used to read user-assigned identities
+	userAssignedIdentityAPIVersion = "2018-11-30"
+)
+
+// checkIdentity rejects provider specs whose user-assigned identity does not exist before any resource is created.
+// Azure would otherwise only fail the VM creation with IdentityNotFound after the NICs and disks were created.
+// An identity in another region than the VM is allowed, but reported.
+func (d *MachinePlugin) checkIdentity(ctx context.Context, clients spi.AzureDriverClientsInterface, providerSpec *api.AzureProviderSpec) error {
+	identityID := providerSpec.Properties.IdentityID
+	if !d.checkIdentityExistence || identityID == nil || *identityID == "" {
+		return nil
+	}
+
+	identity, err := clients.GetResources().GetByID(ctx, *identityID, userAssignedIdentityAPIVersion)
+	if err != nil {
+		if spi.NotFound(err) {
+			return status.Error(codes.InvalidArgument, fmt.Sprintf("User-assigned identity %q does not exist", *identityID))
+		}
+		spi.WarningS(ctx, "Skipping existence check of user-assigned identity", "identity", identityID, "err", spi.OnARMAPIErrorFail(prometheusServiceIdentity, err, "Resources.GetByID"))
+		return nil
+	}
+	spi.OnARMAPISuccess(prometheusServiceIdentity, "Resources.GetByID")
+
+	if identity.Location != nil && !strings.EqualFold(*identity.Location, providerSpec.Location) {
+		spi.WarningS(ctx, "User-assigned identity is located in another location than the VM", "identity", identityID, "identityLocation", identity.Location, "location", providerSpec.Location)
+	}
+	return nil
+}
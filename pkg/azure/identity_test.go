@@ -0,0 +1,69 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-05-01/resources"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/mock"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/codes"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/status"
+	gomock "github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var _ = Describe("Identity", func() {
+	var (
+		ctx        = context.Background()
+		identityID = "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.ManagedIdentity/userAssignedIdentities/id"
+
+		driver       *MachinePlugin
+		clients      *mock.AzureDriverClients
+		providerSpec *api.AzureProviderSpec
+	)
+
+	BeforeEach(func() {
+		spi := mock.NewMockPluginSPIImpl(gomock.NewController(GinkgoT()))
+		driverClients, err := spi.Setup(&corev1.Secret{}, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		driver = NewAzureDriver(spi)
+		driver.checkIdentityExistence = true
+		clients = driverClients.(*mock.AzureDriverClients)
+		providerSpec = &api.AzureProviderSpec{Location: "westeurope"}
+		providerSpec.Properties.IdentityID = to.StringPtr(identityID)
+	})
+
+	It("should accept an existing identity", func() {
+		clients.Resources.EXPECT().GetByID(ctx, identityID, userAssignedIdentityAPIVersion).Return(resources.GenericResource{Location: to.StringPtr("westeurope")}, nil)
+
+		Expect(driver.checkIdentity(ctx, clients, providerSpec)).To(Succeed())
+	})
+
+	It("should reject a missing identity as invalid argument", func() {
+		clients.Resources.EXPECT().GetByID(ctx, identityID, userAssignedIdentityAPIVersion).Return(resources.GenericResource{}, autorest.DetailedError{Response: &http.Response{StatusCode: http.StatusNotFound}})
+
+		err := driver.checkIdentity(ctx, clients, providerSpec)
+		Expect(err).To(HaveOccurred())
+		s, ok := status.FromError(err)
+		Expect(ok).To(BeTrue())
+		Expect(s.Code()).To(Equal(codes.InvalidArgument))
+	})
+
+	It("should skip the check if it is disabled", func() {
+		driver.checkIdentityExistence = false
+
+		Expect(driver.checkIdentity(ctx, clients, providerSpec)).To(Succeed())
+	})
+})
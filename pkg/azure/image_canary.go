@@ -0,0 +1,257 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-08-01/compute"
+	"github.com/Azure/go-autorest/autorest/to"
+	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/spi"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/codes"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/status"
+)
+
+const (
+	// imageCanaryPendingTTL is the duration after which the validation of an image by a canary which did not report
+	// its result, e.g. as the machine controller restarted, is given up so that another machine becomes the canary.
+	// The canary timeout is added to it.
+	imageCanaryPendingTTL = 30 * time.Minute
+	// imageCanaryInitialBackoff is the duration for which an image which failed to boot is rejected before another
+	// canary validates it, e.g. in case the failure was transient. It doubles with every consecutive failure.
+	imageCanaryInitialBackoff = 5 * time.Minute
+	// imageCanaryMaxBackoff bounds the duration for which an image which failed to boot repeatedly is rejected
+	imageCanaryMaxBackoff = time.Hour
+)
+
+// bootFailureErrorCodes are the Azure error codes of VM creations failing as the OS of the image did not boot
+var bootFailureErrorCodes = map[string]bool{
+	"OSProvisioningTimedOut":           true,
+	"OSProvisioningClientError":        true,
+	"OSProvisioningInternalError":      true,
+	"VMAgentStatusCommunicationError":  true,
+	"VMStartTimedOut":                  true,
+	"OSProvisioningTimedOutInternally": true,
+}
+
+// imageValidation is the state of the validation of an image by its canary machine
+type imageValidation struct {
+	// canary is the name of the machine validating the image
+	canary string
+	// validated is true once the canary booted
+	validated bool
+	// reason is the reason why the image failed to boot, it is empty unless the validation failed
+	reason string
+	// failures is the number of consecutive canaries the image failed to boot on
+	failures int
+	// expiry is the time until which a pending or failed validation is considered
+	expiry time.Time
+}
+
+// imageCanaries tracks the boot validation of the images of the machine classes. The first machine created with an
+// image which was not validated yet is its canary. The other machines with the image are rejected until the guest
+// agent of the canary reported ready, so that an unbootable image does not roll out to the whole fleet. The images are
+// identified by their reference, hence a new image version of a machine class is validated again. The validations are
+// kept in memory only, images running on existing VMs are considered validated after a restart instead.
+type imageCanaries struct {
+	// timeout bounds the wait for the guest agent of the canary
+	timeout time.Duration
+
+	mutex       sync.Mutex
+	validations map[string]*imageValidation
+	// seeded are the resource groups whose running VMs were looked up for validated images
+	seeded map[string]bool
+}
+
+// newImageCanaries returns the image validations with the given timeout for the guest agent of the canaries
+func newImageCanaries(timeout time.Duration) *imageCanaries {
+	return &imageCanaries{
+		timeout:     timeout,
+		validations: map[string]*imageValidation{},
+		seeded:      map[string]bool{},
+	}
+}
+
+// imageKey returns the key identifying the image of the provider spec, i.e. its URN or ID
+func imageKey(imageReference api.AzureImageReference) string {
+	if imageReference.ID != "" {
+		return strings.ToLower(imageReference.ID)
+	}
+	return strings.ToLower(to.String(imageReference.URN))
+}
+
+// acquire returns true if the machine is the canary of the image. It returns an error if the image is validated by
+// another machine or failed to boot.
+func (c *imageCanaries) acquire(ctx context.Context, image, machineName string, now time.Time) (bool, error) {
+	if c == nil {
+		return false, nil
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	validation, ok := c.validations[image]
+	switch {
+	case !ok || (!validation.validated && now.After(validation.expiry)):
+		next := &imageValidation{canary: machineName, expiry: now.Add(imageCanaryPendingTTL + c.timeout)}
+		if ok {
+			next.failures = validation.failures
+		}
+		c.validations[image] = next
+		spi.InfoS(ctx, "Machine is the canary of the image", "image", image)
+		return true, nil
+	case validation.validated:
+		return false, nil
+	case validation.reason != "":
+		return false, status.Error(codes.FailedPrecondition, fmt.Sprintf("The image %q failed to boot on the canary machine %q: %s", image, validation.canary, validation.reason))
+	case validation.canary == machineName:
+		return true, nil
+	}
+	return false, status.Error(codes.Unavailable, fmt.Sprintf("The image %q is being validated by the canary machine %q", image, validation.canary))
+}
+
+// seed considers the images of the running VMs of the resource group validated, unless the resource group was looked up
+// already. It is looked up once per resource group, as the validations are lost when the machine controller restarts.
+// If the VMs cannot be listed, the lookup is retried with the next machine.
+func (c *imageCanaries) seed(ctx context.Context, clients spi.AzureDriverClientsInterface, resourceGroupName string) {
+	if c == nil {
+		return
+	}
+	key := strings.ToLower(resourceGroupName)
+	c.mutex.Lock()
+	seeded := c.seeded[key]
+	c.mutex.Unlock()
+	if seeded {
+		return
+	}
+
+	var images []string
+	iterator, err := clients.GetVM().ListComplete(ctx, resourceGroupName, "")
+	for err == nil && iterator.NotDone() {
+		if image, ok := runningImage(iterator.Value()); ok {
+			images = append(images, image)
+		}
+		err = iterator.NextWithContext(ctx)
+	}
+	if err != nil {
+		spi.WarningS(ctx, "Images of the running VMs could not be looked up", "err", spi.OnARMAPIErrorFail(prometheusServiceVM, err, "VM.List"))
+		return
+	}
+	spi.OnARMAPISuccess(prometheusServiceVM, "VM.List")
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for _, image := range images {
+		if _, ok := c.validations[image]; !ok {
+			c.validations[image] = &imageValidation{validated: true}
+		}
+	}
+	c.seeded[key] = true
+}
+
+// runningImage returns the key of the image of a VM which was provisioned successfully
+func runningImage(vm compute.VirtualMachine) (string, bool) {
+	if vm.VirtualMachineProperties == nil || !strings.EqualFold(to.String(vm.ProvisioningState), "Succeeded") ||
+		vm.StorageProfile == nil || vm.StorageProfile.ImageReference == nil {
+		return "", false
+	}
+	reference := vm.StorageProfile.ImageReference
+	if reference.ID != nil {
+		return imageKey(api.AzureImageReference{ID: *reference.ID}), true
+	}
+	if reference.Publisher == nil || reference.Offer == nil || reference.Sku == nil || reference.Version == nil {
+		return "", false
+	}
+	urn := strings.Join([]string{*reference.Publisher, *reference.Offer, *reference.Sku, *reference.Version}, ":")
+	return imageKey(api.AzureImageReference{URN: &urn}), true
+}
+
+// validate records that the canary booted with the image
+func (c *imageCanaries) validate(ctx context.Context, image, machineName string) {
+	c.complete(image, machineName, func(validation *imageValidation) {
+		validation.validated = true
+		spi.InfoS(ctx, "The image was validated by the canary machine", "image", image, "canary", machineName)
+	})
+}
+
+// fail records that the image failed to boot on the canary. The image is rejected for a backoff which doubles with
+// every consecutive failure, after which the next machine validates it again.
+func (c *imageCanaries) fail(ctx context.Context, image, machineName, reason string, now time.Time) {
+	c.complete(image, machineName, func(validation *imageValidation) {
+		validation.reason = reason
+		validation.failures++
+		validation.expiry = now.Add(imageCanaryBackoff(validation.failures))
+		spi.WarningS(ctx, "The image failed to boot on the canary machine", "image", image, "canary", machineName, "reason", reason, "failures", validation.failures)
+	})
+}
+
+// imageCanaryBackoff returns the duration for which an image is rejected after the given number of consecutive failures
+func imageCanaryBackoff(failures int) time.Duration {
+	backoff := imageCanaryInitialBackoff
+	for i := 1; i < failures && backoff < imageCanaryMaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > imageCanaryMaxBackoff {
+		return imageCanaryMaxBackoff
+	}
+	return backoff
+}
+
+// release gives up the pending validation of the canary, e.g. as the creation of the machine failed before the image
+// could be booted, so that the next machine becomes the canary
+func (c *imageCanaries) release(image, machineName string) {
+	if c == nil {
+		return
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if validation, ok := c.validations[image]; ok && validation.canary == machineName && !validation.validated && validation.reason == "" {
+		delete(c.validations, image)
+	}
+}
+
+// complete applies the result of the pending validation of the canary
+func (c *imageCanaries) complete(image, machineName string, apply func(*imageValidation)) {
+	if c == nil {
+		return
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if validation, ok := c.validations[image]; ok && validation.canary == machineName && !validation.validated && validation.reason == "" {
+		apply(validation)
+	}
+}
+
+// isBootFailure returns true if the VM creation failed as the OS of the image did not boot
+func isBootFailure(err error) bool {
+	return bootFailureErrorCodes[serviceErrorCode(err)]
+}
+
+// validateImageBoot waits for the guest agent of the canary VM and records the result of the validation of its image.
+// The guest agent is not waited for if it is not provisioned on the VM, as the successful provisioning of its OS is
+// the only indication of a booted image then.
+func (d *MachinePlugin) validateImageBoot(ctx context.Context, clients spi.AzureDriverClientsInterface, resourceGroupName, vmName, image string) error {
+	if provisionVMAgent := d.AzureProviderSpec.Properties.OsProfile.ProvisionVMAgent; provisionVMAgent != nil && !*provisionVMAgent {
+		d.imageCanaries.validate(ctx, image, vmName)
+		return nil
+	}
+	if err := d.waitForGuestAgentWithin(ctx, clients, resourceGroupName, vmName, d.imageCanaries.timeout); err != nil {
+		if s, ok := err.(*status.Status); ok && s.Code() == codes.DeadlineExceeded && ctx.Err() == nil {
+			d.imageCanaries.fail(ctx, image, vmName, s.Message(), time.Now())
+			return status.Error(codes.FailedPrecondition, s.Message())
+		}
+		return err
+	}
+	d.imageCanaries.validate(ctx, image, vmName)
+	return nil
+}
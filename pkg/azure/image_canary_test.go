@@ -0,0 +1,219 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-08-01/compute"
+	"github.com/Azure/go-autorest/autorest/to"
+	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/mock"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/codes"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/status"
+	gomock "github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ImageCanaries", func() {
+	var (
+		ctx   = context.Background()
+		image = "publisher:offer:sku:1.0.0"
+		now   = time.Now()
+
+		canaries *imageCanaries
+
+		expectCode = func(err error, code codes.Code) {
+			s, ok := status.FromError(err)
+			Expect(ok).To(BeTrue())
+			Expect(s.Code()).To(Equal(code))
+		}
+	)
+
+	BeforeEach(func() {
+		canaries = newImageCanaries(time.Minute)
+	})
+
+	It("should make the first machine the canary and reject other machines until the image is validated", func() {
+		canary, err := canaries.acquire(ctx, image, "machine-1", now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(canary).To(BeTrue())
+
+		canary, err = canaries.acquire(ctx, image, "machine-1", now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(canary).To(BeTrue())
+
+		_, err = canaries.acquire(ctx, image, "machine-2", now)
+		expectCode(err, codes.Unavailable)
+
+		canaries.validate(ctx, image, "machine-1")
+		canary, err = canaries.acquire(ctx, image, "machine-2", now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(canary).To(BeFalse())
+	})
+
+	It("should reject an image which failed to boot until the failure expires", func() {
+		_, err := canaries.acquire(ctx, image, "machine-1", now)
+		Expect(err).NotTo(HaveOccurred())
+		canaries.fail(ctx, image, "machine-1", "OSProvisioningTimedOut", now)
+
+		_, err = canaries.acquire(ctx, image, "machine-2", now)
+		expectCode(err, codes.FailedPrecondition)
+		Expect(err.Error()).To(ContainSubstring("OSProvisioningTimedOut"))
+
+		canary, err := canaries.acquire(ctx, image, "machine-2", now.Add(imageCanaryInitialBackoff+time.Second))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(canary).To(BeTrue())
+	})
+
+	It("should back off exponentially from images which failed to boot repeatedly", func() {
+		_, err := canaries.acquire(ctx, image, "machine-1", now)
+		Expect(err).NotTo(HaveOccurred())
+		canaries.fail(ctx, image, "machine-1", "OSProvisioningTimedOut", now)
+
+		retry := now.Add(imageCanaryInitialBackoff + time.Second)
+		_, err = canaries.acquire(ctx, image, "machine-2", retry)
+		Expect(err).NotTo(HaveOccurred())
+		canaries.fail(ctx, image, "machine-2", "OSProvisioningTimedOut", retry)
+
+		_, err = canaries.acquire(ctx, image, "machine-3", retry.Add(imageCanaryInitialBackoff+time.Second))
+		expectCode(err, codes.FailedPrecondition)
+		canary, err := canaries.acquire(ctx, image, "machine-3", retry.Add(2*imageCanaryInitialBackoff+time.Second))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(canary).To(BeTrue())
+
+		Expect(imageCanaryBackoff(1)).To(Equal(imageCanaryInitialBackoff))
+		Expect(imageCanaryBackoff(3)).To(Equal(4 * imageCanaryInitialBackoff))
+		Expect(imageCanaryBackoff(100)).To(Equal(imageCanaryMaxBackoff))
+	})
+
+	Describe("#seed", func() {
+		var clients *mock.AzureDriverClients
+
+		BeforeEach(func() {
+			sp := mock.NewMockPluginSPIImpl(gomock.NewController(GinkgoT()))
+			_, secret := newProviderSpecCacheFixtures()
+			driverClients, err := sp.Setup(secret, nil)
+			Expect(err).NotTo(HaveOccurred())
+			clients = driverClients.(*mock.AzureDriverClients)
+		})
+
+		It("should consider the images of the running VMs validated once per resource group", func() {
+			vm := func(version, state string) compute.VirtualMachine {
+				return compute.VirtualMachine{VirtualMachineProperties: &compute.VirtualMachineProperties{
+					ProvisioningState: to.StringPtr(state),
+					StorageProfile: &compute.StorageProfile{ImageReference: &compute.ImageReference{
+						Publisher: to.StringPtr("Publisher"), Offer: to.StringPtr("offer"), Sku: to.StringPtr("sku"), Version: to.StringPtr(version),
+					}},
+				}}
+			}
+			clients.VM.EXPECT().ListComplete(gomock.Any(), "rg", "").Return(newVMListIterator(ctx, []compute.VirtualMachine{vm("1.0.0", "Succeeded"), vm("1.1.0", "Failed")}), nil)
+
+			canaries.seed(ctx, clients, "rg")
+			canaries.seed(ctx, clients, "RG")
+
+			canary, err := canaries.acquire(ctx, image, "machine-1", now)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(canary).To(BeFalse())
+			canary, err = canaries.acquire(ctx, "publisher:offer:sku:1.1.0", "machine-1", now)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(canary).To(BeTrue())
+		})
+
+		It("should look up the running VMs again if they could not be listed", func() {
+			clients.VM.EXPECT().ListComplete(gomock.Any(), "rg", "").Return(compute.VirtualMachineListResultIterator{}, errors.New("failed")).Times(2)
+
+			canaries.seed(ctx, clients, "rg")
+			canaries.seed(ctx, clients, "rg")
+		})
+	})
+
+	It("should make the next machine the canary once the pending canary is released or expired", func() {
+		_, err := canaries.acquire(ctx, image, "machine-1", now)
+		Expect(err).NotTo(HaveOccurred())
+		canaries.release(image, "machine-1")
+
+		canary, err := canaries.acquire(ctx, image, "machine-2", now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(canary).To(BeTrue())
+
+		canary, err = canaries.acquire(ctx, image, "machine-3", now.Add(imageCanaryPendingTTL+2*time.Minute))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(canary).To(BeTrue())
+	})
+
+	It("should validate other images independently", func() {
+		_, err := canaries.acquire(ctx, image, "machine-1", now)
+		Expect(err).NotTo(HaveOccurred())
+
+		canary, err := canaries.acquire(ctx, "publisher:offer:sku:1.1.0", "machine-2", now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(canary).To(BeTrue())
+	})
+
+	It("should not validate images if disabled", func() {
+		var disabled *imageCanaries
+		canary, err := disabled.acquire(ctx, image, "machine-1", now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(canary).To(BeFalse())
+	})
+
+	It("should identify images case-insensitively by their ID or URN", func() {
+		Expect(imageKey(api.AzureImageReference{URN: to.StringPtr("Publisher:Offer:SKU:1.0.0")})).To(Equal("publisher:offer:sku:1.0.0"))
+		Expect(imageKey(api.AzureImageReference{ID: "/Subscriptions/s/Images/i"})).To(Equal("/subscriptions/s/images/i"))
+	})
+
+	Describe("#validateImageBoot", func() {
+		var (
+			driver  *MachinePlugin
+			clients *mock.AzureDriverClients
+		)
+
+		BeforeEach(func() {
+			sp := mock.NewMockPluginSPIImpl(gomock.NewController(GinkgoT()))
+			_, secret := newProviderSpecCacheFixtures()
+			driverClients, err := sp.Setup(secret, nil)
+			Expect(err).NotTo(HaveOccurred())
+			clients = driverClients.(*mock.AzureDriverClients)
+
+			driver = NewAzureDriver(sp)
+			driver.AzureProviderSpec = &api.AzureProviderSpec{}
+			driver.guestAgentPollInterval = time.Millisecond
+			driver.imageCanaries = newImageCanaries(20 * time.Millisecond)
+			_, err = driver.imageCanaries.acquire(ctx, image, "machine", now)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should validate the image once the guest agent of the canary is ready", func() {
+			clients.VM.EXPECT().InstanceView(gomock.Any(), "rg", "machine").Return(compute.VirtualMachineInstanceView{VMAgent: &compute.VirtualMachineAgentInstanceView{
+				Statuses: &[]compute.InstanceViewStatus{{DisplayStatus: to.StringPtr(guestAgentReadyStatus)}},
+			}}, nil)
+
+			Expect(driver.validateImageBoot(ctx, clients, "rg", "machine", image)).To(Succeed())
+			canary, err := driver.imageCanaries.acquire(ctx, image, "machine-2", now)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(canary).To(BeFalse())
+		})
+
+		It("should fail the image if the guest agent of the canary does not become ready", func() {
+			clients.VM.EXPECT().InstanceView(gomock.Any(), "rg", "machine").Return(compute.VirtualMachineInstanceView{}, nil).MinTimes(1)
+
+			expectCode(driver.validateImageBoot(ctx, clients, "rg", "machine", image), codes.FailedPrecondition)
+			_, err := driver.imageCanaries.acquire(ctx, image, "machine-2", now)
+			expectCode(err, codes.FailedPrecondition)
+		})
+
+		It("should validate the image without waiting if the guest agent is not provisioned", func() {
+			driver.AzureProviderSpec.Properties.OsProfile.ProvisionVMAgent = to.BoolPtr(false)
+
+			Expect(driver.validateImageBoot(ctx, clients, "rg", "machine", image)).To(Succeed())
+		})
+	})
+})
@@ -0,0 +1,150 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"context"
+	"sort"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-08-01/compute"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/spi"
+	"github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/codes"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/status"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// machineNodeLabel is the label of machines naming their node, as set by the machine controller manager
+const machineNodeLabel = "node"
+
+// ImportManifestRequest is the request to export the import manifest of the existing machines of a machine class
+type ImportManifestRequest struct {
+	// MachineClass whose machines are exported
+	MachineClass *v1alpha1.MachineClass
+	// Secret backing the machineClass object
+	Secret *corev1.Secret
+}
+
+// ImportManifest lists the Azure resources of the existing machines of a machine class along with the machine objects
+// adopting them, e.g. to restore the machine objects after the state of the machine controller manager was lost
+type ImportManifest struct {
+	// Resources are the Azure resources of the machines, ordered by the machine name
+	Resources []ImportedMachineResources `json:"resources"`
+	// Machines are the suggested machine objects of the existing VMs, ordered by their name
+	Machines []v1alpha1.Machine `json:"machines"`
+}
+
+// ImportedMachineResources are the Azure resource IDs of an existing machine
+type ImportedMachineResources struct {
+	// Machine is the name of the machine
+	Machine string `json:"machine"`
+	// VM is the resource ID of the VM
+	VM string `json:"vm"`
+	// NetworkInterfaces are the resource IDs of the NICs of the VM
+	NetworkInterfaces []string `json:"networkInterfaces,omitempty"`
+	// Disks are the resource IDs of the OS disk and the data disks created with the VM. Disks attached later, e.g. the
+	// disks of persistent volumes, are omitted.
+	Disks []string `json:"disks,omitempty"`
+}
+
+// ExportImportManifest returns the import manifest of the VMs carrying the tags of the machine class. VMs handed over
+// to another machine controller instance are omitted. The suggested machine objects reference the machine class and
+// carry the provider ID and node of the VMs, so that the machine controller adopts the VMs instead of recreating them.
+func (d *MachinePlugin) ExportImportManifest(ctx context.Context, req *ImportManifestRequest) (*ImportManifest, error) {
+	ctx = withMachineLogFields(ctx, "ExportImportManifest", "", req.MachineClass)
+	spi.V(2).InfoS(ctx, "Import manifest request has been recieved")
+	defer spi.V(2).InfoS(ctx, "Import manifest request has been processed")
+	ctx = spi.WithMachineClass(ctx, req.MachineClass.Name)
+
+	providerSpec, err := d.decodeProviderSpecAndSecret(req.MachineClass, req.Secret)
+	if err != nil {
+		return nil, err
+	}
+
+	clients, err := d.SPI.Setup(req.Secret, providerSpec.CloudConfiguration)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, err.Error())
+	}
+
+	var vms []compute.VirtualMachine
+	iterator, err := clients.GetVM().ListComplete(ctx, providerSpec.ResourceGroup, "")
+	for err == nil && iterator.NotDone() {
+		if item := iterator.Value(); matchesClassTags(item.Tags, providerSpec.Tags) && !d.ownedByOtherInstance(item.Tags) {
+			vms = append(vms, item)
+		}
+		err = iterator.NextWithContext(ctx)
+	}
+	if err != nil {
+		return nil, machineError(spi.OnARMAPIErrorFail(prometheusServiceVM, err, "VM.List"))
+	}
+	spi.OnARMAPISuccess(prometheusServiceVM, "VM.List")
+	sort.Slice(vms, func(i, j int) bool { return *vms[i].Name < *vms[j].Name })
+
+	manifest := &ImportManifest{Resources: []ImportedMachineResources{}, Machines: []v1alpha1.Machine{}}
+	for _, vm := range vms {
+		manifest.Resources = append(manifest.Resources, importedMachineResources(vm))
+		manifest.Machines = append(manifest.Machines, suggestedMachine(vm, req.MachineClass))
+	}
+	spi.InfoS(ctx, "Import manifest of machine class was exported", "machines", len(vms))
+	return manifest, nil
+}
+
+// importedMachineResources returns the resource IDs of the VM and of the NICs and disks of its model
+func importedMachineResources(vm compute.VirtualMachine) ImportedMachineResources {
+	resources := ImportedMachineResources{Machine: *vm.Name}
+	if vm.ID != nil {
+		resources.VM = *vm.ID
+	}
+	if vm.VirtualMachineProperties == nil {
+		return resources
+	}
+
+	if vm.NetworkProfile != nil && vm.NetworkProfile.NetworkInterfaces != nil {
+		for _, reference := range *vm.NetworkProfile.NetworkInterfaces {
+			if reference.ID != nil {
+				resources.NetworkInterfaces = append(resources.NetworkInterfaces, *reference.ID)
+			}
+		}
+	}
+	if vm.StorageProfile != nil {
+		if osDisk := vm.StorageProfile.OsDisk; osDisk != nil && osDisk.ManagedDisk != nil && osDisk.ManagedDisk.ID != nil {
+			resources.Disks = append(resources.Disks, *osDisk.ManagedDisk.ID)
+		}
+		if vm.StorageProfile.DataDisks != nil {
+			for _, dataDisk := range *vm.StorageProfile.DataDisks {
+				if dataDisk.CreateOption != compute.DiskCreateOptionTypesAttach && dataDisk.ManagedDisk != nil && dataDisk.ManagedDisk.ID != nil {
+					resources.Disks = append(resources.Disks, *dataDisk.ManagedDisk.ID)
+				}
+			}
+		}
+	}
+	return resources
+}
+
+// suggestedMachine returns the machine object of the machine class adopting the VM
+func suggestedMachine(vm compute.VirtualMachine, machineClass *v1alpha1.MachineClass) v1alpha1.Machine {
+	return v1alpha1.Machine{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1alpha1.SchemeGroupVersion.String(),
+			Kind:       "Machine",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      *vm.Name,
+			Namespace: machineClass.Namespace,
+			Labels:    map[string]string{machineNodeLabel: getNodeName(vm)},
+		},
+		Spec: v1alpha1.MachineSpec{
+			Class: v1alpha1.ClassSpec{
+				APIGroup: v1alpha1.SchemeGroupVersion.Group,
+				Kind:     "MachineClass",
+				Name:     machineClass.Name,
+			},
+			ProviderID: encodeMachineID(*vm.Location, *vm.Name),
+		},
+	}
+}
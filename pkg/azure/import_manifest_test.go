@@ -0,0 +1,76 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-08-01/compute"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/mock"
+	gomock "github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ImportManifest", func() {
+	It("should export the resources and suggested machines of the VMs of the machine class", func() {
+		ctx := context.Background()
+		sp := mock.NewMockPluginSPIImpl(gomock.NewController(GinkgoT()))
+		machineClass, secret := newProviderSpecCacheFixtures()
+		driverClients, err := sp.Setup(secret, nil)
+		Expect(err).NotTo(HaveOccurred())
+		clients := driverClients.(*mock.AzureDriverClients)
+
+		providerSpec := UnmarshalProviderSpec(machineClass.ProviderSpec.Raw)
+		classTags := getAzureTags(providerSpec.Tags)
+		prefix := "/subscriptions/sub/resourceGroups/" + providerSpec.ResourceGroup + "/providers/"
+		clients.VM.EXPECT().ListComplete(gomock.Any(), providerSpec.ResourceGroup, "").Return(newVMListIterator(ctx, []compute.VirtualMachine{
+			{Name: to.StringPtr("machine-1"), Location: to.StringPtr("westeurope"), Tags: classTags, ID: to.StringPtr(prefix + "Microsoft.Compute/virtualMachines/machine-1")},
+			{Name: to.StringPtr("foreign"), Location: to.StringPtr("westeurope")},
+			{
+				Name:     to.StringPtr("machine-0"),
+				Location: to.StringPtr("westeurope"),
+				Tags:     classTags,
+				ID:       to.StringPtr(prefix + "Microsoft.Compute/virtualMachines/machine-0"),
+				VirtualMachineProperties: &compute.VirtualMachineProperties{
+					OsProfile: &compute.OSProfile{ComputerName: to.StringPtr("Node-0")},
+					NetworkProfile: &compute.NetworkProfile{NetworkInterfaces: &[]compute.NetworkInterfaceReference{
+						{ID: to.StringPtr(prefix + "Microsoft.Network/networkInterfaces/machine-0-nic")},
+					}},
+					StorageProfile: &compute.StorageProfile{
+						OsDisk: &compute.OSDisk{ManagedDisk: &compute.ManagedDiskParameters{ID: to.StringPtr(prefix + "Microsoft.Compute/disks/machine-0-os-disk")}},
+						DataDisks: &[]compute.DataDisk{
+							{CreateOption: compute.DiskCreateOptionTypesEmpty, ManagedDisk: &compute.ManagedDiskParameters{ID: to.StringPtr(prefix + "Microsoft.Compute/disks/machine-0-0-data-disk")}},
+							{CreateOption: compute.DiskCreateOptionTypesAttach, ManagedDisk: &compute.ManagedDiskParameters{ID: to.StringPtr(prefix + "Microsoft.Compute/disks/pv")}},
+						},
+					},
+				},
+			},
+		}), nil)
+
+		manifest, err := NewAzureDriver(sp).ExportImportManifest(ctx, &ImportManifestRequest{MachineClass: machineClass, Secret: secret})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(manifest.Resources).To(HaveLen(2))
+		Expect(manifest.Resources[0]).To(Equal(ImportedMachineResources{
+			Machine:           "machine-0",
+			VM:                prefix + "Microsoft.Compute/virtualMachines/machine-0",
+			NetworkInterfaces: []string{prefix + "Microsoft.Network/networkInterfaces/machine-0-nic"},
+			Disks:             []string{prefix + "Microsoft.Compute/disks/machine-0-os-disk", prefix + "Microsoft.Compute/disks/machine-0-0-data-disk"},
+		}))
+		Expect(manifest.Resources[1].Machine).To(Equal("machine-1"))
+
+		Expect(manifest.Machines).To(HaveLen(2))
+		machine := manifest.Machines[0]
+		Expect(machine.Name).To(Equal("machine-0"))
+		Expect(machine.Namespace).To(Equal(machineClass.Namespace))
+		Expect(machine.Labels).To(HaveKeyWithValue(machineNodeLabel, "node-0"))
+		Expect(machine.Spec.Class.Name).To(Equal(machineClass.Name))
+		Expect(machine.Spec.ProviderID).To(Equal("azure:///westeurope/machine-0"))
+	})
+})
@@ -0,0 +1,240 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-08-01/compute"
+	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/spi"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/driver"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/codes"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/status"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// inventoryVM is a VM of the inventory
+type inventoryVM struct {
+	name     string
+	nodeName string
+	location string
+	tags     map[string]*string
+}
+
+// vmInventory caches the VMs per subscription and resource group, so that the status of many machines can be
+// determined from a single list request instead of a request per machine. The inventory of a resource group is
+// listed again once it is older than the TTL, and it is refreshed by every listing of the machines. It only holds the
+// VMs carrying the cluster and role tags of the machine class, VMs of other clusters are looked up directly.
+type vmInventory struct {
+	ttl time.Duration
+
+	mutex          sync.Mutex
+	resourceGroups map[string]*resourceGroupInventory
+}
+
+type resourceGroupInventory struct {
+	// mutex serializes the listing of the resource group, so that concurrent status requests share a listing
+	mutex     sync.Mutex
+	expiresAt time.Time
+	vms       map[string]inventoryVM
+	// powerStates are the power states of the VMs read from their instance views, which the listing does not return
+	powerStates map[string]inventoryPowerState
+}
+
+// inventoryPowerState is the power state of a VM of the inventory along with its expiry
+type inventoryPowerState struct {
+	stopped   bool
+	expiresAt time.Time
+}
+
+func newVMInventory(ttl time.Duration) *vmInventory {
+	return &vmInventory{
+		ttl:            ttl,
+		resourceGroups: map[string]*resourceGroupInventory{},
+	}
+}
+
+// inventoryKey returns the key of the resource group in the subscription of the secret
+func inventoryKey(secret *corev1.Secret, resourceGroup string) string {
+	return strings.ToLower(subscriptionID(secret) + "/" + resourceGroup)
+}
+
+// subscriptionID returns the ID of the subscription of the secret
+func subscriptionID(secret *corev1.Secret) string {
+	id := string(secret.Data[api.AzureSubscriptionID])
+	if id == "" {
+		id = string(secret.Data[api.AzureAlternativeSubscriptionID])
+	}
+	return strings.TrimSpace(id)
+}
+
+func (i *vmInventory) resourceGroup(key string) *resourceGroupInventory {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	entry, ok := i.resourceGroups[key]
+	if !ok {
+		entry = &resourceGroupInventory{}
+		i.resourceGroups[key] = entry
+	}
+	return entry
+}
+
+// get returns the VM of the resource group from the inventory, which is listed with the VMs of the class tags if it
+// expired. The second return value is false if the VM is not in the inventory, which may be outdated for VMs created
+// after the last listing.
+func (i *vmInventory) get(ctx context.Context, clients spi.AzureDriverClientsInterface, key, resourceGroup string, classTags map[string]string, vmName string) (inventoryVM, bool, error) {
+	entry := i.resourceGroup(key)
+
+	entry.mutex.Lock()
+	defer entry.mutex.Unlock()
+
+	if time.Now().After(entry.expiresAt) {
+		items, err := listVMs(ctx, clients, resourceGroup, classTags)
+		if err != nil {
+			return inventoryVM{}, false, err
+		}
+		entry.replace(items, time.Now().Add(i.ttl))
+	}
+
+	vm, ok := entry.vms[strings.ToLower(vmName)]
+	return vm, ok, nil
+}
+
+// update replaces the inventory of the resource group with the listed VMs
+func (i *vmInventory) update(key string, items []compute.VirtualMachine) {
+	entry := i.resourceGroup(key)
+
+	entry.mutex.Lock()
+	defer entry.mutex.Unlock()
+	entry.replace(items, time.Now().Add(i.ttl))
+}
+
+// remove removes a deleted VM from the inventory of the resource group
+func (i *vmInventory) remove(key, vmName string) {
+	entry := i.resourceGroup(key)
+
+	entry.mutex.Lock()
+	defer entry.mutex.Unlock()
+	delete(entry.vms, strings.ToLower(vmName))
+	delete(entry.powerStates, strings.ToLower(vmName))
+}
+
+// isStopped returns true if the VM of the resource group is stopped but not deallocated. The power state is read from
+// the instance view of the VM and kept for the TTL of the inventory, so that it is not read on every status request.
+func (i *vmInventory) isStopped(ctx context.Context, clients spi.AzureDriverClientsInterface, key, resourceGroup, vmName string) (bool, error) {
+	entry := i.resourceGroup(key)
+	name := strings.ToLower(vmName)
+
+	entry.mutex.Lock()
+	powerState, ok := entry.powerStates[name]
+	entry.mutex.Unlock()
+	if ok && time.Now().Before(powerState.expiresAt) {
+		return powerState.stopped, nil
+	}
+
+	stopped, err := isVMStopped(ctx, clients, resourceGroup, vmName)
+	if err != nil {
+		return false, err
+	}
+	i.setStopped(key, vmName, stopped)
+	return stopped, nil
+}
+
+// setStopped records the power state of the VM of the resource group, e.g. after it was started
+func (i *vmInventory) setStopped(key, vmName string, stopped bool) {
+	entry := i.resourceGroup(key)
+
+	entry.mutex.Lock()
+	defer entry.mutex.Unlock()
+	if entry.powerStates == nil {
+		entry.powerStates = map[string]inventoryPowerState{}
+	}
+	entry.powerStates[strings.ToLower(vmName)] = inventoryPowerState{stopped: stopped, expiresAt: time.Now().Add(i.ttl)}
+}
+
+func (e *resourceGroupInventory) replace(items []compute.VirtualMachine, expiresAt time.Time) {
+	e.vms = make(map[string]inventoryVM, len(items))
+	for _, item := range items {
+		if item.Name == nil || item.Location == nil {
+			continue
+		}
+		e.vms[strings.ToLower(*item.Name)] = inventoryVM{name: *item.Name, nodeName: getNodeName(item), location: *item.Location, tags: item.Tags}
+	}
+	for name := range e.powerStates {
+		if _, ok := e.vms[name]; !ok {
+			delete(e.powerStates, name)
+		}
+	}
+	e.expiresAt = expiresAt
+}
+
+// listVMs lists the VMs of the resource group which carry the cluster and role tags of the machine class
+func listVMs(ctx context.Context, clients spi.AzureDriverClientsInterface, resourceGroup string, classTags map[string]string) ([]compute.VirtualMachine, error) {
+	var items []compute.VirtualMachine
+	iterator, err := clients.GetVM().ListComplete(ctx, resourceGroup, "")
+	for err == nil && iterator.NotDone() {
+		if item := iterator.Value(); matchesClassTags(item.Tags, classTags) {
+			items = append(items, item)
+		}
+		err = iterator.NextWithContext(ctx)
+	}
+	if err != nil {
+		return nil, spi.OnARMAPIErrorFail(prometheusServiceVM, err, "VM.List")
+	}
+	spi.OnARMAPISuccess(prometheusServiceVM, "VM.List")
+	return items, nil
+}
+
+// getMachineStatusFromInventory returns the status of the machine from the VM inventory. VMs which are not in the
+// inventory, e.g. as they were created after the last listing, are looked up directly.
+func (d *MachinePlugin) getMachineStatusFromInventory(ctx context.Context, req *driver.GetMachineStatusRequest) (*driver.GetMachineStatusResponse, error) {
+	ctx = spi.WithMachineClass(ctx, req.MachineClass.Name)
+
+	providerSpec, err := d.decodeProviderSpecAndSecret(req.MachineClass, req.Secret)
+	if err != nil {
+		return nil, err
+	}
+	clients, err := d.SPI.Setup(req.Secret, providerSpec.CloudConfiguration)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, err.Error())
+	}
+
+	var (
+		resourceGroupName = providerSpec.ResourceGroup
+		vmName            = strings.ToLower(req.Machine.Name)
+	)
+
+	vm, ok, err := d.vmInventory.get(ctx, clients, inventoryKey(req.Secret, resourceGroupName), resourceGroupName, providerSpec.Tags, vmName)
+	if err != nil {
+		spi.WarningS(ctx, "VM inventory of resource group could not be listed, looking up VM directly", spi.LogKeyResourceGroup, resourceGroupName, "vm", vmName, "err", err)
+	}
+	if !ok {
+		item, err := clients.GetVM().Get(ctx, resourceGroupName, vmName, "")
+		if err != nil {
+			if spi.NotFound(err) {
+				return nil, status.Error(codes.NotFound, fmt.Sprintf("Machine '%s' not found", req.Machine.Name))
+			}
+			return nil, machineError(spi.OnARMAPIErrorFail(prometheusServiceVM, err, "VM.Get failed for %s", vmName))
+		}
+		spi.OnARMAPISuccess(prometheusServiceVM, "VM.Get")
+		vm = inventoryVM{name: *item.Name, nodeName: getNodeName(item), location: *item.Location, tags: item.Tags}
+	}
+
+	if vm.name != req.Machine.Name || d.ownedByOtherInstance(vm.tags) {
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("Machine '%s' not found", req.Machine.Name))
+	}
+	return &driver.GetMachineStatusResponse{
+		NodeName:   vm.nodeName,
+		ProviderID: encodeMachineID(vm.location, vm.name),
+	}, nil
+}
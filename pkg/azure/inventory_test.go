@@ -0,0 +1,121 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-08-01/compute"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/mock"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/driver"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/codes"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/status"
+	gomock "github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var _ = Describe("VMInventory", func() {
+	var (
+		ctx = context.Background()
+		key = "sub/rg"
+
+		clients   *mock.AzureDriverClients
+		inventory *vmInventory
+	)
+
+	BeforeEach(func() {
+		spi := mock.NewMockPluginSPIImpl(gomock.NewController(GinkgoT()))
+		driverClients, err := spi.Setup(&corev1.Secret{}, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		clients = driverClients.(*mock.AzureDriverClients)
+		inventory = newVMInventory(time.Hour)
+	})
+
+	It("should list the VMs of the class tags once for all VMs", func() {
+		classTags := map[string]string{"kubernetes.io-cluster-shoot": "1", "kubernetes.io-role-node": "1"}
+		tags := map[string]*string{"kubernetes.io-cluster-shoot": to.StringPtr("1"), "kubernetes.io-role-node": to.StringPtr("1")}
+		clients.VM.EXPECT().ListComplete(ctx, "rg", "").Return(newVMListIterator(ctx, []compute.VirtualMachine{
+			{Name: to.StringPtr("machine-0"), Location: to.StringPtr("westeurope"), Tags: tags},
+			{Name: to.StringPtr("machine-1"), Location: to.StringPtr("westeurope"), Tags: tags},
+			{Name: to.StringPtr("other-cluster"), Location: to.StringPtr("westeurope")},
+		}), nil).Times(1)
+
+		vm, ok, err := inventory.get(ctx, clients, key, "rg", classTags, "machine-0")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(vm.location).To(Equal("westeurope"))
+
+		_, ok, err = inventory.get(ctx, clients, key, "rg", classTags, "Machine-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+
+		_, ok, err = inventory.get(ctx, clients, key, "rg", classTags, "other-cluster")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeFalse())
+
+		inventory.remove(key, "machine-1")
+		_, ok, err = inventory.get(ctx, clients, key, "rg", classTags, "machine-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should use the VMs of the last listing of the machines", func() {
+		inventory.update(key, []compute.VirtualMachine{{Name: to.StringPtr("machine-0"), Location: to.StringPtr("westeurope")}})
+
+		_, ok, err := inventory.get(ctx, clients, key, "rg", nil, "machine-0")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+	})
+
+	Describe("#getMachineStatusFromInventory", func() {
+		var (
+			plugin *MachinePlugin
+			req    *driver.GetMachineStatusRequest
+			rg     = "shoot--i538135--seed-az"
+		)
+
+		BeforeEach(func() {
+			sp := mock.NewMockPluginSPIImpl(gomock.NewController(GinkgoT()))
+			machineClass, secret := newProviderSpecCacheFixtures()
+			driverClients, err := sp.Setup(secret, nil)
+			Expect(err).NotTo(HaveOccurred())
+			clients = driverClients.(*mock.AzureDriverClients)
+
+			plugin = NewAzureDriver(sp)
+			plugin.vmInventory = inventory
+			req = &driver.GetMachineStatusRequest{Machine: newMachine("machine"), MachineClass: machineClass, Secret: secret}
+			inventory.update(inventoryKey(secret, rg), nil)
+		})
+
+		It("should look up VMs which are not in the inventory directly", func() {
+			clients.VM.EXPECT().Get(gomock.Any(), rg, "machine", compute.InstanceViewTypes("")).Return(compute.VirtualMachine{
+				Name:     to.StringPtr("machine"),
+				Location: to.StringPtr("westeurope"),
+			}, nil)
+
+			response, err := plugin.getMachineStatusFromInventory(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.ProviderID).To(Equal(encodeMachineID("westeurope", "machine")))
+		})
+
+		It("should return NotFound if the VM is neither in the inventory nor in the resource group", func() {
+			clients.VM.EXPECT().Get(gomock.Any(), rg, "machine", compute.InstanceViewTypes("")).Return(compute.VirtualMachine{}, autorest.DetailedError{Response: &http.Response{StatusCode: http.StatusNotFound}})
+
+			_, err := plugin.getMachineStatusFromInventory(ctx, req)
+			s, ok := status.FromError(err)
+			Expect(ok).To(BeTrue())
+			Expect(s.Code()).To(Equal(codes.NotFound))
+		})
+	})
+})
@@ -0,0 +1,130 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/codes"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/status"
+)
+
+const (
+	// lastOperationPhaseCreate is the phase of the last operation of machines failing to be created
+	lastOperationPhaseCreate = "Create"
+	// lastOperationPhaseDelete is the phase of the last operation of machines failing to be deleted
+	lastOperationPhaseDelete = "Delete"
+)
+
+// lastOperationBracketReplacer replaces the brackets of error messages. The machine controller manager decodes the
+// code and message of errors from the two bracketed fields of their string, which fails for messages with brackets,
+// e.g. the details of Azure service errors.
+var lastOperationBracketReplacer = strings.NewReplacer("[", "(", "]", ")")
+
+// lastOperationDetail describes the failed Azure operation of a machine. The machine controller manager sets the
+// message of the error as description of the last operation of the machine, so that the detail surfaces in the status
+// of the machine, and via its failed machines in the status of the machine set and deployment.
+type lastOperationDetail struct {
+	// phase is the machine operation, e.g. Create
+	phase string
+	// operation is the failed Azure API operation, e.g. compute.VirtualMachinesClient.CreateOrUpdate
+	operation string
+	// errorClass is the Azure error code, or the machine code if the error has none
+	errorClass string
+	// target is the part of the request the Azure error refers to, e.g. a property of the VM
+	target string
+	// retryable is false if the operation fails again unless the machine class or the Azure configuration changes
+	retryable bool
+	// correlationID identifies the failed request towards the Azure support
+	correlationID string
+}
+
+// newLastOperationDetail returns the detail of the failed Azure operation of the error with the given machine code
+func newLastOperationDetail(phase string, code codes.Code, err error) lastOperationDetail {
+	detail := lastOperationDetail{
+		phase:      phase,
+		errorClass: serviceErrorCode(err),
+		retryable:  isRetryableCode(code),
+	}
+	if detail.errorClass == "" {
+		detail.errorClass = code.String()
+	}
+	if serviceErr := serviceError(err); serviceErr != nil && serviceErr.Target != nil {
+		detail.target = *serviceErr.Target
+	}
+	if detailedErr, ok := err.(autorest.DetailedError); ok {
+		if detailedErr.PackageType != "" && detailedErr.Method != "" {
+			detail.operation = detailedErr.PackageType + "." + detailedErr.Method
+		}
+		if detailedErr.Response != nil {
+			detail.correlationID = detailedErr.Response.Header.Get("X-Ms-Correlation-Request-Id")
+		}
+	}
+	return detail
+}
+
+// String returns the detail as space-separated key-value pairs, omitting the unknown values
+func (l lastOperationDetail) String() string {
+	fields := []string{"phase=" + l.phase}
+	if l.operation != "" {
+		fields = append(fields, "operation="+l.operation)
+	}
+	fields = append(fields, "errorClass="+l.errorClass)
+	if l.target != "" {
+		fields = append(fields, "target="+l.target)
+	}
+	fields = append(fields, fmt.Sprintf("retryable=%t", l.retryable))
+	if l.correlationID != "" {
+		fields = append(fields, "correlationID="+l.correlationID)
+	}
+	return strings.Join(fields, " ")
+}
+
+// operationErrorMessage returns the message of the error of an Azure operation. Azure service errors are described by
+// their code, message and the codes and messages of their details, e.g. "OperationNotAllowed: Operation could not be
+// completed as it results in exceeding approved standardDSv3Family Cores quota.", instead of the message of the
+// wrapping autorest error, which mainly consists of the request. Other errors keep their message.
+func operationErrorMessage(err error) string {
+	serviceErr := serviceError(err)
+	if serviceErr == nil || serviceErr.Code == "" {
+		return err.Error()
+	}
+
+	message := serviceErr.Code
+	if serviceErr.Message != "" {
+		message += ": " + serviceErr.Message
+	}
+	for _, detail := range serviceErr.Details {
+		code, _ := detail["code"].(string)
+		detailMessage, _ := detail["message"].(string)
+		switch {
+		case code != "" && detailMessage != "":
+			message += "; " + code + ": " + detailMessage
+		case code != "" || detailMessage != "":
+			message += "; " + code + detailMessage
+		}
+	}
+	return message
+}
+
+// isRetryableCode returns false for machine codes of errors which persist until the machine class or the Azure
+// configuration changes
+func isRetryableCode(code codes.Code) bool {
+	switch code {
+	case codes.InvalidArgument, codes.PermissionDenied, codes.FailedPrecondition, codes.AlreadyExists, codes.Unimplemented:
+		return false
+	}
+	return true
+}
+
+// lastOperationError returns the error of a machine operation with the given machine code and message, followed by
+// the detail of the failed Azure operation
+func lastOperationError(code codes.Code, message string, detail lastOperationDetail) error {
+	return status.Error(code, lastOperationBracketReplacer.Replace(fmt.Sprintf("%s (%s)", message, detail)))
+}
@@ -0,0 +1,75 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/codes"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/status"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LastOperation", func() {
+	It("should describe the failed Azure operation", func() {
+		err := autorest.DetailedError{
+			Original:    &azure.RequestError{ServiceError: &azure.ServiceError{Code: "QuotaExceeded", Message: "quota exceeded"}},
+			PackageType: "compute.VirtualMachinesClient",
+			Method:      "CreateOrUpdate",
+			Response:    &http.Response{StatusCode: http.StatusConflict, Header: http.Header{"X-Ms-Correlation-Request-Id": []string{"1234"}}},
+		}
+
+		Expect(newLastOperationDetail(lastOperationPhaseCreate, codes.ResourceExhausted, err).String()).To(Equal(
+			"phase=Create operation=compute.VirtualMachinesClient.CreateOrUpdate errorClass=QuotaExceeded retryable=true correlationID=1234"))
+	})
+
+	It("should describe the target of the Azure error", func() {
+		err := autorest.DetailedError{Original: &azure.ServiceError{Code: "InvalidParameter", Target: to.StringPtr("osProfile.adminUsername")}}
+
+		Expect(newLastOperationDetail(lastOperationPhaseCreate, codes.InvalidArgument, err).String()).To(Equal(
+			"phase=Create errorClass=InvalidParameter target=osProfile.adminUsername retryable=false"))
+	})
+
+	It("should describe Azure errors by their code, message and details", func() {
+		err := autorest.DetailedError{
+			Original: &azure.RequestError{ServiceError: &azure.ServiceError{
+				Code:    "OperationNotAllowed",
+				Message: "quota exceeded for family standardDSv3",
+				Details: []map[string]interface{}{{"code": "QuotaExceeded", "message": "12 of 10 cores"}, {"message": "see aka.ms/quota"}},
+			}},
+			PackageType: "compute.VirtualMachinesClient",
+			Method:      "CreateOrUpdate",
+		}
+
+		Expect(operationErrorMessage(err)).To(Equal("OperationNotAllowed: quota exceeded for family standardDSv3; QuotaExceeded: 12 of 10 cores; see aka.ms/quota"))
+		Expect(operationErrorMessage(errors.New("timeout"))).To(Equal("timeout"))
+
+		s, ok := status.FromError(machineError(err))
+		Expect(ok).To(BeTrue())
+		Expect(s.Message()).To(HavePrefix("OperationNotAllowed: quota exceeded"))
+	})
+
+	It("should fall back to the machine code for errors which are not Azure errors", func() {
+		Expect(newLastOperationDetail(lastOperationPhaseDelete, codes.InvalidArgument, errors.New("invalid")).String()).To(Equal(
+			"phase=Delete errorClass=InvalidArgument retryable=false"))
+	})
+
+	It("should return errors which the machine controller manager can decode", func() {
+		detail := newLastOperationDetail(lastOperationPhaseCreate, codes.Unavailable, errors.New("allocation failed"))
+		err := lastOperationError(codes.Unavailable, "allocation failed: Details=[zone 1]", detail)
+
+		s, ok := status.FromError(err)
+		Expect(ok).To(BeTrue())
+		Expect(s.Code()).To(Equal(codes.Unavailable))
+		Expect(s.Message()).To(Equal("allocation failed: Details=(zone 1) (phase=Create errorClass=Unavailable retryable=true)"))
+	})
+})
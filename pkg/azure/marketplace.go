@@ -0,0 +1,165 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-08-01/compute"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/spi"
+	"github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/codes"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/status"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// marketplaceAgreementTTL is the duration for which an accepted marketplace agreement is not checked again, so that
+// terms cancelled by an administrator are noticed eventually
+const marketplaceAgreementTTL = time.Hour
+
+// marketplacePurchaseDeniedErrorCodes are the Azure error codes indicating that the terms of a marketplace plan cannot
+// be accepted, as purchases are disabled by a policy of the tenant or billing account
+var marketplacePurchaseDeniedErrorCodes = map[string]bool{
+	"MarketplacePurchaseEligibilityFailed": true,
+	"ResourcePurchaseValidationFailed":     true,
+	"PurchaseNotAllowed":                   true,
+}
+
+// isMarketplacePurchaseDenied returns true if the error indicates that the marketplace terms cannot be accepted for
+// the subscription. Retrying does not help until an administrator accepts the terms or allows the purchase. Other
+// errors, e.g. missing permissions of the service principal, are not considered a denied purchase.
+func isMarketplacePurchaseDenied(err error) bool {
+	return marketplacePurchaseDeniedErrorCodes[serviceErrorCode(err)]
+}
+
+// onMarketplacePurchaseDenied reports that the terms of the plan of the image cannot be accepted with a warning event on
+// the machine. If the image reference allows it, the plan is removed from the image, so that the VM is created
+// without it. Otherwise, a failed precondition error is returned.
+func (d *MachinePlugin) onMarketplacePurchaseDenied(ctx context.Context, machine *v1alpha1.Machine, image *compute.VirtualMachineImage, err error) error {
+	var (
+		plan    = image.Plan
+		planRef = fmt.Sprintf("%s/%s/%s", *plan.Publisher, *plan.Product, *plan.Name)
+		message = fmt.Sprintf("The marketplace terms of plan %s cannot be accepted for the subscription, as purchases are denied by a policy. An administrator needs to accept the terms, e.g. with 'az vm image terms accept', or allow marketplace purchases", planRef)
+	)
+	_ = spi.OnARMAPIErrorFail(prometheusServiceVM, err, "MarketplaceAgreements.Create was denied for %s", planRef)
+	if d.Recorder != nil {
+		d.Recorder.Event(machine, corev1.EventTypeWarning, "MarketplacePurchaseDenied", message)
+	}
+
+	if d.AzureProviderSpec.Properties.StorageProfile.ImageReference.SkipPlanIfAgreementDenied {
+		spi.WarningS(ctx, "Creating VM without plan, as the marketplace terms cannot be accepted", "plan", planRef)
+		image.Plan = nil
+		return nil
+	}
+	return status.Error(codes.FailedPrecondition, fmt.Sprintf("%s: %v", message, err))
+}
+
+// marketplaceAgreements caches the marketplace plans whose terms are accepted per subscription, so that the agreement
+// is not fetched again for every machine created with the image
+type marketplaceAgreements struct {
+	ttl time.Duration
+
+	mutex    sync.Mutex
+	accepted map[string]time.Time
+}
+
+func newMarketplaceAgreements(ttl time.Duration) *marketplaceAgreements {
+	return &marketplaceAgreements{
+		ttl:      ttl,
+		accepted: map[string]time.Time{},
+	}
+}
+
+// marketplaceAgreementKey returns the key of the agreement of the plan in the subscription
+func marketplaceAgreementKey(subscriptionID string, plan *compute.PurchasePlan) string {
+	return strings.ToLower(strings.Join([]string{subscriptionID, to.String(plan.Publisher), to.String(plan.Product), to.String(plan.Name)}, "/"))
+}
+
+// isAccepted returns true if the agreement was accepted within the TTL
+func (a *marketplaceAgreements) isAccepted(key string, now time.Time) bool {
+	if a == nil {
+		return false
+	}
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	expiry, ok := a.accepted[key]
+	return ok && now.Before(expiry)
+}
+
+// setAccepted records that the agreement is accepted
+func (a *marketplaceAgreements) setAccepted(key string, now time.Time) {
+	if a == nil {
+		return
+	}
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	for k, expiry := range a.accepted {
+		if now.After(expiry) {
+			delete(a.accepted, k)
+		}
+	}
+	a.accepted[key] = now.Add(a.ttl)
+}
+
+// ensureMarketplaceAgreement accepts the terms of the marketplace plan of the image for the subscription if they are
+// not accepted yet. Machine classes which disable the automatic acceptance fail with a failed precondition error
+// instead, as accepting the terms may start billing for the plan.
+func (d *MachinePlugin) ensureMarketplaceAgreement(ctx context.Context, clients spi.AzureDriverClientsInterface, machine *v1alpha1.Machine, image *compute.VirtualMachineImage, machineClassName string) error {
+	var (
+		plan = image.Plan
+		key  = marketplaceAgreementKey(subscriptionID(d.Secret), plan)
+	)
+	if d.marketplaceAgreements.isAccepted(key, time.Now()) {
+		return nil
+	}
+
+	agreement, err := clients.GetMarketplace().Get(ctx, *plan.Publisher, *plan.Product, *plan.Name)
+	if err != nil {
+		return spi.OnARMAPIErrorFail(prometheusServiceVM, err, "MarketplaceAgreementsclient.Get failed for %s", machineClassName)
+	}
+	if agreement.Accepted != nil && *agreement.Accepted {
+		d.marketplaceAgreements.setAccepted(key, time.Now())
+		return nil
+	}
+
+	if d.AzureProviderSpec.Properties.StorageProfile.ImageReference.DisableAgreementAcceptance {
+		return d.onMarketplaceAgreementNotAccepted(machine, image)
+	}
+
+	// Need to accept the terms at least once for the subscription
+	spi.V(2).InfoS(ctx, "Accepting terms for subscription to make use of the plan", "plan", plan.Name)
+	agreement.Accepted = to.BoolPtr(true)
+	_, err = clients.GetMarketplace().Create(ctx, *plan.Publisher, *plan.Product, *plan.Name, agreement)
+	if isMarketplacePurchaseDenied(err) {
+		return d.onMarketplacePurchaseDenied(ctx, machine, image, err)
+	} else if err != nil {
+		return spi.OnARMAPIErrorFail(prometheusServiceVM, err, "MarketplaceAgreementsclientutils.Create failed for %s", machineClassName)
+	}
+	d.marketplaceAgreements.setAccepted(key, time.Now())
+	return nil
+}
+
+// onMarketplaceAgreementNotAccepted reports that the terms of the plan of the image are not accepted with a warning
+// event on the machine, and returns a failed precondition error, as the machine class disables their acceptance
+func (d *MachinePlugin) onMarketplaceAgreementNotAccepted(machine *v1alpha1.Machine, image *compute.VirtualMachineImage) error {
+	var (
+		plan    = image.Plan
+		planRef = fmt.Sprintf("%s/%s/%s", *plan.Publisher, *plan.Product, *plan.Name)
+		message = fmt.Sprintf("The marketplace terms of plan %s are not accepted for the subscription and their automatic acceptance is disabled by the machine class. An administrator needs to accept the terms, e.g. with 'az vm image terms accept'", planRef)
+	)
+	if d.Recorder != nil {
+		d.Recorder.Event(machine, corev1.EventTypeWarning, "MarketplaceAgreementNotAccepted", message)
+	}
+	return status.Error(codes.FailedPrecondition, message)
+}
@@ -0,0 +1,148 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-08-01/compute"
+	"github.com/Azure/azure-sdk-for-go/services/marketplaceordering/mgmt/2015-06-01/marketplaceordering"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/go-autorest/autorest/to"
+	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/mock"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/codes"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/status"
+	gomock "github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/client-go/tools/record"
+)
+
+var _ = Describe("Marketplace", func() {
+	var denied = autorest.DetailedError{
+		Original: &azure.ServiceError{Code: "MarketplacePurchaseEligibilityFailed"},
+		Response: &http.Response{StatusCode: http.StatusBadRequest},
+	}
+
+	Describe("#isMarketplacePurchaseDenied", func() {
+		It("should detect denied purchases", func() {
+			Expect(isMarketplacePurchaseDenied(denied)).To(BeTrue())
+		})
+
+		It("should not detect other errors", func() {
+			Expect(isMarketplacePurchaseDenied(nil)).To(BeFalse())
+			Expect(isMarketplacePurchaseDenied(errors.New("timeout"))).To(BeFalse())
+			Expect(isMarketplacePurchaseDenied(autorest.DetailedError{Response: &http.Response{StatusCode: http.StatusInternalServerError}})).To(BeFalse())
+			Expect(isMarketplacePurchaseDenied(autorest.DetailedError{Response: &http.Response{StatusCode: http.StatusForbidden}})).To(BeFalse())
+			Expect(isMarketplacePurchaseDenied(autorest.DetailedError{
+				Original: &azure.ServiceError{Code: "AuthorizationFailed"},
+				Response: &http.Response{StatusCode: http.StatusForbidden},
+			})).To(BeFalse())
+		})
+	})
+
+	Describe("#onMarketplacePurchaseDenied", func() {
+		var (
+			driver   *MachinePlugin
+			recorder *record.FakeRecorder
+			image    *compute.VirtualMachineImage
+		)
+
+		BeforeEach(func() {
+			recorder = record.NewFakeRecorder(1)
+			driver = &MachinePlugin{AzureProviderSpec: &api.AzureProviderSpec{}, Recorder: recorder}
+			image = &compute.VirtualMachineImage{VirtualMachineImageProperties: &compute.VirtualMachineImageProperties{
+				Plan: &compute.PurchasePlan{Publisher: to.StringPtr("publisher"), Product: to.StringPtr("product"), Name: to.StringPtr("plan")},
+			}}
+		})
+
+		It("should fail with a failed precondition and an event", func() {
+			err := driver.onMarketplacePurchaseDenied(context.Background(), newMachine("machine"), image, denied)
+
+			s, ok := status.FromError(err)
+			Expect(ok).To(BeTrue())
+			Expect(s.Code()).To(Equal(codes.FailedPrecondition))
+			Expect(image.Plan).NotTo(BeNil())
+			Expect(recorder.Events).To(Receive(ContainSubstring("MarketplacePurchaseDenied")))
+		})
+
+		It("should remove the plan if configured", func() {
+			driver.AzureProviderSpec.Properties.StorageProfile.ImageReference.SkipPlanIfAgreementDenied = true
+
+			Expect(driver.onMarketplacePurchaseDenied(context.Background(), newMachine("machine"), image, denied)).To(Succeed())
+			Expect(image.Plan).To(BeNil())
+		})
+	})
+
+	Describe("#ensureMarketplaceAgreement", func() {
+		var (
+			ctx      = context.Background()
+			driver   *MachinePlugin
+			clients  *mock.AzureDriverClients
+			recorder *record.FakeRecorder
+			image    *compute.VirtualMachineImage
+
+			terms = func(accepted bool) marketplaceordering.AgreementTerms {
+				return marketplaceordering.AgreementTerms{AgreementProperties: &marketplaceordering.AgreementProperties{Accepted: to.BoolPtr(accepted)}}
+			}
+		)
+
+		BeforeEach(func() {
+			sp := mock.NewMockPluginSPIImpl(gomock.NewController(GinkgoT()))
+			_, secret := newProviderSpecCacheFixtures()
+			driverClients, err := sp.Setup(secret, nil)
+			Expect(err).NotTo(HaveOccurred())
+			clients = driverClients.(*mock.AzureDriverClients)
+
+			recorder = record.NewFakeRecorder(1)
+			driver = NewAzureDriver(sp)
+			driver.AzureProviderSpec = &api.AzureProviderSpec{}
+			driver.Secret = secret
+			driver.Recorder = recorder
+			image = &compute.VirtualMachineImage{VirtualMachineImageProperties: &compute.VirtualMachineImageProperties{
+				Plan: &compute.PurchasePlan{Publisher: to.StringPtr("publisher"), Product: to.StringPtr("product"), Name: to.StringPtr("plan")},
+			}}
+		})
+
+		It("should accept the terms once and cache the accepted agreement", func() {
+			clients.Marketplace.EXPECT().Get(ctx, "publisher", "product", "plan").Return(terms(false), nil)
+			clients.Marketplace.EXPECT().Create(ctx, "publisher", "product", "plan", terms(true)).Return(terms(true), nil)
+
+			Expect(driver.ensureMarketplaceAgreement(ctx, clients, newMachine("machine-1"), image, "class")).To(Succeed())
+			Expect(driver.ensureMarketplaceAgreement(ctx, clients, newMachine("machine-2"), image, "class")).To(Succeed())
+		})
+
+		It("should cache agreements which are accepted already", func() {
+			clients.Marketplace.EXPECT().Get(ctx, "publisher", "product", "plan").Return(terms(true), nil)
+
+			Expect(driver.ensureMarketplaceAgreement(ctx, clients, newMachine("machine-1"), image, "class")).To(Succeed())
+			Expect(driver.ensureMarketplaceAgreement(ctx, clients, newMachine("machine-2"), image, "class")).To(Succeed())
+		})
+
+		It("should fail with a failed precondition and an event instead of accepting the terms if disabled", func() {
+			driver.AzureProviderSpec.Properties.StorageProfile.ImageReference.DisableAgreementAcceptance = true
+			clients.Marketplace.EXPECT().Get(ctx, "publisher", "product", "plan").Return(terms(false), nil).Times(2)
+
+			for i := 0; i < 2; i++ {
+				err := driver.ensureMarketplaceAgreement(ctx, clients, newMachine("machine"), image, "class")
+				s, ok := status.FromError(err)
+				Expect(ok).To(BeTrue())
+				Expect(s.Code()).To(Equal(codes.FailedPrecondition))
+				Expect(s.Message()).To(ContainSubstring("publisher/product/plan"))
+				Expect(recorder.Events).To(Receive(ContainSubstring("MarketplaceAgreementNotAccepted")))
+			}
+		})
+
+		It("should cache agreements per subscription", func() {
+			Expect(marketplaceAgreementKey("a", image.Plan)).NotTo(Equal(marketplaceAgreementKey("b", image.Plan)))
+		})
+	})
+})
@@ -14,7 +14,7 @@ import (
 	context "context"
 	reflect "reflect"
 
-	resources "github.com/Azure/azure-sdk-for-go/profiles/latest/resources/mgmt/resources"
+	resources "github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-05-01/resources"
 	gomock "github.com/golang/mock/gomock"
 )
 
@@ -55,3 +55,41 @@ func (mr *MockGroupsClientAPIMockRecorder) Get(ctx, resourceGroupName interface{
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockGroupsClientAPI)(nil).Get), ctx, resourceGroupName)
 }
+
+// MockResourcesClientAPI is a mock of ResourcesClientAPI interface
+type MockResourcesClientAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockResourcesClientAPIMockRecorder
+}
+
+// MockResourcesClientAPIMockRecorder is the mock recorder for MockResourcesClientAPI
+type MockResourcesClientAPIMockRecorder struct {
+	mock *MockResourcesClientAPI
+}
+
+// NewMockResourcesClientAPI creates a new mock instance
+func NewMockResourcesClientAPI(ctrl *gomock.Controller) *MockResourcesClientAPI {
+	mock := &MockResourcesClientAPI{ctrl: ctrl}
+	mock.recorder = &MockResourcesClientAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockResourcesClientAPI) EXPECT() *MockResourcesClientAPIMockRecorder {
+	return m.recorder
+}
+
+// GetByID mocks base method
+func (m *MockResourcesClientAPI) GetByID(ctx context.Context, resourceID, APIVersion string) (resources.GenericResource, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, resourceID, APIVersion)
+	ret0, _ := ret[0].(resources.GenericResource)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID
+func (mr *MockResourcesClientAPIMockRecorder) GetByID(ctx, resourceID, APIVersion interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockResourcesClientAPI)(nil).GetByID), ctx, resourceID, APIVersion)
+}
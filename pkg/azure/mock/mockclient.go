@@ -11,7 +11,7 @@ import (
 
 	"github.com/gardener/machine-controller-manager-provider-azure/pkg/spi"
 
-	computeapi "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2019-12-01/compute/computeapi"
+	computeapi "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-08-01/compute/computeapi"
 	marketplaceorderingapi "github.com/Azure/azure-sdk-for-go/services/marketplaceordering/mgmt/2015-06-01/marketplaceordering/marketplaceorderingapi"
 	networkapi "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-04-01/network/networkapi"
 	"github.com/Azure/go-autorest/autorest"
@@ -29,13 +29,22 @@ import (
 
 // AzureDriverClients . . .
 type AzureDriverClients struct {
-	Subnet      *mock_networkapi.MockSubnetsClientAPI
-	NIC         *mock_networkapi.MockInterfacesClientAPI
-	VM          *mock_computeapi.MockVirtualMachinesClientAPI
-	Disk        *mock_computeapi.MockDisksClientAPI
-	Group       *mock_resourcesapi.MockGroupsClientAPI
-	Images      *mock_computeapi.MockVirtualMachineImagesClientAPI
-	Marketplace *mock_marketplaceorderingapi.MockMarketplaceAgreementsClientAPI
+	Subnet           *mock_networkapi.MockSubnetsClientAPI
+	VirtualNetworks  *mock_networkapi.MockVirtualNetworksClientAPI
+	NIC              *mock_networkapi.MockInterfacesClientAPI
+	VM               *mock_computeapi.MockVirtualMachinesClientAPI
+	Disk             *mock_computeapi.MockDisksClientAPI
+	Group            *mock_resourcesapi.MockGroupsClientAPI
+	Images           *mock_computeapi.MockVirtualMachineImagesClientAPI
+	Marketplace      *mock_marketplaceorderingapi.MockMarketplaceAgreementsClientAPI
+	Skus             *mock_computeapi.MockResourceSkusClientAPI
+	PublicIP         *mock_networkapi.MockPublicIPAddressesClientAPI
+	Resources        *mock_resourcesapi.MockResourcesClientAPI
+	Extensions       *mock_computeapi.MockVirtualMachineExtensionsClientAPI
+	AvailabilitySets *mock_computeapi.MockAvailabilitySetsClientAPI
+	Usage            *mock_computeapi.MockUsageClientAPI
+	// Client is the autorest client, e.g. to send the requests for the results of long running operations
+	Client autorest.Client
 
 	// deployments resources.DeploymentsClient
 }
@@ -65,6 +74,18 @@ func (clients *AzureDriverClients) GetSubnet() networkapi.SubnetsClientAPI {
 	return clients.Subnet
 }
 
+// GetSubnetOfSubscription is the getter for the Network Subnets Client of the given subscription from the
+// AzureDriverClients. The subnets of all subscriptions are served by the same mock.
+func (clients *AzureDriverClients) GetSubnetOfSubscription(subscriptionID string) networkapi.SubnetsClientAPI {
+	return clients.Subnet
+}
+
+// GetVirtualNetworksOfSubscription is the getter for the Virtual Networks Client of the given subscription from the
+// AzureDriverClients. The virtual networks of all subscriptions are served by the same mock.
+func (clients *AzureDriverClients) GetVirtualNetworksOfSubscription(subscriptionID string) networkapi.VirtualNetworksClientAPI {
+	return clients.VirtualNetworks
+}
+
 // GetGroup is the getter for the resources Group Client from the AzureDriverClients
 func (clients *AzureDriverClients) GetGroup() resourcesapi.GroupsClientAPI {
 	return clients.Group
@@ -75,9 +96,39 @@ func (clients *AzureDriverClients) GetMarketplace() marketplaceorderingapi.Marke
 	return clients.Marketplace
 }
 
+// GetResourceSkus is the getter for the resource SKUs client from the AzureDriverClients
+func (clients *AzureDriverClients) GetResourceSkus() computeapi.ResourceSkusClientAPI {
+	return clients.Skus
+}
+
+// GetPublicIP is the getter for the public IP addresses client from the AzureDriverClients
+func (clients *AzureDriverClients) GetPublicIP() networkapi.PublicIPAddressesClientAPI {
+	return clients.PublicIP
+}
+
+// GetResources is the getter for the generic resources client from the AzureDriverClients
+func (clients *AzureDriverClients) GetResources() resourcesapi.ResourcesClientAPI {
+	return clients.Resources
+}
+
 // GetClient is the getter for the autorest Client from the AzureDriverClients
 func (clients *AzureDriverClients) GetClient() autorest.Client {
-	return autorest.Client{}
+	return clients.Client
+}
+
+// GetVMExtensions is the getter for the Virtual Machine Extensions Client from the AzureDriverClients
+func (clients *AzureDriverClients) GetVMExtensions() computeapi.VirtualMachineExtensionsClientAPI {
+	return clients.Extensions
+}
+
+// GetAvailabilitySets is the getter for the Availability Sets Client from the AzureDriverClients
+func (clients *AzureDriverClients) GetAvailabilitySets() computeapi.AvailabilitySetsClientAPI {
+	return clients.AvailabilitySets
+}
+
+// GetUsage is the getter for the compute Usage Client from the AzureDriverClients
+func (clients *AzureDriverClients) GetUsage() computeapi.UsageClientAPI {
+	return clients.Usage
 }
 
 // GetDeployments is the getter for the resources deployment from the AzureDriverClients
@@ -99,7 +150,7 @@ func NewMockPluginSPIImpl(controller *gomock.Controller) spi.SessionProviderInte
 }
 
 //Setup creates a compute service instance using the mock
-func (ms *PluginSPIImpl) Setup(secret *corev1.Secret) (spi.AzureDriverClientsInterface, error) {
+func (ms *PluginSPIImpl) Setup(secret *corev1.Secret, cloudConfiguration *api.CloudConfiguration) (spi.AzureDriverClientsInterface, error) {
 
 	if ms.azureDriverClients != nil {
 		return ms.azureDriverClients, nil
@@ -126,14 +177,21 @@ func (ms *PluginSPIImpl) Setup(secret *corev1.Secret) (spi.AzureDriverClientsInt
 func (ms *PluginSPIImpl) newClients(subscriptionID, tenantID, clientID, clientSecret string, env azure.Environment) (*AzureDriverClients, error) {
 
 	subnetClient := mock_networkapi.NewMockSubnetsClientAPI(ms.Controller)
+	virtualNetworksClient := mock_networkapi.NewMockVirtualNetworksClientAPI(ms.Controller)
 	interfacesClient := mock_networkapi.NewMockInterfacesClientAPI(ms.Controller)
 	vmClient := mock_computeapi.NewMockVirtualMachinesClientAPI(ms.Controller)
 	vmImagesClient := mock_computeapi.NewMockVirtualMachineImagesClientAPI(ms.Controller)
 	diskClient := mock_computeapi.NewMockDisksClientAPI(ms.Controller)
 	groupsClients := mock_resourcesapi.NewMockGroupsClientAPI(ms.Controller)
 	marketplaceClient := mock_marketplaceorderingapi.NewMockMarketplaceAgreementsClientAPI(ms.Controller)
+	skusClient := mock_computeapi.NewMockResourceSkusClientAPI(ms.Controller)
+	publicIPClient := mock_networkapi.NewMockPublicIPAddressesClientAPI(ms.Controller)
+	resourcesClient := mock_resourcesapi.NewMockResourcesClientAPI(ms.Controller)
+	extensionsClient := mock_computeapi.NewMockVirtualMachineExtensionsClientAPI(ms.Controller)
+	availabilitySetsClient := mock_computeapi.NewMockAvailabilitySetsClientAPI(ms.Controller)
+	usageClient := mock_computeapi.NewMockUsageClientAPI(ms.Controller)
 
 	// deploymentsClient := resources.NewDeploymentsClient(subscriptionID) // check this subscriptionid
 
-	return &AzureDriverClients{Subnet: subnetClient, NIC: interfacesClient, VM: vmClient, Disk: diskClient, Group: groupsClients, Images: vmImagesClient, Marketplace: marketplaceClient}, nil
+	return &AzureDriverClients{Subnet: subnetClient, VirtualNetworks: virtualNetworksClient, NIC: interfacesClient, VM: vmClient, Disk: diskClient, Group: groupsClients, Images: vmImagesClient, Marketplace: marketplaceClient, Skus: skusClient, PublicIP: publicIPClient, Resources: resourcesClient, Extensions: extensionsClient, AvailabilitySets: availabilitySetsClient, Usage: usageClient}, nil
 }
@@ -0,0 +1,223 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/codes"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/status"
+)
+
+const (
+	nicSuffix      = "-nic"
+	publicIPSuffix = "-pip"
+	diskSuffix     = "-os-disk"
+	dataDiskSuffix = "-data-disk"
+
+	nicPrefix      = "nic-"
+	publicIPPrefix = "pip-"
+	diskPrefix     = "osdisk-"
+	dataDiskPrefix = "datadisk-"
+
+	// maxVMNameLength is the maximum length of VM names
+	maxVMNameLength = 64
+	// maxResourceNameLength is the maximum length of the names of network interfaces, public IPs and managed disks
+	maxResourceNameLength = 80
+	// resourceNameHashLength is the number of hex characters of the hash in truncated resource names
+	resourceNameHashLength = 5
+)
+
+var (
+	// resourceNameRegexp matches the names Azure accepts for VMs, network interfaces, public IPs and managed disks.
+	// They consist of alphanumerics, underscores, periods and hyphens, start with an alphanumeric and end with an
+	// alphanumeric or underscore.
+	resourceNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9_.-]*[a-zA-Z0-9_])?$`)
+	// invalidResourceNameCharacters matches the characters Azure rejects in resource names
+	invalidResourceNameCharacters = regexp.MustCompile(`[^a-zA-Z0-9_.-]`)
+)
+
+// NamingStrategy derives the names of the Azure resources of a machine from the name of its VM. The names must be
+// stable, as the resources of existing machines are looked up by them, and unique per VM.
+type NamingStrategy interface {
+	// NICName returns the name of the network interface. The interface name is empty for the primary interface.
+	NICName(vmName, interfaceName string) string
+	// PublicIPName returns the name of the public IP of the network interface. The interface name is empty for the
+	// primary interface.
+	PublicIPName(vmName, interfaceName string) string
+	// OSDiskName returns the name of the OS disk
+	OSDiskName(vmName string) string
+	// DataDiskName returns the name of the data disk with the given LUN. The disk name is empty for unnamed data disks.
+	DataDiskName(vmName, diskName string, lun int32) string
+}
+
+var (
+	// namingStrategies are the naming strategies which can be selected by the machine classes
+	namingStrategies = map[string]NamingStrategy{
+		api.NamingStrategySuffix: suffixNamingStrategy{},
+		api.NamingStrategyPrefix: prefixNamingStrategy{},
+	}
+	// namingStrategiesMutex guards namingStrategies, as strategies may be registered while requests are served
+	namingStrategiesMutex sync.RWMutex
+)
+
+// RegisterNamingStrategy makes the naming strategy selectable by machine classes under the given name. It is safe to
+// be called concurrently with requests, but strategies must be registered before machine classes select them.
+func RegisterNamingStrategy(name string, strategy NamingStrategy) {
+	namingStrategiesMutex.Lock()
+	defer namingStrategiesMutex.Unlock()
+	namingStrategies[name] = strategy
+}
+
+// lookupNamingStrategy returns the naming strategy registered under the given name
+func lookupNamingStrategy(name string) (NamingStrategy, bool) {
+	namingStrategiesMutex.RLock()
+	defer namingStrategiesMutex.RUnlock()
+	strategy, ok := namingStrategies[name]
+	return strategy, ok
+}
+
+// namingStrategyOf returns the naming strategy selected by the provider spec, which defaults to the suffix strategy
+func namingStrategyOf(providerSpec *api.AzureProviderSpec) NamingStrategy {
+	if providerSpec != nil {
+		if strategy, ok := lookupNamingStrategy(providerSpec.NamingStrategy); ok {
+			return strategy
+		}
+	}
+	return suffixNamingStrategy{}
+}
+
+// validateNamingStrategy returns an error if the naming strategy selected by the provider spec is not registered
+func validateNamingStrategy(providerSpec *api.AzureProviderSpec) error {
+	if providerSpec.NamingStrategy == "" {
+		return nil
+	}
+	if _, ok := lookupNamingStrategy(providerSpec.NamingStrategy); !ok {
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("Error while validating ProviderSpec: unknown naming strategy %q", providerSpec.NamingStrategy))
+	}
+	return nil
+}
+
+// suffixNamingStrategy appends the resource type to the VM name, e.g. <vm>-nic and <vm>-<disk>-<lun>-data-disk. It is
+// the naming of machines created before naming strategies were introduced.
+type suffixNamingStrategy struct{}
+
+func (suffixNamingStrategy) NICName(vmName, interfaceName string) string {
+	return dependencyName(vmName, interfaceName, nicSuffix)
+}
+
+func (suffixNamingStrategy) PublicIPName(vmName, interfaceName string) string {
+	return dependencyName(vmName, interfaceName, publicIPSuffix)
+}
+
+func (suffixNamingStrategy) OSDiskName(vmName string) string {
+	return vmName + diskSuffix
+}
+
+func (suffixNamingStrategy) DataDiskName(vmName, diskName string, lun int32) string {
+	if diskName != "" {
+		return dependencyName(vmName, fmt.Sprintf("%s-%d", diskName, lun), dataDiskSuffix)
+	}
+	return dependencyName(vmName, fmt.Sprintf("%d", lun), dataDiskSuffix)
+}
+
+// prefixNamingStrategy prepends the resource type to the VM name, e.g. nic-<vm> and datadisk-<vm>-<disk>-<lun>,
+// following the abbreviations recommended by Azure for resource names
+type prefixNamingStrategy struct{}
+
+func (prefixNamingStrategy) NICName(vmName, interfaceName string) string {
+	return prefixedDependencyName(nicPrefix, vmName, interfaceName)
+}
+
+func (prefixNamingStrategy) PublicIPName(vmName, interfaceName string) string {
+	return prefixedDependencyName(publicIPPrefix, vmName, interfaceName)
+}
+
+func (prefixNamingStrategy) OSDiskName(vmName string) string {
+	return prefixedDependencyName(diskPrefix, vmName, "")
+}
+
+func (prefixNamingStrategy) DataDiskName(vmName, diskName string, lun int32) string {
+	if diskName != "" {
+		return prefixedDependencyName(dataDiskPrefix, vmName, fmt.Sprintf("%s-%d", diskName, lun))
+	}
+	return prefixedDependencyName(dataDiskPrefix, vmName, fmt.Sprintf("%d", lun))
+}
+
+// prefixedDependencyName returns the name of the dependency of the VM with the given prefix, sanitized and shortened
+// like the names of dependencyName
+func prefixedDependencyName(prefix, vmName, dependency string) string {
+	if dependency == "" {
+		return shortenResourceName(prefix+vmName, "")
+	}
+	dependency = invalidResourceNameCharacters.ReplaceAllString(dependency, "-")
+	return shortenResourceName(prefix+vmName+"-"+dependency, "")
+}
+
+// dependencyName returns the name of the dependency of the VM with the given suffix. Characters of the dependency
+// Azure rejects are replaced by hyphens and names exceeding the maximum length are shortened, see
+// shortenResourceName. Both only affect names Azure would reject, so that the names of existing resources are kept.
+func dependencyName(vmName, dependency, suffix string) string {
+	if dependency == "" {
+		return shortenResourceName(vmName+suffix, suffix)
+	}
+	dependency = invalidResourceNameCharacters.ReplaceAllString(dependency, "-")
+	return shortenResourceName(vmName+"-"+dependency+suffix, suffix)
+}
+
+// shortenResourceName truncates names exceeding the maximum length of resource names before their suffix and inserts
+// a hash of the full name, so that distinct names stay distinct and the suffix still identifies the resource type
+func shortenResourceName(name, suffix string) string {
+	if len(name) <= maxResourceNameLength {
+		return name
+	}
+	sum := sha256.Sum256([]byte(name))
+	prefix := strings.TrimRight(name[:maxResourceNameLength-len(suffix)-resourceNameHashLength-1], "-.")
+	return prefix + "-" + hex.EncodeToString(sum[:])[:resourceNameHashLength] + suffix
+}
+
+// validateResourceNames returns an InvalidArgument error if the name of the VM or of any of its network interfaces,
+// public IPs or disks would be rejected by Azure, so that the creation fails with a clear error before any resource
+// is created instead of with an opaque error of the Azure API once some resources exist
+func validateResourceNames(vmName string, networkInterfaces []networkInterface, diskNames []string) error {
+	if err := validateResourceName("VM", vmName, maxVMNameLength); err != nil {
+		return err
+	}
+	for _, nic := range networkInterfaces {
+		if err := validateResourceName("network interface", nic.name, maxResourceNameLength); err != nil {
+			return err
+		}
+		if nic.publicIP == nil {
+			continue
+		}
+		if err := validateResourceName("public IP", nic.publicIPName, maxResourceNameLength); err != nil {
+			return err
+		}
+	}
+	for _, diskName := range diskNames {
+		if err := validateResourceName("disk", diskName, maxResourceNameLength); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateResourceName(kind, name string, maxLength int) error {
+	if len(name) > maxLength {
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("Name %q of the %s exceeds %d characters", name, kind, maxLength))
+	}
+	if !resourceNameRegexp.MatchString(name) {
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("Name %q of the %s must consist of alphanumerics, underscores, periods and hyphens, start with an alphanumeric and end with an alphanumeric or underscore", name, kind))
+	}
+	return nil
+}
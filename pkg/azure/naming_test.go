@@ -0,0 +1,127 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"fmt"
+	"strings"
+
+	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/codes"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/status"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// orgNamingStrategy prefixes the resource names with an organization
+type orgNamingStrategy struct{}
+
+func (orgNamingStrategy) NICName(vmName, interfaceName string) string {
+	return "org-" + suffixNamingStrategy{}.NICName(vmName, interfaceName)
+}
+
+func (orgNamingStrategy) PublicIPName(vmName, interfaceName string) string {
+	return "org-" + suffixNamingStrategy{}.PublicIPName(vmName, interfaceName)
+}
+
+func (orgNamingStrategy) OSDiskName(vmName string) string {
+	return "org-" + vmName + "-os"
+}
+
+func (orgNamingStrategy) DataDiskName(vmName, diskName string, lun int32) string {
+	return fmt.Sprintf("org-%s-data-%d", vmName, lun)
+}
+
+var _ = Describe("Naming", func() {
+	It("should default to the suffix naming of the machine resources", func() {
+		providerSpec := &api.AzureProviderSpec{}
+		providerSpec.Properties.NetworkProfile.Interfaces = []api.AzureNetworkInterface{{Primary: true}, {Name: "storage"}}
+
+		networkInterfaces := getNetworkInterfaces(providerSpec, "machine")
+		Expect(networkInterfaces[0].name).To(Equal("machine-nic"))
+		Expect(networkInterfaces[0].publicIPName).To(Equal("machine-pip"))
+		Expect(networkInterfaces[1].name).To(Equal("machine-storage-nic"))
+		Expect(networkInterfaces[1].publicIPName).To(Equal("machine-storage-pip"))
+
+		naming := namingStrategyOf(providerSpec)
+		Expect(naming.OSDiskName("machine")).To(Equal("machine-os-disk"))
+		Expect(naming.DataDiskName("machine", "logs", 2)).To(Equal("machine-logs-2-data-disk"))
+		Expect(naming.DataDiskName("machine", "", 0)).To(Equal("machine-0-data-disk"))
+	})
+
+	Context("with a registered naming strategy", func() {
+		BeforeEach(func() {
+			RegisterNamingStrategy("org", orgNamingStrategy{})
+		})
+
+		AfterEach(func() {
+			namingStrategiesMutex.Lock()
+			delete(namingStrategies, "org")
+			namingStrategiesMutex.Unlock()
+		})
+
+		It("should name the machine resources with the strategy selected by the provider spec", func() {
+			providerSpec := &api.AzureProviderSpec{NamingStrategy: "org"}
+
+			networkInterfaces := getNetworkInterfaces(providerSpec, "machine")
+			Expect(networkInterfaces[0].name).To(Equal("org-machine-nic"))
+			Expect(getAzureDataDiskNames(namingStrategyOf(providerSpec), []api.AzureDataDisk{{Name: "logs"}}, 1, "machine")).To(Equal([]string{"org-machine-data-1"}))
+			Expect(validateNamingStrategy(providerSpec)).To(Succeed())
+		})
+	})
+
+	It("should prepend the resource type with the prefix naming strategy", func() {
+		providerSpec := &api.AzureProviderSpec{NamingStrategy: api.NamingStrategyPrefix}
+		providerSpec.Properties.NetworkProfile.Interfaces = []api.AzureNetworkInterface{{Primary: true}, {Name: "storage"}}
+		Expect(validateNamingStrategy(providerSpec)).To(Succeed())
+
+		networkInterfaces := getNetworkInterfaces(providerSpec, "machine")
+		Expect(networkInterfaces[0].name).To(Equal("nic-machine"))
+		Expect(networkInterfaces[0].publicIPName).To(Equal("pip-machine"))
+		Expect(networkInterfaces[1].name).To(Equal("nic-machine-storage"))
+
+		naming := namingStrategyOf(providerSpec)
+		Expect(naming.OSDiskName("machine")).To(Equal("osdisk-machine"))
+		Expect(naming.DataDiskName("machine", "logs", 2)).To(Equal("datadisk-machine-logs-2"))
+		Expect(naming.DataDiskName("machine", "", 0)).To(Equal("datadisk-machine-0"))
+
+		long := naming.DataDiskName(strings.Repeat("m", 80), "logs", 2)
+		Expect(long).To(HaveLen(maxResourceNameLength))
+		Expect(long).To(HavePrefix(dataDiskPrefix))
+		Expect(resourceNameRegexp.MatchString(long)).To(BeTrue())
+	})
+
+	It("should reject unknown naming strategies", func() {
+		err := validateNamingStrategy(&api.AzureProviderSpec{NamingStrategy: "unknown"})
+		Expect(err).To(HaveOccurred())
+		Expect(err.(*status.Status).Code()).To(Equal(codes.InvalidArgument))
+		Expect(validateNamingStrategy(&api.AzureProviderSpec{NamingStrategy: api.NamingStrategySuffix})).To(Succeed())
+	})
+
+	It("should sanitize and shorten the names of dependencies Azure would reject", func() {
+		naming := suffixNamingStrategy{}
+		Expect(naming.DataDiskName("machine", "logs/app", 2)).To(Equal("machine-logs-app-2-data-disk"))
+
+		long := naming.DataDiskName(strings.Repeat("m", 70), "logs", 2)
+		Expect(long).To(HaveLen(maxResourceNameLength))
+		Expect(long).To(HaveSuffix(dataDiskSuffix))
+		Expect(naming.DataDiskName(strings.Repeat("m", 70), "logs", 3)).NotTo(Equal(long))
+	})
+
+	It("should reject VM and resource names Azure would reject", func() {
+		nics := []networkInterface{{name: "machine-nic", publicIPName: "machine-pip", publicIP: &api.AzurePublicIP{}}}
+		Expect(validateResourceNames("machine", nics, []string{"machine-os-disk"})).To(Succeed())
+
+		err := validateResourceNames(strings.Repeat("m", maxVMNameLength+1), nics, nil)
+		Expect(err).To(HaveOccurred())
+		Expect(err.(*status.Status).Code()).To(Equal(codes.InvalidArgument))
+
+		Expect(validateResourceNames("machine", []networkInterface{{name: strings.Repeat("n", maxResourceNameLength+1)}}, nil)).NotTo(Succeed())
+		Expect(validateResourceNames("machine", []networkInterface{{name: "machine-nic", publicIPName: "machine-pip.", publicIP: &api.AzurePublicIP{}}}, nil)).NotTo(Succeed())
+		Expect(validateResourceNames("machine", nil, []string{"-disk"})).NotTo(Succeed())
+	})
+})
@@ -0,0 +1,147 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-04-01/network"
+	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/spi"
+)
+
+// isOwnIPConfiguration returns true if the IP configuration was created together with the NIC
+func isOwnIPConfiguration(ipConfiguration network.InterfaceIPConfiguration, nicName string) bool {
+	return ipConfiguration.Name != nil && (strings.EqualFold(*ipConfiguration.Name, nicName) || strings.EqualFold(*ipConfiguration.Name, ipv6ConfigurationName(nicName)))
+}
+
+// isConfiguredPool returns true if the backend pool is one of the pools of the provider spec the NIC is added to when
+// it is created
+func isConfiguredPool(id *string, pools []api.AzureSubResource) bool {
+	for _, pool := range pools {
+		if id != nil && strings.EqualFold(*id, pool.ID) {
+			return true
+		}
+	}
+	return false
+}
+
+// splitLoadBalancerPools returns the load balancer backend pools of the IP configuration which are configured in the
+// provider spec and the ones which are not
+func splitLoadBalancerPools(properties *network.InterfaceIPConfigurationPropertiesFormat, nic networkInterface) (configured, foreign []network.BackendAddressPool) {
+	if properties.LoadBalancerBackendAddressPools == nil {
+		return nil, nil
+	}
+	for _, pool := range *properties.LoadBalancerBackendAddressPools {
+		if isConfiguredPool(pool.ID, nic.loadBalancerBackendAddressPools) {
+			configured = append(configured, pool)
+		} else {
+			foreign = append(foreign, pool)
+		}
+	}
+	return configured, foreign
+}
+
+// splitApplicationGatewayPools returns the application gateway backend pools of the IP configuration which are
+// configured in the provider spec and the ones which are not
+func splitApplicationGatewayPools(properties *network.InterfaceIPConfigurationPropertiesFormat, nic networkInterface) (configured, foreign []network.ApplicationGatewayBackendAddressPool) {
+	if properties.ApplicationGatewayBackendAddressPools == nil {
+		return nil, nil
+	}
+	for _, pool := range *properties.ApplicationGatewayBackendAddressPools {
+		if isConfiguredPool(pool.ID, nic.applicationGatewayBackendAddressPools) {
+			configured = append(configured, pool)
+		} else {
+			foreign = append(foreign, pool)
+		}
+	}
+	return configured, foreign
+}
+
+// foreignReferences returns the IP configurations and references of the NIC which were added by other controllers,
+// e.g. secondary IP configurations of the Azure CNI IPAM or load balancer backend pools. The NIC is created with an
+// IP configuration named after it and an optional IPv6 IP configuration, which is added to the backend pools of the
+// provider spec.
+func foreignReferences(NIC network.Interface, nic networkInterface) []string {
+	if NIC.InterfacePropertiesFormat == nil || NIC.IPConfigurations == nil {
+		return nil
+	}
+
+	var references []string
+	for _, ipConfiguration := range *NIC.IPConfigurations {
+		if !isOwnIPConfiguration(ipConfiguration, nic.name) {
+			name := ""
+			if ipConfiguration.Name != nil {
+				name = *ipConfiguration.Name
+			}
+			references = append(references, fmt.Sprintf("IP configuration %s", name))
+			continue
+		}
+		properties := ipConfiguration.InterfaceIPConfigurationPropertiesFormat
+		if properties == nil {
+			continue
+		}
+		if _, foreign := splitLoadBalancerPools(properties, nic); len(foreign) > 0 {
+			references = append(references, "load balancer backend address pools")
+		}
+		if properties.LoadBalancerInboundNatRules != nil && len(*properties.LoadBalancerInboundNatRules) > 0 {
+			references = append(references, "load balancer inbound NAT rules")
+		}
+		if _, foreign := splitApplicationGatewayPools(properties, nic); len(foreign) > 0 {
+			references = append(references, "application gateway backend address pools")
+		}
+	}
+	return references
+}
+
+// removeForeignReferences removes the IP configurations and references added to the NIC by other controllers before
+// it is deleted. Azure would otherwise reject the deletion of the NIC as being in use without naming the reference.
+// The backend pools of the provider spec are kept.
+func removeForeignReferences(ctx context.Context, clients spi.AzureDriverClientsInterface, resourceGroupName string, NIC network.Interface, nic networkInterface) error {
+	nicName := nic.name
+	references := foreignReferences(NIC, nic)
+	if len(references) == 0 {
+		return nil
+	}
+	spi.WarningS(ctx, "NIC has references added by other controllers, removing them before its deletion", "nic", nicName, "references", strings.Join(references, ", "))
+
+	var ipConfigurations []network.InterfaceIPConfiguration
+	for _, ipConfiguration := range *NIC.IPConfigurations {
+		if !isOwnIPConfiguration(ipConfiguration, nicName) {
+			continue
+		}
+		if properties := ipConfiguration.InterfaceIPConfigurationPropertiesFormat; properties != nil {
+			var (
+				loadBalancerPools, _       = splitLoadBalancerPools(properties, nic)
+				applicationGatewayPools, _ = splitApplicationGatewayPools(properties, nic)
+			)
+			properties.LoadBalancerBackendAddressPools = nil
+			if len(loadBalancerPools) > 0 {
+				properties.LoadBalancerBackendAddressPools = &loadBalancerPools
+			}
+			properties.LoadBalancerInboundNatRules = nil
+			properties.ApplicationGatewayBackendAddressPools = nil
+			if len(applicationGatewayPools) > 0 {
+				properties.ApplicationGatewayBackendAddressPools = &applicationGatewayPools
+			}
+		}
+		ipConfigurations = append(ipConfigurations, ipConfiguration)
+	}
+	NIC.IPConfigurations = &ipConfigurations
+
+	future, err := clients.GetNic().CreateOrUpdate(ctx, resourceGroupName, nicName, NIC)
+	if err == nil {
+		err = future.WaitForCompletionRef(ctx, clients.GetClient())
+	}
+	if err != nil {
+		return spi.OnARMAPIErrorFail(prometheusServiceNIC, err, "Cannot delete NIC %s, as its %s added by other controllers could not be removed", nicName, strings.Join(references, ", "))
+	}
+	spi.OnARMAPISuccess(prometheusServiceNIC, "NIC.CreateOrUpdate")
+	return nil
+}
@@ -0,0 +1,93 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-04-01/network"
+	"github.com/Azure/go-autorest/autorest/to"
+	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/mock"
+	gomock "github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var _ = Describe("NICCleanup", func() {
+	Describe("#foreignReferences", func() {
+		newNIC := func(ipConfigurations ...network.InterfaceIPConfiguration) network.Interface {
+			return network.Interface{InterfacePropertiesFormat: &network.InterfacePropertiesFormat{IPConfigurations: &ipConfigurations}}
+		}
+		own := network.InterfaceIPConfiguration{Name: to.StringPtr("machine-0-nic"), InterfaceIPConfigurationPropertiesFormat: &network.InterfaceIPConfigurationPropertiesFormat{}}
+		nic := networkInterface{name: "machine-0-nic"}
+
+		It("should not report the own IP configuration", func() {
+			Expect(foreignReferences(newNIC(own), nic)).To(BeEmpty())
+		})
+
+		It("should not report the own IPv6 IP configuration", func() {
+			ipv6 := network.InterfaceIPConfiguration{Name: to.StringPtr(ipv6ConfigurationName("machine-0-nic"))}
+
+			Expect(foreignReferences(newNIC(own, ipv6), nic)).To(BeEmpty())
+		})
+
+		It("should report secondary IP configurations and backend pools", func() {
+			pooled := own
+			pooled.InterfaceIPConfigurationPropertiesFormat = &network.InterfaceIPConfigurationPropertiesFormat{
+				LoadBalancerBackendAddressPools: &[]network.BackendAddressPool{{ID: to.StringPtr("pool")}},
+			}
+			secondary := network.InterfaceIPConfiguration{Name: to.StringPtr("ipconfig-pod-1")}
+
+			Expect(foreignReferences(newNIC(pooled, secondary), nic)).To(ConsistOf(
+				"load balancer backend address pools",
+				"IP configuration ipconfig-pod-1",
+			))
+		})
+
+		It("should not report the backend pools of the provider spec", func() {
+			pooled := own
+			pooled.InterfaceIPConfigurationPropertiesFormat = &network.InterfaceIPConfigurationPropertiesFormat{
+				LoadBalancerBackendAddressPools:       &[]network.BackendAddressPool{{ID: to.StringPtr("/subscriptions/s/LB-Pool")}},
+				ApplicationGatewayBackendAddressPools: &[]network.ApplicationGatewayBackendAddressPool{{ID: to.StringPtr("agw-pool")}, {ID: to.StringPtr("other")}},
+			}
+			configured := networkInterface{
+				name:                                  "machine-0-nic",
+				loadBalancerBackendAddressPools:       []api.AzureSubResource{{ID: "/subscriptions/s/lb-pool"}},
+				applicationGatewayBackendAddressPools: []api.AzureSubResource{{ID: "agw-pool"}},
+			}
+
+			Expect(foreignReferences(newNIC(pooled), configured)).To(ConsistOf("application gateway backend address pools"))
+		})
+	})
+
+	Describe("#removeForeignReferences", func() {
+		It("should keep the backend pools of the provider spec", func() {
+			clients := mock.NewMockPluginSPIImpl(gomock.NewController(GinkgoT()))
+			driverClients, err := clients.Setup(&corev1.Secret{}, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			NIC := network.Interface{InterfacePropertiesFormat: &network.InterfacePropertiesFormat{IPConfigurations: &[]network.InterfaceIPConfiguration{{
+				Name: to.StringPtr("machine-0-nic"),
+				InterfaceIPConfigurationPropertiesFormat: &network.InterfaceIPConfigurationPropertiesFormat{
+					LoadBalancerBackendAddressPools: &[]network.BackendAddressPool{{ID: to.StringPtr("lb-pool")}, {ID: to.StringPtr("service-pool")}},
+				},
+			}}}}
+			nic := networkInterface{name: "machine-0-nic", loadBalancerBackendAddressPools: []api.AzureSubResource{{ID: "lb-pool"}}}
+
+			driverClients.(*mock.AzureDriverClients).NIC.EXPECT().CreateOrUpdate(gomock.Any(), "rg", "machine-0-nic", gomock.Any()).DoAndReturn(
+				func(_ context.Context, _, _ string, parameters network.Interface) (network.InterfacesCreateOrUpdateFuture, error) {
+					Expect(*(*parameters.IPConfigurations)[0].LoadBalancerBackendAddressPools).To(Equal([]network.BackendAddressPool{{ID: to.StringPtr("lb-pool")}}))
+					return network.InterfacesCreateOrUpdateFuture{}, errors.New("failed")
+				})
+
+			Expect(removeForeignReferences(context.Background(), driverClients, "rg", NIC, nic)).To(HaveOccurred())
+		})
+	})
+})
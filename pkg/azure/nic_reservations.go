@@ -0,0 +1,231 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/spi"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/codes"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/status"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// nicReservedForAnotherVM is the error code Azure returns when deleting a NIC which is still reserved for a deleted VM
+	nicReservedForAnotherVM = "NicReservedForAnotherVm"
+
+	// nicReservationEscalationPeriod is the duration after which the IP configurations of a reserved NIC are detached.
+	// Azure usually releases the reservation of a NIC three minutes after its VM is deleted.
+	nicReservationEscalationPeriod = 5 * time.Minute
+	// nicReservationAlertPeriod is the duration after which a reserved NIC is reported as stale
+	nicReservationAlertPeriod = 30 * time.Minute
+
+	nicReservationMinBackoff = 30 * time.Second
+	nicReservationMaxBackoff = 5 * time.Minute
+)
+
+var (
+	// staleNICReservationsGauge is the number of NICs which are reserved for longer than the alert period
+	staleNICReservationsGauge = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "mcm",
+		Subsystem: "azure",
+		Name:      "stale_nic_reservations",
+		Help:      "Number of NICs which could not be deleted for more than 30 minutes because Azure keeps them reserved for a deleted VM.",
+	})
+
+	// forcedNICCleanupsCounter is the number of reserved NICs whose IP configurations were detached
+	forcedNICCleanupsCounter = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "mcm",
+		Subsystem: "azure",
+		Name:      "forced_nic_cleanups_total",
+		Help:      "Number of NICs reserved for a deleted VM whose IP configurations were detached to force their deletion.",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(staleNICReservationsGauge, forcedNICCleanupsCounter)
+}
+
+// nicReservation is the deletion history of a NIC which is reserved for a deleted VM
+type nicReservation struct {
+	firstFailure time.Time
+	attempts     int
+	nextAttempt  time.Time
+	escalated    bool
+}
+
+// nicReservations tracks the deletion attempts of NICs which Azure keeps reserved after their VM is gone.
+// Deletions are retried with an exponential backoff and escalated to a forced cleanup if the reservation persists.
+type nicReservations struct {
+	escalationPeriod time.Duration
+	alertPeriod      time.Duration
+
+	mutex   sync.Mutex
+	entries map[string]*nicReservation
+}
+
+func newNICReservations(escalationPeriod, alertPeriod time.Duration) *nicReservations {
+	return &nicReservations{
+		escalationPeriod: escalationPeriod,
+		alertPeriod:      alertPeriod,
+		entries:          map[string]*nicReservation{},
+	}
+}
+
+// nextAttempt returns the time before which the deletion of the NIC should not be attempted again
+func (r *nicReservations) nextAttempt(key string) time.Time {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if entry, ok := r.entries[key]; ok {
+		return entry.nextAttempt
+	}
+	return time.Time{}
+}
+
+// failed records a deletion attempt which failed due to the reservation of the NIC. It returns true once if
+// the reservation persists beyond the escalation period and the forced cleanup should be performed.
+func (r *nicReservations) failed(key string, now time.Time) bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	entry, ok := r.entries[key]
+	if !ok {
+		entry = &nicReservation{firstFailure: now}
+		r.entries[key] = entry
+	}
+	entry.attempts++
+
+	backoff := nicReservationMinBackoff << uint(entry.attempts-1)
+	if backoff > nicReservationMaxBackoff || backoff <= 0 {
+		backoff = nicReservationMaxBackoff
+	}
+	entry.nextAttempt = now.Add(backoff)
+
+	r.updateMetric(now)
+
+	if !entry.escalated && now.Sub(entry.firstFailure) >= r.escalationPeriod {
+		entry.escalated = true
+		return true
+	}
+	return false
+}
+
+// forget removes the NIC from the tracked reservations, e.g. after it was deleted
+func (r *nicReservations) forget(key string, now time.Time) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, ok := r.entries[key]; !ok {
+		return
+	}
+	delete(r.entries, key)
+	r.updateMetric(now)
+}
+
+func (r *nicReservations) updateMetric(now time.Time) {
+	var stale int
+	for _, entry := range r.entries {
+		if now.Sub(entry.firstFailure) >= r.alertPeriod {
+			stale++
+		}
+	}
+	staleNICReservationsGauge.Set(float64(stale))
+}
+
+// deleteReservedNIC deletes the NIC. If Azure keeps the NIC reserved for a deleted VM, the deletion is retried with
+// an exponential backoff, and the IP configurations of the NIC are detached if the reservation persists. The
+// reservation is reported as unavailable error, as Azure answers it with a bad request although it is transient.
+func (d *MachinePlugin) deleteReservedNIC(ctx context.Context, clients spi.AzureDriverClientsInterface, resourceGroupName, nicName string, deleteNIC func() error) error {
+	var (
+		key = nicReservationKey(resourceGroupName, nicName)
+		now = time.Now()
+	)
+
+	if d.nicReservations != nil {
+		if next := d.nicReservations.nextAttempt(key); now.Before(next) {
+			return status.Error(codes.Unavailable, fmt.Sprintf("NIC %s is reserved for a deleted VM, its deletion is retried after %s", nicName, next.Format(time.RFC3339)))
+		}
+	}
+
+	err := deleteNIC()
+	if err == nil || spi.NotFound(err) {
+		d.forgetReservedNIC(resourceGroupName, nicName)
+		return nil
+	}
+	if serviceErrorCode(err) != nicReservedForAnotherVM {
+		return err
+	}
+	reservedErr := status.Error(codes.Unavailable, fmt.Sprintf("NIC %s is still reserved for a deleted VM by Azure: %s", nicName, operationErrorMessage(err)))
+	if d.nicReservations == nil {
+		return reservedErr
+	}
+
+	if d.nicReservations.failed(key, now) {
+		spi.WarningS(ctx, "NIC is still reserved for a deleted VM, detaching its IP configurations", "nic", nicName, "escalationPeriod", d.nicReservations.escalationPeriod)
+		if detachErr := detachIPConfigurations(ctx, clients, resourceGroupName, nicName); detachErr != nil {
+			spi.ErrorS(ctx, detachErr, "Could not detach IP configurations of NIC", "nic", nicName)
+		} else {
+			forcedNICCleanupsCounter.Inc()
+		}
+	}
+	return reservedErr
+}
+
+// forgetReservedNIC stops tracking the reservation of the NIC, e.g. once it is found to be gone
+func (d *MachinePlugin) forgetReservedNIC(resourceGroupName, nicName string) {
+	if d.nicReservations == nil {
+		return
+	}
+	d.nicReservations.forget(nicReservationKey(resourceGroupName, nicName), time.Now())
+}
+
+// nicReservationKey returns the key of the NIC in the tracked reservations
+func nicReservationKey(resourceGroupName, nicName string) string {
+	return strings.ToLower(resourceGroupName + "/" + nicName)
+}
+
+// detachIPConfigurations removes all references of the NIC and its IP configurations to other network resources
+func detachIPConfigurations(ctx context.Context, clients spi.AzureDriverClientsInterface, resourceGroupName, nicName string) error {
+	NIC, err := clients.GetNic().Get(ctx, resourceGroupName, nicName, "")
+	if err != nil {
+		return spi.OnARMAPIErrorFail(prometheusServiceNIC, err, "NIC.Get failed for %s", nicName)
+	}
+	if NIC.InterfacePropertiesFormat == nil {
+		return nil
+	}
+
+	NIC.NetworkSecurityGroup = nil
+	if NIC.IPConfigurations != nil {
+		for i := range *NIC.IPConfigurations {
+			ipConfiguration := &(*NIC.IPConfigurations)[i]
+			if ipConfiguration.InterfaceIPConfigurationPropertiesFormat == nil {
+				continue
+			}
+			ipConfiguration.PublicIPAddress = nil
+			ipConfiguration.ApplicationSecurityGroups = nil
+			ipConfiguration.LoadBalancerBackendAddressPools = nil
+			ipConfiguration.LoadBalancerInboundNatRules = nil
+			ipConfiguration.ApplicationGatewayBackendAddressPools = nil
+		}
+	}
+
+	future, err := clients.GetNic().CreateOrUpdate(ctx, resourceGroupName, nicName, NIC)
+	if err != nil {
+		return spi.OnARMAPIErrorFail(prometheusServiceNIC, err, "NIC.CreateOrUpdate failed for %s", nicName)
+	}
+	if err := future.WaitForCompletionRef(ctx, clients.GetClient()); err != nil {
+		return spi.OnARMAPIErrorFail(prometheusServiceNIC, err, "NIC.WaitForCompletionRef failed for %s", nicName)
+	}
+	spi.OnARMAPISuccess(prometheusServiceNIC, "NIC.CreateOrUpdate")
+	return nil
+}
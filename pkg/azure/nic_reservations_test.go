@@ -0,0 +1,75 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/codes"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/status"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NICReservations", func() {
+	It("should back off exponentially and escalate once the reservation persists", func() {
+		var (
+			reservations = newNICReservations(5*time.Minute, 30*time.Minute)
+			now          = time.Now()
+		)
+
+		Expect(reservations.failed("rg/nic", now)).To(BeFalse())
+		Expect(reservations.nextAttempt("rg/nic")).To(Equal(now.Add(30 * time.Second)))
+
+		Expect(reservations.failed("rg/nic", now.Add(time.Minute))).To(BeFalse())
+		Expect(reservations.nextAttempt("rg/nic")).To(Equal(now.Add(2 * time.Minute)))
+
+		Expect(reservations.failed("rg/nic", now.Add(5*time.Minute))).To(BeTrue())
+		Expect(reservations.failed("rg/nic", now.Add(10*time.Minute))).To(BeFalse())
+		Expect(reservations.nextAttempt("rg/nic")).To(Equal(now.Add(14 * time.Minute)))
+
+		reservations.forget("rg/nic", now.Add(15*time.Minute))
+		Expect(reservations.nextAttempt("rg/nic")).To(BeZero())
+	})
+
+	Describe("#deleteReservedNIC", func() {
+		var (
+			ctx      = context.Background()
+			plugin   *MachinePlugin
+			reserved = autorest.DetailedError{Original: &azure.RequestError{ServiceError: &azure.ServiceError{Code: nicReservedForAnotherVM}}}
+			notFound = autorest.DetailedError{Response: &http.Response{StatusCode: http.StatusNotFound}}
+		)
+
+		BeforeEach(func() {
+			plugin = NewAzureDriver(nil)
+		})
+
+		It("should report the reservation as unavailable and back off", func() {
+			err := plugin.deleteReservedNIC(ctx, nil, "rg", "nic", func() error { return reserved })
+			Expect(err.(*status.Status).Code()).To(Equal(codes.Unavailable))
+			Expect(plugin.nicReservations.nextAttempt("rg/nic")).NotTo(BeZero())
+
+			err = plugin.deleteReservedNIC(ctx, nil, "rg", "nic", func() error {
+				Fail("NIC must not be deleted before the backoff expired")
+				return nil
+			})
+			Expect(err.(*status.Status).Code()).To(Equal(codes.Unavailable))
+		})
+
+		It("should forget the reservation of a NIC which is gone", func() {
+			plugin.nicReservations.failed("rg/nic", time.Now().Add(-time.Hour))
+			plugin.nicReservations.entries["rg/nic"].nextAttempt = time.Time{}
+
+			Expect(plugin.deleteReservedNIC(ctx, nil, "rg", "nic", func() error { return notFound })).To(Succeed())
+			Expect(plugin.nicReservations.entries).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,58 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"context"
+
+	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/eventgrid"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/spi"
+	"github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
+)
+
+const (
+	operationCreate = "create"
+	operationDelete = "delete"
+)
+
+// publishMachineEvent records the usage of the machine class and publishes a machine lifecycle event if a publisher
+// is configured. Failures are only logged as notifications must never fail the machine operation itself.
+func (d *MachinePlugin) publishMachineEvent(ctx context.Context, operation string, machine *v1alpha1.Machine, machineClass *v1alpha1.MachineClass, providerSpec *api.AzureProviderSpec, providerID string, opErr error) {
+	recordMachineClassUsage(operation, machineClass, opErr)
+
+	if d.Publisher == nil || machine == nil {
+		return
+	}
+
+	data := eventgrid.MachineEventData{
+		MachineName: machine.Name,
+		ProviderID:  providerID,
+		Operation:   operation,
+	}
+	if machineClass != nil {
+		data.MachineClass = machineClass.Name
+	}
+	if providerSpec != nil {
+		data.ResourceGroup = providerSpec.ResourceGroup
+		data.Location = providerSpec.Location
+	}
+
+	eventType := eventgrid.EventTypeMachineFailed
+	switch {
+	case opErr != nil:
+		data.Error = opErr.Error()
+	case operation == operationCreate:
+		eventType = eventgrid.EventTypeMachineCreated
+	case operation == operationDelete:
+		eventType = eventgrid.EventTypeMachineDeleted
+	}
+
+	if err := d.Publisher.Publish(ctx, eventgrid.NewMachineEvent(eventType, data)); err != nil {
+		spi.WarningS(ctx, "Could not publish machine event", "eventType", eventType, "err", err)
+	}
+}
@@ -0,0 +1,448 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/bootstrap"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/eventgrid"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/spi"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/spot"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/userdata"
+	"github.com/gardener/machine-controller-manager/pkg/client/clientset/versioned"
+	"github.com/gardener/machine-controller-manager/pkg/client/clientset/versioned/scheme"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/codes"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/status"
+	"github.com/spf13/pflag"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/record"
+)
+
+// DriverOptions contains the provider specific options of the machine controller
+type DriverOptions struct {
+	// EventGridTopicEndpoint is the endpoint of the Event Grid topic machine lifecycle events are published to
+	EventGridTopicEndpoint string
+	// EventGridTopicKeyFile is the path to a file containing the access key of the Event Grid topic
+	EventGridTopicKeyFile string
+	// EventGridTimeout is the timeout for publishing events to the Event Grid topic
+	EventGridTimeout time.Duration
+	// EventGridQueueSize is the maximum number of events queued for publishing to the Event Grid topic
+	EventGridQueueSize int
+	// OrphanGracePeriod is the duration NICs and disks must be detached before they are garbage collected
+	OrphanGracePeriod time.Duration
+	// TagSyncInterval is the interval in which the tags of the machine classes are synchronized to the resources of
+	// their machines on GetMachineStatus
+	TagSyncInterval time.Duration
+	// OwnerID identifies the machine controller instance in the owner tag of the machine resources
+	OwnerID string
+	// TagValuePolicy determines how tag values exceeding the Azure limit are handled
+	TagValuePolicy string
+	// ReservedTagKeys are the tag keys machine classes must not set
+	ReservedTagKeys []string
+	// MaxInFlightCreations caps the concurrent machine creations per resource group
+	MaxInFlightCreations int
+	// NetworkDiagnostics enables recording the effective network configuration of machines whose node failed to join
+	NetworkDiagnostics bool
+	// CheckIdentityExistence enables verifying that the user-assigned identity exists before a machine is created
+	CheckIdentityExistence bool
+	// NICCreateTimeout is the timeout of the creation of a network interface
+	NICCreateTimeout time.Duration
+	// VMCreateTimeout is the timeout of the creation of a VM
+	VMCreateTimeout time.Duration
+	// DeleteTimeout is the timeout of the deletion of a VM and of the deletion of its network interfaces and disks
+	DeleteTimeout time.Duration
+	// GracefulShutdownTimeout is the timeout of the shutdown of a VM through its OS before it is deleted
+	GracefulShutdownTimeout time.Duration
+	// GuestAgentReadyTimeout is the timeout for the guest agent of a created VM to report ready
+	GuestAgentReadyTimeout time.Duration
+	// ImageCanaryTimeout is the timeout for the guest agent of the canary machine of a new image to report ready
+	ImageCanaryTimeout time.Duration
+	// AsyncDeletion enables issuing the deletion of machine resources without waiting for it to complete
+	AsyncDeletion bool
+	// StrictProviderSpecDecoding enables rejecting unknown fields of the provider specs of all machine classes
+	StrictProviderSpecDecoding bool
+	// BootstrapTokenTTL is the lifetime of the bootstrap tokens issued for machines
+	BootstrapTokenTTL time.Duration
+	// SpotTrackingInterval is the interval in which the spot signals of the listed VM sizes are queried
+	SpotTrackingInterval time.Duration
+	// SpotAnnotateMachineDeployments enables annotating machine deployments with the spot signals of their VM size.
+	// It is disabled by default, as it requires the provider to list machine classes and to list and update machine
+	// deployments in the control namespace.
+	SpotAnnotateMachineDeployments bool
+	// TargetKubeconfig is the path to the kubeconfig of the cluster the machines join
+	TargetKubeconfig string
+	// ControlKubeconfig is the path to the kubeconfig of the cluster the machine objects are stored in
+	ControlKubeconfig string
+	// Namespace is the namespace of the machine objects in the control cluster
+	Namespace string
+	// MachineStatusCacheTTL is the duration for which the VM inventory of a resource group is used to determine the
+	// status of machines before it is listed again
+	MachineStatusCacheTTL time.Duration
+	// MachineStatusWatchInterval is the interval in which the Activity Log of the listed resource groups is polled for
+	// VM changes, which are pushed into the VM inventory
+	MachineStatusWatchInterval time.Duration
+	// RegionHealthWindow is the window in which consecutive unavailable errors are counted to detect region-wide issues
+	RegionHealthWindow time.Duration
+	// RegionHealthThreshold is the number of consecutive unavailable errors after which a region is considered degraded
+	RegionHealthThreshold int
+	// DryRun enables the read-only mode in which mutating Azure and Kubernetes API requests are logged but not sent
+	DryRun bool
+	// DryRunErrorCode is the Azure error code returned for mutating requests in dry run mode, which succeed if empty
+	DryRunErrorCode string
+	// ThrottlingRetries is the maximum number of retries of Azure API requests throttled by Azure Resource Manager
+	ThrottlingRetries int
+	// ThrottlingMinBackoff is the backoff of the first retry of a throttled request without Retry-After header
+	ThrottlingMinBackoff time.Duration
+	// ThrottlingMaxBackoff caps the backoff of the retries of throttled requests
+	ThrottlingMaxBackoff time.Duration
+	// ClientCacheTTL is the duration for which the Azure clients of a secret are reused across requests
+	ClientCacheTTL time.Duration
+	// LookupCacheTTL is the duration for which the results of subnet and VM image lookups are reused across requests
+	LookupCacheTTL time.Duration
+	// TokenRefreshBefore is the duration before their expiry in which the AAD tokens of the cached Azure clients are
+	// refreshed in the background
+	TokenRefreshBefore time.Duration
+	// UserAgentSuffix is appended to the User-Agent header of all Azure API requests
+	UserAgentSuffix string
+	// PartnerID is the GUID of the Microsoft partner the Azure usage is attributed to
+	PartnerID string
+	// UserDataTransformers are the names of the built-in transformers applied to the user data, in order
+	UserDataTransformers []string
+	// InjectedLatency is the artificial delay of all Azure API requests, for non-production environments only
+	InjectedLatency time.Duration
+	// InjectedLatencyJitter is the upper bound of the random delay added to the injected latency
+	InjectedLatencyJitter time.Duration
+	// StopCh stops the background loops started by ApplyTo once it is closed. They run until the process exits if it
+	// is nil.
+	StopCh <-chan struct{}
+}
+
+// NewDriverOptions returns the DriverOptions with their default values
+func NewDriverOptions() *DriverOptions {
+	return &DriverOptions{
+		EventGridTimeout:      10 * time.Second,
+		EventGridQueueSize:    1000,
+		RegionHealthThreshold: 5,
+		ThrottlingMinBackoff:  5 * time.Second,
+		ThrottlingMaxBackoff:  time.Minute,
+		TagValuePolicy:        TagValuePolicyFail,
+	}
+}
+
+// AddFlags adds the provider specific flags to the given flag set
+func (o *DriverOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.EventGridTopicEndpoint, "event-grid-topic-endpoint", o.EventGridTopicEndpoint, "Endpoint of the Event Grid topic machine lifecycle events are published to. Publishing is disabled if empty")
+	fs.StringVar(&o.EventGridTopicKeyFile, "event-grid-topic-key-file", o.EventGridTopicKeyFile, "Filepath to the access key of the Event Grid topic")
+	fs.DurationVar(&o.EventGridTimeout, "event-grid-timeout", o.EventGridTimeout, "Timeout for publishing machine lifecycle events to the Event Grid topic")
+	fs.IntVar(&o.EventGridQueueSize, "event-grid-queue-size", o.EventGridQueueSize, "Maximum number of machine lifecycle events queued for publishing to the Event Grid topic. Events are published in the background, so that machine operations do not wait for Event Grid, and dropped if the queue is full")
+	fs.DurationVar(&o.BootstrapTokenTTL, "bootstrap-token-ttl", o.BootstrapTokenTTL, fmt.Sprintf("Lifetime of the bootstrap tokens issued in the target cluster for new machines and rendered into the %s placeholder of the user data. Issuing is disabled if zero", bootstrap.TokenPlaceholder))
+	fs.DurationVar(&o.SpotTrackingInterval, "spot-tracking-interval", o.SpotTrackingInterval, "Interval in which the spot price and eviction rate of the VM sizes of all machine classes are queried and exported as metrics. Tracking is disabled if zero")
+	fs.BoolVar(&o.SpotAnnotateMachineDeployments, "spot-annotate-machine-deployments", o.SpotAnnotateMachineDeployments, "Annotate machine deployments with the tracked spot price and eviction rate of their VM size. Requires --spot-tracking-interval and RBAC permissions to list machineclasses and to list and update machinedeployments of the machine.sapcloud.io API group in the control namespace")
+	fs.StringVar(&o.OwnerID, "owner-id", o.OwnerID, "Identifier of this machine controller instance, e.g. the seed name. It is tagged on created machine resources; VMs tagged with another owner are neither listed nor deleted. Machines annotated with "+api.MachineAnnotationHandOverTo+" are handed over to the named owner when they are deleted")
+	fs.StringVar(&o.TagValuePolicy, "tag-value-policy", o.TagValuePolicy, fmt.Sprintf("Handling of tag values exceeding %d characters: %q leaves them to Azure, which fails the creation, %q truncates them and %q truncates them and appends a hash of the full value. Shortened values are reported with a warning event on the machine", tagValueMaxLength, TagValuePolicyFail, TagValuePolicyTruncate, TagValuePolicyHash))
+	fs.StringSliceVar(&o.ReservedTagKeys, "reserved-tag-keys", o.ReservedTagKeys, "Tag keys which are reserved by the operator, e.g. costcenter or owner tags enforced by governance. The creation of machines and the reconciliation of tags of machine classes setting one of them fails. A key ending with * reserves all keys with its prefix. Keys are compared case-insensitively")
+	fs.BoolVar(&o.CheckIdentityExistence, "check-identity-existence", o.CheckIdentityExistence, "Verify that the user-assigned identity of a machine class exists before any resource of a machine is created, at the cost of an additional Azure API request per creation")
+	fs.DurationVar(&o.NICCreateTimeout, "nic-create-timeout", o.NICCreateTimeout, "Timeout of the creation of a network interface, after which the machine creation fails and is retried. The creation is only bounded by the request and the Azure SDK polling duration if zero")
+	fs.DurationVar(&o.VMCreateTimeout, "vm-create-timeout", o.VMCreateTimeout, "Timeout of the creation of a VM, after which the machine creation fails and is retried. The creation is only bounded by the request and the Azure SDK polling duration if zero")
+	fs.DurationVar(&o.GracefulShutdownTimeout, "graceful-shutdown-timeout", o.GracefulShutdownTimeout, "Timeout of the shutdown of a VM through its OS before it is deleted, so that in-flight workloads can terminate and local writes are flushed. The VM is deleted anyway if the shutdown fails or times out. VMs are deleted without shutdown if zero")
+	fs.DurationVar(&o.DeleteTimeout, "delete-timeout", o.DeleteTimeout, "Timeout of the deletion of a VM and of the deletion of its network interfaces and disks, which starts after the graceful shutdown of the VM, after which the machine deletion fails and is retried. The deletion is only bounded by the request and the Azure SDK polling duration if zero")
+	fs.DurationVar(&o.GuestAgentReadyTimeout, "guest-agent-ready-timeout", o.GuestAgentReadyTimeout, "Timeout for the guest agent of a created VM to report ready in the instance view of the VM, before the machine creation succeeds. A VM whose guest agent does not become ready, e.g. due to an image with broken cloud-init, fails the creation and is recreated. The guest agent is not waited for if zero")
+	fs.DurationVar(&o.ImageCanaryTimeout, "image-canary-timeout", o.ImageCanaryTimeout, "Timeout for the guest agent of the first machine created with an image which was not validated yet to report ready. Other machines with the image are rejected as unavailable until this canary booted, and the image is rejected for a backoff starting at 5 minutes and doubling up to an hour if it fails to boot, so that an unbootable image does not roll out to the whole fleet. The validation is kept in memory, images of running VMs are considered validated after a restart. Images are not validated if zero")
+	fs.IntVar(&o.MaxInFlightCreations, "max-in-flight-creations", o.MaxInFlightCreations, "Maximum number of concurrent machine creations per resource group, counting each creation until its VM is created and its guest agent is ready. Further creations fail with ResourceExhausted and are retried by the machine controller, so that a misbehaving autoscaler cannot exhaust the quota or cause throttling. The creations are counted per machine controller process, creations of other processes are not capped. Creations are not capped if zero")
+	fs.BoolVar(&o.NetworkDiagnostics, "network-diagnostics", o.NetworkDiagnostics, "Record the effective security rules and routes of the primary network interface of a failed machine whose node never joined with a warning event on the machine before it is deleted, to speed up the investigation of nodes which cannot reach the API server")
+	fs.BoolVar(&o.AsyncDeletion, "async-deletion", o.AsyncDeletion, "Issue the graceful shutdown and the deletion of the VM, NICs and disks of a machine without waiting for them to complete. The machine is reported as deleted once a subsequent delete request finds all resources gone")
+	fs.BoolVar(&o.StrictProviderSpecDecoding, "strict-provider-spec-decoding", o.StrictProviderSpecDecoding, fmt.Sprintf("Reject provider specs with unknown fields, e.g. misspelled ones like diskSizeGb, with an error naming the fields instead of ignoring them. Machine classes can opt in individually with the %s annotation", api.MachineClassAnnotationStrictDecoding))
+	fs.DurationVar(&o.MachineStatusCacheTTL, "machine-status-cache-ttl", o.MachineStatusCacheTTL, "Duration for which the listed VMs of a resource group are used to determine the status of machines, instead of looking them up for every machine. Only the VMs carrying the cluster and role tags of the machine class are listed, other VMs are looked up directly. Caching is disabled if zero, which is the default")
+	fs.DurationVar(&o.MachineStatusWatchInterval, "machine-status-watch-interval", o.MachineStatusWatchInterval, "Interval in which the Activity Log of the resource groups of all listed machine classes is polled for VM changes, e.g. out-of-band deletions, which are removed from the cached VMs. Changes are detected once Azure makes them available in the Activity Log, which usually takes a few minutes but is not bounded, hence the machine status cache TTL still bounds how long a stale status is served. Watching is disabled if zero")
+	fs.DurationVar(&o.RegionHealthWindow, "region-health-window", o.RegionHealthWindow, "Window in which consecutive unavailable errors of machine creations are counted per region. A region is reported as degraded in the mcm_azure_region_degraded metric and the machine errors once the threshold is reached across at least two machine classes. Detection is disabled if zero")
+	fs.IntVar(&o.RegionHealthThreshold, "region-health-threshold", o.RegionHealthThreshold, "Number of consecutive unavailable errors within the region health window after which a region is considered degraded")
+	fs.BoolVar(&o.DryRun, "dry-run", o.DryRun, "Read-only mode in which mutating Azure and Kubernetes API requests are logged and answered with a synthetic response instead of being sent, e.g. for shadow deployments against production machine classes")
+	fs.StringVar(&o.DryRunErrorCode, "dry-run-error-code", o.DryRunErrorCode, "Azure error code of the synthetic failure returned for mutating requests in dry run mode. Mutating requests succeed if empty")
+	fs.IntVar(&o.ThrottlingRetries, "azure-throttling-retries", o.ThrottlingRetries, "Maximum number of retries of Azure API requests throttled by Azure Resource Manager with status code 429 or error code TooManyRequests. Requests which are still throttled fail with ResourceExhausted. Retrying is disabled if zero")
+	fs.DurationVar(&o.ThrottlingMinBackoff, "azure-throttling-min-backoff", o.ThrottlingMinBackoff, "Backoff of the first retry of a throttled Azure API request without Retry-After header. It is doubled for every further retry and randomized by up to half")
+	fs.DurationVar(&o.ThrottlingMaxBackoff, "azure-throttling-max-backoff", o.ThrottlingMaxBackoff, "Maximum backoff of the retries of a throttled Azure API request, including the Retry-After of Azure")
+	fs.DurationVar(&o.ClientCacheTTL, "azure-client-cache-ttl", o.ClientCacheTTL, "Duration for which the Azure clients and their AAD token are reused for requests with the same credentials, instead of acquiring a new token for every request. Cached clients are dropped once Azure rejects their token. Caching is disabled if zero")
+	fs.DurationVar(&o.LookupCacheTTL, "azure-lookup-cache-ttl", o.LookupCacheTTL, "Duration for which the results of subnet and VM image lookups are reused for requests with the same credentials, so that creating many machines of a machine class does not exhaust the Azure read quota. Changes of the subnets, e.g. their provisioning state, are noticed with this delay. Caching is disabled if zero")
+	fs.DurationVar(&o.TokenRefreshBefore, "azure-token-refresh-before", o.TokenRefreshBefore, fmt.Sprintf("Duration before their expiry in which the AAD tokens of the Azure clients cached for --azure-client-cache-ttl are refreshed in the background, so that requests do not wait for their refresh. Must be less than %s, the minimum lifetime of AAD tokens. Tokens are only refreshed when used if zero", spi.MaxTokenRefreshBefore))
+	fs.StringVar(&o.UserAgentSuffix, "azure-user-agent-suffix", o.UserAgentSuffix, "Suffix appended to the User-Agent header of all Azure API requests, e.g. to identify the installation in support requests")
+	fs.StringVar(&o.PartnerID, "azure-partner-id", o.PartnerID, "GUID of the Microsoft partner the Azure usage is attributed to. It is appended to the User-Agent header of all Azure API requests as pid-<GUID>")
+	fs.StringSliceVar(&o.UserDataTransformers, "user-data-transformers", o.UserDataTransformers, fmt.Sprintf("Ordered list of transformers applied to the user data of machines: %q substitutes the machine name, class, location and resource group placeholders, %q resolves <<SECRET_REF:name/key>> placeholders from secrets in the namespace of the machine objects which are labelled with %s=true, %q compresses the user data and %q rejects user data exceeding the Azure limit of %d bytes", userdata.NameVariables, userdata.NameSecretRefs, userdata.SecretRefLabel, userdata.NameGzip, userdata.NameSizeGuard, userdata.MaxSize))
+	fs.DurationVar(&o.InjectedLatency, "inject-azure-api-latency", o.InjectedLatency, "Artificial delay of every Azure API request to validate timeouts and backoffs against a slow Azure API. Must not be used in production environments")
+	fs.DurationVar(&o.InjectedLatencyJitter, "inject-azure-api-latency-jitter", o.InjectedLatencyJitter, "Upper bound of the random delay added to the injected Azure API latency")
+	fs.DurationVar(&o.TagSyncInterval, "tag-sync-interval", o.TagSyncInterval, "Interval in which the tags of the machine class are added to the VM, NICs and disks of existing machines when their status is requested. Changed tags of a machine class are synchronized with the next status request, so that tag rollouts do not require rolling the machines. Tags which are not set by the machine class are kept. Synchronization is disabled if zero")
+	fs.DurationVar(&o.OrphanGracePeriod, "orphan-resources-grace-period", o.OrphanGracePeriod, "Duration after which tagged NICs and disks created by the provider which are not attached to any VM are deleted when listing machines. Garbage collection is disabled if zero")
+}
+
+// ApplyTo configures the given driver according to the options
+func (o *DriverOptions) ApplyTo(d *MachinePlugin) error {
+	if o.EventGridTopicEndpoint != "" {
+		if o.EventGridTopicKeyFile == "" {
+			return fmt.Errorf("--event-grid-topic-key-file is required if --event-grid-topic-endpoint is set")
+		}
+		key, err := ioutil.ReadFile(o.EventGridTopicKeyFile)
+		if err != nil {
+			return fmt.Errorf("Could not read Event Grid topic key: %v", err)
+		}
+		if o.EventGridQueueSize <= 0 {
+			return fmt.Errorf("--event-grid-queue-size must be positive")
+		}
+		d.Publisher = eventgrid.NewAsyncPublisher(eventgrid.NewTopicPublisher(o.EventGridTopicEndpoint, strings.TrimSpace(string(key)), o.EventGridTimeout), o.EventGridQueueSize, o.stopCh(), func(err error) {
+			spi.WarningS(context.Background(), "Could not publish machine events", "err", err)
+		})
+	}
+	if o.BootstrapTokenTTL > 0 {
+		config, err := o.buildConfig(o.TargetKubeconfig)
+		if err != nil {
+			return fmt.Errorf("Could not load target kubeconfig: %v", err)
+		}
+		client, err := kubernetes.NewForConfig(config)
+		if err != nil {
+			return fmt.Errorf("Could not create target cluster client: %v", err)
+		}
+		d.TokenIssuer = bootstrap.NewTokenIssuer(client, o.BootstrapTokenTTL)
+	}
+	latencyInjection := &spi.LatencyInjection{Latency: o.InjectedLatency, Jitter: o.InjectedLatencyJitter}
+	if latencyInjection.Enabled() || o.DryRun {
+		impl, ok := d.SPI.(*spi.PluginSPIImpl)
+		if !ok {
+			return fmt.Errorf("Azure API latency injection and dry run are not supported by the session provider %T", d.SPI)
+		}
+		if latencyInjection.Enabled() {
+			spi.WarningS(context.Background(), "Injecting latency into all Azure API requests", "latency", o.InjectedLatency, "jitter", o.InjectedLatencyJitter)
+			impl.LatencyInjection = latencyInjection
+		}
+		if o.DryRun {
+			spi.WarningS(context.Background(), "Running in dry run mode, mutating Azure and Kubernetes API requests are not sent")
+			impl.DryRun = &spi.DryRun{ErrorCode: o.DryRunErrorCode}
+		}
+	}
+	if o.UserAgentSuffix != "" || o.PartnerID != "" {
+		userAgent := &spi.UserAgent{Suffix: o.UserAgentSuffix, PartnerID: o.PartnerID}
+		if err := userAgent.Validate(); err != nil {
+			return fmt.Errorf("Invalid --azure-partner-id: %v", err)
+		}
+		impl, ok := d.SPI.(*spi.PluginSPIImpl)
+		if !ok {
+			return fmt.Errorf("Azure User-Agent extensions are not supported by the session provider %T", d.SPI)
+		}
+		impl.UserAgent = userAgent
+	}
+	if o.ThrottlingRetries > 0 {
+		impl, ok := d.SPI.(*spi.PluginSPIImpl)
+		if !ok {
+			return fmt.Errorf("Retries of throttled Azure API requests are not supported by the session provider %T", d.SPI)
+		}
+		impl.Throttling = &spi.Throttling{MaxRetries: o.ThrottlingRetries, MinBackoff: o.ThrottlingMinBackoff, MaxBackoff: o.ThrottlingMaxBackoff}
+	}
+	if o.ClientCacheTTL > 0 {
+		impl, ok := d.SPI.(*spi.PluginSPIImpl)
+		if !ok {
+			return fmt.Errorf("Caching of Azure clients is not supported by the session provider %T", d.SPI)
+		}
+		if o.TokenRefreshBefore < 0 || o.TokenRefreshBefore >= spi.MaxTokenRefreshBefore {
+			return fmt.Errorf("--azure-token-refresh-before must be less than %s, the minimum lifetime of AAD tokens", spi.MaxTokenRefreshBefore)
+		}
+		impl.ClientCache = spi.NewClientCache(o.ClientCacheTTL, o.TokenRefreshBefore)
+		if o.TokenRefreshBefore > 0 {
+			go wait.Until(func() { impl.ClientCache.Refresh(context.Background()) }, o.TokenRefreshBefore/2, o.stopCh())
+		}
+	} else if o.TokenRefreshBefore > 0 {
+		return fmt.Errorf("--azure-token-refresh-before requires --azure-client-cache-ttl, as only the tokens of cached Azure clients are refreshed")
+	}
+	if o.LookupCacheTTL > 0 {
+		impl, ok := d.SPI.(*spi.PluginSPIImpl)
+		if !ok {
+			return fmt.Errorf("Caching of Azure lookups is not supported by the session provider %T", d.SPI)
+		}
+		impl.LookupCache = spi.NewLookupCache(o.LookupCacheTTL)
+	}
+	switch o.TagValuePolicy {
+	case TagValuePolicyFail:
+	case TagValuePolicyTruncate, TagValuePolicyHash:
+		if err := o.applyEventRecorder(d); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("--tag-value-policy must be one of %q, %q or %q", TagValuePolicyFail, TagValuePolicyTruncate, TagValuePolicyHash)
+	}
+	d.tagValuePolicy = o.TagValuePolicy
+	d.reservedTagKeys = o.ReservedTagKeys
+	if o.NetworkDiagnostics {
+		if err := o.applyEventRecorder(d); err != nil {
+			return err
+		}
+		d.networkDiagnostics = true
+	}
+	if len(o.UserDataTransformers) > 0 {
+		var getSecret userdata.SecretGetter
+		for _, name := range o.UserDataTransformers {
+			if strings.TrimSpace(name) != userdata.NameSecretRefs {
+				continue
+			}
+			config, err := o.buildConfig(o.controlKubeconfig())
+			if err != nil {
+				return fmt.Errorf("Could not load control kubeconfig: %v", err)
+			}
+			client, err := kubernetes.NewForConfig(config)
+			if err != nil {
+				return fmt.Errorf("Could not create control cluster client: %v", err)
+			}
+			getSecret = newSecretGetter(client, o.Namespace)
+			break
+		}
+		chain, err := userdata.NewChain(o.UserDataTransformers, getSecret)
+		if err != nil {
+			return err
+		}
+		d.UserDataTransformers = chain
+	}
+	d.ownerID = o.OwnerID
+	d.asyncDeletion = o.AsyncDeletion
+	d.strictDecoding = o.StrictProviderSpecDecoding
+	d.nicCreateTimeout = o.NICCreateTimeout
+	d.vmCreateTimeout = o.VMCreateTimeout
+	d.guestAgentReadyTimeout = o.GuestAgentReadyTimeout
+	if o.ImageCanaryTimeout > 0 {
+		d.imageCanaries = newImageCanaries(o.ImageCanaryTimeout)
+	}
+	d.deleteTimeout = o.DeleteTimeout
+	d.gracefulShutdownTimeout = o.GracefulShutdownTimeout
+	d.checkIdentityExistence = o.CheckIdentityExistence
+	if o.MaxInFlightCreations > 0 {
+		d.creationBudget = newCreationBudget(o.MaxInFlightCreations)
+	}
+	if o.RegionHealthWindow > 0 {
+		if o.RegionHealthThreshold <= 0 {
+			return fmt.Errorf("--region-health-threshold must be positive")
+		}
+		d.regionHealth = newRegionHealth(o.RegionHealthWindow, o.RegionHealthThreshold)
+	}
+	if o.MachineStatusCacheTTL > 0 {
+		d.vmInventory = newVMInventory(o.MachineStatusCacheTTL)
+	}
+	if o.MachineStatusWatchInterval > 0 {
+		if d.vmInventory == nil {
+			return fmt.Errorf("--machine-status-watch-interval requires a positive --machine-status-cache-ttl")
+		}
+		d.vmWatcher = newVMWatcher(d.vmInventory)
+		go wait.Until(func() { d.vmWatcher.sync(context.Background()) }, o.MachineStatusWatchInterval, o.stopCh())
+	}
+	if o.OrphanGracePeriod > 0 {
+		d.orphanCollector = newOrphanCollector(o.OrphanGracePeriod)
+	}
+	if o.TagSyncInterval > 0 {
+		if err := o.applyEventRecorder(d); err != nil {
+			return err
+		}
+		d.tagSyncs = newTagSyncs(o.TagSyncInterval)
+	}
+	if o.SpotTrackingInterval > 0 {
+		var annotator *spot.Annotator
+		if o.SpotAnnotateMachineDeployments {
+			config, err := o.buildConfig(o.controlKubeconfig())
+			if err != nil {
+				return fmt.Errorf("Could not load control kubeconfig: %v", err)
+			}
+			client, err := versioned.NewForConfig(config)
+			if err != nil {
+				return fmt.Errorf("Could not create control cluster client: %v", err)
+			}
+			annotator = spot.NewAnnotator(client, o.Namespace)
+		}
+		d.spotTracker = spot.NewTracker(spot.NewRetailPriceFetcher("", 30*time.Second), annotator)
+		go wait.Until(func() { d.spotTracker.Sync(context.Background()) }, o.SpotTrackingInterval, o.stopCh())
+	} else if o.SpotAnnotateMachineDeployments {
+		return fmt.Errorf("--spot-annotate-machine-deployments requires a positive --spot-tracking-interval")
+	}
+	return nil
+}
+
+// applyEventRecorder configures the driver to record events on the machine objects in the control cluster, unless
+// it records them already
+func (o *DriverOptions) applyEventRecorder(d *MachinePlugin) error {
+	if d.Recorder != nil {
+		return nil
+	}
+	config, err := o.buildConfig(o.controlKubeconfig())
+	if err != nil {
+		return fmt.Errorf("Could not load control kubeconfig: %v", err)
+	}
+	client, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return fmt.Errorf("Could not create control cluster client: %v", err)
+	}
+	broadcaster := record.NewBroadcaster()
+	broadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: client.CoreV1().Events(o.Namespace)})
+	d.Recorder = broadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: "machine-controller"})
+	return nil
+}
+
+// newSecretGetter returns a secret getter reading the secrets of the given namespace which are labelled to be
+// referenced by user data
+func newSecretGetter(client kubernetes.Interface, namespace string) userdata.SecretGetter {
+	return func(name, key string) ([]byte, error) {
+		secret, err := client.CoreV1().Secrets(namespace).Get(name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("secret %s/%s does not exist", namespace, name))
+		} else if err != nil {
+			return nil, status.Error(codes.Unavailable, err.Error())
+		}
+		if secret.Labels[userdata.SecretRefLabel] != "true" {
+			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("secret %s/%s may not be referenced by user data, as it is not labelled with %s=true", namespace, name, userdata.SecretRefLabel))
+		}
+		value, ok := secret.Data[key]
+		if !ok {
+			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("secret %s/%s has no key %q", namespace, name, key))
+		}
+		return value, nil
+	}
+}
+
+// stopCh returns the channel stopping the background loops, which defaults to a channel which is never closed
+func (o *DriverOptions) stopCh() <-chan struct{} {
+	if o.StopCh == nil {
+		return wait.NeverStop
+	}
+	return o.StopCh
+}
+
+// controlKubeconfig returns the kubeconfig of the control cluster, which defaults to the target cluster
+func (o *DriverOptions) controlKubeconfig() string {
+	if o.ControlKubeconfig == "" {
+		return o.TargetKubeconfig
+	}
+	return o.ControlKubeconfig
+}
+
+// buildConfig loads the given kubeconfig. The in-cluster config is used if the path is empty or 'inClusterConfig'.
+// Mutating requests of the clients of the config are suppressed in dry run mode.
+func (o *DriverOptions) buildConfig(kubeconfig string) (*rest.Config, error) {
+	if kubeconfig == "inClusterConfig" {
+		kubeconfig = ""
+	}
+	config, err := clientcmd.BuildConfigFromFlags("", kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+	if o.DryRun {
+		config.WrapTransport = spi.DryRunTransport
+	}
+	return config, nil
+}
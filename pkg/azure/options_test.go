@@ -0,0 +1,49 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/userdata"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/codes"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/status"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+var _ = Describe("Options", func() {
+	Describe("#newSecretGetter", func() {
+		var getSecret userdata.SecretGetter
+
+		BeforeEach(func() {
+			getSecret = newSecretGetter(fake.NewSimpleClientset(
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "bootstrap", Namespace: "shoot", Labels: map[string]string{userdata.SecretRefLabel: "true"}},
+					Data:       map[string][]byte{"ca": []byte("certificate")},
+				},
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "cloudprovider", Namespace: "shoot"},
+					Data:       map[string][]byte{"clientSecret": []byte("secret")},
+				},
+			), "shoot")
+		})
+
+		It("should return the keys of labelled secrets", func() {
+			Expect(getSecret("bootstrap", "ca")).To(Equal([]byte("certificate")))
+		})
+
+		It("should reject secrets which are not labelled, missing secrets and missing keys", func() {
+			for _, ref := range [][2]string{{"cloudprovider", "clientSecret"}, {"missing", "ca"}, {"bootstrap", "missing"}} {
+				_, err := getSecret(ref[0], ref[1])
+				Expect(err).To(HaveOccurred())
+				Expect(err.(*status.Status).Code()).To(Equal(codes.InvalidArgument))
+			}
+		})
+	})
+})
@@ -0,0 +1,211 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-08-01/compute"
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-04-01/network"
+	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/spi"
+)
+
+// orphanCollector garbage collects NICs and disks which carry the tags of a machine class
+// but are not attached to any VM anymore. Such resources are leftovers of failed creations
+// or deletions. Disks are only collected if they are marked as created by the provider, as
+// detached disks of persistent volumes may carry the tags of the machine class as well. A resource is only deleted once it has been seen as orphaned for longer than
+// the grace period, so that resources of machines which are currently being created are not touched.
+type orphanCollector struct {
+	gracePeriod time.Duration
+
+	mutex     sync.Mutex
+	firstSeen map[string]time.Time
+}
+
+// orphanedResource is a NIC or disk which is not attached to any VM
+type orphanedResource struct {
+	id      string
+	name    string
+	deleter func() error
+}
+
+func newOrphanCollector(gracePeriod time.Duration) *orphanCollector {
+	return &orphanCollector{
+		gracePeriod: gracePeriod,
+		firstSeen:   map[string]time.Time{},
+	}
+}
+
+// collect deletes all NICs and disks in the resource groups of the provider spec which match the
+// machine class tags, are not attached to a VM and have been orphaned for longer than the grace period
+func (c *orphanCollector) collect(ctx context.Context, clients spi.AzureDriverClientsInterface, providerSpec *api.AzureProviderSpec) error {
+	var (
+		resourceGroupName = providerSpec.ResourceGroup
+		nicResourceGroup  = nicResourceGroupOf(providerSpec)
+	)
+
+	orphans, err := listOrphanedNICs(ctx, clients, nicResourceGroup, providerSpec.Tags)
+	if err != nil {
+		return err
+	}
+	orphanedDisks, err := listOrphanedDisks(ctx, clients, resourceGroupName, providerSpec.Tags)
+	if err != nil {
+		return err
+	}
+	orphans = append(orphans, orphanedDisks...)
+
+	expired := c.expired([]string{resourceGroupName, nicResourceGroup}, orphans, time.Now())
+	if len(expired) == 0 {
+		return nil
+	}
+
+	var deleters []func() error
+	for _, orphan := range expired {
+		spi.V(2).InfoS(ctx, "Deleting orphaned resource which is not attached to any VM", "resource", orphan.name, "gracePeriod", c.gracePeriod)
+		deleters = append(deleters, orphan.deleter)
+	}
+	if err := spi.RunInParallel(deleters); err != nil {
+		return err
+	}
+
+	c.forget(expired)
+	return nil
+}
+
+// expired records the given orphans and returns those which are orphaned for longer than the grace period.
+// Resources of the resource groups that are not orphaned anymore are forgotten.
+func (c *orphanCollector) expired(resourceGroupNames []string, orphans []orphanedResource, now time.Time) []orphanedResource {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	current := make(map[string]bool, len(orphans))
+	var expired []orphanedResource
+	for _, orphan := range orphans {
+		current[orphan.id] = true
+		firstSeen, ok := c.firstSeen[orphan.id]
+		if !ok {
+			c.firstSeen[orphan.id] = now
+			continue
+		}
+		if now.Sub(firstSeen) > c.gracePeriod {
+			expired = append(expired, orphan)
+		}
+	}
+
+	for _, resourceGroupName := range resourceGroupNames {
+		prefix := strings.ToLower("/resourceGroups/" + resourceGroupName + "/")
+		for id := range c.firstSeen {
+			if strings.Contains(id, prefix) && !current[id] {
+				delete(c.firstSeen, id)
+			}
+		}
+	}
+	return expired
+}
+
+func (c *orphanCollector) forget(orphans []orphanedResource) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for _, orphan := range orphans {
+		delete(c.firstSeen, orphan.id)
+	}
+}
+
+func listOrphanedNICs(ctx context.Context, clients spi.AzureDriverClientsInterface, resourceGroupName string, tags map[string]string) ([]orphanedResource, error) {
+	var (
+		items  []network.Interface
+		result network.InterfaceListResultPage
+		err    error
+	)
+
+	result, err = clients.GetNic().List(ctx, resourceGroupName)
+	if err != nil {
+		return nil, spi.OnARMAPIErrorFail(prometheusServiceNIC, err, "NIC.List")
+	}
+	items = append(items, result.Values()...)
+	for result.NotDone() {
+		if err = result.NextWithContext(ctx); err != nil {
+			return nil, spi.OnARMAPIErrorFail(prometheusServiceNIC, err, "NIC.List")
+		}
+		items = append(items, result.Values()...)
+	}
+	spi.OnARMAPISuccess(prometheusServiceNIC, "NIC.List")
+
+	var orphans []orphanedResource
+	for _, nic := range items {
+		if nic.ID == nil || nic.Name == nil || nic.VirtualMachine != nil || !matchesClassTags(nic.Tags, tags) {
+			continue
+		}
+		nicName := *nic.Name
+		orphans = append(orphans, orphanedResource{
+			id:   strings.ToLower(*nic.ID),
+			name: nicName,
+			deleter: func() error {
+				return spi.DeleteNIC(ctx, clients, resourceGroupName, nicName)
+			},
+		})
+	}
+	return orphans, nil
+}
+
+// listOrphanedDisks returns the detached disks of the machine class which are marked as created by the provider. Disks
+// of VMs created before the marker was introduced are marked by the tag reconciler while they are still attached.
+func listOrphanedDisks(ctx context.Context, clients spi.AzureDriverClientsInterface, resourceGroupName string, tags map[string]string) ([]orphanedResource, error) {
+	var (
+		items  []compute.Disk
+		result compute.DiskListPage
+		err    error
+	)
+
+	result, err = clients.GetDisk().ListByResourceGroup(ctx, resourceGroupName)
+	if err != nil {
+		return nil, spi.OnARMAPIErrorFail(prometheusServiceDisk, err, "Disk.ListByResourceGroup")
+	}
+	items = append(items, result.Values()...)
+	for result.NotDone() {
+		if err = result.NextWithContext(ctx); err != nil {
+			return nil, spi.OnARMAPIErrorFail(prometheusServiceDisk, err, "Disk.ListByResourceGroup")
+		}
+		items = append(items, result.Values()...)
+	}
+	spi.OnARMAPISuccess(prometheusServiceDisk, "Disk.ListByResourceGroup")
+
+	var orphans []orphanedResource
+	for _, disk := range items {
+		if disk.ID == nil || disk.Name == nil || disk.ManagedBy != nil || !matchesClassTags(disk.Tags, tags) || !spi.IsManagedByProvider(disk.Tags) || isRetained(disk.Tags) || isShared(disk.Tags) {
+			continue
+		}
+		orphans = append(orphans, orphanedResource{
+			id:      strings.ToLower(*disk.ID),
+			name:    *disk.Name,
+			deleter: spi.GetDeleterForDisk(ctx, clients, resourceGroupName, *disk.Name),
+		})
+	}
+	return orphans, nil
+}
+
+// matchesClassTags checks whether the resource carries the cluster and role tags of the machine class.
+// Resources without such tags are never considered, as they have not been created by this provider.
+func matchesClassTags(resourceTags map[string]*string, classTags map[string]string) bool {
+	var matched int
+	for key, value := range classTags {
+		if !strings.HasPrefix(key, api.ClusterTagPrefix) && !strings.HasPrefix(key, api.RoleTagPrefix) {
+			continue
+		}
+		resourceValue, ok := resourceTags[key]
+		if !ok || resourceValue == nil || *resourceValue != value {
+			return false
+		}
+		matched++
+	}
+	return matched > 0
+}
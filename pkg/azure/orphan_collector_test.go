@@ -0,0 +1,112 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"context"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-08-01/compute"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/mock"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/spi"
+	gomock "github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("OrphanCollector", func() {
+
+	var (
+		classTags = map[string]string{
+			"Name":                                  "shoot--foo--bar",
+			"kubernetes.io-cluster-shoot--foo--bar": "1",
+			"kubernetes.io-role-node":               "1",
+		}
+		nic = orphanedResource{id: "/subscriptions/sub/resourcegroups/rg/providers/microsoft.network/networkinterfaces/vm-nic", name: "vm-nic"}
+	)
+
+	Describe("#matchesClassTags", func() {
+		It("should match resources carrying the cluster and role tags", func() {
+			Expect(matchesClassTags(map[string]*string{
+				"kubernetes.io-cluster-shoot--foo--bar": to.StringPtr("1"),
+				"kubernetes.io-role-node":               to.StringPtr("1"),
+			}, classTags)).To(BeTrue())
+		})
+
+		It("should not match resources of another cluster", func() {
+			Expect(matchesClassTags(map[string]*string{
+				"kubernetes.io-cluster-shoot--foo--baz": to.StringPtr("1"),
+				"kubernetes.io-role-node":               to.StringPtr("1"),
+			}, classTags)).To(BeFalse())
+		})
+
+		It("should not match anything if the class has no cluster or role tags", func() {
+			Expect(matchesClassTags(map[string]*string{"Name": to.StringPtr("shoot--foo--bar")}, map[string]string{"Name": "shoot--foo--bar"})).To(BeFalse())
+		})
+	})
+
+	Describe("#listOrphanedDisks", func() {
+		It("should only return the detached disks marked as created by the provider", func() {
+			ctx := context.Background()
+			sp := mock.NewMockPluginSPIImpl(gomock.NewController(GinkgoT()))
+			_, secret := newProviderSpecCacheFixtures()
+			driverClients, err := sp.Setup(secret, nil)
+			Expect(err).NotTo(HaveOccurred())
+			clients := driverClients.(*mock.AzureDriverClients)
+
+			disk := func(name string, managedBy *string, markers ...string) compute.Disk {
+				tags := map[string]*string{
+					"kubernetes.io-cluster-shoot--foo--bar": to.StringPtr("1"),
+					"kubernetes.io-role-node":               to.StringPtr("1"),
+				}
+				for _, marker := range markers {
+					tags[marker] = to.StringPtr(spi.DiskManagedByTagValue)
+				}
+				return compute.Disk{ID: to.StringPtr("/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Compute/disks/" + name), Name: to.StringPtr(name), ManagedBy: managedBy, Tags: tags}
+			}
+			disks := []compute.Disk{
+				disk("vm-os-disk", nil, spi.DiskManagedByTagKey),
+				disk("vm-attached-os-disk", to.StringPtr("vm-attached"), spi.DiskManagedByTagKey),
+				disk("pvc-disk", nil),
+			}
+			page := compute.NewDiskListPage(compute.DiskList{}, func(_ context.Context, last compute.DiskList) (compute.DiskList, error) {
+				if last.Value != nil {
+					return compute.DiskList{}, nil
+				}
+				return compute.DiskList{Value: &disks}, nil
+			})
+			Expect(page.NextWithContext(ctx)).To(Succeed())
+			clients.Disk.EXPECT().ListByResourceGroup(ctx, "rg").Return(page, nil)
+
+			orphans, err := listOrphanedDisks(ctx, clients, "rg", classTags)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(orphans).To(HaveLen(1))
+			Expect(orphans[0].name).To(Equal("vm-os-disk"))
+		})
+	})
+
+	Describe("#expired", func() {
+		It("should only return resources orphaned for longer than the grace period", func() {
+			collector := newOrphanCollector(time.Minute)
+			now := time.Now()
+
+			Expect(collector.expired([]string{"rg"}, []orphanedResource{nic}, now)).To(BeEmpty())
+			Expect(collector.expired([]string{"rg"}, []orphanedResource{nic}, now.Add(30*time.Second))).To(BeEmpty())
+			Expect(collector.expired([]string{"rg"}, []orphanedResource{nic}, now.Add(2*time.Minute))).To(HaveLen(1))
+		})
+
+		It("should forget resources which are not orphaned anymore", func() {
+			collector := newOrphanCollector(time.Minute)
+			now := time.Now()
+
+			Expect(collector.expired([]string{"rg"}, []orphanedResource{nic}, now)).To(BeEmpty())
+			Expect(collector.expired([]string{"rg"}, nil, now.Add(30*time.Second))).To(BeEmpty())
+			Expect(collector.expired([]string{"rg"}, []orphanedResource{nic}, now.Add(2*time.Minute))).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,37 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"fmt"
+
+	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+	"github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/codes"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/status"
+)
+
+// selectOSProfile returns the provider spec with the OS profile, the image and the license type replaced by the
+// alternative OS profile named by the os-profile label of the machine, so that a machine deployment can mix machines
+// of different operating systems. The provider spec is returned as it is if the machine is not labeled, and it is
+// never modified.
+func selectOSProfile(providerSpec *api.AzureProviderSpec, machine *v1alpha1.Machine) (*api.AzureProviderSpec, error) {
+	name, ok := machine.Labels[api.MachineLabelOSProfile]
+	if !ok {
+		return providerSpec, nil
+	}
+	alternative, ok := providerSpec.Properties.AlternativeOSProfiles[name]
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("OS profile %q of machine %q is not defined by the machine class", name, machine.Name))
+	}
+
+	selected := *providerSpec
+	selected.Properties.OsProfile = alternative.OsProfile
+	selected.Properties.StorageProfile.ImageReference = alternative.ImageReference
+	selected.Properties.LicenseType = alternative.LicenseType
+	return &selected, nil
+}
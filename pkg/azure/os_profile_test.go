@@ -0,0 +1,87 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"encoding/json"
+
+	"github.com/Azure/go-autorest/autorest/to"
+	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/mock"
+	"github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/codes"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/status"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("OSProfile", func() {
+	var (
+		providerSpec *api.AzureProviderSpec
+		windows      api.AzureAlternativeOSProfile
+
+		machine = func(labels map[string]string) *v1alpha1.Machine {
+			return &v1alpha1.Machine{ObjectMeta: metav1.ObjectMeta{Name: "machine", Labels: labels}}
+		}
+	)
+
+	BeforeEach(func() {
+		providerSpec = UnmarshalProviderSpec(mock.AzureProviderSpec)
+		windows = api.AzureAlternativeOSProfile{
+			OsProfile:      api.AzureOSProfile{AdminUsername: "admin", OSType: api.OSTypeWindows},
+			ImageReference: api.AzureImageReference{URN: to.StringPtr("MicrosoftWindowsServer:WindowsServer:2019-Datacenter:latest")},
+			LicenseType:    to.StringPtr(api.LicenseTypeWindowsServer),
+		}
+		providerSpec.Properties.AlternativeOSProfiles = map[string]api.AzureAlternativeOSProfile{"windows": windows}
+	})
+
+	It("should keep the OS profile of the machine class for machines without label", func() {
+		Expect(selectOSProfile(providerSpec, machine(nil))).To(BeIdenticalTo(providerSpec))
+	})
+
+	It("should select the alternative OS profile named by the label of the machine", func() {
+		osProfile, imageReference := providerSpec.Properties.OsProfile, providerSpec.Properties.StorageProfile.ImageReference
+
+		selected, err := selectOSProfile(providerSpec, machine(map[string]string{api.MachineLabelOSProfile: "windows"}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(selected.Properties.OsProfile).To(Equal(windows.OsProfile))
+		Expect(selected.Properties.StorageProfile.ImageReference).To(Equal(windows.ImageReference))
+		Expect(selected.Properties.LicenseType).To(Equal(windows.LicenseType))
+		Expect(selected.Properties.HardwareProfile).To(Equal(providerSpec.Properties.HardwareProfile))
+
+		Expect(providerSpec.Properties.OsProfile).To(Equal(osProfile))
+		Expect(providerSpec.Properties.StorageProfile.ImageReference).To(Equal(imageReference))
+	})
+
+	It("should reject OS profiles which are not defined by the machine class", func() {
+		_, err := selectOSProfile(providerSpec, machine(map[string]string{api.MachineLabelOSProfile: "freebsd"}))
+		s, ok := status.FromError(err)
+		Expect(ok).To(BeTrue())
+		Expect(s.Code()).To(Equal(codes.InvalidArgument))
+	})
+
+	It("should validate the alternative OS profiles", func() {
+		machineClass, secret := newProviderSpecCacheFixtures()
+		secret.Data[api.WindowsAdminPasswordSecretKey] = []byte("password")
+		raw, err := json.Marshal(providerSpec)
+		Expect(err).NotTo(HaveOccurred())
+		machineClass.ProviderSpec.Raw = raw
+
+		_, err = decodeProviderSpecAndSecret(machineClass, secret)
+		Expect(err).NotTo(HaveOccurred())
+
+		windows.ImageReference = api.AzureImageReference{}
+		providerSpec.Properties.AlternativeOSProfiles["windows"] = windows
+		raw, err = json.Marshal(providerSpec)
+		Expect(err).NotTo(HaveOccurred())
+		machineClass.ProviderSpec.Raw = raw
+
+		_, err = decodeProviderSpecAndSecret(machineClass, secret)
+		Expect(err).To(MatchError(ContainSubstring("properties.alternativeOSProfiles[windows].imageReference")))
+	})
+})
@@ -0,0 +1,35 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/spi"
+	"github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/codes"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/status"
+)
+
+// isPaused returns true if the reconciliation of the machine is paused by its annotation
+func isPaused(machine *v1alpha1.Machine) bool {
+	paused, err := strconv.ParseBool(machine.Annotations[api.MachineAnnotationPaused])
+	return err == nil && paused
+}
+
+// checkPaused returns an unavailable error if the reconciliation of the machine is paused, so that the machine
+// controller retries the operation until the annotation is removed
+func checkPaused(ctx context.Context, machine *v1alpha1.Machine, operation string) error {
+	if !isPaused(machine) {
+		return nil
+	}
+	spi.InfoS(ctx, "Machine is paused, the operation is skipped", "annotation", api.MachineAnnotationPaused)
+	return status.Error(codes.Unavailable, fmt.Sprintf("The %s of machine %q is skipped, as it is paused by the annotation %s", operation, machine.Name, api.MachineAnnotationPaused))
+}
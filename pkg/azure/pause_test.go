@@ -0,0 +1,66 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"context"
+
+	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/mock"
+	"github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/driver"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/codes"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/status"
+	gomock "github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Pause", func() {
+	var (
+		ctx = context.Background()
+
+		pausedMachine = func(value string) *v1alpha1.Machine {
+			machine := newMachine("machine")
+			machine.Annotations = map[string]string{api.MachineAnnotationPaused: value}
+			return machine
+		}
+		expectUnavailable = func(err error) {
+			s, ok := status.FromError(err)
+			Expect(ok).To(BeTrue())
+			Expect(s.Code()).To(Equal(codes.Unavailable))
+			Expect(s.Message()).To(ContainSubstring(api.MachineAnnotationPaused))
+		}
+	)
+
+	Describe("#isPaused", func() {
+		It("should only pause machines annotated with true", func() {
+			Expect(isPaused(newMachine("machine"))).To(BeFalse())
+			Expect(isPaused(pausedMachine("false"))).To(BeFalse())
+			Expect(isPaused(pausedMachine("yes"))).To(BeFalse())
+			Expect(isPaused(pausedMachine("true"))).To(BeTrue())
+		})
+	})
+
+	Describe("mutating operations", func() {
+		var d *MachinePlugin
+
+		BeforeEach(func() {
+			// The mock fails the test on any Azure API request
+			d = NewAzureDriver(mock.NewMockPluginSPIImpl(gomock.NewController(GinkgoT())))
+		})
+
+		It("should skip the creation and deletion of paused machines", func() {
+			machineClass, secret := newProviderSpecCacheFixtures()
+
+			_, err := d.CreateMachine(ctx, &driver.CreateMachineRequest{Machine: pausedMachine("true"), MachineClass: machineClass, Secret: secret})
+			expectUnavailable(err)
+			_, err = d.DeleteMachine(ctx, &driver.DeleteMachineRequest{Machine: pausedMachine("true"), MachineClass: machineClass, Secret: secret})
+			expectUnavailable(err)
+		})
+	})
+})
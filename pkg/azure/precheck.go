@@ -0,0 +1,169 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-08-01/compute"
+	"github.com/Azure/go-autorest/autorest/to"
+	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis/validation"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/spi"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/status"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const (
+	prometheusServiceUsage = "usage"
+
+	// totalRegionalVCPUsQuota is the name of the quota of all vCPUs in a location, regardless of their family
+	totalRegionalVCPUsQuota = "cores"
+)
+
+// Precheck validates the provider spec and secret and checks against the Azure API that the given number of machines
+// can be created from them, so that invalid worker pools can be rejected before they are rolled out, e.g. by an
+// admission component. It checks that the VM size is offered in the location and supports the requested features, that
+// the vCPU quotas of the VM size family and the location suffice for the machines, that the image referenced by URN
+// exists and that the subnets exist and can host the network interfaces.
+// The secret must contain the credentials and user data like the secret of a machine class. The tags of the provider
+// spec must not set the tag keys reserved by the operator, see validation.ValidateReservedTags. The live checks are
+// skipped if the provider spec or secret are invalid. Checks which cannot be performed due to errors of the Azure API
+// are reported as internal errors.
+func Precheck(ctx context.Context, sp spi.SessionProviderInterface, providerSpec *api.AzureProviderSpec, secret *corev1.Secret, machines int, reservedTagKeys []string) []error {
+	errs := validation.ValidateAzureSpecNSecret(providerSpec, secret)
+	errs = append(errs, validation.ValidateReservedTags(providerSpec.Tags, reservedTagKeys)...)
+	if len(errs) > 0 {
+		return errs
+	}
+
+	clients, err := sp.Setup(secret, providerSpec.CloudConfiguration)
+	if err != nil {
+		return []error{field.InternalError(nil, err)}
+	}
+
+	var allErrs []error
+	allErrs = append(allErrs, precheckVMSize(ctx, clients, providerSpec, machines)...)
+	allErrs = append(allErrs, precheckImage(ctx, clients, providerSpec)...)
+	allErrs = append(allErrs, precheckSubnets(ctx, clients, providerSpec)...)
+	return allErrs
+}
+
+func precheckVMSize(ctx context.Context, clients spi.AzureDriverClientsInterface, providerSpec *api.AzureProviderSpec, machines int) []error {
+	var (
+		fldPath = field.NewPath("properties", "hardwareProfile", "vmSize")
+		vmSize  = providerSpec.Properties.HardwareProfile.VMSize
+	)
+
+	sizes, err := listVMCapabilities(ctx, clients, strings.ToLower(providerSpec.Location))
+	if err != nil {
+		return []error{field.InternalError(fldPath, err)}
+	}
+	capabilities, ok := sizes[strings.ToLower(vmSize)]
+	if !ok {
+		return []error{field.Invalid(fldPath, vmSize, fmt.Sprintf("VM size is not offered in location %q", providerSpec.Location))}
+	}
+
+	allErrs := validateVMCapabilities(providerSpec, capabilities)
+	if machines <= 0 || capabilities.vCPUs == nil || capabilities.family == "" {
+		return allErrs
+	}
+
+	usages, err := listUsages(ctx, clients, providerSpec.Location)
+	if err != nil {
+		return append(allErrs, field.InternalError(fldPath, err))
+	}
+	required := int64(*capabilities.vCPUs * machines)
+	for _, quota := range []string{capabilities.family, totalRegionalVCPUsQuota} {
+		usage, ok := usages[strings.ToLower(quota)]
+		if !ok || usage.Limit == nil || usage.CurrentValue == nil {
+			continue
+		}
+		if available := *usage.Limit - int64(*usage.CurrentValue); required > available {
+			allErrs = append(allErrs, field.Forbidden(fldPath, fmt.Sprintf("%d machines require %d vCPUs of quota %s in location %q, but only %d of %d are available", machines, required, quota, providerSpec.Location, available, *usage.Limit)))
+		}
+	}
+	return allErrs
+}
+
+// listUsages returns the compute usages of the location by their lower-case name
+func listUsages(ctx context.Context, clients spi.AzureDriverClientsInterface, location string) (map[string]compute.Usage, error) {
+	usages := map[string]compute.Usage{}
+
+	result, err := clients.GetUsage().List(ctx, location)
+	if err != nil {
+		return nil, spi.OnARMAPIErrorFail(prometheusServiceUsage, err, "Usage.List")
+	}
+	for {
+		for _, usage := range result.Values() {
+			if usage.Name != nil && usage.Name.Value != nil {
+				usages[strings.ToLower(*usage.Name.Value)] = usage
+			}
+		}
+		if !result.NotDone() {
+			break
+		}
+		if err := result.NextWithContext(ctx); err != nil {
+			return nil, spi.OnARMAPIErrorFail(prometheusServiceUsage, err, "Usage.List")
+		}
+	}
+	spi.OnARMAPISuccess(prometheusServiceUsage, "Usage.List")
+	return usages, nil
+}
+
+func precheckImage(ctx context.Context, clients spi.AzureDriverClientsInterface, providerSpec *api.AzureProviderSpec) []error {
+	spec := providerSpec.Properties.StorageProfile.ImageReference
+	if spec.ID != "" || spec.URN == nil {
+		return nil
+	}
+
+	fldPath := field.NewPath("properties", "storageProfile", "imageReference", "urn")
+	imageReference := imageReferenceFromSpec(spec)
+	if _, err := clients.GetImages().Get(ctx, providerSpec.Location, *imageReference.Publisher, *imageReference.Offer, *imageReference.Sku, *imageReference.Version); err != nil {
+		if spi.NotFound(err) {
+			return []error{field.NotFound(fldPath, *spec.URN)}
+		}
+		return []error{field.InternalError(fldPath, spi.OnARMAPIErrorFail(prometheusServiceVM, err, "VirtualMachineImages.Get failed for %s", *spec.URN))}
+	}
+	spi.OnARMAPISuccess(prometheusServiceVM, "VirtualMachineImages.Get")
+	return nil
+}
+
+func precheckSubnets(ctx context.Context, clients spi.AzureDriverClientsInterface, providerSpec *api.AzureProviderSpec) []error {
+	var allErrs []error
+
+	for i, nic := range getNetworkInterfaces(providerSpec, "precheck") {
+		fldPath := field.NewPath("subnetInfo")
+		if len(providerSpec.Properties.NetworkProfile.Interfaces) > 0 {
+			fldPath = field.NewPath("properties", "networkProfile", "interfaces").Index(i).Child("subnetInfo")
+		}
+
+		vnetResourceGroup := providerSpec.ResourceGroup
+		if nic.subnetInfo.VnetResourceGroup != nil {
+			vnetResourceGroup = *nic.subnetInfo.VnetResourceGroup
+		}
+		subnet, err := clients.GetSubnetOfSubscription(to.String(nic.subnetInfo.VnetSubscriptionID)).Get(ctx, vnetResourceGroup, nic.subnetInfo.VnetName, nic.subnetInfo.SubnetName, "")
+		if err != nil {
+			if spi.NotFound(err) {
+				allErrs = append(allErrs, field.NotFound(fldPath.Child("subnetName"), nic.subnetInfo.SubnetName))
+				continue
+			}
+			allErrs = append(allErrs, field.InternalError(fldPath, spi.OnARMAPIErrorFail(prometheusServiceSubnet, err, "Subnet.Get failed for %s", nic.subnetInfo.SubnetName)))
+			continue
+		}
+		spi.OnARMAPISuccess(prometheusServiceSubnet, "subnet.Get")
+
+		if err := checkSubnet(subnet, nic); err != nil {
+			s, _ := status.FromError(err)
+			allErrs = append(allErrs, field.Forbidden(fldPath, s.Message()))
+		}
+	}
+	return allErrs
+}
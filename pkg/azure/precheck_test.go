@@ -0,0 +1,114 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-08-01/compute"
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-04-01/network"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/mock"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/spi"
+	"github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
+	gomock "github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// newUsagesPage returns a single result page containing the given usages
+func newUsagesPage(ctx context.Context, usages ...compute.Usage) compute.ListUsagesResultPage {
+	page := compute.NewListUsagesResultPage(compute.ListUsagesResult{}, func(_ context.Context, last compute.ListUsagesResult) (compute.ListUsagesResult, error) {
+		if last.Value != nil {
+			return compute.ListUsagesResult{}, nil
+		}
+		return compute.ListUsagesResult{Value: &usages}, nil
+	})
+	_ = page.NextWithContext(ctx)
+	return page
+}
+
+var _ = Describe("Precheck", func() {
+	var (
+		ctx           = context.Background()
+		resourceGroup = "shoot--i538135--seed-az"
+
+		sp           spi.SessionProviderInterface
+		clients      *mock.AzureDriverClients
+		providerSpec *api.AzureProviderSpec
+		secret       *corev1.Secret
+	)
+
+	BeforeEach(func() {
+		sp = mock.NewMockPluginSPIImpl(gomock.NewController(GinkgoT()))
+		var machineClass *v1alpha1.MachineClass
+		machineClass, secret = newProviderSpecCacheFixtures()
+		driverClients, err := sp.Setup(secret, nil)
+		Expect(err).NotTo(HaveOccurred())
+		clients = driverClients.(*mock.AzureDriverClients)
+		providerSpec = UnmarshalProviderSpec(machineClass.ProviderSpec.Raw)
+
+		clients.Skus.EXPECT().List(ctx, "location eq 'westeurope'", "").Return(newResourceSkusPage(ctx, compute.ResourceSku{
+			ResourceType: to.StringPtr("virtualMachines"),
+			Name:         to.StringPtr("Standard_DS2_v2"),
+			Family:       to.StringPtr("standardDSv2Family"),
+			Capabilities: &[]compute.ResourceSkuCapabilities{{Name: to.StringPtr("vCPUs"), Value: to.StringPtr("2")}},
+		}), nil).AnyTimes()
+		clients.Images.EXPECT().Get(ctx, "westeurope", "sap", "gardenlinux", "greatest", "27.1.0").Return(compute.VirtualMachineImage{}, nil).AnyTimes()
+		clients.Subnet.EXPECT().Get(ctx, resourceGroup, resourceGroup, resourceGroup+"-nodes", "").Return(network.Subnet{}, nil).AnyTimes()
+	})
+
+	It("should accept machines which can be created", func() {
+		clients.Usage.EXPECT().List(ctx, "westeurope").Return(newUsagesPage(ctx, compute.Usage{
+			Name: &compute.UsageName{Value: to.StringPtr("standardDSv2Family")}, CurrentValue: to.Int32Ptr(10), Limit: to.Int64Ptr(20),
+		}), nil)
+
+		Expect(Precheck(ctx, sp, providerSpec, secret, 5, nil)).To(BeEmpty())
+	})
+
+	It("should reject machines exceeding the vCPU quota", func() {
+		clients.Usage.EXPECT().List(ctx, "westeurope").Return(newUsagesPage(ctx, compute.Usage{
+			Name: &compute.UsageName{Value: to.StringPtr("cores")}, CurrentValue: to.Int32Ptr(95), Limit: to.Int64Ptr(100),
+		}), nil)
+
+		errs := Precheck(ctx, sp, providerSpec, secret, 3, nil)
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0].Error()).To(ContainSubstring("3 machines require 6 vCPUs of quota cores"))
+	})
+
+	It("should reject a VM size which is not offered", func() {
+		providerSpec.Properties.HardwareProfile.VMSize = "Standard_M416ms_v2"
+
+		errs := Precheck(ctx, sp, providerSpec, secret, 1, nil)
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0].Error()).To(ContainSubstring("properties.hardwareProfile.vmSize"))
+	})
+
+	It("should reject a missing image", func() {
+		providerSpec.Properties.StorageProfile.ImageReference.URN = to.StringPtr("sap:gardenlinux:greatest:0.0.0")
+		clients.Images.EXPECT().Get(ctx, "westeurope", "sap", "gardenlinux", "greatest", "0.0.0").Return(compute.VirtualMachineImage{}, autorest.DetailedError{Response: &http.Response{StatusCode: http.StatusNotFound}})
+
+		errs := Precheck(ctx, sp, providerSpec, secret, 0, nil)
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0].Error()).To(ContainSubstring("properties.storageProfile.imageReference.urn"))
+	})
+
+	It("should skip the live checks for an invalid provider spec", func() {
+		providerSpec.Location = ""
+
+		Expect(Precheck(ctx, sp, providerSpec, secret, 1, nil)).NotTo(BeEmpty())
+	})
+	It("should reject tags reserved by the operator without the live checks", func() {
+		errs := Precheck(ctx, sp, providerSpec, secret, 1, []string{"kubernetes.io-role-*"})
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0].Error()).To(ContainSubstring("providerSpec.tags[kubernetes.io-role-mcm]"))
+	})
+})
@@ -0,0 +1,107 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"sync"
+
+	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+	"github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// providerSpecCache caches the decoded and validated provider specs per machine class, so that hot paths like
+// ListMachines and the health checks of large clusters don't unmarshal and validate them on every call. Only the
+// latest version of every machine class is kept.
+type providerSpecCache struct {
+	mutex   sync.RWMutex
+	entries map[string]providerSpecCacheEntry
+}
+
+type providerSpecCacheEntry struct {
+	version      string
+	strict       bool
+	providerSpec *api.AzureProviderSpec
+}
+
+func newProviderSpecCache() *providerSpecCache {
+	return &providerSpecCache{entries: map[string]providerSpecCacheEntry{}}
+}
+
+// decodeProviderSpecAndSecret decodes and validates the provider spec of the machine class like
+// decodeProviderSpecAndSecret, but reuses the result of previous calls for the same version of the machine class and
+// secret. Unknown fields are rejected in strict decoding mode. The returned provider spec is a shallow copy, hence its
+// fields may be replaced, but not modified in place. The secret is remembered for GetVolumeIDs, see volumeSessions.
+func (d *MachinePlugin) decodeProviderSpecAndSecret(machineClass *v1alpha1.MachineClass, secret *corev1.Secret) (*api.AzureProviderSpec, error) {
+	var (
+		providerSpec *api.AzureProviderSpec
+		err          error
+	)
+	if d.providerSpecs == nil {
+		providerSpec, err = decodeProviderSpec(machineClass, secret, d.strictDecoding)
+	} else {
+		providerSpec, err = d.providerSpecs.get(machineClass, secret, d.strictDecoding)
+	}
+	if err != nil {
+		return nil, err
+	}
+	d.volumeSessions.record(secret, providerSpec.CloudConfiguration)
+	return providerSpec, nil
+}
+
+func (c *providerSpecCache) get(machineClass *v1alpha1.MachineClass, secret *corev1.Secret, strict bool) (*api.AzureProviderSpec, error) {
+	key, version := machineClass.Namespace+"/"+machineClass.Name, providerSpecVersion(machineClass, secret)
+
+	c.mutex.RLock()
+	entry, ok := c.entries[key]
+	c.mutex.RUnlock()
+	if !ok || entry.version != version || entry.strict != strict {
+		providerSpec, err := decodeProviderSpec(machineClass, secret, strict)
+		if err != nil {
+			return nil, err
+		}
+		entry = providerSpecCacheEntry{version: version, strict: strict, providerSpec: providerSpec}
+
+		c.mutex.Lock()
+		c.entries[key] = entry
+		c.mutex.Unlock()
+	}
+
+	providerSpec := *entry.providerSpec
+	return &providerSpec, nil
+}
+
+// providerSpecVersion returns the version of the machine class and secret the provider spec is decoded and validated
+// from. The machine class is identified by its resource version if it has one. The secret is always hashed, as the
+// machine controller passes a copy with machine specific user data.
+func providerSpecVersion(machineClass *v1alpha1.MachineClass, secret *corev1.Secret) string {
+	hash := sha256.New()
+	if machineClass.ResourceVersion != "" {
+		hash.Write([]byte(machineClass.ResourceVersion))
+	} else {
+		hash.Write(machineClass.ProviderSpec.Raw)
+	}
+	hash.Write([]byte{0})
+
+	if secret != nil {
+		keys := make([]string, 0, len(secret.Data))
+		for key := range secret.Data {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			hash.Write([]byte(key))
+			hash.Write([]byte{0})
+			hash.Write(secret.Data[key])
+			hash.Write([]byte{0})
+		}
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
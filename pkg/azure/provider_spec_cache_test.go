@@ -0,0 +1,126 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"strings"
+	"testing"
+
+	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/mock"
+	"github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/codes"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/status"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func newProviderSpecCacheFixtures() (*v1alpha1.MachineClass, *corev1.Secret) {
+	machineClass := &v1alpha1.MachineClass{
+		ObjectMeta:   metav1.ObjectMeta{Name: "class", Namespace: "default"},
+		ProviderSpec: runtime.RawExtension{Raw: mock.AzureProviderSpec},
+	}
+	secret := &corev1.Secret{Data: map[string][]byte{
+		api.AzureClientID:       []byte("client"),
+		api.AzureClientSecret:   []byte("secret"),
+		api.AzureSubscriptionID: []byte("subscription"),
+		api.AzureTenantID:       []byte("tenant"),
+		"userData":              []byte("#cloud-config"),
+	}}
+	return machineClass, secret
+}
+
+var _ = Describe("ProviderSpecCache", func() {
+	var (
+		cache        *providerSpecCache
+		machineClass *v1alpha1.MachineClass
+		secret       *corev1.Secret
+	)
+
+	BeforeEach(func() {
+		cache = newProviderSpecCache()
+		machineClass, secret = newProviderSpecCacheFixtures()
+	})
+
+	It("should return copies of the cached provider spec", func() {
+		first, err := cache.get(machineClass, secret, false)
+		Expect(err).NotTo(HaveOccurred())
+		first.Tags = map[string]string{"modified": "true"}
+
+		second, err := cache.get(machineClass, secret, false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(second).NotTo(BeIdenticalTo(first))
+		Expect(second.Tags).NotTo(HaveKey("modified"))
+		Expect(cache.entries).To(HaveLen(1))
+	})
+
+	It("should decode a changed provider spec again", func() {
+		_, err := cache.get(machineClass, secret, false)
+		Expect(err).NotTo(HaveOccurred())
+
+		machineClass.ProviderSpec.Raw = mock.AzureProviderSpecWithoutLocation
+		_, err = cache.get(machineClass, secret, false)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should validate the provider spec against a changed secret", func() {
+		_, err := cache.get(machineClass, secret, false)
+		Expect(err).NotTo(HaveOccurred())
+
+		delete(secret.Data, api.AzureClientSecret)
+		_, err = cache.get(machineClass, secret, false)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should reject unknown fields in strict decoding mode", func() {
+		machineClass.ProviderSpec.Raw = []byte(strings.Replace(string(machineClass.ProviderSpec.Raw), `"diskSizeGB"`, `"diskSizeGb"`, 1))
+		_, err := cache.get(machineClass, secret, false)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = cache.get(machineClass, secret, true)
+		Expect(err).To(HaveOccurred())
+		Expect(err.(*status.Status).Code()).To(Equal(codes.InvalidArgument))
+		Expect(err.Error()).To(ContainSubstring("properties.storageProfile.osDisk.diskSizeGb"))
+
+		machineClass.Annotations = map[string]string{api.MachineClassAnnotationStrictDecoding: "true"}
+		_, err = decodeProviderSpecAndSecret(machineClass, secret)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should identify the machine class by its resource version", func() {
+		machineClass.ResourceVersion = "1"
+		version := providerSpecVersion(machineClass, secret)
+
+		machineClass.ProviderSpec.Raw = mock.AzureProviderSpecWithoutLocation
+		Expect(providerSpecVersion(machineClass, secret)).To(Equal(version))
+		machineClass.ResourceVersion = "2"
+		Expect(providerSpecVersion(machineClass, secret)).NotTo(Equal(version))
+	})
+})
+
+func BenchmarkDecodeProviderSpec(b *testing.B) {
+	machineClass, secret := newProviderSpecCacheFixtures()
+	for i := 0; i < b.N; i++ {
+		if _, err := decodeProviderSpecAndSecret(machineClass, secret); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodeProviderSpecCached(b *testing.B) {
+	machineClass, secret := newProviderSpecCacheFixtures()
+	machineClass.ResourceVersion = "1"
+	cache := newProviderSpecCache()
+	for i := 0; i < b.N; i++ {
+		if _, err := cache.get(machineClass, secret, false); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
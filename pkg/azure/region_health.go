@@ -0,0 +1,132 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/spi"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// regionHealthMinClasses is the number of machine classes which must be affected for an issue to be region-wide
+const regionHealthMinClasses = 2
+
+// unavailableErrorCodes are the Azure error codes indicating that a region cannot serve the request temporarily
+var unavailableErrorCodes = map[string]bool{
+	"AllocationFailed":                      true,
+	"ZonalAllocationFailed":                 true,
+	"OverconstrainedAllocationRequest":      true,
+	"OverconstrainedZonalAllocationRequest": true,
+	"InternalServerError":                   true,
+	"ServiceUnavailable":                    true,
+	"RetryableError":                        true,
+}
+
+// regionDegradedGauge is 1 for regions in which a region-wide issue is detected and 0 otherwise
+var regionDegradedGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: "mcm",
+	Subsystem: "azure",
+	Name:      "region_degraded",
+	Help:      "Whether consecutive unavailable errors across machine classes indicate a region-wide issue. Capacity should be shifted to another region while it is 1.",
+}, []string{"region"})
+
+func init() {
+	prometheus.MustRegister(regionDegradedGauge)
+}
+
+// isUnavailable returns true if the error indicates that Azure cannot serve the request temporarily
+func isUnavailable(err error) bool {
+	if unavailableErrorCodes[serviceErrorCode(err)] {
+		return true
+	}
+	if detailedErr, ok := err.(autorest.DetailedError); ok && detailedErr.Response != nil {
+		return detailedErr.Response.StatusCode >= http.StatusInternalServerError
+	}
+	return false
+}
+
+// unavailableError is an unavailable error observed for a machine class
+type unavailableError struct {
+	class string
+	at    time.Time
+}
+
+// regionHealth detects region-wide issues from consecutive unavailable errors across machine classes. A region is
+// degraded once the number of unavailable errors within the detection window without any success in between reaches
+// the threshold, and at least two machine classes are affected. The state is kept in memory only.
+type regionHealth struct {
+	window    time.Duration
+	threshold int
+
+	mutex   sync.Mutex
+	streaks map[string][]unavailableError
+}
+
+func newRegionHealth(window time.Duration, threshold int) *regionHealth {
+	return &regionHealth{
+		window:    window,
+		threshold: threshold,
+		streaks:   map[string][]unavailableError{},
+	}
+}
+
+// record records the result of an operation for a machine class in a region and returns whether the region is degraded.
+// Errors which do not indicate unavailability neither extend nor reset the streak.
+func (h *regionHealth) record(region, class string, err error, now time.Time) bool {
+	region = strings.ToLower(region)
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	switch {
+	case err == nil:
+		delete(h.streaks, region)
+	case isUnavailable(err):
+		streak := append(h.streaks[region], unavailableError{class: class, at: now})
+		for len(streak) > 0 && now.Sub(streak[0].at) > h.window {
+			streak = streak[1:]
+		}
+		h.streaks[region] = streak
+	}
+
+	degraded := h.degraded(region)
+	if degraded {
+		regionDegradedGauge.WithLabelValues(region).Set(1)
+	} else {
+		regionDegradedGauge.WithLabelValues(region).Set(0)
+	}
+	return degraded
+}
+
+func (h *regionHealth) degraded(region string) bool {
+	streak := h.streaks[region]
+	if len(streak) < h.threshold {
+		return false
+	}
+	classes := map[string]bool{}
+	for _, e := range streak {
+		classes[e.class] = true
+	}
+	return len(classes) >= regionHealthMinClasses
+}
+
+// recordRegionHealth records the result of a machine operation and adds a failover hint to the error
+// if a region-wide issue is detected
+func (d *MachinePlugin) recordRegionHealth(ctx context.Context, region, class string, err error) error {
+	if d.regionHealth == nil || !d.regionHealth.record(region, class, err, time.Now()) || err == nil {
+		return err
+	}
+	spi.WarningS(ctx, "Region appears to be degraded, consecutive unavailable errors across machine classes occurred", "region", region, "threshold", d.regionHealth.threshold, "window", d.regionHealth.window)
+	return fmt.Errorf("%s (region %s appears to be degraded, consider shifting capacity to another region)", operationErrorMessage(err), region)
+}
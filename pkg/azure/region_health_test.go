@@ -0,0 +1,80 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-08-01/compute"
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-04-01/network"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/mock"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/driver"
+	gomock "github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RegionHealth", func() {
+	var (
+		unavailable = autorest.DetailedError{Original: &azure.ServiceError{Code: "AllocationFailed"}}
+		now         = time.Now()
+	)
+
+	It("should detect consecutive unavailable errors across machine classes", func() {
+		health := newRegionHealth(10*time.Minute, 3)
+
+		Expect(health.record("westeurope", "a", unavailable, now)).To(BeFalse())
+		Expect(health.record("westeurope", "a", unavailable, now.Add(time.Minute))).To(BeFalse())
+		Expect(health.record("WestEurope", "b", unavailable, now.Add(2*time.Minute))).To(BeTrue())
+		Expect(health.record("northeurope", "b", nil, now.Add(3*time.Minute))).To(BeFalse())
+
+		Expect(health.record("westeurope", "a", nil, now.Add(4*time.Minute))).To(BeFalse())
+		Expect(health.record("westeurope", "b", unavailable, now.Add(5*time.Minute))).To(BeFalse())
+	})
+
+	It("should not consider a single machine class a region-wide issue", func() {
+		health := newRegionHealth(10*time.Minute, 2)
+
+		Expect(health.record("westeurope", "a", unavailable, now)).To(BeFalse())
+		Expect(health.record("westeurope", "a", unavailable, now.Add(time.Minute))).To(BeFalse())
+	})
+
+	It("should only count errors within the window", func() {
+		health := newRegionHealth(10*time.Minute, 2)
+
+		Expect(health.record("westeurope", "a", unavailable, now)).To(BeFalse())
+		Expect(health.record("westeurope", "b", unavailable, now.Add(11*time.Minute))).To(BeFalse())
+		Expect(health.record("westeurope", "a", errors.New("invalid spec"), now.Add(12*time.Minute))).To(BeFalse())
+		Expect(health.record("westeurope", "a", unavailable, now.Add(13*time.Minute))).To(BeTrue())
+	})
+
+	It("should record the results of machine creations for the location of the request", func() {
+		sp := mock.NewMockPluginSPIImpl(gomock.NewController(GinkgoT()))
+		machineClass, secret := newProviderSpecCacheFixtures()
+		driverClients, err := sp.Setup(secret, nil)
+		Expect(err).NotTo(HaveOccurred())
+		clients := driverClients.(*mock.AzureDriverClients)
+		clients.Skus.EXPECT().List(gomock.Any(), "location eq 'westeurope'", "").Return(compute.ResourceSkusResultPage{}, errors.New("failed"))
+		clients.VirtualNetworks.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), "").Return(network.VirtualNetwork{}, errors.New("failed"))
+		clients.VM.EXPECT().Get(gomock.Any(), "shoot--i538135--seed-az", "machine", compute.InstanceViewTypes("")).Return(compute.VirtualMachine{}, unavailable)
+
+		plugin := NewAzureDriver(sp)
+		plugin.regionHealth = newRegionHealth(10*time.Minute, 1)
+		// The provider spec of a concurrent request of another machine class
+		plugin.AzureProviderSpec = &api.AzureProviderSpec{Location: "northeurope"}
+
+		_, err = plugin.CreateMachine(context.Background(), &driver.CreateMachineRequest{Machine: newMachine("machine"), MachineClass: machineClass, Secret: secret})
+		Expect(err).To(HaveOccurred())
+		Expect(plugin.regionHealth.streaks).To(HaveKey("westeurope"))
+		Expect(plugin.regionHealth.streaks).NotTo(HaveKey("northeurope"))
+	})
+})
@@ -0,0 +1,66 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/spi"
+	"github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// machinesOfDeletedResourceGroupsCounter is the number of machines considered deleted as their resource group is gone
+var machinesOfDeletedResourceGroupsCounter = prometheus.NewCounter(prometheus.CounterOpts{
+	Namespace: "mcm",
+	Subsystem: "azure",
+	Name:      "machines_of_deleted_resource_groups_total",
+	Help:      "Number of machines whose deletion succeeded without deleting any resource because their resource group does not exist anymore.",
+})
+
+func init() {
+	prometheus.MustRegister(machinesOfDeletedResourceGroupsCounter)
+}
+
+// resourceGroupNotFound returns true if the error reports that the resource group of the request does not exist. A
+// not found error of a resource in the group does not qualify.
+func resourceGroupNotFound(err error) bool {
+	serviceErr := serviceError(err)
+	return serviceErr != nil && serviceErr.Code == "ResourceGroupNotFound"
+}
+
+// deleteNICsOfDeletedResourceGroup deletes the NICs and public IPs of a machine whose resource group is gone, but
+// which are placed in another resource group
+func (d *MachinePlugin) deleteNICsOfDeletedResourceGroup(ctx context.Context, clients spi.AzureDriverClientsInterface, resourceGroupName string, networkInterfaces []networkInterface) error {
+	ctx, cancel := withOperationTimeout(ctx, d.deleteTimeout)
+	defer cancel()
+
+	var deleters []func() error
+	for _, nic := range networkInterfaces {
+		if strings.EqualFold(nic.resourceGroupName(resourceGroupName), resourceGroupName) {
+			continue
+		}
+		deleters = append(deleters, d.getDeleterForNIC(ctx, clients, resourceGroupName, nic))
+	}
+	return operationTimeoutError(ctx, spi.RunInParallel(deleters))
+}
+
+// forgetMachineOfDeletedResourceGroup drops the state kept for a machine whose resource group is gone. The machine is
+// considered deleted once its resources in other resource groups are deleted, so that the machine does not get stuck
+// in deletion, e.g. when the resource group of a hibernated or torn down cluster was removed.
+func (d *MachinePlugin) forgetMachineOfDeletedResourceGroup(ctx context.Context, machine *v1alpha1.Machine, machineClass *v1alpha1.MachineClass, resourceGroupName, vmName string) {
+	spi.InfoS(ctx, "Resource group of machine does not exist anymore, the machine is considered deleted", "vm", vmName)
+	machinesOfDeletedResourceGroupsCounter.Inc()
+
+	d.releaseIPHandoff(machine, machineClass)
+	if d.vmInventory != nil {
+		d.vmInventory.remove(inventoryKey(d.Secret, resourceGroupName), vmName)
+	}
+	d.tagSyncs.forget(machine.Name)
+	d.userDataSyncs.forget(machine.Name)
+}
@@ -0,0 +1,104 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-04-01/network"
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-05-01/resources"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/mock"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/driver"
+	gomock "github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// resourceGroupNotFoundError is the error Azure returns for requests to a resource group which does not exist
+var resourceGroupNotFoundError = autorest.DetailedError{
+	Response: &http.Response{StatusCode: http.StatusNotFound},
+	Original: &azure.RequestError{ServiceError: &azure.ServiceError{Code: "ResourceGroupNotFound"}},
+}
+
+var _ = Describe("ResourceGroup", func() {
+	var (
+		ctx = context.Background()
+
+		plugin  *MachinePlugin
+		clients *mock.AzureDriverClients
+		req     *driver.DeleteMachineRequest
+	)
+
+	BeforeEach(func() {
+		sp := mock.NewMockPluginSPIImpl(gomock.NewController(GinkgoT()))
+		machineClass, secret := newProviderSpecCacheFixtures()
+		driverClients, err := sp.Setup(secret, nil)
+		Expect(err).NotTo(HaveOccurred())
+		clients = driverClients.(*mock.AzureDriverClients)
+
+		plugin = NewAzureDriver(sp)
+		req = &driver.DeleteMachineRequest{Machine: newMachine("machine"), MachineClass: machineClass, Secret: secret}
+	})
+
+	It("should consider machines deleted if their resource group does not exist anymore", func() {
+		deleted := testutil.ToFloat64(machinesOfDeletedResourceGroupsCounter)
+		clients.Group.EXPECT().Get(gomock.Any(), "shoot--i538135--seed-az").Return(resources.Group{}, resourceGroupNotFoundError)
+
+		_, err := plugin.DeleteMachine(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(testutil.ToFloat64(machinesOfDeletedResourceGroupsCounter)).To(Equal(deleted + 1))
+	})
+
+	It("should not consider machines deleted for other not found errors", func() {
+		clients.Group.EXPECT().Get(gomock.Any(), "shoot--i538135--seed-az").Return(resources.Group{}, autorest.DetailedError{Response: &http.Response{StatusCode: http.StatusNotFound}})
+
+		_, err := plugin.DeleteMachine(ctx, req)
+		Expect(err).To(HaveOccurred())
+	})
+
+	Context("with NICs in another resource group", func() {
+		BeforeEach(func() {
+			providerSpec := UnmarshalProviderSpec(req.MachineClass.ProviderSpec.Raw)
+			providerSpec.NICResourceGroup = to.StringPtr("network")
+			raw, err := json.Marshal(providerSpec)
+			Expect(err).NotTo(HaveOccurred())
+			req.MachineClass.ProviderSpec.Raw = raw
+
+			clients.Group.EXPECT().Get(gomock.Any(), "shoot--i538135--seed-az").Return(resources.Group{}, resourceGroupNotFoundError)
+		})
+
+		It("should delete the NICs before considering the machine deleted", func() {
+			clients.NIC.EXPECT().Get(gomock.Any(), "network", "machine-nic", "").Return(network.Interface{}, autorest.DetailedError{Response: &http.Response{StatusCode: http.StatusNotFound}})
+			clients.PublicIP.EXPECT().Delete(gomock.Any(), "network", "machine-pip").Return(network.PublicIPAddressesDeleteFuture{}, autorest.DetailedError{Response: &http.Response{StatusCode: http.StatusNotFound}})
+
+			_, err := plugin.DeleteMachine(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should fail the deletion if the NICs cannot be deleted", func() {
+			deleted := testutil.ToFloat64(machinesOfDeletedResourceGroupsCounter)
+			clients.NIC.EXPECT().Get(gomock.Any(), "network", "machine-nic", "").Return(network.Interface{}, autorest.DetailedError{Response: &http.Response{StatusCode: http.StatusInternalServerError}})
+
+			_, err := plugin.DeleteMachine(ctx, req)
+			Expect(err).To(HaveOccurred())
+			Expect(testutil.ToFloat64(machinesOfDeletedResourceGroupsCounter)).To(Equal(deleted))
+		})
+	})
+
+	It("should fail the deletion if the resource group cannot be read", func() {
+		clients.Group.EXPECT().Get(gomock.Any(), "shoot--i538135--seed-az").Return(resources.Group{}, autorest.DetailedError{Response: &http.Response{StatusCode: http.StatusForbidden}})
+
+		_, err := plugin.DeleteMachine(ctx, req)
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,129 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-08-01/compute"
+	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/spi"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/driver"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// powerStateStopped is the status code of VMs which are stopped but still allocated, unlike deallocated VMs
+const powerStateStopped = "PowerState/stopped"
+
+// restartStoppedVM starts the VM of the machine if it is stopped but not deallocated, e.g. after a crash or shutdown
+// inside the guest, and its machine class restarts stopped VMs outside its maintenance windows. The start is issued
+// without waiting for its completion, and failures are only logged, as the status of the machine is reported anyway.
+// VMs of paused machines are not restarted, as they may be stopped for manual repairs, and neither are the VMs of
+// machines being deleted, as their VMs are powered off before the deletion. The power state is taken from the VM
+// inventory if the status of the machines is cached.
+func (d *MachinePlugin) restartStoppedVM(ctx context.Context, req *driver.GetMachineStatusRequest, now time.Time) {
+	if isPaused(req.Machine) || req.Machine.DeletionTimestamp != nil {
+		return
+	}
+	providerSpec, err := d.decodeProviderSpecAndSecret(req.MachineClass, req.Secret)
+	if err != nil {
+		return
+	}
+	restartPolicy := providerSpec.Properties.RestartPolicy
+	if restartPolicy == nil || !restartPolicy.RestartStopped || inMaintenanceWindow(restartPolicy.MaintenanceWindows, now) {
+		return
+	}
+
+	clients, err := d.SPI.Setup(req.Secret, providerSpec.CloudConfiguration)
+	if err != nil {
+		spi.WarningS(ctx, "Power state of VM could not be checked", "err", err)
+		return
+	}
+
+	var (
+		resourceGroupName = providerSpec.ResourceGroup
+		vmName            = strings.ToLower(req.Machine.Name)
+	)
+
+	var stopped bool
+	if d.vmInventory != nil {
+		stopped, err = d.vmInventory.isStopped(ctx, clients, inventoryKey(req.Secret, resourceGroupName), resourceGroupName, vmName)
+	} else {
+		stopped, err = isVMStopped(ctx, clients, resourceGroupName, vmName)
+	}
+	if err != nil {
+		spi.WarningS(ctx, "Power state of VM could not be checked", "vm", vmName, "err", err)
+		return
+	}
+	if !stopped {
+		return
+	}
+
+	if _, err := clients.GetVM().Start(ctx, resourceGroupName, vmName); err != nil {
+		spi.WarningS(ctx, "Stopped VM could not be restarted", "vm", vmName, "err", spi.OnARMAPIErrorFail(prometheusServiceVM, err, "VM.Start failed for %s", vmName))
+		return
+	}
+	spi.OnARMAPISuccess(prometheusServiceVM, "VM start was issued for %s", vmName)
+	if d.vmInventory != nil {
+		d.vmInventory.setStopped(inventoryKey(req.Secret, resourceGroupName), vmName, false)
+	}
+	spi.WarningS(ctx, "VM was found stopped and is restarted", "vm", vmName)
+	if d.Recorder != nil {
+		d.Recorder.Event(req.Machine, corev1.EventTypeWarning, "VMRestarted", fmt.Sprintf("VM %s was found stopped and is restarted", vmName))
+	}
+}
+
+// isVMStopped reads the instance view of the VM and returns true if it is stopped but not deallocated
+func isVMStopped(ctx context.Context, clients spi.AzureDriverClientsInterface, resourceGroupName, vmName string) (bool, error) {
+	instanceView, err := clients.GetVM().InstanceView(ctx, resourceGroupName, vmName)
+	if err != nil {
+		return false, spi.OnARMAPIErrorFail(prometheusServiceVM, err, "VM.InstanceView failed for %s", vmName)
+	}
+	spi.OnARMAPISuccess(prometheusServiceVM, "VM.InstanceView")
+	return isStopped(instanceView), nil
+}
+
+// isStopped returns true if the instance view reports the VM as stopped but not deallocated
+func isStopped(instanceView compute.VirtualMachineInstanceView) bool {
+	if instanceView.Statuses == nil {
+		return false
+	}
+	for _, s := range *instanceView.Statuses {
+		if s.Code != nil && strings.EqualFold(*s.Code, powerStateStopped) {
+			return true
+		}
+	}
+	return false
+}
+
+// inMaintenanceWindow returns true if the UTC time of day of now is in one of the daily windows. A window ending
+// before it begins ends on the next day, a window ending when it begins is empty.
+func inMaintenanceWindow(windows []api.AzureMaintenanceWindow, now time.Time) bool {
+	now = now.UTC()
+	minute := now.Hour()*60 + now.Minute()
+	for _, window := range windows {
+		begin, err := time.Parse(api.MaintenanceWindowTimeLayout, window.Begin)
+		if err != nil {
+			continue
+		}
+		end, err := time.Parse(api.MaintenanceWindowTimeLayout, window.End)
+		if err != nil {
+			continue
+		}
+		beginMinute, endMinute := begin.Hour()*60+begin.Minute(), end.Hour()*60+end.Minute()
+		if beginMinute <= endMinute && minute >= beginMinute && minute < endMinute {
+			return true
+		}
+		if beginMinute > endMinute && (minute >= beginMinute || minute < endMinute) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,116 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-08-01/compute"
+	"github.com/Azure/go-autorest/autorest/to"
+	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/mock"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/driver"
+	gomock "github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+var _ = Describe("Restart", func() {
+	var (
+		ctx = context.Background()
+		now = time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+		plugin   *MachinePlugin
+		clients  *mock.AzureDriverClients
+		recorder *record.FakeRecorder
+		req      *driver.GetMachineStatusRequest
+		rg       string
+
+		stopped = compute.VirtualMachineInstanceView{Statuses: &[]compute.InstanceViewStatus{
+			{Code: to.StringPtr("ProvisioningState/succeeded")},
+			{Code: to.StringPtr(powerStateStopped)},
+		}}
+	)
+
+	BeforeEach(func() {
+		sp := mock.NewMockPluginSPIImpl(gomock.NewController(GinkgoT()))
+		machineClass, secret := newProviderSpecCacheFixtures()
+		driverClients, err := sp.Setup(secret, nil)
+		Expect(err).NotTo(HaveOccurred())
+		clients = driverClients.(*mock.AzureDriverClients)
+
+		var providerSpec api.AzureProviderSpec
+		Expect(json.Unmarshal(machineClass.ProviderSpec.Raw, &providerSpec)).To(Succeed())
+		providerSpec.Properties.RestartPolicy = &api.AzureRestartPolicy{
+			RestartStopped:     true,
+			MaintenanceWindows: []api.AzureMaintenanceWindow{{Begin: "22:00", End: "02:00"}},
+		}
+		machineClass.ProviderSpec.Raw, err = json.Marshal(providerSpec)
+		Expect(err).NotTo(HaveOccurred())
+		rg = providerSpec.ResourceGroup
+
+		recorder = record.NewFakeRecorder(1)
+		plugin = NewAzureDriver(sp)
+		plugin.Recorder = recorder
+		req = &driver.GetMachineStatusRequest{Machine: newMachine("machine"), MachineClass: machineClass, Secret: secret}
+	})
+
+	It("should start stopped VMs", func() {
+		clients.VM.EXPECT().InstanceView(gomock.Any(), rg, "machine").Return(stopped, nil)
+		clients.VM.EXPECT().Start(gomock.Any(), rg, "machine").Return(compute.VirtualMachinesStartFuture{}, nil)
+
+		plugin.restartStoppedVM(ctx, req, now)
+		Expect(recorder.Events).To(Receive(ContainSubstring("VMRestarted")))
+	})
+
+	It("should not start VMs which are running or deallocated", func() {
+		clients.VM.EXPECT().InstanceView(gomock.Any(), rg, "machine").Return(compute.VirtualMachineInstanceView{Statuses: &[]compute.InstanceViewStatus{
+			{Code: to.StringPtr("PowerState/deallocated")},
+		}}, nil)
+
+		plugin.restartStoppedVM(ctx, req, now)
+		Expect(recorder.Events).NotTo(Receive())
+	})
+
+	It("should not restart VMs within a maintenance window or of paused machines", func() {
+		plugin.restartStoppedVM(ctx, req, time.Date(2026, 1, 1, 23, 0, 0, 0, time.UTC))
+		plugin.restartStoppedVM(ctx, req, time.Date(2026, 1, 2, 1, 59, 0, 0, time.UTC))
+
+		req.Machine.Annotations = map[string]string{api.MachineAnnotationPaused: "true"}
+		plugin.restartStoppedVM(ctx, req, now)
+	})
+
+	It("should not restart VMs of machines being deleted", func() {
+		req.Machine.DeletionTimestamp = &metav1.Time{Time: now}
+		plugin.restartStoppedVM(ctx, req, now)
+		Expect(recorder.Events).NotTo(Receive())
+	})
+
+	It("should take the power state from the VM inventory", func() {
+		plugin.vmInventory = newVMInventory(time.Hour)
+		clients.VM.EXPECT().InstanceView(gomock.Any(), rg, "machine").Return(stopped, nil)
+		clients.VM.EXPECT().Start(gomock.Any(), rg, "machine").Return(compute.VirtualMachinesStartFuture{}, nil)
+
+		plugin.restartStoppedVM(ctx, req, now)
+		Expect(recorder.Events).To(Receive(ContainSubstring("VMRestarted")))
+
+		plugin.restartStoppedVM(ctx, req, now)
+		Expect(recorder.Events).NotTo(Receive())
+	})
+
+	It("should determine whether the time is in a maintenance window", func() {
+		windows := []api.AzureMaintenanceWindow{{Begin: "08:00", End: "09:30"}}
+		Expect(inMaintenanceWindow(windows, time.Date(2026, 1, 1, 8, 0, 0, 0, time.UTC))).To(BeTrue())
+		Expect(inMaintenanceWindow(windows, time.Date(2026, 1, 1, 9, 30, 0, 0, time.UTC))).To(BeFalse())
+		Expect(inMaintenanceWindow(windows, time.Date(2026, 1, 1, 10, 0, 0, 0, time.FixedZone("CET", 3600)))).To(BeTrue())
+		Expect(inMaintenanceWindow(nil, now)).To(BeFalse())
+	})
+})
@@ -0,0 +1,62 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-08-01/compute"
+	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/spi"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/codes"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/status"
+)
+
+// checkSecurityProfile rejects provider specs with a security profile whose image referenced by URN is not a Gen2 image
+// before any resource is created, as Trusted Launch and confidential VMs require Gen2 images.
+func checkSecurityProfile(ctx context.Context, clients spi.AzureDriverClientsInterface, providerSpec *api.AzureProviderSpec) error {
+	securityProfile := providerSpec.Properties.SecurityProfile
+	if securityProfile == nil {
+		return nil
+	}
+
+	if spec := providerSpec.Properties.StorageProfile.ImageReference; spec.ID == "" && spec.URN != nil {
+		imageReference := imageReferenceFromSpec(spec)
+		image, err := clients.GetImages().Get(ctx, providerSpec.Location, *imageReference.Publisher, *imageReference.Offer, *imageReference.Sku, *imageReference.Version)
+		if err != nil {
+			return spi.OnARMAPIErrorFail(prometheusServiceVM, err, "VirtualMachineImages.Get failed for %s", *spec.URN)
+		}
+		spi.OnARMAPISuccess(prometheusServiceVM, "VirtualMachineImages.Get")
+		if image.VirtualMachineImageProperties == nil || image.HyperVGeneration != compute.HyperVGenerationTypesV2 {
+			return status.Error(codes.InvalidArgument, fmt.Sprintf("Security type %s requires a Gen2 image, but image %s is not", securityProfile.SecurityType, *spec.URN))
+		}
+	}
+
+	return nil
+}
+
+// getSecurityProfile returns the security profile of the VM. Confidential VMs additionally need the encryption type of
+// their OS disk, which is returned as second value. Only the VM guest state is encrypted, so that all VM sizes and
+// images supporting confidential VMs can be used.
+func getSecurityProfile(securityProfile *api.AzureSecurityProfile) (*compute.SecurityProfile, *compute.VMDiskSecurityProfile) {
+	if securityProfile == nil {
+		return nil, nil
+	}
+
+	vmSecurityProfile := &compute.SecurityProfile{
+		SecurityType: compute.SecurityTypes(securityProfile.SecurityType),
+		UefiSettings: &compute.UefiSettings{
+			SecureBootEnabled: securityProfile.SecureBootEnabled,
+			VTpmEnabled:       securityProfile.VTPMEnabled,
+		},
+	}
+	if securityProfile.SecurityType != api.SecurityTypeConfidentialVM {
+		return vmSecurityProfile, nil
+	}
+	return vmSecurityProfile, &compute.VMDiskSecurityProfile{SecurityEncryptionType: compute.VMGuestStateOnly}
+}
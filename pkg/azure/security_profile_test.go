@@ -0,0 +1,44 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-08-01/compute"
+	"github.com/Azure/go-autorest/autorest/to"
+	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SecurityProfile", func() {
+	Describe("#getSecurityProfile", func() {
+		It("should return no security profile if none is configured", func() {
+			securityProfile, diskSecurityProfile := getSecurityProfile(nil)
+			Expect(securityProfile).To(BeNil())
+			Expect(diskSecurityProfile).To(BeNil())
+		})
+
+		It("should enable Trusted Launch with the UEFI settings of the provider spec", func() {
+			securityProfile, diskSecurityProfile := getSecurityProfile(&api.AzureSecurityProfile{
+				SecurityType:      api.SecurityTypeTrustedLaunch,
+				SecureBootEnabled: to.BoolPtr(true),
+				VTPMEnabled:       to.BoolPtr(false),
+			})
+			Expect(securityProfile).To(Equal(&compute.SecurityProfile{
+				SecurityType: compute.SecurityTypesTrustedLaunch,
+				UefiSettings: &compute.UefiSettings{SecureBootEnabled: to.BoolPtr(true), VTpmEnabled: to.BoolPtr(false)},
+			}))
+			Expect(diskSecurityProfile).To(BeNil())
+		})
+
+		It("should encrypt the VM guest state of confidential VMs", func() {
+			securityProfile, diskSecurityProfile := getSecurityProfile(&api.AzureSecurityProfile{SecurityType: api.SecurityTypeConfidentialVM})
+			Expect(securityProfile.SecurityType).To(Equal(compute.SecurityTypesConfidentialVM))
+			Expect(diskSecurityProfile).To(Equal(&compute.VMDiskSecurityProfile{SecurityEncryptionType: compute.VMGuestStateOnly}))
+		})
+	})
+})
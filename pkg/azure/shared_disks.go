@@ -0,0 +1,172 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-08-01/compute"
+	"github.com/Azure/go-autorest/autorest/to"
+	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/spi"
+)
+
+// sharedDiskTagKey is the tag of the shared data disks, which belong to the machine class instead of a machine. They
+// are neither deleted with a machine nor collected as orphans, as they are attached to the other machines of the class.
+const sharedDiskTagKey = "machine-controller-manager-shared"
+
+// isSharedDataDisk returns true if the data disk belongs to the machine class and can be attached to more than one VM.
+// Attached shared disks exist already and are attached by their ID, so they are not created for the class.
+func isSharedDataDisk(dataDisk api.AzureDataDisk) bool {
+	return dataDisk.MaxShares != nil && *dataDisk.MaxShares > 1 && dataDisk.CreateOption != api.DataDiskCreateOptionAttach
+}
+
+// isShared returns true if the disk is tagged as shared data disk of a machine class
+func isShared(tags map[string]*string) bool {
+	_, ok := tags[sharedDiskTagKey]
+	return ok
+}
+
+// sharedDataDiskName returns the name of the shared data disk of the machine class. It does not depend on the VM, as
+// all machines of the class attach the same disk.
+func sharedDataDiskName(machineClassName, diskName string) string {
+	return fmt.Sprintf("%s-%s-shared-data-disk", strings.ToLower(machineClassName), diskName)
+}
+
+// getSharedDataDiskNames returns the names of the shared data disks of the machine class
+func getSharedDataDiskNames(machineClassName string, azureDataDisks []api.AzureDataDisk) []string {
+	var names []string
+	for _, dataDisk := range azureDataDisks {
+		if isSharedDataDisk(dataDisk) {
+			names = append(names, sharedDataDiskName(machineClassName, dataDisk.Name))
+		}
+	}
+	return names
+}
+
+// sharedDataDisk is an existing shared data disk attached to a VM
+type sharedDataDisk struct {
+	name string
+	id   string
+}
+
+// ensureSharedDataDisks creates the shared data disks of the machine class which do not exist yet, as Azure only
+// creates disks with a single share along with a VM. Existing disks are reused, so that all machines of the class
+// attach the same disks. It returns the disks by their LUN.
+func (d *MachinePlugin) ensureSharedDataDisks(ctx context.Context, clients spi.AzureDriverClientsInterface, resourceGroupName, machineClassName string) (map[int32]sharedDataDisk, error) {
+	var (
+		storageProfile = d.AzureProviderSpec.Properties.StorageProfile
+		luns           = make([]int32, len(storageProfile.DataDisks))
+		disks          = make([]sharedDataDisk, len(storageProfile.DataDisks))
+		ensurers       []func() error
+	)
+
+	for i, dataDisk := range storageProfile.DataDisks {
+		if !isSharedDataDisk(dataDisk) {
+			continue
+		}
+		i, parameters := i, d.getSharedDataDiskParameters(dataDisk)
+		luns[i] = *getDataDiskLun(dataDisk, i, storageProfile.DataDiskLunOffset)
+		disks[i].name = sharedDataDiskName(machineClassName, dataDisk.Name)
+		ensurers = append(ensurers, func() (err error) {
+			disks[i].id, err = ensureDisk(ctx, clients, resourceGroupName, disks[i].name, parameters)
+			return err
+		})
+	}
+
+	if err := spi.RunInParallel(ensurers); err != nil {
+		return nil, err
+	}
+	result := map[int32]sharedDataDisk{}
+	for i, disk := range disks {
+		if disk.name != "" {
+			result[luns[i]] = disk
+		}
+	}
+	return result, nil
+}
+
+// getSharedDataDiskParameters returns the parameters of a shared data disk, which is tagged like the disks created
+// along with the VMs and as shared disk. It is created in the zone of the machine class, if the class has a single one.
+// Classes spreading their machines across zones need zone-redundant disks, which are created without a zone.
+func (d *MachinePlugin) getSharedDataDiskParameters(dataDisk api.AzureDataDisk) compute.Disk {
+	tags := getAzureTags(d.AzureProviderSpec.Tags)
+	tags[spi.DiskManagedByTagKey] = to.StringPtr(spi.DiskManagedByTagValue)
+	tags[sharedDiskTagKey] = to.StringPtr("true")
+
+	disk := compute.Disk{
+		Location: to.StringPtr(d.AzureProviderSpec.Location),
+		Tags:     tags,
+		Sku:      &compute.DiskSku{Name: compute.DiskStorageAccountTypes(dataDisk.StorageAccountType)},
+		DiskProperties: &compute.DiskProperties{
+			CreationData: &compute.CreationData{CreateOption: compute.Empty},
+			DiskSizeGB:   to.Int32Ptr(dataDisk.DiskSizeGB),
+			MaxShares:    dataDisk.MaxShares,
+		},
+	}
+	if zone := d.AzureProviderSpec.Properties.Zone; zone != nil {
+		disk.Zones = &[]string{strconv.Itoa(*zone)}
+	}
+	if dataDisk.DiskEncryptionSetID != nil {
+		disk.Encryption = &compute.Encryption{DiskEncryptionSetID: dataDisk.DiskEncryptionSetID, Type: compute.EncryptionTypeEncryptionAtRestWithCustomerKey}
+	}
+	return disk
+}
+
+// ensureDisk returns the resource ID of the managed disk, which is created if it does not exist
+func ensureDisk(ctx context.Context, clients spi.AzureDriverClientsInterface, resourceGroupName, diskName string, parameters compute.Disk) (string, error) {
+	disk, err := clients.GetDisk().Get(ctx, resourceGroupName, diskName)
+	if err == nil {
+		spi.OnARMAPISuccess(prometheusServiceDisk, "Disk.Get")
+		return to.String(disk.ID), nil
+	}
+	if !spi.NotFound(err) {
+		return "", spi.OnARMAPIErrorFail(prometheusServiceDisk, err, "Disk.Get failed for %s", diskName)
+	}
+	return createDisk(ctx, clients, resourceGroupName, diskName, parameters)
+}
+
+// createDisk creates the managed disk and returns its resource ID
+func createDisk(ctx context.Context, clients spi.AzureDriverClientsInterface, resourceGroupName, diskName string, parameters compute.Disk) (string, error) {
+	future, err := clients.GetDisk().CreateOrUpdate(ctx, resourceGroupName, diskName, parameters)
+	if err != nil {
+		return "", spi.OnARMAPIErrorFail(prometheusServiceDisk, err, "Disk.CreateOrUpdate failed for %s", diskName)
+	}
+	if err := future.WaitForCompletionRef(ctx, clients.GetClient()); err != nil {
+		return "", spi.OnARMAPIErrorFail(prometheusServiceDisk, err, "Disk.WaitForCompletionRef failed for %s", diskName)
+	}
+	disk, err := clients.GetDisk().Get(ctx, resourceGroupName, diskName)
+	if err != nil {
+		return "", spi.OnARMAPIErrorFail(prometheusServiceDisk, err, "Disk.Get failed for %s", diskName)
+	}
+	spi.OnARMAPISuccess(prometheusServiceDisk, "Disk.CreateOrUpdate")
+	return to.String(disk.ID), nil
+}
+
+// attachSharedDataDisks replaces the data disks of the VM parameters which are shared disks of the machine class with
+// attachments of the existing disks
+func attachSharedDataDisks(vm *compute.VirtualMachine, disks map[int32]sharedDataDisk) {
+	if len(disks) == 0 || vm.StorageProfile == nil || vm.StorageProfile.DataDisks == nil {
+		return
+	}
+	for i, dataDisk := range *vm.StorageProfile.DataDisks {
+		if dataDisk.Lun == nil {
+			continue
+		}
+		disk, ok := disks[*dataDisk.Lun]
+		if !ok {
+			continue
+		}
+		(*vm.StorageProfile.DataDisks)[i].Name = to.StringPtr(disk.name)
+		(*vm.StorageProfile.DataDisks)[i].CreateOption = compute.DiskCreateOptionTypesAttach
+		(*vm.StorageProfile.DataDisks)[i].ManagedDisk = &compute.ManagedDiskParameters{ID: to.StringPtr(disk.id)}
+		(*vm.StorageProfile.DataDisks)[i].DiskSizeGB = nil
+	}
+}
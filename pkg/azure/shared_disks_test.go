@@ -0,0 +1,125 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-08-01/compute"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/mock"
+	gomock "github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SharedDisks", func() {
+	var plugin *MachinePlugin
+
+	BeforeEach(func() {
+		plugin = &MachinePlugin{AzureProviderSpec: &api.AzureProviderSpec{
+			Location: "westeurope",
+			Tags:     map[string]string{"cluster": "shoot"},
+		}}
+		plugin.AzureProviderSpec.Properties.Zone = to.IntPtr(2)
+	})
+
+	It("should create shared data disks in the zone of the machine class", func() {
+		dataDisk := api.AzureDataDisk{Name: "quorum", StorageAccountType: "Premium_LRS", DiskSizeGB: 256, MaxShares: to.Int32Ptr(3)}
+
+		disk := plugin.getSharedDataDiskParameters(dataDisk)
+		Expect(isSharedDataDisk(dataDisk)).To(BeTrue())
+		Expect(*disk.Zones).To(Equal([]string{"2"}))
+		Expect(disk.Sku.Name).To(Equal(compute.PremiumLRS))
+		Expect(*disk.MaxShares).To(Equal(int32(3)))
+		Expect(*disk.DiskSizeGB).To(Equal(int32(256)))
+		Expect(disk.CreationData.CreateOption).To(Equal(compute.Empty))
+		Expect(disk.Tags).To(HaveKeyWithValue("cluster", to.StringPtr("shoot")))
+		Expect(isShared(disk.Tags)).To(BeTrue())
+		Expect(disk.Encryption).To(BeNil())
+	})
+
+	It("should not share data disks with a single share", func() {
+		Expect(isSharedDataDisk(api.AzureDataDisk{MaxShares: to.Int32Ptr(1)})).To(BeFalse())
+		Expect(isSharedDataDisk(api.AzureDataDisk{})).To(BeFalse())
+		Expect(isSharedDataDisk(api.AzureDataDisk{CreateOption: api.DataDiskCreateOptionAttach, MaxShares: to.Int32Ptr(3)})).To(BeFalse())
+	})
+
+	It("should attach the shared data disks created beforehand", func() {
+		plugin.AzureProviderSpec.Properties.StorageProfile.DataDisks = []api.AzureDataDisk{
+			{Name: "quorum", StorageAccountType: "Premium_LRS", DiskSizeGB: 256, MaxShares: to.Int32Ptr(3)},
+			{Name: "logs", StorageAccountType: "Standard_LRS", DiskSizeGB: 32},
+		}
+		dataDisks := plugin.generateDataDisks("machine", plugin.AzureProviderSpec.Properties.StorageProfile.DataDisks, 0)
+		vm := compute.VirtualMachine{VirtualMachineProperties: &compute.VirtualMachineProperties{StorageProfile: &compute.StorageProfile{DataDisks: &dataDisks}}}
+
+		attachSharedDataDisks(&vm, map[int32]sharedDataDisk{0: {name: "class-quorum-shared-data-disk", id: "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Compute/disks/class-quorum-shared-data-disk"}})
+
+		Expect(dataDisks[0].CreateOption).To(Equal(compute.DiskCreateOptionTypesAttach))
+		Expect(*dataDisks[0].Name).To(Equal("class-quorum-shared-data-disk"))
+		Expect(*dataDisks[0].ManagedDisk.ID).To(HaveSuffix("/disks/class-quorum-shared-data-disk"))
+		Expect(dataDisks[0].DiskSizeGB).To(BeNil())
+		Expect(dataDisks[1].CreateOption).To(Equal(compute.DiskCreateOptionTypesEmpty))
+		Expect(*dataDisks[1].DiskSizeGB).To(Equal(int32(32)))
+	})
+
+	It("should neither tag nor delete the shared data disks with the VM", func() {
+		dataDisks := []api.AzureDataDisk{
+			{Name: "quorum", MaxShares: to.Int32Ptr(3)},
+			{Name: "logs"},
+		}
+
+		Expect(getAzureDataDiskNames(suffixNamingStrategy{}, dataDisks, 0, "machine")).To(Equal([]string{"machine-logs-1-data-disk"}))
+		Expect(getSharedDataDiskNames("Class", dataDisks)).To(Equal([]string{"class-quorum-shared-data-disk"}))
+	})
+
+	Describe("#ensureSharedDataDisks", func() {
+		var (
+			ctx     = context.Background()
+			clients *mock.AzureDriverClients
+		)
+
+		BeforeEach(func() {
+			sp := mock.NewMockPluginSPIImpl(gomock.NewController(GinkgoT()))
+			_, secret := newProviderSpecCacheFixtures()
+			driverClients, err := sp.Setup(secret, nil)
+			Expect(err).NotTo(HaveOccurred())
+			clients = driverClients.(*mock.AzureDriverClients)
+
+			plugin.AzureProviderSpec.Properties.StorageProfile.DataDisks = []api.AzureDataDisk{
+				{Name: "quorum", StorageAccountType: "Premium_LRS", DiskSizeGB: 256, MaxShares: to.Int32Ptr(3)},
+				{Name: "logs", StorageAccountType: "Standard_LRS", DiskSizeGB: 32},
+			}
+		})
+
+		It("should attach the existing shared data disk of the machine class", func() {
+			clients.Disk.EXPECT().Get(ctx, "rg", "class-quorum-shared-data-disk").Return(compute.Disk{ID: to.StringPtr("quorum-id")}, nil)
+
+			disks, err := plugin.ensureSharedDataDisks(ctx, clients, "rg", "class")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(disks).To(Equal(map[int32]sharedDataDisk{0: {name: "class-quorum-shared-data-disk", id: "quorum-id"}}))
+		})
+
+		It("should create the shared data disk if it does not exist", func() {
+			var future compute.DisksCreateOrUpdateFuture
+			Expect(json.Unmarshal([]byte(succeededFuture), &future)).To(Succeed())
+			gomock.InOrder(
+				clients.Disk.EXPECT().Get(ctx, "rg", "class-quorum-shared-data-disk").Return(compute.Disk{}, autorest.DetailedError{Response: &http.Response{StatusCode: http.StatusNotFound}}),
+				clients.Disk.EXPECT().CreateOrUpdate(ctx, "rg", "class-quorum-shared-data-disk", gomock.Any()).Return(future, nil),
+				clients.Disk.EXPECT().Get(ctx, "rg", "class-quorum-shared-data-disk").Return(compute.Disk{ID: to.StringPtr("quorum-id")}, nil),
+			)
+
+			disks, err := plugin.ensureSharedDataDisks(ctx, clients, "rg", "class")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(disks[0].id).To(Equal("quorum-id"))
+		})
+	})
+})
@@ -0,0 +1,79 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"context"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/spi"
+)
+
+// shutDownVM powers off the VM through its OS before it is deleted, so that the workloads can terminate and local
+// writes are flushed. The shutdown is bounded by the graceful shutdown timeout, and a failed or timed out shutdown is
+// only logged, as it must not block the deletion of the machine. VMs are deleted without shutdown if the timeout is
+// zero or their deletion is forced.
+func (d *MachinePlugin) shutDownVM(ctx context.Context, clients spi.AzureDriverClientsInterface, resourceGroupName, vmName string) {
+	if d.gracefulShutdownTimeout <= 0 || spi.ForceDeletion(ctx) {
+		return
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(ctx, d.gracefulShutdownTimeout)
+	defer cancel()
+
+	start := time.Now()
+	spi.V(2).InfoS(ctx, "VM graceful shutdown has begun", "vm", vmName)
+	future, err := clients.GetVM().PowerOff(shutdownCtx, resourceGroupName, vmName, to.BoolPtr(false))
+	if err != nil {
+		spi.WarningS(ctx, "VM could not be shut down gracefully, deleting it anyway", "vm", vmName, "err", spi.OnARMAPIErrorFail(prometheusServiceVM, err, "vm.PowerOff"))
+		return
+	}
+	if err := future.WaitForCompletionRef(shutdownCtx, clients.GetClient()); err != nil {
+		spi.WarningS(ctx, "VM could not be shut down gracefully, deleting it anyway", "vm", vmName, "duration", time.Since(start), "err", spi.OnARMAPIErrorFail(prometheusServiceVM, err, "vm.PowerOff"))
+		return
+	}
+	spi.OnARMAPISuccess(prometheusServiceVM, "VM power off was successful for %s", vmName)
+	spi.InfoS(ctx, "VM was shut down gracefully", "vm", vmName, "duration", time.Since(start))
+}
+
+// shutDownVMAsync issues the power off of the VM through its OS without waiting for it, as the asynchronous deletion
+// must not block, see shutDownVM. It has to be called repeatedly with the key of the asynchronous deletion and returns
+// true once the VM can be deleted, i.e. once it is stopped, the graceful shutdown timeout elapsed since the power off
+// was issued or the power off could not be issued.
+func (d *MachinePlugin) shutDownVMAsync(ctx context.Context, clients spi.AzureDriverClientsInterface, key, resourceGroupName, vmName string) bool {
+	if d.gracefulShutdownTimeout <= 0 || spi.ForceDeletion(ctx) {
+		return true
+	}
+
+	issuedAt, ok := d.asyncDeletions.loadShutdown(key)
+	if !ok {
+		spi.V(2).InfoS(ctx, "VM graceful shutdown has begun", "vm", vmName)
+		if _, err := clients.GetVM().PowerOff(ctx, resourceGroupName, vmName, to.BoolPtr(false)); err != nil {
+			spi.WarningS(ctx, "VM could not be shut down gracefully, deleting it anyway", "vm", vmName, "err", spi.OnARMAPIErrorFail(prometheusServiceVM, err, "vm.PowerOff"))
+			return true
+		}
+		spi.OnARMAPISuccess(prometheusServiceVM, "VM power off was issued for %s", vmName)
+		d.asyncDeletions.storeShutdown(key, time.Now())
+		return false
+	}
+
+	if time.Since(issuedAt) >= d.gracefulShutdownTimeout {
+		spi.WarningS(ctx, "VM could not be shut down gracefully, deleting it anyway", "vm", vmName, "duration", time.Since(issuedAt), "err", "timeout exceeded")
+		return true
+	}
+	stopped, err := isVMStopped(ctx, clients, resourceGroupName, vmName)
+	if err != nil {
+		// The power state is read again by the next call, the timeout bounds the shutdown nevertheless
+		spi.V(2).InfoS(ctx, "Power state of VM could not be read", "vm", vmName, "err", err)
+		return false
+	}
+	if stopped {
+		spi.InfoS(ctx, "VM was shut down gracefully", "vm", vmName, "duration", time.Since(issuedAt))
+	}
+	return stopped
+}
@@ -0,0 +1,86 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-08-01/compute"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/mock"
+	gomock "github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Shutdown", func() {
+	var (
+		ctx     = context.Background()
+		driver  *MachinePlugin
+		clients *mock.AzureDriverClients
+	)
+
+	BeforeEach(func() {
+		sp := mock.NewMockPluginSPIImpl(gomock.NewController(GinkgoT()))
+		_, secret := newProviderSpecCacheFixtures()
+		driverClients, err := sp.Setup(secret, nil)
+		Expect(err).NotTo(HaveOccurred())
+		clients = driverClients.(*mock.AzureDriverClients)
+
+		driver = NewAzureDriver(sp)
+		driver.gracefulShutdownTimeout = time.Minute
+	})
+
+	It("should power off the VM through its OS", func() {
+		var future compute.VirtualMachinesPowerOffFuture
+		Expect(json.Unmarshal([]byte(succeededFuture), &future)).To(Succeed())
+		clients.VM.EXPECT().PowerOff(gomock.Any(), "rg", "machine", to.BoolPtr(false)).Return(future, nil)
+
+		driver.shutDownVM(ctx, clients, "rg", "machine")
+	})
+
+	It("should not block the deletion if the VM cannot be powered off", func() {
+		clients.VM.EXPECT().PowerOff(gomock.Any(), "rg", "machine", to.BoolPtr(false)).Return(compute.VirtualMachinesPowerOffFuture{}, errors.New("conflict"))
+
+		driver.shutDownVM(ctx, clients, "rg", "machine")
+	})
+
+	It("should not power off the VM if graceful shutdown is disabled", func() {
+		driver.gracefulShutdownTimeout = 0
+
+		driver.shutDownVM(ctx, clients, "rg", "machine")
+	})
+	It("should not shut down the VM within the timeout of its deletion", func() {
+		driver.deleteTimeout = 10 * time.Millisecond
+		var (
+			powerOffFuture compute.VirtualMachinesPowerOffFuture
+			deleteFuture   compute.VirtualMachinesDeleteFuture
+		)
+		Expect(json.Unmarshal([]byte(succeededFuture), &powerOffFuture)).To(Succeed())
+		Expect(json.Unmarshal([]byte(succeededFuture), &deleteFuture)).To(Succeed())
+
+		clients.VM.EXPECT().Get(gomock.Any(), "rg", "machine", compute.InstanceViewTypes("")).Return(compute.VirtualMachine{
+			Name:                     to.StringPtr("machine"),
+			VirtualMachineProperties: &compute.VirtualMachineProperties{StorageProfile: &compute.StorageProfile{DataDisks: &[]compute.DataDisk{}}},
+		}, nil)
+		clients.VM.EXPECT().PowerOff(gomock.Any(), "rg", "machine", to.BoolPtr(false)).DoAndReturn(func(ctx context.Context, _, _ string, _ *bool) (compute.VirtualMachinesPowerOffFuture, error) {
+			time.Sleep(2 * driver.deleteTimeout)
+			return powerOffFuture, nil
+		})
+		clients.VM.EXPECT().Delete(gomock.Any(), "rg", "machine", nil).DoAndReturn(func(ctx context.Context, _, _ string, _ *bool) (compute.VirtualMachinesDeleteFuture, error) {
+			return deleteFuture, ctx.Err()
+		})
+		clients.Disk.EXPECT().Get(gomock.Any(), "rg", "machine-os-disk").Return(compute.Disk{}, autorest.DetailedError{Response: &http.Response{StatusCode: http.StatusNotFound}})
+
+		Expect(driver.deleteVMNicDisks(ctx, clients, "rg", "machine", nil, "machine-os-disk", nil)).To(Succeed())
+	})
+})
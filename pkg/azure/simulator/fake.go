@@ -0,0 +1,303 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package simulator simulates the lifecycle of machines against an in-memory fake of the Azure API
+package simulator
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+)
+
+// Fake is an in-memory fake of the Azure Resource Manager API. It stores the resources put by the Azure clients and
+// answers their reads, lists, updates and deletions like Azure does for the requests of the machine controller.
+// Long-running operations complete immediately.
+type Fake struct {
+	// VMSizes are the VM sizes offered by the resource SKUs API in every location
+	VMSizes []string
+	// VMCreationFailureRate is the share of VM creations which fail with an allocation failure, between 0 and 1
+	VMCreationFailureRate float64
+	// Latency delays every request
+	Latency time.Duration
+
+	mutex     sync.Mutex
+	random    *rand.Rand
+	resources map[string]map[string]interface{}
+	requests  map[string]int
+	// allocations records whether the allocation of a VM fails, so that the retries of its creation fail as well
+	allocations map[string]bool
+}
+
+// NewFake returns an empty fake. The seed determines which VM creations fail.
+func NewFake(seed int64) *Fake {
+	return &Fake{
+		random:      rand.New(rand.NewSource(seed)),
+		resources:   map[string]map[string]interface{}{},
+		requests:    map[string]int{},
+		allocations: map[string]bool{},
+	}
+}
+
+// Sender returns the sender passing the requests of the Azure clients to the fake in-process
+func (f *Fake) Sender() autorest.Sender {
+	return autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+		if f.Latency > 0 {
+			time.Sleep(f.Latency)
+		}
+		recorder := httptest.NewRecorder()
+		f.ServeHTTP(recorder, r)
+		resp := recorder.Result()
+		resp.Request = r
+		return resp, nil
+	})
+}
+
+// Put stores the resource with the given ID, e.g. to create the resource group and subnet of the machines
+func (f *Fake) Put(id string, resource map[string]interface{}) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.store(id, resource)
+}
+
+// Count returns the number of resources of the given type, e.g. Microsoft.Compute/virtualMachines
+func (f *Fake) Count(resourceType string) int {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	count := 0
+	for id := range f.resources {
+		if t, _ := parseID(id); t == strings.ToLower(resourceType) {
+			count++
+		}
+	}
+	return count
+}
+
+// Requests returns the number of requests served per HTTP method
+func (f *Fake) Requests() map[string]int {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	requests := make(map[string]int, len(f.requests))
+	for method, count := range f.requests {
+		requests[method] = count
+	}
+	return requests
+}
+
+// ServeHTTP serves a request of the Azure Resource Manager API
+func (f *Fake) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.requests[r.Method]++
+
+	var (
+		id             = strings.ToLower(r.URL.Path)
+		resourceType   string
+		isCollection   bool
+		resource, ok   = f.resources[id]
+		vmResourceType = "microsoft.compute/virtualmachines"
+	)
+	resourceType, isCollection = parseID(id)
+
+	switch {
+	case strings.HasSuffix(id, "/providers/microsoft.compute/skus"):
+		f.listSKUs(w)
+	case strings.Contains(id, "/providers/microsoft.compute/locations/") && strings.Contains(id, "/versions/"):
+		writeJSON(w, http.StatusOK, map[string]interface{}{"id": r.URL.Path, "name": path.Base(r.URL.Path), "properties": map[string]interface{}{}})
+	case r.Method == http.MethodGet && ok:
+		writeJSON(w, http.StatusOK, resource)
+	case r.Method == http.MethodGet && isCollection:
+		f.list(w, id)
+	case r.Method == http.MethodGet, r.Method == http.MethodPatch && !ok:
+		writeError(w, http.StatusNotFound, "ResourceNotFound", fmt.Sprintf("The resource %s was not found", r.URL.Path))
+	case r.Method == http.MethodPut:
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeError(w, http.StatusBadRequest, "InvalidRequestContent", err.Error())
+			return
+		}
+		if resourceType == vmResourceType && !ok && f.allocationFails(id) {
+			writeError(w, http.StatusConflict, "AllocationFailed", "Allocation failed. We do not have sufficient capacity for the requested VM size in this region.")
+			return
+		}
+		resource = f.store(r.URL.Path, body)
+		if resourceType == vmResourceType {
+			f.attach(r.URL.Path, resource)
+		}
+		writeJSON(w, http.StatusOK, resource)
+	case r.Method == http.MethodPatch:
+		var update map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+			writeError(w, http.StatusBadRequest, "InvalidRequestContent", err.Error())
+			return
+		}
+		for key, value := range update {
+			resource[key] = value
+		}
+		writeJSON(w, http.StatusOK, resource)
+	case r.Method == http.MethodDelete && ok:
+		if resourceType == vmResourceType {
+			f.detach(r.URL.Path)
+		}
+		delete(f.resources, id)
+		w.WriteHeader(http.StatusOK)
+	case r.Method == http.MethodDelete:
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", fmt.Sprintf("%s is not supported by the fake", r.Method))
+	}
+}
+
+// allocationFails returns whether the creation of the VM fails with an allocation failure
+func (f *Fake) allocationFails(id string) bool {
+	fails, ok := f.allocations[id]
+	if !ok {
+		fails = f.random.Float64() < f.VMCreationFailureRate
+		f.allocations[id] = fails
+	}
+	return fails
+}
+
+// store stores the resource with its ID and name and a succeeded provisioning state
+func (f *Fake) store(id string, resource map[string]interface{}) map[string]interface{} {
+	if resource == nil {
+		resource = map[string]interface{}{}
+	}
+	resource["id"] = id
+	resource["name"] = path.Base(id)
+	properties(resource)["provisioningState"] = "Succeeded"
+	f.resources[strings.ToLower(id)] = resource
+	return resource
+}
+
+// attach attaches the NICs of the VM to it and creates its managed disks, as Azure does
+func (f *Fake) attach(vmID string, vm map[string]interface{}) {
+	var (
+		vmProperties    = properties(vm)
+		storageProfile  = child(vmProperties, "storageProfile")
+		resourceGroupID = vmID[:strings.Index(strings.ToLower(vmID), "/providers/")]
+		disks           []map[string]interface{}
+	)
+
+	if nics, ok := child(vmProperties, "networkProfile")["networkInterfaces"].([]interface{}); ok {
+		for _, item := range nics {
+			if nic, ok := item.(map[string]interface{}); ok {
+				if resource, ok := f.resources[strings.ToLower(fmt.Sprint(nic["id"]))]; ok {
+					properties(resource)["virtualMachine"] = map[string]interface{}{"id": vmID}
+				}
+			}
+		}
+	}
+
+	disks = append(disks, child(storageProfile, "osDisk"))
+	if dataDisks, ok := storageProfile["dataDisks"].([]interface{}); ok {
+		for _, item := range dataDisks {
+			if disk, ok := item.(map[string]interface{}); ok {
+				disks = append(disks, disk)
+			}
+		}
+	} else {
+		storageProfile["dataDisks"] = []interface{}{}
+	}
+	for _, disk := range disks {
+		name, ok := disk["name"].(string)
+		if !ok || name == "" {
+			continue
+		}
+		diskID := resourceGroupID + "/providers/Microsoft.Compute/disks/" + name
+		if _, ok := f.resources[strings.ToLower(diskID)]; !ok {
+			f.store(diskID, map[string]interface{}{"location": vm["location"], "managedBy": vmID})
+		}
+		child(disk, "managedDisk")["id"] = diskID
+	}
+}
+
+// detach detaches the NICs and disks of the deleted VM, which are kept by Azure
+func (f *Fake) detach(vmID string) {
+	for _, resource := range f.resources {
+		if managedBy, ok := resource["managedBy"].(string); ok && strings.EqualFold(managedBy, vmID) {
+			delete(resource, "managedBy")
+		}
+		if vm, ok := properties(resource)["virtualMachine"].(map[string]interface{}); ok && strings.EqualFold(fmt.Sprint(vm["id"]), vmID) {
+			delete(properties(resource), "virtualMachine")
+		}
+	}
+}
+
+// list lists the resources of the collection
+func (f *Fake) list(w http.ResponseWriter, collection string) {
+	items := []interface{}{}
+	for id, resource := range f.resources {
+		if strings.HasPrefix(id, collection+"/") && !strings.Contains(strings.TrimPrefix(id, collection+"/"), "/") {
+			items = append(items, resource)
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"value": items})
+}
+
+// listSKUs lists the offered VM sizes
+func (f *Fake) listSKUs(w http.ResponseWriter) {
+	items := []interface{}{}
+	for _, size := range f.VMSizes {
+		items = append(items, map[string]interface{}{"resourceType": "virtualMachines", "name": size})
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"value": items})
+}
+
+// parseID returns the lower-case type of the resource or collection with the given ID, and whether the ID denotes a
+// collection
+func parseID(id string) (string, bool) {
+	id = strings.ToLower(id)
+	index := strings.LastIndex(id, "/providers/")
+	if index < 0 {
+		return "", false
+	}
+	segments := strings.Split(strings.Trim(id[index+len("/providers/"):], "/"), "/")
+	if len(segments) < 2 {
+		return "", false
+	}
+
+	resourceType := segments[0]
+	for i := 1; i < len(segments); i += 2 {
+		resourceType += "/" + segments[i]
+	}
+	return resourceType, len(segments)%2 == 0
+}
+
+// properties returns the properties of the resource, which are added if missing
+func properties(resource map[string]interface{}) map[string]interface{} {
+	return child(resource, "properties")
+}
+
+// child returns the object of the key, which is added if missing
+func child(object map[string]interface{}, key string) map[string]interface{} {
+	value, ok := object[key].(map[string]interface{})
+	if !ok {
+		value = map[string]interface{}{}
+		object[key] = value
+	}
+	return value
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, statusCode int, code, message string) {
+	writeJSON(w, statusCode, map[string]interface{}{"error": map[string]interface{}{"code": code, "message": message}})
+}
@@ -0,0 +1,127 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package simulator
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/driver"
+)
+
+// Result is the result of a step of a scenario
+type Result struct {
+	// Step is the name of the step
+	Step string
+	// Succeeded is the number of machines for which the step succeeded
+	Succeeded int
+	// Failed is the number of machines for which the step failed
+	Failed int
+	// Duration is the duration of the step
+	Duration time.Duration
+}
+
+// Throughput returns the number of machines reconciled per second by the step, regardless of their outcome
+func (r Result) Throughput() float64 {
+	if r.Duration <= 0 {
+		return 0
+	}
+	return float64(r.Succeeded+r.Failed) / r.Duration.Seconds()
+}
+
+// Step is a step of a scenario
+type Step struct {
+	// Name is the name of the step
+	Name string
+	// Run runs the step against the simulator
+	Run func(ctx context.Context, s *Simulator) (Result, error)
+}
+
+// Scenario is a sequence of steps replayed by the simulator
+type Scenario []Step
+
+// DefaultScenario scales up 100 machines of which 10% fail to be created, determines their status and deletes all of
+// them again
+func DefaultScenario() Scenario {
+	return Scenario{FailVMCreations(0.1), ScaleUp(100), Reconcile(), DeleteAll()}
+}
+
+// FailVMCreations lets the given share of subsequent VM creations fail with an allocation failure
+func FailVMCreations(rate float64) Step {
+	return Step{
+		Name: fmt.Sprintf("fail %.0f%% of VM creations", rate*100),
+		Run: func(_ context.Context, s *Simulator) (Result, error) {
+			s.Fake.mutex.Lock()
+			defer s.Fake.mutex.Unlock()
+			s.Fake.VMCreationFailureRate = rate
+			return Result{}, nil
+		},
+	}
+}
+
+// ScaleUp creates the given number of new machines
+func ScaleUp(count int) Step {
+	return Step{
+		Name: fmt.Sprintf("scale up %d", count),
+		Run: func(ctx context.Context, s *Simulator) (Result, error) {
+			s.mutex.Lock()
+			names := make([]string, 0, count)
+			for i := 0; i < count; i++ {
+				names = append(names, fmt.Sprintf("%s-%d", s.MachineClass.Name, s.created+i))
+			}
+			s.created += count
+			s.mutex.Unlock()
+
+			return s.reconcile(names, func(name string) error {
+				machine := s.newMachine(name)
+				if _, err := s.Driver.CreateMachine(ctx, &driver.CreateMachineRequest{Machine: machine, MachineClass: s.MachineClass, Secret: s.Secret}); err != nil {
+					return err
+				}
+				s.mutex.Lock()
+				defer s.mutex.Unlock()
+				s.machines[name] = machine
+				return nil
+			}), nil
+		},
+	}
+}
+
+// Reconcile lists the machines of the machine class and determines the status of every machine, like the health
+// checks of the machine controller do
+func Reconcile() Step {
+	return Step{
+		Name: "reconcile",
+		Run: func(ctx context.Context, s *Simulator) (Result, error) {
+			if _, err := s.Driver.ListMachines(ctx, &driver.ListMachinesRequest{MachineClass: s.MachineClass, Secret: s.Secret}); err != nil {
+				return Result{}, err
+			}
+			return s.reconcile(s.Machines(), func(name string) error {
+				_, err := s.Driver.GetMachineStatus(ctx, &driver.GetMachineStatusRequest{Machine: s.newMachine(name), MachineClass: s.MachineClass, Secret: s.Secret})
+				return err
+			}), nil
+		},
+	}
+}
+
+// DeleteAll deletes all machines
+func DeleteAll() Step {
+	return Step{
+		Name: "delete all",
+		Run: func(ctx context.Context, s *Simulator) (Result, error) {
+			return s.reconcile(s.Machines(), func(name string) error {
+				if _, err := s.Driver.DeleteMachine(ctx, &driver.DeleteMachineRequest{Machine: s.newMachine(name), MachineClass: s.MachineClass, Secret: s.Secret}); err != nil {
+					return err
+				}
+				s.mutex.Lock()
+				defer s.mutex.Unlock()
+				delete(s.machines, name)
+				return nil
+			}), nil
+		},
+	}
+}
@@ -0,0 +1,196 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package simulator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/azure"
+	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/spi"
+	"github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/driver"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	subscriptionID          = "00000000-0000-0000-0000-000000000000"
+	resourceManagerEndpoint = "https://management.simulator.local"
+)
+
+// providerSpec is the provider spec of the machine class of the simulated machines
+var providerSpec = []byte(`{
+	"location": "westeurope",
+	"resourceGroup": "shoot--simulator",
+	"subnetInfo": {"vnetName": "shoot--simulator", "subnetName": "shoot--simulator-nodes"},
+	"properties": {
+		"hardwareProfile": {"vmSize": "Standard_DS2_v2"},
+		"osProfile": {
+			"adminUsername": "core",
+			"linuxConfiguration": {
+				"disablePasswordAuthentication": true,
+				"ssh": {"publicKeys": {"path": "/home/core/.ssh/authorized_keys", "keyData": "ssh-rsa simulator"}}
+			}
+		},
+		"storageProfile": {
+			"imageReference": {"urn": "sap:gardenlinux:greatest:27.1.0"},
+			"osDisk": {"caching": "None", "createOption": "FromImage", "diskSizeGB": 50, "managedDisk": {"storageAccountType": "Standard_LRS"}}
+		},
+		"zone": 2
+	},
+	"tags": {"kubernetes.io-cluster-shoot--simulator": "1", "kubernetes.io-role-node": "1"}
+}`)
+
+// session is the session provider of the plugin, which returns clients talking to the fake
+type session struct {
+	fake *Fake
+}
+
+// Setup returns the Azure clients talking to the fake
+func (s session) Setup(_ *corev1.Secret, _ *api.CloudConfiguration) (spi.AzureDriverClientsInterface, error) {
+	return spi.NewClients(subscriptionID, resourceManagerEndpoint, autorest.NullAuthorizer{}, s.fake.Sender()), nil
+}
+
+// Simulator drives the machine plugin through scenarios against the fake of the Azure API, like the machine
+// controller does
+type Simulator struct {
+	// Fake is the fake of the Azure API the plugin talks to
+	Fake *Fake
+	// Driver is the machine plugin under simulation
+	Driver driver.Driver
+	// MachineClass is the machine class of all machines
+	MachineClass *v1alpha1.MachineClass
+	// Secret is the secret of the machine class
+	Secret *corev1.Secret
+	// Workers is the number of machines which are reconciled concurrently
+	Workers int
+
+	mutex    sync.Mutex
+	created  int
+	machines map[string]*v1alpha1.Machine
+}
+
+// New returns a simulator of the plugin with the default options against the fake. The resource group and subnet of
+// the machine class are created in the fake.
+func New(fake *Fake) (*Simulator, error) {
+	spec := &api.AzureProviderSpec{}
+	if err := json.Unmarshal(providerSpec, spec); err != nil {
+		return nil, err
+	}
+	fake.VMSizes = append(fake.VMSizes, spec.Properties.HardwareProfile.VMSize)
+
+	resourceGroupID := fmt.Sprintf("/subscriptions/%s/resourceGroups/%s", subscriptionID, spec.ResourceGroup)
+	vnetID := fmt.Sprintf("%s/providers/Microsoft.Network/virtualNetworks/%s", resourceGroupID, spec.SubnetInfo.VnetName)
+	fake.Put(resourceGroupID, map[string]interface{}{"location": spec.Location})
+	fake.Put(vnetID, map[string]interface{}{"location": spec.Location})
+	fake.Put(fmt.Sprintf("%s/subnets/%s", vnetID, spec.SubnetInfo.SubnetName), map[string]interface{}{
+		"properties": map[string]interface{}{"addressPrefix": "10.250.0.0/16"},
+	})
+
+	plugin := azure.NewAzureDriver(session{fake: fake})
+	if err := azure.NewDriverOptions().ApplyTo(plugin); err != nil {
+		return nil, err
+	}
+
+	return &Simulator{
+		Fake:   fake,
+		Driver: plugin,
+		MachineClass: &v1alpha1.MachineClass{
+			ObjectMeta:   metav1.ObjectMeta{Name: "simulator"},
+			ProviderSpec: runtime.RawExtension{Raw: providerSpec},
+		},
+		Secret: &corev1.Secret{Data: map[string][]byte{
+			api.AzureClientID:       []byte("client"),
+			api.AzureClientSecret:   []byte("secret"),
+			api.AzureSubscriptionID: []byte(subscriptionID),
+			api.AzureTenantID:       []byte("tenant"),
+			"userData":              []byte("#cloud-config"),
+		}},
+		Workers:  1,
+		machines: map[string]*v1alpha1.Machine{},
+	}, nil
+}
+
+// Machines returns the names of the machines which were created and not deleted yet
+func (s *Simulator) Machines() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	names := make([]string, 0, len(s.machines))
+	for name := range s.machines {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Run runs the steps of the scenario in order and returns their results. It stops at the first step which fails as a
+// whole, e.g. as the machines cannot be listed.
+func (s *Simulator) Run(ctx context.Context, scenario Scenario) ([]Result, error) {
+	var results []Result
+	for _, step := range scenario {
+		start := time.Now()
+		result, err := step.Run(ctx, s)
+		result.Step = step.Name
+		result.Duration = time.Since(start)
+		results = append(results, result)
+		if err != nil {
+			return results, fmt.Errorf("step %q failed: %v", step.Name, err)
+		}
+	}
+	return results, nil
+}
+
+// reconcile calls the function for all items with the configured number of workers and counts the items for which it
+// succeeded and failed
+func (s *Simulator) reconcile(items []string, fn func(item string) error) Result {
+	var (
+		result  Result
+		mutex   sync.Mutex
+		wg      sync.WaitGroup
+		queue   = make(chan string)
+		workers = s.Workers
+	)
+	if workers < 1 {
+		workers = 1
+	}
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for item := range queue {
+				err := fn(item)
+				mutex.Lock()
+				if err != nil {
+					result.Failed++
+				} else {
+					result.Succeeded++
+				}
+				mutex.Unlock()
+			}
+		}()
+	}
+	for _, item := range items {
+		queue <- item
+	}
+	close(queue)
+	wg.Wait()
+	return result
+}
+
+func (s *Simulator) newMachine(name string) *v1alpha1.Machine {
+	return &v1alpha1.Machine{ObjectMeta: metav1.ObjectMeta{Name: name}}
+}
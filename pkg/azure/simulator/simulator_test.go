@@ -0,0 +1,133 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package simulator
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// budget is the maximum cost of a scenario, beyond which its test and benchmark fail
+type budget struct {
+	// machines is the number of machines the scenario creates
+	machines int
+	// requestsPerMachine is the maximum number of Azure API requests per machine
+	requestsPerMachine float64
+	// minThroughput is the minimum throughput of the steps reconciling machines in machines per second, which leaves
+	// ample room for slow CI runners
+	minThroughput float64
+}
+
+var (
+	scaleUpBudget             = budget{machines: 100, requestsPerMachine: 9, minThroughput: 20}
+	scaleUpWithFailuresBudget = budget{machines: 100, requestsPerMachine: 9, minThroughput: 20}
+	defaultScenarioBudget     = budget{machines: 100, requestsPerMachine: 18, minThroughput: 20}
+)
+
+// requestsPerMachine returns the number of requests the fake served per machine
+func requestsPerMachine(fake *Fake, machines int) float64 {
+	var requests int
+	for _, count := range fake.Requests() {
+		requests += count
+	}
+	return float64(requests) / float64(machines)
+}
+
+var _ = Describe("Simulator", func() {
+	It("should replay the default scenario without leaking resources", func() {
+		simulator, err := New(NewFake(1))
+		Expect(err).NotTo(HaveOccurred())
+
+		results, err := simulator.Run(context.Background(), DefaultScenario())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results).To(HaveLen(4))
+
+		scaleUp, reconcile, deleteAll := results[1], results[2], results[3]
+		Expect(scaleUp.Succeeded + scaleUp.Failed).To(Equal(100))
+		Expect(scaleUp.Failed).To(BeNumerically("~", 10, 8))
+		Expect(reconcile.Succeeded).To(Equal(scaleUp.Succeeded))
+		Expect(reconcile.Failed).To(BeZero())
+		Expect(deleteAll.Succeeded).To(Equal(scaleUp.Succeeded))
+		Expect(deleteAll.Failed).To(BeZero())
+
+		Expect(simulator.Machines()).To(BeEmpty())
+		Expect(simulator.Fake.Count("Microsoft.Compute/virtualMachines")).To(BeZero())
+		Expect(simulator.Fake.Count("Microsoft.Compute/disks")).To(BeZero())
+		Expect(simulator.Fake.Count("Microsoft.Network/networkInterfaces")).To(BeZero())
+		Expect(requestsPerMachine(simulator.Fake, defaultScenarioBudget.machines)).To(BeNumerically("<=", defaultScenarioBudget.requestsPerMachine))
+	})
+
+	It("should scale up within the request budget", func() {
+		simulator, err := New(NewFake(1))
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = simulator.Run(context.Background(), Scenario{ScaleUp(scaleUpBudget.machines)})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(requestsPerMachine(simulator.Fake, scaleUpBudget.machines)).To(BeNumerically("<=", scaleUpBudget.requestsPerMachine))
+	})
+
+	It("should report the status of created machines", func() {
+		simulator, err := New(NewFake(1))
+		Expect(err).NotTo(HaveOccurred())
+
+		results, err := simulator.Run(context.Background(), Scenario{ScaleUp(3), Reconcile()})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results[0].Succeeded).To(Equal(3))
+		Expect(results[1].Succeeded).To(Equal(3))
+		Expect(simulator.Machines()).To(Equal([]string{"simulator-0", "simulator-1", "simulator-2"}))
+		Expect(simulator.Fake.Count("Microsoft.Compute/virtualMachines")).To(Equal(3))
+	})
+})
+
+// benchmarkScenario replays the scenario once per iteration and reports the throughput of its steps. It fails if a
+// replay exceeds the request budget or a step reconciling machines falls below the throughput budget.
+func benchmarkScenario(b *testing.B, scenario Scenario, budget budget) {
+	throughput := map[string]float64{}
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		simulator, err := New(NewFake(int64(i)))
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.StartTimer()
+
+		results, err := simulator.Run(context.Background(), scenario)
+		if err != nil {
+			b.Fatal(err)
+		}
+		for _, result := range results {
+			throughput[result.Step] += result.Throughput() / float64(b.N)
+		}
+		if requests := requestsPerMachine(simulator.Fake, budget.machines); requests > budget.requestsPerMachine {
+			b.Fatalf("%.1f requests per machine exceed the budget of %.1f", requests, budget.requestsPerMachine)
+		}
+	}
+	for step, value := range throughput {
+		if value > 0 {
+			b.ReportMetric(value, "machines/s-"+strings.ReplaceAll(step, " ", "-"))
+			if value < budget.minThroughput {
+				b.Errorf("throughput of step %q of %.1f machines/s is below the budget of %.1f", step, value, budget.minThroughput)
+			}
+		}
+	}
+}
+
+func BenchmarkScaleUp(b *testing.B) {
+	benchmarkScenario(b, Scenario{ScaleUp(scaleUpBudget.machines)}, scaleUpBudget)
+}
+
+func BenchmarkScaleUpWithFailures(b *testing.B) {
+	benchmarkScenario(b, Scenario{FailVMCreations(0.1), ScaleUp(scaleUpWithFailuresBudget.machines)}, scaleUpWithFailuresBudget)
+}
+
+func BenchmarkDefaultScenario(b *testing.B) {
+	benchmarkScenario(b, DefaultScenario(), defaultScenarioBudget)
+}
@@ -0,0 +1,192 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-04-01/network"
+	"github.com/Azure/go-autorest/autorest/to"
+	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/spi"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/codes"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/status"
+)
+
+// vnetLocationTTL is the duration for which the location of a virtual network is cached. A virtual network cannot be
+// moved, but it can be deleted and recreated with the same name in another region.
+const vnetLocationTTL = 15 * time.Minute
+
+// checkSubnet rejects subnets which cannot host the network interface before it is created. Azure would otherwise
+// only fail the NIC creation with an error which does not name the cause.
+func checkSubnet(subnet network.Subnet, nic networkInterface) error {
+	name := nic.subnetInfo.SubnetName
+	if subnet.SubnetPropertiesFormat == nil {
+		return nil
+	}
+
+	if state := subnet.ProvisioningState; state != "" && state != network.Succeeded {
+		return status.Error(codes.Unavailable, fmt.Sprintf("Subnet %s is in provisioning state %s and cannot host NIC %s yet", name, state, nic.name))
+	}
+
+	if subnet.Delegations != nil && len(*subnet.Delegations) > 0 {
+		var services []string
+		for _, delegation := range *subnet.Delegations {
+			if delegation.ServiceDelegationPropertiesFormat != nil && delegation.ServiceName != nil {
+				services = append(services, *delegation.ServiceName)
+			}
+		}
+		return status.Error(codes.FailedPrecondition, fmt.Sprintf("Subnet %s is delegated to %s and cannot host NIC %s of a VM", name, strings.Join(services, ", "), nic.name))
+	}
+
+	if subnet.NatGateway != nil && nic.publicIP != nil && nic.publicIP.SKU == api.PublicIPSKUBasic {
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("Subnet %s is associated with a NAT gateway, which does not support the basic public IP address of NIC %s", name, nic.name))
+	}
+
+	return nil
+}
+
+// ipv6ConfigurationName returns the name of the IPv6 IP configuration of the network interface
+func ipv6ConfigurationName(nicName string) string {
+	return nicName + "-ipv6"
+}
+
+// hasIPv6AddressPrefix returns true if the subnet has an IPv6 address prefix. Subnets whose prefixes are not returned
+// are assumed to have one, and Azure is left to reject the IP configuration.
+func hasIPv6AddressPrefix(subnet network.Subnet) bool {
+	if subnet.SubnetPropertiesFormat == nil || (subnet.AddressPrefix == nil && subnet.AddressPrefixes == nil) {
+		return true
+	}
+	prefixes := []string{to.String(subnet.AddressPrefix)}
+	if subnet.AddressPrefixes != nil {
+		prefixes = append(prefixes, *subnet.AddressPrefixes...)
+	}
+	for _, prefix := range prefixes {
+		if strings.Contains(prefix, ":") {
+			return true
+		}
+	}
+	return false
+}
+
+// getIPv6Subnet returns the subnet of the IPv6 IP configuration of the network interface, which is the given subnet of
+// the interface unless another subnet of its vnet is configured. It returns nil for IPv4 only interfaces.
+func (d *MachinePlugin) getIPv6Subnet(ctx context.Context, clients spi.AzureDriverClientsInterface, vnetResourceGroup string, nic networkInterface, subnet *network.Subnet) (*network.Subnet, error) {
+	if nic.ipv6 == nil {
+		return nil, nil
+	}
+
+	subnetName := nic.subnetInfo.SubnetName
+	if nic.ipv6.SubnetName != "" && nic.ipv6.SubnetName != subnetName {
+		subnetName = nic.ipv6.SubnetName
+		ipv6Subnet, err := clients.GetSubnetOfSubscription(to.String(nic.subnetInfo.VnetSubscriptionID)).Get(ctx, vnetResourceGroup, nic.subnetInfo.VnetName, subnetName, "")
+		if err != nil {
+			return nil, spi.OnARMAPIErrorFail(prometheusServiceSubnet, err, "Subnet.Get failed for %s due to %s", subnetName, err)
+		}
+		spi.OnARMAPISuccess(prometheusServiceSubnet, "subnet.Get")
+		subnet = &ipv6Subnet
+	}
+
+	if !hasIPv6AddressPrefix(*subnet) {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("Subnet %s has no IPv6 address prefix for the IPv6 IP configuration of NIC %s", subnetName, nic.name))
+	}
+	return subnet, nil
+}
+
+// vnetLocations caches the locations of the virtual networks by their subscription, resource group and name
+type vnetLocations struct {
+	ttl time.Duration
+
+	mutex     sync.Mutex
+	locations map[string]vnetLocation
+}
+
+// vnetLocation is the cached location of a virtual network along with its expiry
+type vnetLocation struct {
+	location string
+	expiry   time.Time
+}
+
+func newVNetLocations(ttl time.Duration) *vnetLocations {
+	return &vnetLocations{
+		ttl:       ttl,
+		locations: map[string]vnetLocation{},
+	}
+}
+
+// get returns the location of the virtual network. The virtual network is read without holding the lock, so that a
+// slow request does not block the location checks of other machines.
+func (v *vnetLocations) get(ctx context.Context, clients spi.AzureDriverClientsInterface, subscriptionID, resourceGroup, vnetName string) (string, error) {
+	key := strings.ToLower(strings.Join([]string{subscriptionID, resourceGroup, vnetName}, "/"))
+
+	v.mutex.Lock()
+	entry, ok := v.locations[key]
+	v.mutex.Unlock()
+	if ok && time.Now().Before(entry.expiry) {
+		return entry.location, nil
+	}
+
+	vnet, err := clients.GetVirtualNetworksOfSubscription(subscriptionID).Get(ctx, resourceGroup, vnetName, "")
+	if err != nil {
+		return "", spi.OnARMAPIErrorFail(prometheusServiceSubnet, err, "VirtualNetworks.Get failed for %s", vnetName)
+	}
+	spi.OnARMAPISuccess(prometheusServiceSubnet, "VirtualNetworks.Get")
+
+	location := to.String(vnet.Location)
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	now := time.Now()
+	for k, entry := range v.locations {
+		if now.After(entry.expiry) {
+			delete(v.locations, k)
+		}
+	}
+	v.locations[key] = vnetLocation{location: location, expiry: now.Add(v.ttl)}
+	return location, nil
+}
+
+// checkVNetLocations rejects network interfaces in virtual networks of another region than the VM before any resource
+// is created. Azure would otherwise only fail the VM creation with a confusing error after its NICs were created.
+// Virtual networks which cannot be read are skipped, as the NIC creation reports missing ones. The virtual networks
+// are read in the given subscription of the request unless the subnet info names another one.
+func (d *MachinePlugin) checkVNetLocations(ctx context.Context, clients spi.AzureDriverClientsInterface, subscriptionID string, providerSpec *api.AzureProviderSpec, networkInterfaces []networkInterface) error {
+	if d.vnetLocations == nil {
+		return nil
+	}
+
+	for _, nic := range networkInterfaces {
+		vnetResourceGroup := providerSpec.ResourceGroup
+		if nic.subnetInfo.VnetResourceGroup != nil {
+			vnetResourceGroup = *nic.subnetInfo.VnetResourceGroup
+		}
+		vnetSubscriptionID := subscriptionID
+		if nic.subnetInfo.VnetSubscriptionID != nil {
+			vnetSubscriptionID = *nic.subnetInfo.VnetSubscriptionID
+		}
+
+		location, err := d.vnetLocations.get(ctx, clients, vnetSubscriptionID, vnetResourceGroup, nic.subnetInfo.VnetName)
+		if err != nil {
+			if !spi.NotFound(err) {
+				spi.WarningS(ctx, "Skipping location check of virtual network", "vnet", nic.subnetInfo.VnetName, "err", err)
+			}
+			continue
+		}
+		if location != "" && normalizeLocation(location) != normalizeLocation(providerSpec.Location) {
+			return status.Error(codes.InvalidArgument, fmt.Sprintf("Virtual network %s of NIC %s is located in %s, but the VM is created in %s", nic.subnetInfo.VnetName, nic.name, location, providerSpec.Location))
+		}
+	}
+	return nil
+}
+
+// normalizeLocation returns the name of the Azure region, which is also accepted in its display form, e.g. West Europe
+func normalizeLocation(location string) string {
+	return strings.ToLower(strings.ReplaceAll(location, " ", ""))
+}
@@ -0,0 +1,176 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-04-01/network"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/mock"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/codes"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/status"
+	gomock "github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Subnet", func() {
+	expectCode := func(err error, code codes.Code) {
+		Expect(err).To(HaveOccurred())
+		s, ok := status.FromError(err)
+		Expect(ok).To(BeTrue())
+		Expect(s.Code()).To(Equal(code))
+	}
+
+	Describe("#checkSubnet", func() {
+		var (
+			nic    networkInterface
+			subnet network.Subnet
+		)
+
+		BeforeEach(func() {
+			nic = networkInterface{name: "machine-0-nic", subnetInfo: api.AzureSubnetInfo{SubnetName: "nodes"}}
+			subnet = network.Subnet{SubnetPropertiesFormat: &network.SubnetPropertiesFormat{ProvisioningState: network.Succeeded}}
+		})
+
+		It("should accept a plain subnet", func() {
+			Expect(checkSubnet(subnet, nic)).To(Succeed())
+		})
+
+		It("should reject a delegated subnet naming the service", func() {
+			subnet.Delegations = &[]network.Delegation{{ServiceDelegationPropertiesFormat: &network.ServiceDelegationPropertiesFormat{ServiceName: to.StringPtr("Microsoft.Web/serverFarms")}}}
+
+			err := checkSubnet(subnet, nic)
+			expectCode(err, codes.FailedPrecondition)
+			Expect(err.Error()).To(ContainSubstring("Microsoft.Web/serverFarms"))
+		})
+
+		It("should reject basic public IPs in subnets with a NAT gateway", func() {
+			subnet.NatGateway = &network.SubResource{ID: to.StringPtr("nat")}
+			nic.publicIP = &api.AzurePublicIP{SKU: api.PublicIPSKUBasic}
+			expectCode(checkSubnet(subnet, nic), codes.InvalidArgument)
+
+			nic.publicIP.SKU = api.PublicIPSKUStandard
+			Expect(checkSubnet(subnet, nic)).To(Succeed())
+		})
+
+		It("should report subnets which are being updated as unavailable", func() {
+			subnet.ProvisioningState = network.Updating
+			expectCode(checkSubnet(subnet, nic), codes.Unavailable)
+		})
+	})
+
+	Describe("#getIPv6Subnet", func() {
+		var (
+			ctx     = context.Background()
+			driver  *MachinePlugin
+			clients *mock.AzureDriverClients
+			nic     networkInterface
+			subnet  *network.Subnet
+
+			dualStack = network.Subnet{ID: to.StringPtr("dual-stack"), SubnetPropertiesFormat: &network.SubnetPropertiesFormat{
+				AddressPrefixes: &[]string{"10.250.0.0/16", "fd00::/64"},
+			}}
+		)
+
+		BeforeEach(func() {
+			sp := mock.NewMockPluginSPIImpl(gomock.NewController(GinkgoT()))
+			_, secret := newProviderSpecCacheFixtures()
+			driverClients, err := sp.Setup(secret, nil)
+			Expect(err).NotTo(HaveOccurred())
+			clients = driverClients.(*mock.AzureDriverClients)
+			driver = NewAzureDriver(sp)
+
+			nic = networkInterface{name: "machine-0-nic", subnetInfo: api.AzureSubnetInfo{VnetName: "vnet", SubnetName: "nodes"}, ipv6: &api.AzureIPv6Configuration{}}
+			subnet = &network.Subnet{ID: to.StringPtr("nodes"), SubnetPropertiesFormat: &network.SubnetPropertiesFormat{AddressPrefix: to.StringPtr("10.250.0.0/16")}}
+		})
+
+		It("should return no subnet for IPv4 only interfaces", func() {
+			nic.ipv6 = nil
+
+			Expect(driver.getIPv6Subnet(ctx, clients, "rg", nic, subnet)).To(BeNil())
+		})
+
+		It("should use the subnet of the interface if it has an IPv6 address prefix", func() {
+			Expect(driver.getIPv6Subnet(ctx, clients, "rg", nic, &dualStack)).To(Equal(&dualStack))
+
+			_, err := driver.getIPv6Subnet(ctx, clients, "rg", nic, subnet)
+			expectCode(err, codes.InvalidArgument)
+		})
+
+		It("should look up the configured subnet in the vnet of the interface", func() {
+			nic.ipv6.SubnetName = "nodes-ipv6"
+			clients.Subnet.EXPECT().Get(ctx, "rg", "vnet", "nodes-ipv6", "").Return(dualStack, nil)
+
+			Expect(driver.getIPv6Subnet(ctx, clients, "rg", nic, subnet)).To(Equal(&dualStack))
+		})
+	})
+
+	Describe("#checkVNetLocations", func() {
+		var (
+			ctx          = context.Background()
+			driver       *MachinePlugin
+			clients      *mock.AzureDriverClients
+			providerSpec *api.AzureProviderSpec
+			nics         []networkInterface
+		)
+
+		BeforeEach(func() {
+			sp := mock.NewMockPluginSPIImpl(gomock.NewController(GinkgoT()))
+			_, secret := newProviderSpecCacheFixtures()
+			driverClients, err := sp.Setup(secret, nil)
+			Expect(err).NotTo(HaveOccurred())
+			clients = driverClients.(*mock.AzureDriverClients)
+			driver = NewAzureDriver(sp)
+			providerSpec = UnmarshalProviderSpec(mock.AzureProviderSpec)
+			nics = getNetworkInterfaces(providerSpec, "machine")
+		})
+
+		It("should accept virtual networks in the region of the VM and cache their location", func() {
+			clients.VirtualNetworks.EXPECT().Get(ctx, providerSpec.ResourceGroup, providerSpec.SubnetInfo.VnetName, "").Return(network.VirtualNetwork{Location: to.StringPtr(providerSpec.Location)}, nil)
+
+			Expect(driver.checkVNetLocations(ctx, clients, "subscription", providerSpec, nics)).To(Succeed())
+			Expect(driver.checkVNetLocations(ctx, clients, "subscription", providerSpec, nics)).To(Succeed())
+		})
+
+		It("should cache the locations per subscription of the request and read them again once expired", func() {
+			driver.vnetLocations = newVNetLocations(time.Nanosecond)
+			clients.VirtualNetworks.EXPECT().Get(ctx, providerSpec.ResourceGroup, providerSpec.SubnetInfo.VnetName, "").Return(network.VirtualNetwork{Location: to.StringPtr(providerSpec.Location)}, nil).Times(2)
+
+			Expect(driver.checkVNetLocations(ctx, clients, "subscription", providerSpec, nics)).To(Succeed())
+			Expect(driver.checkVNetLocations(ctx, clients, "subscription", providerSpec, nics)).To(Succeed())
+
+			driver.vnetLocations = newVNetLocations(time.Hour)
+			gomock.InOrder(
+				clients.VirtualNetworks.EXPECT().Get(ctx, providerSpec.ResourceGroup, providerSpec.SubnetInfo.VnetName, "").Return(network.VirtualNetwork{Location: to.StringPtr(providerSpec.Location)}, nil),
+				clients.VirtualNetworks.EXPECT().Get(ctx, providerSpec.ResourceGroup, providerSpec.SubnetInfo.VnetName, "").Return(network.VirtualNetwork{Location: to.StringPtr("northeurope")}, nil),
+			)
+			Expect(driver.checkVNetLocations(ctx, clients, "subscription", providerSpec, nics)).To(Succeed())
+			Expect(driver.checkVNetLocations(ctx, clients, "other-subscription", providerSpec, nics)).To(HaveOccurred())
+			Expect(driver.vnetLocations.locations).To(HaveLen(2))
+		})
+
+		It("should reject virtual networks in another region", func() {
+			clients.VirtualNetworks.EXPECT().Get(ctx, providerSpec.ResourceGroup, providerSpec.SubnetInfo.VnetName, "").Return(network.VirtualNetwork{Location: to.StringPtr("northeurope")}, nil)
+
+			err := driver.checkVNetLocations(ctx, clients, "subscription", providerSpec, nics)
+			expectCode(err, codes.InvalidArgument)
+			Expect(err.Error()).To(ContainSubstring("northeurope"))
+		})
+
+		It("should skip virtual networks which cannot be read", func() {
+			clients.VirtualNetworks.EXPECT().Get(ctx, providerSpec.ResourceGroup, providerSpec.SubnetInfo.VnetName, "").Return(network.VirtualNetwork{}, autorest.DetailedError{Response: &http.Response{StatusCode: http.StatusNotFound}})
+
+			Expect(driver.checkVNetLocations(ctx, clients, "subscription", providerSpec, nics)).To(Succeed())
+		})
+	})
+})
@@ -0,0 +1,383 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-08-01/compute"
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-04-01/network"
+	"github.com/Azure/go-autorest/autorest/to"
+	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/spi"
+	"github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/codes"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/status"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/util/flowcontrol"
+)
+
+// ReconcileTagsRequest is the request to reconcile the tags of the existing machines of a machine class
+type ReconcileTagsRequest struct {
+	// MachineClass whose tags are reconciled
+	MachineClass *v1alpha1.MachineClass
+	// Secret backing the machineClass object
+	Secret *corev1.Secret
+	// Continue is the continuation token of a previous request, after which the reconciliation resumes. The
+	// reconciliation starts from the beginning if empty.
+	Continue string
+	// BatchSize is the maximum number of machines reconciled by the request. All machines are reconciled if zero.
+	BatchSize int
+	// RequestsPerSecond limits the rate of the Azure API requests of the reconciliation. It is unlimited if zero.
+	RequestsPerSecond float32
+}
+
+// ReconcileTagsResponse is the response of the reconciliation of the tags of a batch of machines
+type ReconcileTagsResponse struct {
+	// Updated are the names of the VMs of which at least one resource was updated
+	Updated []string
+	// Continue is the continuation token passed to the next request to reconcile the next batch. It is empty once
+	// all machines are reconciled. If the request fails, it continues after the last VM reconciled before the failure.
+	Continue string
+}
+
+const (
+	// tagListingTTL is the duration for which the VMs listed by a tag reconciliation are kept for its next batches
+	tagListingTTL = time.Hour
+	// tagContinueSeparator separates the listing and the name of the last reconciled VM in the continuation token.
+	// VM names cannot contain it.
+	tagContinueSeparator = ":"
+)
+
+// tagListings keeps the names of the VMs listed by the tag reconciliations, so that the batches of a reconciliation
+// page through a single listing instead of listing all VMs of the machine class for every batch
+type tagListings struct {
+	ttl time.Duration
+
+	mutex    sync.Mutex
+	listings map[string]tagListing
+}
+
+type tagListing struct {
+	vmNames   []string
+	expiresAt time.Time
+}
+
+func newTagListings(ttl time.Duration) *tagListings {
+	return &tagListings{
+		ttl:      ttl,
+		listings: map[string]tagListing{},
+	}
+}
+
+// get returns the sorted names of the VMs of the listing, or false if it is unknown or expired
+func (l *tagListings) get(id string) ([]string, bool) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	listing, ok := l.listings[id]
+	if !ok || time.Now().After(listing.expiresAt) {
+		return nil, false
+	}
+	return listing.vmNames, true
+}
+
+// add keeps the sorted names of the listed VMs and returns the ID of the listing
+func (l *tagListings) add(vmNames []string) string {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	now := time.Now()
+	for id, listing := range l.listings {
+		if now.After(listing.expiresAt) {
+			delete(l.listings, id)
+		}
+	}
+	id := newListingID()
+	l.listings[id] = tagListing{vmNames: vmNames, expiresAt: now.Add(l.ttl)}
+	return id
+}
+
+func newListingID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("%d", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b)
+}
+
+// parseTagContinue returns the listing and the name of the last reconciled VM of the continuation token. Tokens
+// without listing, e.g. of a previous version, are the name of the last reconciled VM.
+func parseTagContinue(token string) (string, string) {
+	if i := strings.Index(token, tagContinueSeparator); i >= 0 {
+		return token[:i], token[i+len(tagContinueSeparator):]
+	}
+	return "", token
+}
+
+// ReconcileTags reconciles the tags of the VMs, NICs and disks of the existing machines of a machine class with the
+// tags of its provider spec, e.g. after the tag policy changed for a fleet created long ago. The machines are
+// reconciled in the order of their names in rate-limited batches, so that a large fleet can be reconciled in a
+// resumable job. The batches page through the VMs listed by the first request, which are listed again if the
+// listing expired. Tags of the provider spec are added or updated, and tags dropped from it are removed. Other tags
+// of the resources are kept, as are the tags dropped before their keys were recorded in the managed tags.
+func (d *MachinePlugin) ReconcileTags(ctx context.Context, req *ReconcileTagsRequest) (*ReconcileTagsResponse, error) {
+	ctx = withMachineLogFields(ctx, "ReconcileTags", "", req.MachineClass)
+	spi.V(2).InfoS(ctx, "Tag reconciliation request has been recieved")
+	defer spi.V(2).InfoS(ctx, "Tag reconciliation request has been processed")
+	ctx = spi.WithMachineClass(ctx, req.MachineClass.Name)
+
+	providerSpec, err := d.decodeProviderSpecAndSecret(req.MachineClass, req.Secret)
+	if err != nil {
+		return nil, err
+	}
+	if err := d.validateReservedTags(providerSpec); err != nil {
+		return nil, err
+	}
+	d.AzureProviderSpec = providerSpec
+	d.Secret = req.Secret
+
+	clients, err := d.SPI.Setup(req.Secret, providerSpec.CloudConfiguration)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, err.Error())
+	}
+
+	limiter := flowcontrol.NewFakeAlwaysRateLimiter()
+	if req.RequestsPerSecond > 0 {
+		limiter = flowcontrol.NewTokenBucketRateLimiter(req.RequestsPerSecond, 1)
+	}
+	defer limiter.Stop()
+
+	listingID, continueAfter := parseTagContinue(req.Continue)
+	vmNames, ok := d.tagListings.get(listingID)
+	if !ok {
+		if err := limiter.Wait(ctx); err != nil {
+			return nil, status.Error(codes.DeadlineExceeded, err.Error())
+		}
+		vms, err := d.listClassVMs(ctx, clients, providerSpec.ResourceGroup, providerSpec.Tags)
+		if err != nil {
+			return nil, machineError(err)
+		}
+		vmNames = make([]string, 0, len(vms))
+		for _, vm := range vms {
+			vmNames = append(vmNames, *vm.Name)
+		}
+		sort.Strings(vmNames)
+		listingID = d.tagListings.add(vmNames)
+	}
+	batch, next := nextTagBatch(vmNames, continueAfter, req.BatchSize)
+
+	desired := d.desiredTags(providerSpec)
+	response := &ReconcileTagsResponse{Continue: req.Continue}
+	for _, vmName := range batch {
+		updated, err := d.reconcileMachineTagsOf(ctx, clients, limiter, providerSpec, vmName, desired)
+		if err != nil {
+			// The response continues with the failed machine, so that the reconciliation can be resumed
+			return response, machineError(fmt.Errorf("Tags of machine %q could not be reconciled: %v", vmName, err))
+		}
+		if updated {
+			response.Updated = append(response.Updated, vmName)
+		}
+		response.Continue = listingID + tagContinueSeparator + vmName
+	}
+	response.Continue = ""
+	if next != "" {
+		response.Continue = listingID + tagContinueSeparator + next
+	}
+	spi.InfoS(ctx, "Tags of machines of machine class were updated", "updated", len(response.Updated), "reconciled", len(batch))
+	return response, nil
+}
+
+// desiredTags returns the tags of the machine resources of the provider spec, with shortened values and the owner tag
+func (d *MachinePlugin) desiredTags(providerSpec *api.AzureProviderSpec) map[string]*string {
+	tagSpec, _ := shortenTagValues(providerSpec.Tags, d.tagValuePolicy)
+	desired := getAzureTags(tagSpec)
+	if d.ownerID != "" {
+		desired[ownerTagKey] = to.StringPtr(d.ownerID)
+	}
+	return desired
+}
+
+// listClassVMs returns the VMs carrying the cluster and role tags of the machine class and owned by this instance
+func (d *MachinePlugin) listClassVMs(ctx context.Context, clients spi.AzureDriverClientsInterface, resourceGroupName string, classTags map[string]string) ([]compute.VirtualMachine, error) {
+	var vms []compute.VirtualMachine
+	iterator, err := clients.GetVM().ListComplete(ctx, resourceGroupName, "")
+	for err == nil && iterator.NotDone() {
+		if item := iterator.Value(); matchesClassTags(item.Tags, classTags) && !d.ownedByOtherInstance(item.Tags) {
+			vms = append(vms, item)
+		}
+		err = iterator.NextWithContext(ctx)
+	}
+	if err != nil {
+		return nil, spi.OnARMAPIErrorFail(prometheusServiceVM, err, "VM.List")
+	}
+	spi.OnARMAPISuccess(prometheusServiceVM, "VM.List")
+	return vms, nil
+}
+
+// nextTagBatch returns the names of the VMs of the batch after the VM with the given name from the sorted names, and
+// the name of the last VM of the batch if there are VMs left
+func nextTagBatch(vmNames []string, continueAfter string, size int) ([]string, string) {
+	start := sort.Search(len(vmNames), func(i int) bool { return vmNames[i] > continueAfter })
+	vmNames = vmNames[start:]
+	if size <= 0 || len(vmNames) <= size {
+		return vmNames, ""
+	}
+	return vmNames[:size], vmNames[size-1]
+}
+
+// reconcileMachineTagsOf reads the VM of a listed machine and reconciles the tags of its resources. The VM is read
+// again, as its tags may have changed since the listing. Machines which are gone or were handed over to another
+// instance are skipped.
+func (d *MachinePlugin) reconcileMachineTagsOf(ctx context.Context, clients spi.AzureDriverClientsInterface, limiter flowcontrol.RateLimiter, providerSpec *api.AzureProviderSpec, vmName string, desired map[string]*string) (bool, error) {
+	if err := limiter.Wait(ctx); err != nil {
+		return false, err
+	}
+	vm, err := clients.GetVM().Get(ctx, providerSpec.ResourceGroup, strings.ToLower(vmName), "")
+	if err != nil {
+		if spi.NotFound(err) {
+			return false, nil
+		}
+		return false, spi.OnARMAPIErrorFail(prometheusServiceVM, err, "VM.Get failed for %s", vmName)
+	}
+	spi.OnARMAPISuccess(prometheusServiceVM, "VM.Get")
+	if d.ownedByOtherInstance(vm.Tags) {
+		return false, nil
+	}
+	return d.reconcileMachineTags(ctx, clients, limiter, providerSpec, vm, desired)
+}
+
+// reconcileMachineTags adds the desired tags to the VM, NICs and disks of a machine, and returns true if any of them
+// was updated
+func (d *MachinePlugin) reconcileMachineTags(ctx context.Context, clients spi.AzureDriverClientsInterface, limiter flowcontrol.RateLimiter, providerSpec *api.AzureProviderSpec, vm compute.VirtualMachine, desired map[string]*string) (bool, error) {
+	var (
+		resourceGroupName = providerSpec.ResourceGroup
+		vmName            = strings.ToLower(*vm.Name)
+		updated           bool
+	)
+
+	if tags, changed := mergeTags(vm.Tags, desired); changed {
+		if err := limiter.Wait(ctx); err != nil {
+			return updated, err
+		}
+		future, err := clients.GetVM().Update(ctx, resourceGroupName, vmName, compute.VirtualMachineUpdate{Tags: tags})
+		if err != nil {
+			return updated, spi.OnARMAPIErrorFail(prometheusServiceVM, err, "VM.Update failed for %s", vmName)
+		}
+		if err := future.WaitForCompletionRef(ctx, clients.GetClient()); err != nil {
+			return updated, spi.OnARMAPIErrorFail(prometheusServiceVM, err, "VM.WaitForCompletionRef failed for %s", vmName)
+		}
+		spi.OnARMAPISuccess(prometheusServiceVM, "VM.Update")
+		updated = true
+	}
+
+	for _, nic := range getNetworkInterfaces(providerSpec, vmName) {
+		if err := limiter.Wait(ctx); err != nil {
+			return updated, err
+		}
+		nicResourceGroup := nic.resourceGroupName(resourceGroupName)
+		NIC, err := clients.GetNic().Get(ctx, nicResourceGroup, nic.name, "")
+		if err != nil {
+			if spi.NotFound(err) {
+				continue
+			}
+			return updated, spi.OnARMAPIErrorFail(prometheusServiceNIC, err, "NIC.Get failed for %s", nic.name)
+		}
+		tags, changed := mergeTags(NIC.Tags, desired)
+		if !changed {
+			continue
+		}
+		if err := limiter.Wait(ctx); err != nil {
+			return updated, err
+		}
+		if _, err := clients.GetNic().UpdateTags(ctx, nicResourceGroup, nic.name, network.TagsObject{Tags: tags}); err != nil {
+			return updated, spi.OnARMAPIErrorFail(prometheusServiceNIC, err, "NIC.UpdateTags failed for %s", nic.name)
+		}
+		spi.OnARMAPISuccess(prometheusServiceNIC, "NIC.UpdateTags")
+		updated = true
+	}
+
+	// The disks are marked as created by the provider, as done when they are tagged after the VM creation
+	diskTags := make(map[string]*string, len(desired)+1)
+	for key, value := range desired {
+		diskTags[key] = value
+	}
+	diskTags[spi.DiskManagedByTagKey] = to.StringPtr(spi.DiskManagedByTagValue)
+
+	naming := namingStrategyOf(providerSpec)
+	diskNames := []string{naming.OSDiskName(vmName)}
+	if storageProfile := providerSpec.Properties.StorageProfile; len(storageProfile.DataDisks) > 0 {
+		diskNames = append(diskNames, getAzureDataDiskNames(naming, storageProfile.DataDisks, storageProfile.DataDiskLunOffset, vmName)...)
+	}
+	for _, diskName := range diskNames {
+		if err := limiter.Wait(ctx); err != nil {
+			return updated, err
+		}
+		disk, err := clients.GetDisk().Get(ctx, resourceGroupName, diskName)
+		if err != nil {
+			if spi.NotFound(err) {
+				continue
+			}
+			return updated, spi.OnARMAPIErrorFail(prometheusServiceDisk, err, "Disk.Get failed for %s", diskName)
+		}
+		tags, changed := mergeTags(disk.Tags, diskTags)
+		if !changed {
+			continue
+		}
+		if err := limiter.Wait(ctx); err != nil {
+			return updated, err
+		}
+		if err := spi.UpdateDiskTags(ctx, clients, resourceGroupName, diskName, tags); err != nil {
+			return updated, err
+		}
+		updated = true
+	}
+
+	return updated, nil
+}
+
+// mergeTags returns the tags with the desired tags added or updated, and true if any tag changed. Tags recorded in
+// the managed tags which are not desired anymore are removed, unless they are managed by the provider itself. The
+// given tags are not modified.
+func mergeTags(tags, desired map[string]*string) (map[string]*string, bool) {
+	var (
+		result  = make(map[string]*string, len(tags)+len(desired))
+		changed bool
+	)
+	for key, value := range tags {
+		result[key] = value
+	}
+	for _, key := range managedTagKeys(tags) {
+		if _, ok := desired[key]; ok || isProtectedTag(key) {
+			continue
+		}
+		if _, ok := result[key]; ok {
+			delete(result, key)
+			changed = true
+		}
+	}
+	for key := range tags {
+		if _, ok := desired[key]; !ok && isManagedTagsKey(key) {
+			delete(result, key)
+			changed = true
+		}
+	}
+	for key, value := range desired {
+		if current, ok := result[key]; !ok || to.String(current) != to.String(value) {
+			changed = true
+		}
+		result[key] = value
+	}
+	return result, changed
+}
@@ -0,0 +1,180 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-08-01/compute"
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-04-01/network"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/mock"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/spi"
+	gomock "github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TagReconciler", func() {
+	Describe("#mergeTags", func() {
+		It("should add and update the desired tags and keep the others", func() {
+			tags := map[string]*string{"foreign": to.StringPtr("kept"), "pool": to.StringPtr("old")}
+
+			merged, changed := mergeTags(tags, map[string]*string{"pool": to.StringPtr("new"), "cost-center": to.StringPtr("42")})
+			Expect(changed).To(BeTrue())
+			Expect(merged).To(Equal(map[string]*string{"foreign": to.StringPtr("kept"), "pool": to.StringPtr("new"), "cost-center": to.StringPtr("42")}))
+			Expect(*tags["pool"]).To(Equal("old"))
+		})
+
+		It("should not report up-to-date tags as changed", func() {
+			_, changed := mergeTags(map[string]*string{"foreign": to.StringPtr("kept"), "pool": to.StringPtr("new")}, map[string]*string{"pool": to.StringPtr("new")})
+			Expect(changed).To(BeFalse())
+		})
+
+		It("should remove the managed tags which are not desired anymore", func() {
+			tags := getAzureTags(map[string]string{"pool": "worker", "cost-center": "42"})
+			tags["foreign"] = to.StringPtr("kept")
+			desired := getAzureTags(map[string]string{"pool": "worker"})
+
+			merged, changed := mergeTags(tags, desired)
+			Expect(changed).To(BeTrue())
+			Expect(merged).NotTo(HaveKey("cost-center"))
+			Expect(merged).To(HaveKeyWithValue("foreign", to.StringPtr("kept")))
+			Expect(merged).To(HaveKeyWithValue("pool", to.StringPtr("worker")))
+			Expect(managedTagKeys(merged)).To(Equal([]string{"pool"}))
+
+			_, changed = mergeTags(merged, desired)
+			Expect(changed).To(BeFalse())
+		})
+
+		It("should keep the tags managed by the provider itself", func() {
+			tags := map[string]*string{spi.DiskManagedByTagKey: to.StringPtr(spi.DiskManagedByTagValue)}
+			for key, value := range managedTags([]string{spi.DiskManagedByTagKey, "pool"}) {
+				tags[key] = value
+			}
+
+			merged, _ := mergeTags(tags, managedTags(nil))
+			Expect(merged).To(HaveKeyWithValue(spi.DiskManagedByTagKey, to.StringPtr(spi.DiskManagedByTagValue)))
+		})
+
+		It("should remove the stale chunks of the managed tags", func() {
+			tags := map[string]*string{managedTagsKey(0): to.StringPtr("pool"), managedTagsKey(1): to.StringPtr("cost-center")}
+
+			merged, changed := mergeTags(tags, map[string]*string{managedTagsKey(0): to.StringPtr("pool")})
+			Expect(changed).To(BeTrue())
+			Expect(merged).To(Equal(map[string]*string{managedTagsKey(0): to.StringPtr("pool")}))
+		})
+	})
+
+	Describe("#nextTagBatch", func() {
+		It("should return the batches in the order of the names", func() {
+			batch, next := nextTagBatch([]string{"a", "b", "c"}, "", 2)
+			Expect(batch).To(Equal([]string{"a", "b"}))
+			Expect(next).To(Equal("b"))
+
+			batch, next = nextTagBatch([]string{"a", "b", "c"}, next, 2)
+			Expect(batch).To(Equal([]string{"c"}))
+			Expect(next).To(BeEmpty())
+		})
+
+		It("should resume after a machine which is gone", func() {
+			batch, next := nextTagBatch([]string{"a", "c", "d"}, "b", 0)
+			Expect(batch).To(Equal([]string{"c", "d"}))
+			Expect(next).To(BeEmpty())
+		})
+	})
+
+	Describe("#parseTagContinue", func() {
+		It("should return the listing and the last reconciled machine", func() {
+			listingID, continueAfter := parseTagContinue("0123:machine-0")
+			Expect(listingID).To(Equal("0123"))
+			Expect(continueAfter).To(Equal("machine-0"))
+		})
+
+		It("should treat a token without listing as the last reconciled machine", func() {
+			listingID, continueAfter := parseTagContinue("machine-0")
+			Expect(listingID).To(BeEmpty())
+			Expect(continueAfter).To(Equal("machine-0"))
+		})
+	})
+
+	Describe("#ReconcileTags", func() {
+		var (
+			ctx           = context.Background()
+			resourceGroup = "shoot--i538135--seed-az"
+			notFound      = autorest.DetailedError{Response: &http.Response{StatusCode: http.StatusNotFound}}
+		)
+
+		It("should reconcile the machines in resumable batches", func() {
+			sp := mock.NewMockPluginSPIImpl(gomock.NewController(GinkgoT()))
+			machineClass, secret := newProviderSpecCacheFixtures()
+			driverClients, err := sp.Setup(secret, nil)
+			Expect(err).NotTo(HaveOccurred())
+			clients := driverClients.(*mock.AzureDriverClients)
+
+			providerSpec := UnmarshalProviderSpec(machineClass.ProviderSpec.Raw)
+			var (
+				classTags = getAzureTags(providerSpec.Tags)
+				diskTags  = getAzureTags(providerSpec.Tags)
+			)
+			diskTags[spi.DiskManagedByTagKey] = to.StringPtr(spi.DiskManagedByTagValue)
+
+			clients.VM.EXPECT().ListComplete(gomock.Any(), resourceGroup, "").Return(newVMListIterator(ctx, []compute.VirtualMachine{
+				{Name: to.StringPtr("machine-1"), Tags: classTags},
+				{Name: to.StringPtr("machine-0"), Tags: classTags},
+			}), nil).Times(1)
+			clients.VM.EXPECT().Get(gomock.Any(), resourceGroup, "machine-0", compute.InstanceViewTypes("")).Return(compute.VirtualMachine{Name: to.StringPtr("machine-0"), Tags: classTags}, nil)
+			clients.NIC.EXPECT().Get(gomock.Any(), resourceGroup, "machine-0-nic", "").Return(network.Interface{Tags: map[string]*string{"foreign": to.StringPtr("kept")}}, nil)
+			clients.NIC.EXPECT().UpdateTags(gomock.Any(), resourceGroup, "machine-0-nic", gomock.Any()).DoAndReturn(func(_ context.Context, _, _ string, parameters network.TagsObject) (network.Interface, error) {
+				Expect(parameters.Tags).To(HaveKeyWithValue("foreign", to.StringPtr("kept")))
+				Expect(parameters.Tags).To(HaveKeyWithValue("worker.gardener.cloud_pool", to.StringPtr("worker-m0exd")))
+				return network.Interface{}, nil
+			})
+			clients.Disk.EXPECT().Get(gomock.Any(), resourceGroup, "machine-0-os-disk").Return(compute.Disk{Tags: diskTags}, nil)
+
+			driver := NewAzureDriver(sp)
+			response, err := driver.ReconcileTags(ctx, &ReconcileTagsRequest{MachineClass: machineClass, Secret: secret, BatchSize: 1})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.Updated).To(Equal([]string{"machine-0"}))
+			Expect(response.Continue).To(HaveSuffix(":machine-0"))
+
+			clients.VM.EXPECT().Get(gomock.Any(), resourceGroup, "machine-1", compute.InstanceViewTypes("")).Return(compute.VirtualMachine{Name: to.StringPtr("machine-1"), Tags: classTags}, nil)
+			clients.NIC.EXPECT().Get(gomock.Any(), resourceGroup, "machine-1-nic", "").Return(network.Interface{}, notFound)
+			clients.Disk.EXPECT().Get(gomock.Any(), resourceGroup, "machine-1-os-disk").Return(compute.Disk{}, notFound)
+
+			response, err = driver.ReconcileTags(ctx, &ReconcileTagsRequest{MachineClass: machineClass, Secret: secret, BatchSize: 1, Continue: response.Continue})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.Updated).To(BeEmpty())
+			Expect(response.Continue).To(BeEmpty())
+		})
+
+		It("should list the machines again if the listing of the continuation token is unknown", func() {
+			sp := mock.NewMockPluginSPIImpl(gomock.NewController(GinkgoT()))
+			machineClass, secret := newProviderSpecCacheFixtures()
+			driverClients, err := sp.Setup(secret, nil)
+			Expect(err).NotTo(HaveOccurred())
+			clients := driverClients.(*mock.AzureDriverClients)
+
+			providerSpec := UnmarshalProviderSpec(machineClass.ProviderSpec.Raw)
+			classTags := getAzureTags(providerSpec.Tags)
+
+			clients.VM.EXPECT().ListComplete(gomock.Any(), resourceGroup, "").Return(newVMListIterator(ctx, []compute.VirtualMachine{
+				{Name: to.StringPtr("machine-1"), Tags: classTags},
+				{Name: to.StringPtr("machine-0"), Tags: classTags},
+			}), nil)
+			clients.VM.EXPECT().Get(gomock.Any(), resourceGroup, "machine-1", compute.InstanceViewTypes("")).Return(compute.VirtualMachine{}, notFound)
+
+			driver := NewAzureDriver(sp)
+			response, err := driver.ReconcileTags(ctx, &ReconcileTagsRequest{MachineClass: machineClass, Secret: secret, Continue: "expired:machine-0"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.Updated).To(BeEmpty())
+			Expect(response.Continue).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,144 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/spi"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/driver"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/util/flowcontrol"
+)
+
+// tagSyncs tracks the tags last synchronized to the resources of each machine, so that the tags of a machine are only
+// patched on GetMachineStatus once the tags of its machine class changed or the sync interval passed
+type tagSyncs struct {
+	interval time.Duration
+
+	mutex  sync.Mutex
+	synced map[string]tagSync
+}
+
+// tagSync is the fingerprint of the tags synchronized to a machine along with the time of the synchronization
+type tagSync struct {
+	fingerprint string
+	time        time.Time
+}
+
+func newTagSyncs(interval time.Duration) *tagSyncs {
+	return &tagSyncs{
+		interval: interval,
+		synced:   map[string]tagSync{},
+	}
+}
+
+// due returns true if the tags with the given fingerprint have to be synchronized to the machine
+func (t *tagSyncs) due(machineName, fingerprint string, now time.Time) bool {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	last, ok := t.synced[machineName]
+	return !ok || last.fingerprint != fingerprint || now.Sub(last.time) >= t.interval
+}
+
+// done records the synchronization of the tags with the given fingerprint to the machine
+func (t *tagSyncs) done(machineName, fingerprint string, now time.Time) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.synced[machineName] = tagSync{fingerprint: fingerprint, time: now}
+}
+
+// forget drops the synchronization of a deleted machine
+func (t *tagSyncs) forget(machineName string) {
+	if t == nil {
+		return
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	delete(t.synced, machineName)
+}
+
+// tagsFingerprint returns a fingerprint of the tags which is independent of their order
+func tagsFingerprint(tags map[string]*string) string {
+	pairs := make([]string, 0, len(tags))
+	for key, value := range tags {
+		pairs = append(pairs, key+"="+to.String(value))
+	}
+	sort.Strings(pairs)
+	sum := sha256.Sum256([]byte(strings.Join(pairs, "\n")))
+	return hex.EncodeToString(sum[:])
+}
+
+// syncMachineTags adds the tags of the machine class to the VM, NICs and disks of the machine if they changed since
+// the last synchronization or the sync interval passed, so that tag rollouts do not require rolling the machines.
+// Failures are only logged, as the status of the machine is reported anyway, and the synchronization is retried with
+// the next status request. VMs of paused machines and VMs owned by other instances are not touched.
+func (d *MachinePlugin) syncMachineTags(ctx context.Context, req *driver.GetMachineStatusRequest, now time.Time) {
+	if d.tagSyncs == nil || isPaused(req.Machine) {
+		return
+	}
+	providerSpec, err := d.decodeProviderSpecAndSecret(req.MachineClass, req.Secret)
+	if err != nil {
+		return
+	}
+	if err := d.validateReservedTags(providerSpec); err != nil {
+		return
+	}
+
+	var (
+		desired     = d.desiredTags(providerSpec)
+		fingerprint = tagsFingerprint(desired)
+	)
+	if !d.tagSyncs.due(req.Machine.Name, fingerprint, now) {
+		return
+	}
+
+	clients, err := d.SPI.Setup(req.Secret, providerSpec.CloudConfiguration)
+	if err != nil {
+		spi.WarningS(ctx, "Tags of machine could not be synchronized", "err", err)
+		return
+	}
+
+	var (
+		resourceGroupName = providerSpec.ResourceGroup
+		vmName            = strings.ToLower(req.Machine.Name)
+	)
+	vm, err := clients.GetVM().Get(ctx, resourceGroupName, vmName, "")
+	if err != nil {
+		spi.WarningS(ctx, "Tags of machine could not be synchronized", "vm", vmName, "err", spi.OnARMAPIErrorFail(prometheusServiceVM, err, "VM.Get failed for %s", vmName))
+		return
+	}
+	spi.OnARMAPISuccess(prometheusServiceVM, "VM.Get")
+	if d.ownedByOtherInstance(vm.Tags) {
+		return
+	}
+
+	updated, err := d.reconcileMachineTags(ctx, clients, flowcontrol.NewFakeAlwaysRateLimiter(), providerSpec, vm, desired)
+	if err != nil {
+		spi.WarningS(ctx, "Tags of machine could not be synchronized", "vm", vmName, "err", err)
+		return
+	}
+	d.tagSyncs.done(req.Machine.Name, fingerprint, now)
+	if !updated {
+		return
+	}
+	spi.InfoS(ctx, "Tags of machine resources were updated to the tags of the machine class", "vm", vmName)
+	if d.Recorder != nil {
+		d.Recorder.Event(req.Machine, corev1.EventTypeNormal, "TagsUpdated", fmt.Sprintf("Tags of the resources of VM %s were updated to the tags of machine class %s", vmName, req.MachineClass.Name))
+	}
+}
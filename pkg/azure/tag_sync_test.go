@@ -0,0 +1,87 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"context"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-08-01/compute"
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-04-01/network"
+	"github.com/Azure/go-autorest/autorest/to"
+	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/mock"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/spi"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/driver"
+	gomock "github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/client-go/tools/record"
+)
+
+var _ = Describe("TagSync", func() {
+	var (
+		ctx = context.Background()
+		now = time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+		plugin   *MachinePlugin
+		clients  *mock.AzureDriverClients
+		recorder *record.FakeRecorder
+		req      *driver.GetMachineStatusRequest
+		rg       = "shoot--i538135--seed-az"
+	)
+
+	BeforeEach(func() {
+		sp := mock.NewMockPluginSPIImpl(gomock.NewController(GinkgoT()))
+		machineClass, secret := newProviderSpecCacheFixtures()
+		driverClients, err := sp.Setup(secret, nil)
+		Expect(err).NotTo(HaveOccurred())
+		clients = driverClients.(*mock.AzureDriverClients)
+
+		recorder = record.NewFakeRecorder(1)
+		plugin = NewAzureDriver(sp)
+		plugin.Recorder = recorder
+		plugin.tagSyncs = newTagSyncs(time.Hour)
+		req = &driver.GetMachineStatusRequest{Machine: newMachine("machine"), MachineClass: machineClass, Secret: secret}
+	})
+
+	It("should add the tags of the machine class once until they change or the interval passed", func() {
+		providerSpec := UnmarshalProviderSpec(req.MachineClass.ProviderSpec.Raw)
+		diskTags := getAzureTags(providerSpec.Tags)
+		diskTags[spi.DiskManagedByTagKey] = to.StringPtr(spi.DiskManagedByTagValue)
+
+		clients.VM.EXPECT().Get(gomock.Any(), rg, "machine", compute.InstanceViewTypes("")).Return(compute.VirtualMachine{Name: to.StringPtr("machine"), Tags: getAzureTags(providerSpec.Tags)}, nil).Times(2)
+		clients.NIC.EXPECT().Get(gomock.Any(), rg, "machine-nic", "").Return(network.Interface{Tags: map[string]*string{"foreign": to.StringPtr("kept")}}, nil)
+		clients.NIC.EXPECT().UpdateTags(gomock.Any(), rg, "machine-nic", gomock.Any()).DoAndReturn(func(_ context.Context, _, _ string, parameters network.TagsObject) (network.Interface, error) {
+			Expect(parameters.Tags).To(HaveKeyWithValue("foreign", to.StringPtr("kept")))
+			Expect(parameters.Tags).To(HaveKeyWithValue("worker.gardener.cloud_pool", to.StringPtr("worker-m0exd")))
+			return network.Interface{}, nil
+		})
+		clients.Disk.EXPECT().Get(gomock.Any(), rg, "machine-os-disk").Return(compute.Disk{Tags: diskTags}, nil).Times(2)
+
+		plugin.syncMachineTags(ctx, req, now)
+		Expect(recorder.Events).To(Receive(ContainSubstring("TagsUpdated")))
+		// The provider spec of concurrent requests is not replaced by the one of the status request
+		Expect(plugin.AzureProviderSpec).To(BeNil())
+
+		plugin.syncMachineTags(ctx, req, now.Add(time.Minute))
+
+		clients.NIC.EXPECT().Get(gomock.Any(), rg, "machine-nic", "").Return(network.Interface{Tags: getAzureTags(providerSpec.Tags)}, nil)
+		plugin.syncMachineTags(ctx, req, now.Add(time.Hour))
+		Expect(recorder.Events).NotTo(Receive())
+	})
+
+	It("should not synchronize the tags of paused machines", func() {
+		req.Machine.Annotations = map[string]string{api.MachineAnnotationPaused: "true"}
+		plugin.syncMachineTags(ctx, req, now)
+	})
+
+	It("should fingerprint the tags independent of their order", func() {
+		Expect(tagsFingerprint(map[string]*string{"a": to.StringPtr("1"), "b": to.StringPtr("2")})).To(Equal(tagsFingerprint(map[string]*string{"b": to.StringPtr("2"), "a": to.StringPtr("1")})))
+		Expect(tagsFingerprint(map[string]*string{"a": to.StringPtr("1")})).NotTo(Equal(tagsFingerprint(map[string]*string{"a": to.StringPtr("2")})))
+	})
+})
@@ -0,0 +1,186 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Azure/go-autorest/autorest/to"
+	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis/validation"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/spi"
+	"github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/codes"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/status"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// tagValueMaxLength is the maximum number of characters of a tag value accepted by Azure
+	tagValueMaxLength = 256
+	// tagValueHashLength is the number of hex characters of the hash appended to hashed tag values
+	tagValueHashLength = 16
+
+	// TagValuePolicyFail passes over-long tag values to Azure, which fails the creation
+	TagValuePolicyFail = "fail"
+	// TagValuePolicyTruncate truncates over-long tag values to the maximum length
+	TagValuePolicyTruncate = "truncate"
+	// TagValuePolicyHash truncates over-long tag values and appends a hash of the full value, so that distinct
+	// values stay distinct
+	TagValuePolicyHash = "hash"
+
+	// managedTagsTagKey is the tag of the machine resources listing the comma-separated keys of the tags of their
+	// machine class, so that tags dropped from the machine class are removed from the resources. Lists exceeding the
+	// maximum length of tag values continue in the tags with the suffixes -1, -2 and so on.
+	managedTagsTagKey = "machine-controller-manager-managed-tags"
+)
+
+// protectedTagKeys are the tags managed by the provider itself, which are never removed as tags dropped from the
+// machine class
+var protectedTagKeys = []string{ownerTagKey, userDataTagKey, sharedDiskTagKey, retainedTagKey, zoneTagKey, spi.DiskManagedByTagKey}
+
+// managedTags returns the tags listing the given tag keys in their sorted order. Keys containing a comma or exceeding
+// the maximum length of tag values cannot be listed and are not tracked.
+func managedTags(keys []string) map[string]*string {
+	var (
+		tags   = map[string]*string{}
+		chunks []string
+		chunk  []string
+		length int
+	)
+	sorted := append([]string(nil), keys...)
+	sort.Strings(sorted)
+	for _, key := range sorted {
+		if strings.Contains(key, ",") || len(key) > tagValueMaxLength {
+			continue
+		}
+		if len(chunk) > 0 && length+1+len(key) > tagValueMaxLength {
+			chunks, chunk, length = append(chunks, strings.Join(chunk, ",")), nil, 0
+		}
+		if len(chunk) > 0 {
+			length++
+		}
+		chunk, length = append(chunk, key), length+len(key)
+	}
+	if len(chunk) > 0 {
+		chunks = append(chunks, strings.Join(chunk, ","))
+	}
+	for i, value := range chunks {
+		tags[managedTagsKey(i)] = to.StringPtr(value)
+	}
+	return tags
+}
+
+// managedTagsKey returns the key of the i-th tag listing the managed tag keys
+func managedTagsKey(i int) string {
+	if i == 0 {
+		return managedTagsTagKey
+	}
+	return fmt.Sprintf("%s-%d", managedTagsTagKey, i)
+}
+
+// isManagedTagsKey returns true if the key is one of the tags listing the managed tag keys
+func isManagedTagsKey(key string) bool {
+	if key == managedTagsTagKey {
+		return true
+	}
+	suffix := strings.TrimPrefix(key, managedTagsTagKey+"-")
+	if suffix == key {
+		return false
+	}
+	_, err := strconv.Atoi(suffix)
+	return err == nil
+}
+
+// managedTagKeys returns the keys of the tags of the machine class recorded in the tags of a resource
+func managedTagKeys(tags map[string]*string) []string {
+	var keys []string
+	for i := 0; ; i++ {
+		value, ok := tags[managedTagsKey(i)]
+		if !ok {
+			return keys
+		}
+		for _, key := range strings.Split(to.String(value), ",") {
+			if key != "" {
+				keys = append(keys, key)
+			}
+		}
+	}
+}
+
+// isProtectedTag returns true if the tag is managed by the provider itself
+func isProtectedTag(key string) bool {
+	if isManagedTagsKey(key) {
+		return true
+	}
+	for _, protected := range protectedTagKeys {
+		if strings.EqualFold(key, protected) {
+			return true
+		}
+	}
+	return false
+}
+
+// shortenTagValues returns the tags with all values exceeding the maximum length shortened according to the policy,
+// and the sorted keys of the shortened tags. The given tags are not modified.
+func shortenTagValues(tags api.Tags, policy string) (api.Tags, []string) {
+	if policy != TagValuePolicyTruncate && policy != TagValuePolicyHash {
+		return tags, nil
+	}
+
+	var (
+		result    = make(api.Tags, len(tags))
+		shortened []string
+	)
+	for key, value := range tags {
+		runes := []rune(value)
+		if len(runes) <= tagValueMaxLength {
+			result[key] = value
+			continue
+		}
+
+		if policy == TagValuePolicyHash {
+			sum := sha256.Sum256([]byte(value))
+			result[key] = string(runes[:tagValueMaxLength-tagValueHashLength-1]) + "-" + hex.EncodeToString(sum[:])[:tagValueHashLength]
+		} else {
+			result[key] = string(runes[:tagValueMaxLength])
+		}
+		shortened = append(shortened, key)
+	}
+	sort.Strings(shortened)
+	return result, shortened
+}
+
+// applyTagValuePolicy shortens the over-long tag values of the provider spec and reports them with a warning event
+// on the machine
+func (d *MachinePlugin) applyTagValuePolicy(ctx context.Context, providerSpec *api.AzureProviderSpec, machine *v1alpha1.Machine) {
+	tags, shortened := shortenTagValues(providerSpec.Tags, d.tagValuePolicy)
+	if len(shortened) == 0 {
+		return
+	}
+	providerSpec.Tags = tags
+
+	spi.WarningS(ctx, "Values of tags exceed the maximum length and were shortened", "tags", strings.Join(shortened, ", "), "maxLength", tagValueMaxLength, "policy", d.tagValuePolicy)
+	if d.Recorder != nil {
+		d.Recorder.Eventf(machine, corev1.EventTypeWarning, "TagValuesShortened", "Values of tags %s exceed %d characters and were shortened (policy %q)", strings.Join(shortened, ", "), tagValueMaxLength, d.tagValuePolicy)
+	}
+}
+
+// validateReservedTags returns an invalid argument error if the provider spec sets tags reserved by the operator, see
+// validation.ValidateReservedTags
+func (d *MachinePlugin) validateReservedTags(providerSpec *api.AzureProviderSpec) error {
+	if keys := validation.ReservedTags(providerSpec.Tags, d.reservedTagKeys); len(keys) > 0 {
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("The tags %s are reserved and must not be set by the machine class", strings.Join(keys, ", ")))
+	}
+	return nil
+}
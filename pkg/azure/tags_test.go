@@ -0,0 +1,107 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/codes"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/status"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Tags", func() {
+	Describe("#UnmarshalJSON", func() {
+		It("should convert numbers and booleans to strings", func() {
+			var spec api.AzureProviderSpec
+			Expect(json.Unmarshal([]byte(`{"tags":{"name":"shoot","replicas":3,"ratio":0.5,"enabled":true,"empty":null}}`), &spec)).To(Succeed())
+
+			Expect(spec.Tags).To(Equal(api.Tags{"name": "shoot", "replicas": "3", "ratio": "0.5", "enabled": "true", "empty": ""}))
+		})
+
+		It("should reject nested values", func() {
+			var spec api.AzureProviderSpec
+			Expect(json.Unmarshal([]byte(`{"tags":{"name":{"foo":"bar"}}}`), &spec)).NotTo(Succeed())
+		})
+	})
+
+	Describe("#shortenTagValues", func() {
+		var (
+			long = strings.Repeat("ä", 300)
+			tags = api.Tags{"short": "value", "long": long}
+		)
+
+		It("should keep the values with the fail policy", func() {
+			result, shortened := shortenTagValues(tags, TagValuePolicyFail)
+
+			Expect(result).To(Equal(tags))
+			Expect(shortened).To(BeEmpty())
+		})
+
+		It("should truncate over-long values by characters", func() {
+			result, shortened := shortenTagValues(tags, TagValuePolicyTruncate)
+
+			Expect(shortened).To(Equal([]string{"long"}))
+			Expect(result["short"]).To(Equal("value"))
+			Expect(result["long"]).To(Equal(strings.Repeat("ä", tagValueMaxLength)))
+			Expect(tags["long"]).To(Equal(long))
+		})
+
+		It("should keep distinct values distinct when hashing", func() {
+			result, _ := shortenTagValues(tags, TagValuePolicyHash)
+			other, _ := shortenTagValues(api.Tags{"long": long + "x"}, TagValuePolicyHash)
+
+			Expect(utf8.RuneCountInString(result["long"])).To(Equal(tagValueMaxLength))
+			Expect(result["long"]).NotTo(Equal(other["long"]))
+		})
+	})
+
+	Describe("#managedTags", func() {
+		It("should record the keys in sorted chunks within the value limit", func() {
+			keys := []string{"with,comma"}
+			for i := 0; i < 30; i++ {
+				keys = append(keys, fmt.Sprintf("%s-%02d", strings.Repeat("k", 20), i))
+			}
+
+			tags := managedTags(keys)
+			Expect(tags).To(HaveLen(3))
+			for _, value := range tags {
+				Expect(len(*value)).To(BeNumerically("<=", tagValueMaxLength))
+			}
+			Expect(managedTagKeys(tags)).To(Equal(keys[1:]))
+		})
+	})
+
+	Describe("#validateReservedTags", func() {
+		var (
+			driver       = &MachinePlugin{reservedTagKeys: []string{"costcenter", "owner"}}
+			providerSpec = func(tags api.Tags) *api.AzureProviderSpec { return &api.AzureProviderSpec{Tags: tags} }
+		)
+
+		It("should allow tags which are not reserved", func() {
+			Expect(driver.validateReservedTags(providerSpec(api.Tags{"team": "a", "costcenter-team": "b"}))).To(Succeed())
+		})
+
+		It("should reject reserved tags case-insensitively", func() {
+			err := driver.validateReservedTags(providerSpec(api.Tags{"team": "a", "Owner": "b", "CostCenter": "c"}))
+
+			s, ok := status.FromError(err)
+			Expect(ok).To(BeTrue())
+			Expect(s.Code()).To(Equal(codes.InvalidArgument))
+			Expect(s.Message()).To(ContainSubstring("CostCenter, Owner"))
+		})
+
+		It("should allow all tags if no keys are reserved", func() {
+			Expect((&MachinePlugin{}).validateReservedTags(providerSpec(api.Tags{"owner": "a"}))).To(Succeed())
+		})
+	})
+})
@@ -0,0 +1,37 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// machineClassOperationsCounter is the number of machine operations per machine class and result. Together with the
+// Azure API requests per machine class, it shows which worker pools churn the most and drive throttling.
+var machineClassOperationsCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "mcm",
+	Subsystem: "azure",
+	Name:      "machine_class_operations_total",
+	Help:      "Number of machines created or deleted per machine class, by result.",
+}, []string{"machine_class", "operation", "result"})
+
+func init() {
+	prometheus.MustRegister(machineClassOperationsCounter)
+}
+
+// recordMachineClassUsage counts the completed machine operation for its machine class
+func recordMachineClassUsage(operation string, machineClass *v1alpha1.MachineClass, opErr error) {
+	if machineClass == nil {
+		return
+	}
+	result := "succeeded"
+	if opErr != nil {
+		result = "failed"
+	}
+	machineClassOperationsCounter.WithLabelValues(machineClass.Name, operation, result).Inc()
+}
@@ -0,0 +1,45 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"errors"
+
+	"github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// seriesOf returns the number of series of the collector
+func seriesOf(collector prometheus.Collector) int {
+	metrics := make(chan prometheus.Metric, 1000)
+	collector.Collect(metrics)
+	close(metrics)
+	return len(metrics)
+}
+
+var _ = Describe("Usage", func() {
+	It("should count the operations of the machine class by result", func() {
+		machineClass := &v1alpha1.MachineClass{ObjectMeta: metav1.ObjectMeta{Name: "usage-class"}}
+		recordMachineClassUsage(operationCreate, machineClass, nil)
+		recordMachineClassUsage(operationCreate, machineClass, errors.New("quota exceeded"))
+		recordMachineClassUsage(operationDelete, machineClass, nil)
+
+		Expect(testutil.ToFloat64(machineClassOperationsCounter.WithLabelValues("usage-class", operationCreate, "succeeded"))).To(Equal(float64(1)))
+		Expect(testutil.ToFloat64(machineClassOperationsCounter.WithLabelValues("usage-class", operationCreate, "failed"))).To(Equal(float64(1)))
+		Expect(testutil.ToFloat64(machineClassOperationsCounter.WithLabelValues("usage-class", operationDelete, "succeeded"))).To(Equal(float64(1)))
+	})
+
+	It("should not count the operations without machine class", func() {
+		before := seriesOf(machineClassOperationsCounter)
+		recordMachineClassUsage(operationCreate, nil, nil)
+		Expect(seriesOf(machineClassOperationsCounter)).To(Equal(before))
+	})
+})
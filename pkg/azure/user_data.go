@@ -0,0 +1,152 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-08-01/compute"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/spi"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/driver"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// userDataTagKey is the tag of the VMs passed the user data via their userData property. It carries the fingerprint
+// of the user data of the secret the VM was created or last updated with.
+const userDataTagKey = "machine-controller-manager-user-data"
+
+// userDataFingerprint returns the fingerprint of the user data of the secret. It is taken before the user data is
+// rendered, as rendering issues a new bootstrap token each time.
+func userDataFingerprint(secret *corev1.Secret) string {
+	sum := sha256.Sum256(secret.Data["userData"])
+	return hex.EncodeToString(sum[:])
+}
+
+// userDataSyncs tracks the fingerprints of the user data last synchronized to the VM of each machine, so that the VM
+// is only fetched on GetMachineStatus once the user data of the secret changed
+type userDataSyncs struct {
+	mutex  sync.Mutex
+	synced map[string]string
+}
+
+func newUserDataSyncs() *userDataSyncs {
+	return &userDataSyncs{synced: map[string]string{}}
+}
+
+// due returns true if the user data with the given fingerprint has not been synchronized to the machine yet
+func (u *userDataSyncs) due(machineName, fingerprint string) bool {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	return u.synced[machineName] != fingerprint
+}
+
+// done records the synchronization of the user data with the given fingerprint to the machine
+func (u *userDataSyncs) done(machineName, fingerprint string) {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	u.synced[machineName] = fingerprint
+}
+
+// forget drops the synchronization of a deleted machine
+func (u *userDataSyncs) forget(machineName string) {
+	if u == nil {
+		return
+	}
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	delete(u.synced, machineName)
+}
+
+// syncUserData updates the userData property of the VM of a machine class passing the user data via the userData
+// property, if the user data of the secret changed since the VM was created or last updated. The node can then be
+// re-bootstrapped from the instance metadata service without recreating the machine. Failures are only logged, as the
+// status of the machine is reported anyway, and the update is retried with the next status request. VMs of paused
+// machines and VMs owned by other instances are not touched.
+func (d *MachinePlugin) syncUserData(ctx context.Context, req *driver.GetMachineStatusRequest) {
+	if d.userDataSyncs == nil || isPaused(req.Machine) {
+		return
+	}
+	providerSpec, err := d.decodeProviderSpecAndSecret(req.MachineClass, req.Secret)
+	if err != nil || !providerSpec.Properties.UseUserData {
+		return
+	}
+	if providerSpec, err = selectOSProfile(providerSpec, req.Machine); err != nil {
+		return
+	}
+
+	fingerprint := userDataFingerprint(req.Secret)
+	if !d.userDataSyncs.due(req.Machine.Name, fingerprint) {
+		return
+	}
+
+	clients, err := d.SPI.Setup(req.Secret, providerSpec.CloudConfiguration)
+	if err != nil {
+		spi.WarningS(ctx, "User data of machine could not be synchronized", "err", err)
+		return
+	}
+
+	var (
+		resourceGroupName = providerSpec.ResourceGroup
+		vmName            = strings.ToLower(req.Machine.Name)
+	)
+	vm, err := clients.GetVM().Get(ctx, resourceGroupName, vmName, "")
+	if err != nil {
+		spi.WarningS(ctx, "User data of machine could not be synchronized", "vm", vmName, "err", spi.OnARMAPIErrorFail(prometheusServiceVM, err, "VM.Get failed for %s", vmName))
+		return
+	}
+	spi.OnARMAPISuccess(prometheusServiceVM, "VM.Get")
+	if d.ownedByOtherInstance(vm.Tags) {
+		return
+	}
+	if to.String(vm.Tags[userDataTagKey]) == fingerprint {
+		d.userDataSyncs.done(req.Machine.Name, fingerprint)
+		return
+	}
+
+	userData, err := d.getUserData(ctx, req.Secret, providerSpec, req.Machine.Name, req.MachineClass.Name)
+	if err != nil {
+		spi.WarningS(ctx, "User data of machine could not be synchronized", "vm", vmName, "err", err)
+		return
+	}
+	tags := make(map[string]*string, len(vm.Tags)+1)
+	for key, value := range vm.Tags {
+		tags[key] = value
+	}
+	tags[userDataTagKey] = to.StringPtr(fingerprint)
+
+	future, err := clients.GetVM().Update(ctx, resourceGroupName, vmName, compute.VirtualMachineUpdate{
+		VirtualMachineProperties: &compute.VirtualMachineProperties{
+			UserData: to.StringPtr(base64.StdEncoding.EncodeToString(userData)),
+		},
+		Tags: tags,
+	})
+	if err != nil {
+		spi.WarningS(ctx, "User data of machine could not be synchronized", "vm", vmName, "err", spi.OnARMAPIErrorFail(prometheusServiceVM, err, "VM.Update failed for %s", vmName))
+		return
+	}
+	if err := future.WaitForCompletionRef(ctx, clients.GetClient()); err != nil {
+		spi.WarningS(ctx, "User data of machine could not be synchronized", "vm", vmName, "err", spi.OnARMAPIErrorFail(prometheusServiceVM, err, "VM.WaitForCompletionRef failed for %s", vmName))
+		return
+	}
+	spi.OnARMAPISuccess(prometheusServiceVM, "VM.Update")
+	d.userDataSyncs.done(req.Machine.Name, fingerprint)
+
+	spi.InfoS(ctx, "User data of VM was updated to the user data of the secret", "vm", vmName)
+	if d.Recorder != nil {
+		d.Recorder.Event(req.Machine, corev1.EventTypeNormal, "UserDataUpdated", fmt.Sprintf("User data of VM %s was updated, the node picks it up when it is re-bootstrapped", vmName))
+	}
+}
@@ -0,0 +1,113 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-08-01/compute"
+	"github.com/Azure/go-autorest/autorest/to"
+	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/mock"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/driver"
+	gomock "github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/client-go/tools/record"
+)
+
+var _ = Describe("UserData", func() {
+	var (
+		ctx = context.Background()
+
+		plugin   *MachinePlugin
+		clients  *mock.AzureDriverClients
+		recorder *record.FakeRecorder
+		req      *driver.GetMachineStatusRequest
+		rg       = "shoot--i538135--seed-az"
+	)
+
+	BeforeEach(func() {
+		sp := mock.NewMockPluginSPIImpl(gomock.NewController(GinkgoT()))
+		machineClass, secret := newProviderSpecCacheFixtures()
+		driverClients, err := sp.Setup(secret, nil)
+		Expect(err).NotTo(HaveOccurred())
+		clients = driverClients.(*mock.AzureDriverClients)
+
+		providerSpec := UnmarshalProviderSpec(machineClass.ProviderSpec.Raw)
+		providerSpec.Properties.UseUserData = true
+		machineClass.ProviderSpec.Raw, err = json.Marshal(providerSpec)
+		Expect(err).NotTo(HaveOccurred())
+
+		recorder = record.NewFakeRecorder(1)
+		plugin = NewAzureDriver(sp)
+		plugin.Recorder = recorder
+		req = &driver.GetMachineStatusRequest{Machine: newMachine("machine"), MachineClass: machineClass, Secret: secret}
+	})
+
+	It("should update the user data of VMs created with another user data once", func() {
+		var future compute.VirtualMachinesUpdateFuture
+		Expect(json.Unmarshal([]byte(succeededFuture), &future)).To(Succeed())
+		clients.VM.EXPECT().Get(gomock.Any(), rg, "machine", compute.InstanceViewTypes("")).Return(compute.VirtualMachine{
+			Name: to.StringPtr("machine"),
+			Tags: map[string]*string{"foreign": to.StringPtr("kept"), userDataTagKey: to.StringPtr("outdated")},
+		}, nil)
+		clients.VM.EXPECT().Update(gomock.Any(), rg, "machine", gomock.Any()).DoAndReturn(func(_ context.Context, _, _ string, parameters compute.VirtualMachineUpdate) (compute.VirtualMachinesUpdateFuture, error) {
+			Expect(*parameters.UserData).To(Equal(base64.StdEncoding.EncodeToString([]byte("#cloud-config"))))
+			Expect(parameters.Tags).To(HaveKeyWithValue("foreign", to.StringPtr("kept")))
+			Expect(parameters.Tags).To(HaveKeyWithValue(userDataTagKey, to.StringPtr(userDataFingerprint(req.Secret))))
+			return future, nil
+		})
+
+		plugin.syncUserData(ctx, req)
+		Expect(recorder.Events).To(Receive(ContainSubstring("UserDataUpdated")))
+
+		plugin.syncUserData(ctx, req)
+	})
+
+	It("should not update VMs created with the user data of the secret", func() {
+		clients.VM.EXPECT().Get(gomock.Any(), rg, "machine", compute.InstanceViewTypes("")).Return(compute.VirtualMachine{
+			Name: to.StringPtr("machine"),
+			Tags: map[string]*string{userDataTagKey: to.StringPtr(userDataFingerprint(req.Secret))},
+		}, nil)
+
+		plugin.syncUserData(ctx, req)
+		plugin.syncUserData(ctx, req)
+		Expect(recorder.Events).NotTo(Receive())
+	})
+
+	It("should fetch the VM again once the user data of the secret changed", func() {
+		clients.VM.EXPECT().Get(gomock.Any(), rg, "machine", compute.InstanceViewTypes("")).Return(compute.VirtualMachine{
+			Name: to.StringPtr("machine"),
+			Tags: map[string]*string{userDataTagKey: to.StringPtr(userDataFingerprint(req.Secret))},
+		}, nil)
+		plugin.syncUserData(ctx, req)
+
+		req.Secret.Data["userData"] = []byte("#cloud-config\nruncmd: []")
+		clients.VM.EXPECT().Get(gomock.Any(), rg, "machine", compute.InstanceViewTypes("")).Return(compute.VirtualMachine{}, nil)
+		clients.VM.EXPECT().Update(gomock.Any(), rg, "machine", gomock.Any()).Return(compute.VirtualMachinesUpdateFuture{}, errors.New("failed"))
+		plugin.syncUserData(ctx, req)
+	})
+
+	It("should not touch VMs of machine classes passing the user data as custom data", func() {
+		providerSpec := UnmarshalProviderSpec(req.MachineClass.ProviderSpec.Raw)
+		providerSpec.Properties.UseUserData = false
+		raw, err := json.Marshal(providerSpec)
+		Expect(err).NotTo(HaveOccurred())
+		req.MachineClass.ProviderSpec.Raw = raw
+
+		plugin.syncUserData(ctx, req)
+	})
+
+	It("should not touch VMs of paused machines", func() {
+		req.Machine.Annotations = map[string]string{api.MachineAnnotationPaused: "true"}
+		plugin.syncUserData(ctx, req)
+	})
+})
@@ -8,31 +8,36 @@ SPDX-License-Identifier: Apache-2.0
 package azure
 
 import (
+	"bytes"
 	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"net"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"text/template"
 	"time"
 
-	"github.com/Azure/azure-sdk-for-go/profiles/latest/compute/mgmt/compute"
-	"github.com/Azure/azure-sdk-for-go/profiles/latest/network/mgmt/network"
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-08-01/compute"
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-04-01/network"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
 	"github.com/Azure/go-autorest/autorest/to"
 	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis/defaults"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis/validation"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/bootstrap"
 	"github.com/gardener/machine-controller-manager-provider-azure/pkg/spi"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/userdata"
 	"github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
 	"github.com/gardener/machine-controller-manager/pkg/util/provider/driver"
 	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/codes"
 	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/status"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/klog"
-)
-
-const (
-	nicSuffix      = "-nic"
-	diskSuffix     = "-os-disk"
-	dataDiskSuffix = "-data-disk"
 )
 
 const (
@@ -40,67 +45,370 @@ const (
 	prometheusServiceVM     = "virtual_machine"
 	prometheusServiceNIC    = "network_interfaces"
 	prometheusServiceDisk   = "disks"
+
+	prometheusServicePublicIP = "public_ip_addresses"
 )
 
-func dependencyNameFromVMName(vmName, suffix string) string {
-	return vmName + suffix
+// getAzureTags converts the tags of the provider spec into the format expected by the Azure SDK. Their keys are
+// recorded in the managed tags, so that tags dropped from the provider spec can be removed from the resources.
+func getAzureTags(tags map[string]string) map[string]*string {
+	tagList := map[string]*string{}
+	keys := make([]string, 0, len(tags))
+	for idx, element := range tags {
+		tagList[idx] = to.StringPtr(element)
+		keys = append(keys, idx)
+	}
+	for key, value := range managedTags(keys) {
+		tagList[key] = value
+	}
+	return tagList
 }
 
-func dependencyNameFromVMNameAndDependency(dependency, vmName, suffix string) string {
-	return vmName + "-" + dependency + suffix
+// withMachineLogFields returns a context logging the operation, machine and machine class of a request on every line.
+// The machine is omitted for requests of a whole machine class.
+func withMachineLogFields(ctx context.Context, operation, machineName string, machineClass *v1alpha1.MachineClass) context.Context {
+	keysAndValues := []interface{}{spi.LogKeyOperation, operation}
+	if machineName != "" {
+		keysAndValues = append(keysAndValues, spi.LogKeyMachine, machineName)
+	}
+	if machineClass != nil {
+		keysAndValues = append(keysAndValues, spi.LogKeyMachineClass, machineClass.Name)
+	}
+	return spi.WithLogFields(ctx, keysAndValues...)
 }
 
-func getAzureDataDiskPrefix(name string, lun *int32) string {
-	if name != "" {
-		return fmt.Sprintf("%s-%d", name, *lun)
+// getDataDiskLun returns the LUN of the data disk at the given index. Data disks without LUN are assigned consecutive
+// LUNs starting at the offset.
+func getDataDiskLun(disk api.AzureDataDisk, i int, lunOffset int32) *int32 {
+	if disk.Lun != nil {
+		return disk.Lun
 	}
-	return fmt.Sprintf("%d", *lun)
+	lun := lunOffset + int32(i)
+	return &lun
 }
 
-func getAzureDataDiskNames(azureDataDisks []api.AzureDataDisk, vmname, suffix string) []string {
-	azureDataDiskNames := make([]string, len(azureDataDisks))
+// resolveDataDiskLunOffset returns the LUN offset the data disks of the VM were created with. The offset of the machine
+// class may have been changed since, which changes the names of its data disks without LUN, so the offset is resolved
+// from the names of the data disks in the VM model. The offset of the machine class is returned if the VM is gone or
+// its data disks do not match any offset.
+func resolveDataDiskLunOffset(ctx context.Context, clients spi.AzureDriverClientsInterface, resourceGroupName, vmName string, naming NamingStrategy, azureDataDisks []api.AzureDataDisk, lunOffset int32) (int32, error) {
+	var withoutLun []int
 	for i, disk := range azureDataDisks {
-		var diskLun *int32
-		if disk.Lun != nil {
-			diskLun = disk.Lun
-		} else {
-			lun := int32(i)
-			diskLun = &lun
+		if disk.Lun == nil && disk.CreateOption != api.DataDiskCreateOptionAttach && !isSharedDataDisk(disk) {
+			withoutLun = append(withoutLun, i)
+		}
+	}
+	if len(withoutLun) == 0 {
+		return lunOffset, nil
+	}
+
+	vm, err := clients.GetVM().Get(ctx, resourceGroupName, vmName, "")
+	if err != nil {
+		if spi.NotFound(err) {
+			return lunOffset, nil
 		}
-		azureDataDiskNames[i] = dependencyNameFromVMNameAndDependency(getAzureDataDiskPrefix(disk.Name, diskLun), vmname, suffix)
+		return 0, spi.OnARMAPIErrorFail(prometheusServiceVM, err, "VM.Get failed for %s", vmName)
+	}
+	if vm.VirtualMachineProperties == nil || vm.StorageProfile == nil || vm.StorageProfile.DataDisks == nil {
+		return lunOffset, nil
+	}
+
+	var (
+		names = map[int32]string{}
+		luns  []int32
+	)
+	for _, dataDisk := range *vm.StorageProfile.DataDisks {
+		if dataDisk.Lun != nil {
+			names[*dataDisk.Lun] = to.String(dataDisk.Name)
+			luns = append(luns, *dataDisk.Lun)
+		}
+	}
+	matches := func(offset int32) bool {
+		for _, i := range withoutLun {
+			lun := offset + int32(i)
+			if name, ok := names[lun]; !ok || !strings.EqualFold(name, naming.DataDiskName(vmName, azureDataDisks[i].Name, lun)) {
+				return false
+			}
+		}
+		return true
+	}
+
+	if matches(lunOffset) {
+		return lunOffset, nil
+	}
+	sort.Slice(luns, func(i, j int) bool { return luns[i] < luns[j] })
+	for _, lun := range luns {
+		if offset := lun - int32(withoutLun[0]); offset >= 0 && matches(offset) {
+			spi.InfoS(ctx, "Data disks of VM were created with another LUN offset than the one of the machine class", "vm", vmName, "lunOffset", offset)
+			return offset, nil
+		}
+	}
+	return lunOffset, nil
+}
+
+// getAzureDataDiskNames returns the names of the data disks created along with the VM. Attached existing disks and the
+// shared disks of the machine class are omitted, as they must neither be tagged nor deleted with the VM.
+func getAzureDataDiskNames(naming NamingStrategy, azureDataDisks []api.AzureDataDisk, lunOffset int32, vmname string) []string {
+	azureDataDiskNames := make([]string, 0, len(azureDataDisks))
+	for i, disk := range azureDataDisks {
+		if disk.CreateOption == api.DataDiskCreateOptionAttach || isSharedDataDisk(disk) {
+			continue
+		}
+		diskLun := getDataDiskLun(disk, i, lunOffset)
+		azureDataDiskNames = append(azureDataDiskNames, naming.DataDiskName(vmname, disk.Name, *diskLun))
 	}
 	return azureDataDiskNames
 }
 
-func (d *MachinePlugin) getNICParameters(vmName string, subnet *network.Subnet) network.Interface {
+// networkInterface is a network interface to be created for a VM
+type networkInterface struct {
+	name                  string
+	subnetInfo            api.AzureSubnetInfo
+	acceleratedNetworking *bool
+	// enableIPForwarding enables IP forwarding on the interface if nil or true
+	enableIPForwarding *bool
+	primary            bool
+	// staticPrivateIP requests a static private IP address, which is the first of privateIPAddresses not in use
+	staticPrivateIP    bool
+	privateIPAddresses []string
+	// publicIP is the public IP address attached to the interface, named publicIPName. It is nil if none is attached.
+	publicIP     *api.AzurePublicIP
+	publicIPName string
+	// networkSecurityGroup and applicationSecurityGroups are existing security groups the interface is associated with
+	networkSecurityGroup      *api.AzureSubResource
+	applicationSecurityGroups []api.AzureSubResource
+	// ipv6 is the secondary IPv6 IP configuration of the interface, it is nil for IPv4 only interfaces
+	ipv6 *api.AzureIPv6Configuration
+	// loadBalancerBackendAddressPools and applicationGatewayBackendAddressPools are existing backend pools the IPv4 IP
+	// configuration of the interface is added to
+	loadBalancerBackendAddressPools       []api.AzureSubResource
+	applicationGatewayBackendAddressPools []api.AzureSubResource
+	// dnsLabel is the DNS label of the public IP, overriding its template. It is set if the label is handed off.
+	dnsLabel *string
+	// vmName is the name of the VM the interface belongs to
+	vmName string
+	// resourceGroup is the resource group of the interface and its public IP. It is empty if they are in the resource
+	// group of the VM.
+	resourceGroup string
+}
+
+// resourceGroupName returns the resource group of the network interface, which defaults to the given resource group
+// of the VM
+func (nic networkInterface) resourceGroupName(vmResourceGroup string) string {
+	if nic.resourceGroup != "" {
+		return nic.resourceGroup
+	}
+	return vmResourceGroup
+}
+
+// getNetworkInterfaces returns the network interfaces to be created for the VM. The primary
+// interface is always named after the VM, so that machines created before multiple interfaces
+// were supported are still handled.
+func getNetworkInterfaces(providerSpec *api.AzureProviderSpec, vmName string) []networkInterface {
+	naming := namingStrategyOf(providerSpec)
+	networkProfile := providerSpec.Properties.NetworkProfile
+	if len(networkProfile.Interfaces) == 0 {
+		return []networkInterface{
+			{
+				name:                                  naming.NICName(vmName, ""),
+				subnetInfo:                            providerSpec.SubnetInfo,
+				acceleratedNetworking:                 networkProfile.AcceleratedNetworking,
+				enableIPForwarding:                    networkProfile.EnableIPForwarding,
+				primary:                               true,
+				staticPrivateIP:                       networkProfile.PrivateIPAllocationMethod == api.PrivateIPAllocationMethodStatic,
+				privateIPAddresses:                    privateIPAddresses(networkProfile.PrivateIPAddress),
+				publicIP:                              networkProfile.PublicIP,
+				publicIPName:                          naming.PublicIPName(vmName, ""),
+				networkSecurityGroup:                  networkProfile.NetworkSecurityGroup,
+				applicationSecurityGroups:             networkProfile.ApplicationSecurityGroups,
+				ipv6:                                  networkProfile.IPv6,
+				loadBalancerBackendAddressPools:       networkProfile.LoadBalancerBackendAddressPools,
+				applicationGatewayBackendAddressPools: networkProfile.ApplicationGatewayBackendAddressPools,
+				vmName:                                vmName,
+				resourceGroup:                         to.String(providerSpec.NICResourceGroup),
+			},
+		}
+	}
+
+	var networkInterfaces []networkInterface
+	for _, nic := range networkProfile.Interfaces {
+		subnetInfo := nic.SubnetInfo
+		if subnetInfo.VnetName == "" {
+			subnetInfo.VnetName = providerSpec.SubnetInfo.VnetName
+			if subnetInfo.VnetResourceGroup == nil {
+				subnetInfo.VnetResourceGroup = providerSpec.SubnetInfo.VnetResourceGroup
+			}
+			if subnetInfo.VnetSubscriptionID == nil {
+				subnetInfo.VnetSubscriptionID = providerSpec.SubnetInfo.VnetSubscriptionID
+			}
+		}
+
+		nicName := naming.NICName(vmName, nic.Name)
+		publicIPName := naming.PublicIPName(vmName, nic.Name)
+		if nic.Primary {
+			nicName = naming.NICName(vmName, "")
+			publicIPName = naming.PublicIPName(vmName, "")
+		}
+
+		networkInterfaces = append(networkInterfaces, networkInterface{
+			name:                                  nicName,
+			subnetInfo:                            subnetInfo,
+			acceleratedNetworking:                 nic.AcceleratedNetworking,
+			enableIPForwarding:                    nic.EnableIPForwarding,
+			primary:                               nic.Primary,
+			staticPrivateIP:                       nic.PrivateIPAllocationMethod == api.PrivateIPAllocationMethodStatic,
+			privateIPAddresses:                    privateIPAddresses(nic.PrivateIPAddress),
+			publicIP:                              nic.PublicIP,
+			publicIPName:                          publicIPName,
+			networkSecurityGroup:                  nic.NetworkSecurityGroup,
+			applicationSecurityGroups:             nic.ApplicationSecurityGroups,
+			ipv6:                                  nic.IPv6,
+			loadBalancerBackendAddressPools:       nic.LoadBalancerBackendAddressPools,
+			applicationGatewayBackendAddressPools: nic.ApplicationGatewayBackendAddressPools,
+			vmName:                                vmName,
+			resourceGroup:                         to.String(providerSpec.NICResourceGroup),
+		})
+	}
+	return networkInterfaces
+}
+
+// nicResourceGroupOf returns the resource group of the NICs and public IPs of the provider spec, which defaults to the
+// resource group of the VMs
+func nicResourceGroupOf(providerSpec *api.AzureProviderSpec) string {
+	if providerSpec.NICResourceGroup != nil {
+		return *providerSpec.NICResourceGroup
+	}
+	return providerSpec.ResourceGroup
+}
+
+func privateIPAddresses(address *string) []string {
+	if address == nil {
+		return nil
+	}
+	return []string{*address}
+}
+
+// applyPrivateIPAddressPool assigns the private IP address pool annotated on the machine to the primary
+// network interface. The pool takes precedence over the address of the provider spec.
+func applyPrivateIPAddressPool(networkInterfaces []networkInterface, machine *v1alpha1.Machine) error {
+	pool, ok := machine.Annotations[api.MachineAnnotationPrivateIPAddressPool]
+	if !ok {
+		return nil
+	}
+
+	var addresses []string
+	for _, address := range strings.Split(pool, ",") {
+		address = strings.TrimSpace(address)
+		if address == "" {
+			continue
+		}
+		if net.ParseIP(address) == nil {
+			return status.Error(codes.InvalidArgument, fmt.Sprintf("Invalid address %q in annotation %s of machine %q", address, api.MachineAnnotationPrivateIPAddressPool, machine.Name))
+		}
+		addresses = append(addresses, address)
+	}
+	if len(addresses) == 0 {
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("Annotation %s of machine %q contains no addresses", api.MachineAnnotationPrivateIPAddressPool, machine.Name))
+	}
+
+	for i := range networkInterfaces {
+		if networkInterfaces[i].primary {
+			networkInterfaces[i].staticPrivateIP = true
+			networkInterfaces[i].privateIPAddresses = addresses
+		}
+	}
+	return nil
+}
+
+func (d *MachinePlugin) getNICParameters(nic networkInterface, subnet, ipv6Subnet *network.Subnet, privateIPAddress string, publicIP *network.PublicIPAddress) network.Interface {
 
 	var (
-		nicName            = dependencyNameFromVMName(vmName, nicSuffix)
+		nicName            = nic.name
 		location           = d.AzureProviderSpec.Location
-		enableIPForwarding = true
+		enableIPForwarding = nic.enableIPForwarding == nil || *nic.enableIPForwarding
+		allocationMethod   = network.Dynamic
+		address            *string
 	)
 
+	if privateIPAddress != "" {
+		allocationMethod = network.Static
+		address = &privateIPAddress
+	}
+
+	var networkSecurityGroup *network.SecurityGroup
+	if nic.networkSecurityGroup != nil {
+		networkSecurityGroup = &network.SecurityGroup{ID: to.StringPtr(nic.networkSecurityGroup.ID)}
+	}
+
+	var applicationSecurityGroups *[]network.ApplicationSecurityGroup
+	if len(nic.applicationSecurityGroups) > 0 {
+		groups := make([]network.ApplicationSecurityGroup, len(nic.applicationSecurityGroups))
+		for i, group := range nic.applicationSecurityGroups {
+			groups[i] = network.ApplicationSecurityGroup{ID: to.StringPtr(group.ID)}
+		}
+		applicationSecurityGroups = &groups
+	}
+
+	var loadBalancerBackendAddressPools *[]network.BackendAddressPool
+	if len(nic.loadBalancerBackendAddressPools) > 0 {
+		pools := make([]network.BackendAddressPool, len(nic.loadBalancerBackendAddressPools))
+		for i, pool := range nic.loadBalancerBackendAddressPools {
+			pools[i] = network.BackendAddressPool{ID: to.StringPtr(pool.ID)}
+		}
+		loadBalancerBackendAddressPools = &pools
+	}
+
+	var applicationGatewayBackendAddressPools *[]network.ApplicationGatewayBackendAddressPool
+	if len(nic.applicationGatewayBackendAddressPools) > 0 {
+		pools := make([]network.ApplicationGatewayBackendAddressPool, len(nic.applicationGatewayBackendAddressPools))
+		for i, pool := range nic.applicationGatewayBackendAddressPools {
+			pools[i] = network.ApplicationGatewayBackendAddressPool{ID: to.StringPtr(pool.ID)}
+		}
+		applicationGatewayBackendAddressPools = &pools
+	}
+
 	// Add tags to the machine resources
-	tagList := map[string]*string{}
-	for idx, element := range d.AzureProviderSpec.Tags {
-		tagList[idx] = to.StringPtr(element)
+	tagList := getAzureTags(d.AzureProviderSpec.Tags)
+
+	ipConfigurations := []network.InterfaceIPConfiguration{
+		{
+			Name: &nicName,
+			InterfaceIPConfigurationPropertiesFormat: &network.InterfaceIPConfigurationPropertiesFormat{
+				PrivateIPAllocationMethod:             allocationMethod,
+				PrivateIPAddress:                      address,
+				Subnet:                                subnet,
+				PublicIPAddress:                       publicIP,
+				ApplicationSecurityGroups:             applicationSecurityGroups,
+				LoadBalancerBackendAddressPools:       loadBalancerBackendAddressPools,
+				ApplicationGatewayBackendAddressPools: applicationGatewayBackendAddressPools,
+			},
+		},
+	}
+	if nic.ipv6 != nil {
+		ipv6AllocationMethod := network.Dynamic
+		if nic.ipv6.PrivateIPAddress != nil {
+			ipv6AllocationMethod = network.Static
+		}
+		ipConfigurations = append(ipConfigurations, network.InterfaceIPConfiguration{
+			Name: to.StringPtr(ipv6ConfigurationName(nicName)),
+			InterfaceIPConfigurationPropertiesFormat: &network.InterfaceIPConfigurationPropertiesFormat{
+				PrivateIPAddressVersion:   network.IPv6,
+				PrivateIPAllocationMethod: ipv6AllocationMethod,
+				PrivateIPAddress:          nic.ipv6.PrivateIPAddress,
+				Subnet:                    ipv6Subnet,
+				ApplicationSecurityGroups: applicationSecurityGroups,
+			},
+		})
 	}
 
 	NICParameters := network.Interface{
 		Name:     &nicName,
 		Location: &location,
 		InterfacePropertiesFormat: &network.InterfacePropertiesFormat{
-			IPConfigurations: &[]network.InterfaceIPConfiguration{
-				{
-					Name: &nicName,
-					InterfaceIPConfigurationPropertiesFormat: &network.InterfaceIPConfigurationPropertiesFormat{
-						PrivateIPAllocationMethod: network.Dynamic,
-						Subnet:                    subnet,
-					},
-				},
-			},
+			IPConfigurations:            &ipConfigurations,
+			NetworkSecurityGroup:        networkSecurityGroup,
 			EnableIPForwarding:          &enableIPForwarding,
-			EnableAcceleratedNetworking: d.AzureProviderSpec.Properties.NetworkProfile.AcceleratedNetworking,
+			EnableAcceleratedNetworking: nic.acceleratedNetworking,
 		},
 		Tags: tagList,
 	}
@@ -108,19 +416,82 @@ func (d *MachinePlugin) getNICParameters(vmName string, subnet *network.Subnet)
 	return NICParameters
 }
 
-func (d *MachinePlugin) generateDataDisks(vmName string, azureDataDisks []api.AzureDataDisk) []compute.DataDisk {
+func (d *MachinePlugin) getPublicIPParameters(nic networkInterface) (network.PublicIPAddress, error) {
+	var (
+		publicIPName     = nic.publicIPName
+		location         = d.AzureProviderSpec.Location
+		sku              = network.PublicIPAddressSkuNameStandard
+		allocationMethod = network.Static
+	)
+
+	if nic.publicIP.SKU == api.PublicIPSKUBasic {
+		sku = network.PublicIPAddressSkuNameBasic
+	}
+	if nic.publicIP.AllocationMethod == api.PrivateIPAllocationMethodDynamic {
+		allocationMethod = network.Dynamic
+	}
+
+	publicIPParameters := network.PublicIPAddress{
+		Name:     &publicIPName,
+		Location: &location,
+		Sku:      &network.PublicIPAddressSku{Name: sku},
+		PublicIPAddressPropertiesFormat: &network.PublicIPAddressPropertiesFormat{
+			PublicIPAllocationMethod: allocationMethod,
+			PublicIPAddressVersion:   network.IPv4,
+		},
+		Tags: getAzureTags(d.AzureProviderSpec.Tags),
+	}
+
+	if nic.dnsLabel != nil {
+		publicIPParameters.DNSSettings = &network.PublicIPAddressDNSSettings{DomainNameLabel: nic.dnsLabel}
+	} else if nic.publicIP.DNSLabelTemplate != nil {
+		dnsLabel, err := renderDNSLabel(*nic.publicIP.DNSLabelTemplate, nic.vmName)
+		if err != nil {
+			return network.PublicIPAddress{}, err
+		}
+		publicIPParameters.DNSSettings = &network.PublicIPAddressDNSSettings{DomainNameLabel: &dnsLabel}
+	}
+
+	return publicIPParameters, nil
+}
+
+// dnsLabelRegexp matches the DNS labels accepted by Azure for public IP addresses
+var dnsLabelRegexp = regexp.MustCompile(`^[a-z][a-z0-9-]{1,61}[a-z0-9]$`)
+
+// renderDNSLabel renders the DNS label template of a public IP address for the machine
+func renderDNSLabel(dnsLabelTemplate, machineName string) (string, error) {
+	tmpl, err := template.New("dnsLabel").Parse(dnsLabelTemplate)
+	if err != nil {
+		return "", status.Error(codes.InvalidArgument, fmt.Sprintf("Invalid DNS label template: %v", err))
+	}
+
+	var dnsLabel bytes.Buffer
+	if err := tmpl.Execute(&dnsLabel, struct{ MachineName string }{MachineName: machineName}); err != nil {
+		return "", status.Error(codes.InvalidArgument, fmt.Sprintf("Could not render DNS label template: %v", err))
+	}
+	label := strings.ToLower(dnsLabel.String())
+	if !dnsLabelRegexp.MatchString(label) {
+		return "", status.Error(codes.InvalidArgument, fmt.Sprintf("Rendered DNS label %q must consist of 3 to 63 lowercase letters, digits and hyphens, start with a letter and end with a letter or digit", label))
+	}
+	return label, nil
+}
+
+// getDiskEncryptionSet returns the reference to the disk encryption set of a managed disk, or nil if it is platform-managed
+func getDiskEncryptionSet(diskEncryptionSetID *string) *compute.DiskEncryptionSetParameters {
+	if diskEncryptionSetID == nil {
+		return nil
+	}
+	return &compute.DiskEncryptionSetParameters{ID: diskEncryptionSetID}
+}
+
+func (d *MachinePlugin) generateDataDisks(vmName string, azureDataDisks []api.AzureDataDisk, lunOffset int32) []compute.DataDisk {
 	var dataDisks []compute.DataDisk
+	naming := namingStrategyOf(d.AzureProviderSpec)
 	for i, azureDataDisk := range azureDataDisks {
 
-		var dataDiskLun *int32
-		if azureDataDisk.Lun != nil {
-			dataDiskLun = azureDataDisk.Lun
-		} else {
-			lun := int32(i)
-			dataDiskLun = &lun
-		}
+		dataDiskLun := getDataDiskLun(azureDataDisk, i, lunOffset)
 
-		dataDiskName := dependencyNameFromVMNameAndDependency(getAzureDataDiskPrefix(azureDataDisk.Name, dataDiskLun), vmName, dataDiskSuffix)
+		dataDiskName := naming.DataDiskName(vmName, azureDataDisk.Name, *dataDiskLun)
 
 		var caching compute.CachingTypes
 		if azureDataDisk.Caching != "" {
@@ -137,35 +508,51 @@ func (d *MachinePlugin) generateDataDisks(vmName string, azureDataDisks []api.Az
 			Caching: caching,
 			ManagedDisk: &compute.ManagedDiskParameters{
 				StorageAccountType: compute.StorageAccountTypes(azureDataDisk.StorageAccountType),
+				DiskEncryptionSet:  getDiskEncryptionSet(azureDataDisk.DiskEncryptionSetID),
 			},
-			DiskSizeGB:   &dataDiskSize,
-			CreateOption: compute.DiskCreateOptionTypesEmpty,
+			DiskSizeGB:              &dataDiskSize,
+			CreateOption:            compute.DiskCreateOptionTypesEmpty,
+			WriteAcceleratorEnabled: azureDataDisk.WriteAcceleratorEnabled,
+		}
+		switch azureDataDisk.CreateOption {
+		case api.DataDiskCreateOptionAttach:
+			// The existing disk keeps its name, size, SKU and encryption
+			dataDisk.Name = to.StringPtr(resourceNameFromID(*azureDataDisk.ManagedDiskID))
+			dataDisk.ManagedDisk = &compute.ManagedDiskParameters{ID: azureDataDisk.ManagedDiskID}
+			dataDisk.DiskSizeGB = nil
+			dataDisk.CreateOption = compute.DiskCreateOptionTypesAttach
+		case api.DataDiskCreateOptionFromImage:
+			// The size and SKU of the data disk of the image are used unless overridden
+			if dataDiskSize == 0 {
+				dataDisk.DiskSizeGB = nil
+			}
+			if azureDataDisk.StorageAccountType == "" {
+				dataDisk.ManagedDisk.StorageAccountType = ""
+			}
+			dataDisk.CreateOption = compute.DiskCreateOptionTypesFromImage
 		}
 		dataDisks = append(dataDisks, dataDisk)
 	}
 	return dataDisks
 }
 
-func (d *MachinePlugin) getVMParameters(vmName string, image *compute.VirtualMachineImage, networkInterfaceReferenceID string) compute.VirtualMachine {
+func (d *MachinePlugin) getVMParameters(ctx context.Context, vmName string, image *compute.VirtualMachineImage, networkInterfaceReferences []compute.NetworkInterfaceReference, userData []byte, secret *corev1.Secret) compute.VirtualMachine {
 
 	var (
-		diskName    = dependencyNameFromVMName(vmName, diskSuffix)
-		UserDataEnc = base64.StdEncoding.EncodeToString([]byte(d.Secret.Data["userData"]))
+		diskName    = namingStrategyOf(d.AzureProviderSpec).OSDiskName(vmName)
+		UserDataEnc = base64.StdEncoding.EncodeToString(userData)
 		location    = d.AzureProviderSpec.Location
 	)
 
 	// Add tags to the machine resources
-	tagList := map[string]*string{}
-	for idx, element := range d.AzureProviderSpec.Tags {
-		tagList[idx] = to.StringPtr(element)
-	}
+	tagList := getAzureTags(d.AzureProviderSpec.Tags)
 
 	imageReference := getImageReference(d)
 
 	var plan *compute.Plan
 	if image != nil && image.Plan != nil {
 		// If image.Plan exists, create a plan object and attach it to the VM
-		klog.V(2).Infof("Creating a plan object and attaching it to the VM - %q", vmName)
+		spi.V(2).InfoS(ctx, "Creating a plan object and attaching it to the VM", "vm", vmName)
 		plan = &compute.Plan{
 			Name:      image.VirtualMachineImageProperties.Plan.Name,
 			Product:   image.VirtualMachineImageProperties.Plan.Product,
@@ -182,54 +569,60 @@ func (d *MachinePlugin) getVMParameters(vmName string, image *compute.VirtualMac
 				VMSize: compute.VirtualMachineSizeTypes(d.AzureProviderSpec.Properties.HardwareProfile.VMSize),
 			},
 			StorageProfile: &compute.StorageProfile{
-				ImageReference: &imageReference,
+				ImageReference:     &imageReference,
+				DiskControllerType: compute.DiskControllerTypes(d.AzureProviderSpec.Properties.StorageProfile.DiskControllerType),
 				OsDisk: &compute.OSDisk{
 					Name:    &diskName,
 					Caching: compute.CachingTypes(d.AzureProviderSpec.Properties.StorageProfile.OsDisk.Caching),
 					ManagedDisk: &compute.ManagedDiskParameters{
 						StorageAccountType: compute.StorageAccountTypes(d.AzureProviderSpec.Properties.StorageProfile.OsDisk.ManagedDisk.StorageAccountType),
+						DiskEncryptionSet:  getDiskEncryptionSet(d.AzureProviderSpec.Properties.StorageProfile.OsDisk.DiskEncryptionSetID),
 					},
-					DiskSizeGB:   &d.AzureProviderSpec.Properties.StorageProfile.OsDisk.DiskSizeGB,
-					CreateOption: compute.DiskCreateOptionTypes(d.AzureProviderSpec.Properties.StorageProfile.OsDisk.CreateOption),
+					DiskSizeGB:              &d.AzureProviderSpec.Properties.StorageProfile.OsDisk.DiskSizeGB,
+					CreateOption:            compute.DiskCreateOptionTypes(d.AzureProviderSpec.Properties.StorageProfile.OsDisk.CreateOption),
+					WriteAcceleratorEnabled: d.AzureProviderSpec.Properties.StorageProfile.OsDisk.WriteAcceleratorEnabled,
 				},
 			},
 			OsProfile: &compute.OSProfile{
-				ComputerName:  &vmName,
-				AdminUsername: &d.AzureProviderSpec.Properties.OsProfile.AdminUsername,
-				CustomData:    &UserDataEnc,
-				LinuxConfiguration: &compute.LinuxConfiguration{
-					DisablePasswordAuthentication: &d.AzureProviderSpec.Properties.OsProfile.LinuxConfiguration.DisablePasswordAuthentication,
-					SSH: &compute.SSHConfiguration{
-						PublicKeys: &[]compute.SSHPublicKey{
-							{
-								Path:    &d.AzureProviderSpec.Properties.OsProfile.LinuxConfiguration.SSH.PublicKeys.Path,
-								KeyData: &d.AzureProviderSpec.Properties.OsProfile.LinuxConfiguration.SSH.PublicKeys.KeyData,
-							},
-						},
-					},
-				},
+				ComputerName:             &vmName,
+				AdminUsername:            &d.AzureProviderSpec.Properties.OsProfile.AdminUsername,
+				CustomData:               &UserDataEnc,
+				AllowExtensionOperations: d.AzureProviderSpec.Properties.OsProfile.AllowExtensionOperations,
 			},
 			NetworkProfile: &compute.NetworkProfile{
-				NetworkInterfaces: &[]compute.NetworkInterfaceReference{
-					{
-						ID: &networkInterfaceReferenceID,
-						NetworkInterfaceReferenceProperties: &compute.NetworkInterfaceReferenceProperties{
-							Primary: to.BoolPtr(true),
-						},
-					},
-				},
+				NetworkInterfaces: &networkInterfaceReferences,
 			},
+			LicenseType: d.AzureProviderSpec.Properties.LicenseType,
 		},
 		Tags: tagList,
 	}
 
+	if osProfile := d.AzureProviderSpec.Properties.OsProfile; isWindows(osProfile) {
+		adminPassword := getWindowsAdminPassword(osProfile, secret)
+		VMParameters.OsProfile.AdminPassword = &adminPassword
+		VMParameters.OsProfile.WindowsConfiguration = getWindowsConfiguration(osProfile)
+	} else {
+		VMParameters.OsProfile.LinuxConfiguration = &compute.LinuxConfiguration{
+			ProvisionVMAgent:              osProfile.ProvisionVMAgent,
+			DisablePasswordAuthentication: &d.AzureProviderSpec.Properties.OsProfile.LinuxConfiguration.DisablePasswordAuthentication,
+			SSH: &compute.SSHConfiguration{
+				PublicKeys: getSSHPublicKeys(osProfile, secret),
+			},
+		}
+	}
+
+	VMParameters.SecurityProfile, VMParameters.StorageProfile.OsDisk.ManagedDisk.SecurityProfile = getSecurityProfile(d.AzureProviderSpec.Properties.SecurityProfile)
+
 	if d.AzureProviderSpec.Properties.StorageProfile.DataDisks != nil && len(d.AzureProviderSpec.Properties.StorageProfile.DataDisks) > 0 {
-		dataDisks := d.generateDataDisks(vmName, d.AzureProviderSpec.Properties.StorageProfile.DataDisks)
+		dataDisks := d.generateDataDisks(vmName, d.AzureProviderSpec.Properties.StorageProfile.DataDisks, d.AzureProviderSpec.Properties.StorageProfile.DataDiskLunOffset)
 		VMParameters.StorageProfile.DataDisks = &dataDisks
 	}
 
-	if d.AzureProviderSpec.Properties.Zone != nil {
-		VMParameters.Zones = &[]string{strconv.Itoa(*d.AzureProviderSpec.Properties.Zone)}
+	if zone := getZone(d.AzureProviderSpec.Properties, vmName); zone != nil {
+		VMParameters.Zones = &[]string{strconv.Itoa(*zone)}
+		if len(d.AzureProviderSpec.Properties.Zones) > 0 {
+			VMParameters.Tags[zoneTagKey] = to.StringPtr(strconv.Itoa(*zone))
+		}
 	} else if d.AzureProviderSpec.Properties.AvailabilitySet != nil {
 		VMParameters.VirtualMachineProperties.AvailabilitySet = &compute.SubResource{
 			ID: &d.AzureProviderSpec.Properties.AvailabilitySet.ID,
@@ -239,7 +632,7 @@ func (d *MachinePlugin) getVMParameters(vmName string, image *compute.VirtualMac
 	if d.AzureProviderSpec.Properties.IdentityID != nil && *d.AzureProviderSpec.Properties.IdentityID != "" {
 		VMParameters.Identity = &compute.VirtualMachineIdentity{
 			Type: compute.ResourceIdentityTypeUserAssigned,
-			UserAssignedIdentities: map[string]*compute.VirtualMachineIdentityUserAssignedIdentitiesValue{
+			UserAssignedIdentities: map[string]*compute.UserAssignedIdentitiesValue{
 				*d.AzureProviderSpec.Properties.IdentityID: {},
 			},
 		}
@@ -248,8 +641,71 @@ func (d *MachinePlugin) getVMParameters(vmName string, image *compute.VirtualMac
 	return VMParameters
 }
 
+// getUserData returns the user data of the secret. If a bootstrap token issuer is configured,
+// a short-lived bootstrap token is issued for the machine and rendered into the user data.
+// The user data is passed through the configured transformers afterwards.
+func (d *MachinePlugin) getUserData(ctx context.Context, secret *corev1.Secret, providerSpec *api.AzureProviderSpec, machineName, machineClassName string) ([]byte, error) {
+	userData := secret.Data["userData"]
+	if d.TokenIssuer != nil && bytes.Contains(userData, []byte(bootstrap.TokenPlaceholder)) {
+		token, err := d.TokenIssuer.Issue(ctx, machineName)
+		if err != nil {
+			return nil, err
+		}
+		userData = bootstrap.RenderUserData(userData, token)
+	}
+
+	if len(d.UserDataTransformers) == 0 {
+		return userData, nil
+	}
+	return d.UserDataTransformers.Transform(userdata.Machine{
+		Name:          machineName,
+		Class:         machineClassName,
+		Location:      providerSpec.Location,
+		ResourceGroup: providerSpec.ResourceGroup,
+	}, userData)
+}
+
+// getSSHPublicKeys returns all public keys of the OS profile, including the ones of the machine class secret. Keys
+// without a path are placed in the authorized_keys file of the admin user. Keys given both inline and by the secret,
+// e.g. during a rotation, are only placed once.
+func getSSHPublicKeys(osProfile api.AzureOSProfile, secret *corev1.Secret) *[]compute.SSHPublicKey {
+	var (
+		ssh         = osProfile.LinuxConfiguration.SSH
+		defaultPath = fmt.Sprintf("/home/%s/.ssh/authorized_keys", osProfile.AdminUsername)
+		publicKeys  []compute.SSHPublicKey
+		placed      = map[string]bool{}
+	)
+
+	keys := append(append([]api.AzureSSHPublicKey{}, ssh.PublicKeys...), ssh.AdditionalPublicKeys...)
+	if ssh.PublicKeysSecretKey != "" && secret != nil {
+		keys = append(keys, api.ParseAuthorizedKeys(secret.Data[ssh.PublicKeysSecretKey])...)
+	}
+	for _, key := range keys {
+		if key.KeyData == "" && key.Path == "" {
+			continue
+		}
+		path := key.Path
+		if path == "" {
+			path = defaultPath
+		}
+		if placed[path+"\n"+key.KeyData] {
+			continue
+		}
+		placed[path+"\n"+key.KeyData] = true
+		publicKeys = append(publicKeys, compute.SSHPublicKey{
+			Path:    to.StringPtr(path),
+			KeyData: to.StringPtr(key.KeyData),
+		})
+	}
+	return &publicKeys
+}
+
 func getImageReference(d *MachinePlugin) compute.ImageReference {
-	imageRefClass := d.AzureProviderSpec.Properties.StorageProfile.ImageReference
+	return imageReferenceFromSpec(d.AzureProviderSpec.Properties.StorageProfile.ImageReference)
+}
+
+// imageReferenceFromSpec converts the image reference of a provider spec, which is either an ID or a URN
+func imageReferenceFromSpec(imageRefClass api.AzureImageReference) compute.ImageReference {
 	if imageRefClass.ID != "" {
 		return compute.ImageReference{
 			ID: &imageRefClass.ID,
@@ -269,230 +725,583 @@ func getImageReference(d *MachinePlugin) compute.ImageReference {
 	}
 }
 
-func (d *MachinePlugin) createVMNicDisk(req *driver.CreateMachineRequest) (*compute.VirtualMachine, error) {
+func (d *MachinePlugin) createVMNicDisk(ctx context.Context, req *driver.CreateMachineRequest) (_ *compute.VirtualMachine, err error) {
 
-	providerSpec, err := decodeProviderSpecAndSecret(req.MachineClass, req.Secret)
+	// Reject machine classes requesting features the VM size does not support before any resource is created
+	providerSpec, clients, err := d.decodeAndValidateProviderSpec(ctx, req.MachineClass, req.Secret)
 	if err != nil {
 		return nil, err
 	}
+	if providerSpec, err = selectOSProfile(providerSpec, req.Machine); err != nil {
+		return nil, err
+	}
+	d.applyTagValuePolicy(ctx, providerSpec, req.Machine)
+	if d.ownerID != "" {
+		tags := make(map[string]string, len(providerSpec.Tags)+1)
+		for key, value := range providerSpec.Tags {
+			tags[key] = value
+		}
+		tags[ownerTagKey] = d.ownerID
+		providerSpec.Tags = tags
+	}
 	d.AzureProviderSpec = providerSpec
+	ctx = spi.WithMachineClass(ctx, req.MachineClass.Name)
+	ctx = spi.WithLogFields(ctx, spi.LogKeyResourceGroup, providerSpec.ResourceGroup)
 
 	var (
-		ctx               = context.Background()
 		vmName            = strings.ToLower(req.Machine.Name)
 		resourceGroupName = providerSpec.ResourceGroup
-		vnetName          = providerSpec.SubnetInfo.VnetName
-		vnetResourceGroup = resourceGroupName
-		subnetName        = providerSpec.SubnetInfo.SubnetName
-		nicName           = dependencyNameFromVMName(vmName, nicSuffix)
-		diskName          = dependencyNameFromVMName(vmName, diskSuffix)
+		networkInterfaces = getNetworkInterfaces(providerSpec, vmName)
+		diskName          = namingStrategyOf(providerSpec).OSDiskName(vmName)
 		vmImageRef        *compute.VirtualMachineImage
 	)
 
-	// get the azuredriverclients
-	clients, err := d.SPI.Setup(req.Secret)
+	diskNames := append([]string{diskName}, getAzureDataDiskNames(namingStrategyOf(providerSpec), providerSpec.Properties.StorageProfile.DataDisks, providerSpec.Properties.StorageProfile.DataDiskLunOffset, vmName)...)
+	diskNames = append(diskNames, getSharedDataDiskNames(req.MachineClass.Name, providerSpec.Properties.StorageProfile.DataDisks)...)
+	if err := validateResourceNames(vmName, networkInterfaces, diskNames); err != nil {
+		return nil, err
+	}
+
+	endCreation, err := d.creationBudget.acquire(subscriptionID(req.Secret), resourceGroupName, vmName)
+	if err != nil {
+		return nil, err
+	}
+	defer endCreation()
+
+	if err := applyPrivateIPAddressPool(networkInterfaces, req.Machine); err != nil {
+		return nil, err
+	}
+	restoreIPHandoff, err := d.applyIPHandoff(ctx, networkInterfaces, req.Machine, req.MachineClass)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			restoreIPHandoff()
+		}
+	}()
+
+	if err := d.checkVNetLocations(ctx, clients, subscriptionID(req.Secret), providerSpec, networkInterfaces); err != nil {
+		return nil, err
+	}
+	computerName, err := getComputerName(providerSpec.Properties.OsProfile, vmName)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkWindowsComputerName(providerSpec.Properties.OsProfile, computerName); err != nil {
+		return nil, err
+	}
+	if err := checkSecurityProfile(ctx, clients, providerSpec); err != nil {
+		return nil, err
+	}
+	if err := d.checkIdentity(ctx, clients, providerSpec); err != nil {
+		return nil, err
+	}
+	availabilitySetID, err := ensureAvailabilitySet(ctx, clients, providerSpec)
 	if err != nil {
 		return nil, err
 	}
 
-	// Check if the machine should be assigned to a vnet in a different resource group.
-	if providerSpec.SubnetInfo.VnetResourceGroup != nil {
-		vnetResourceGroup = *providerSpec.SubnetInfo.VnetResourceGroup
+	// Machines with an image which was not validated yet are rejected until the canary of the image booted. Images of
+	// running VMs are considered validated.
+	image := imageKey(providerSpec.Properties.StorageProfile.ImageReference)
+	d.imageCanaries.seed(ctx, clients, resourceGroupName)
+	canary, err := d.imageCanaries.acquire(ctx, image, vmName, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if canary {
+		defer d.imageCanaries.release(image, vmName)
 	}
 
 	var dataDiskNames []string
 	if providerSpec.Properties.StorageProfile.DataDisks != nil && len(providerSpec.Properties.StorageProfile.DataDisks) > 0 {
-		dataDiskNames = getAzureDataDiskNames(providerSpec.Properties.StorageProfile.DataDisks, vmName, dataDiskSuffix)
+		dataDiskNames = getAzureDataDiskNames(namingStrategyOf(providerSpec), providerSpec.Properties.StorageProfile.DataDisks, providerSpec.Properties.StorageProfile.DataDiskLunOffset, vmName)
+	}
+
+	// A VM with the name of the machine may exist already, e.g. if a previous request timed out after Azure accepted
+	// the creation. It is adopted instead of creating its NICs again, which would fail with a conflict.
+	if vm, err := d.existingVM(ctx, clients, providerSpec, vmName); err != nil {
+		return nil, err
+	} else if vm != nil {
+		spi.InfoS(ctx, "VM exists already and is adopted", "vm", vmName)
+		if err := d.initializeVM(ctx, clients, resourceGroupName, vmName); err != nil {
+			return nil, err
+		}
+		return vm, nil
+	}
+
+	// The user data is only rendered for a VM which is created, as rendering it issues a bootstrap token
+	userData, err := d.getUserData(ctx, req.Secret, providerSpec, req.Machine.Name, req.MachineClass.Name)
+	if err != nil {
+		return nil, err
 	}
 
 	/*
-		Subnet fetching
+		NIC and shared data disk creation and image lookup
 	*/
-	// Getting the subnet object for subnetName
-	subnet, err := clients.GetSubnet().Get(
-		ctx,
-		vnetResourceGroup,
-		vnetName,
-		subnetName,
-		"",
+	// The NICs and shared data disks are created while the image and the agreement of its marketplace plan are looked
+	// up, as they don't depend on each other
+	var (
+		nicReferences []compute.NetworkInterfaceReference
+		sharedDisks   map[int32]sharedDataDisk
 	)
+	err = spi.RunInParallel([]func() error{
+		func() (err error) {
+			nicReferences, err = d.createNICs(ctx, clients, resourceGroupName, networkInterfaces)
+			return err
+		},
+		func() (err error) {
+			sharedDisks, err = d.ensureSharedDataDisks(ctx, clients, resourceGroupName, req.MachineClass.Name)
+			return err
+		},
+		func() (err error) {
+			vmImageRef, err = d.getVMImage(ctx, clients, req.Machine, req.MachineClass.Name)
+			return err
+		},
+	})
 	if err != nil {
-		return nil, spi.OnARMAPIErrorFail(prometheusServiceSubnet, err, "Subnet.Get failed for %s due to %s", subnetName, err)
+		// Since machine creation failed, delete any infra resources created
+		d.rollbackVMNicDisks(ctx, clients, resourceGroupName, vmName, networkInterfaces, diskName, dataDiskNames)
+
+		return nil, err
 	}
-	spi.OnARMAPISuccess(prometheusServiceSubnet, "subnet.Get")
 
 	/*
-		NIC creation
+		VM creation
 	*/
+	startTime := time.Now()
 
-	// Creating NICParameters for new NIC creation request
-	NICParameters := d.getNICParameters(vmName, &subnet)
+	// Creating VMParameters for new VM creation request
+	VMParameters := d.getVMParameters(ctx, vmName, vmImageRef, nicReferences, userData, req.Secret)
+	VMParameters.OsProfile.ComputerName = &computerName
+	attachSharedDataDisks(&VMParameters, sharedDisks)
+	if availabilitySetID != nil {
+		VMParameters.AvailabilitySet = &compute.SubResource{ID: availabilitySetID}
+	}
+	if providerSpec.Properties.UseUserData {
+		VMParameters.UserData, VMParameters.OsProfile.CustomData = VMParameters.OsProfile.CustomData, nil
+		VMParameters.Tags[userDataTagKey] = to.StringPtr(userDataFingerprint(req.Secret))
+	}
 
-	// NIC creation request
-	NICFuture, err := clients.GetNic().CreateOrUpdate(ctx, resourceGroupName, *NICParameters.Name, NICParameters)
+	// VM creation request, the clean up after a failure is not bounded by the timeout of the creation
+	vmCtx, cancel := withOperationTimeout(ctx, d.vmCreateTimeout)
+	defer cancel()
+	VMFuture, err := clients.GetVM().CreateOrUpdate(vmCtx, resourceGroupName, *VMParameters.Name, VMParameters)
 	if err != nil {
 		// Since machine creation failed, delete any infra resources created
-		deleteErr := d.deleteVMNicDisks(ctx, clients, resourceGroupName, vmName, nicName, diskName, dataDiskNames)
-		if deleteErr != nil {
-			klog.Errorf("Error occurred during resource clean up: %s", deleteErr)
-		}
+		d.rollbackVMNicDisks(ctx, clients, resourceGroupName, vmName, networkInterfaces, diskName, dataDiskNames)
 
-		return nil, spi.OnARMAPIErrorFail(prometheusServiceNIC, err, "NIC.CreateOrUpdate failed for %s", *NICParameters.Name)
+		return nil, operationTimeoutError(vmCtx, spi.OnARMAPIErrorFail(prometheusServiceVM, err, "GetVM().CreateOrUpdate failed for %s", *VMParameters.Name))
 	}
 
-	// Wait until NIC is created
-	err = NICFuture.WaitForCompletionRef(ctx, clients.GetClient())
+	// Wait until VM is created
+	err = VMFuture.WaitForCompletionRef(vmCtx, clients.GetClient())
 	if err != nil {
 		// Since machine creation failed, delete any infra resources created
-		deleteErr := d.deleteVMNicDisks(ctx, clients, resourceGroupName, vmName, nicName, diskName, dataDiskNames)
-		if deleteErr != nil {
-			klog.Errorf("Error occurred during resource clean up: %s", deleteErr)
+		d.rollbackVMNicDisks(ctx, clients, resourceGroupName, vmName, networkInterfaces, diskName, dataDiskNames)
+		if canary && isBootFailure(err) {
+			d.imageCanaries.fail(ctx, image, vmName, serviceErrorCode(err), time.Now())
 		}
 
-		return nil, spi.OnARMAPIErrorFail(prometheusServiceNIC, err, "NIC.WaitForCompletionRef failed for %s", *NICParameters.Name)
+		return nil, operationTimeoutError(vmCtx, spi.OnARMAPIErrorFail(prometheusServiceVM, err, "VMFuture.WaitForCompletionRef failed for %s", *VMParameters.Name))
 	}
-	spi.OnARMAPISuccess(prometheusServiceNIC, "NIC.CreateOrUpdate")
+	spi.InfoS(ctx, "VM created", "vm", vmName, "duration", time.Since(startTime))
 
-	// Fetch NIC details
-	NIC, err := NICFuture.Result(clients.GetNic().(network.InterfacesClient))
+	// Fetch VM details
+	VM, err := clients.GetVM().Get(ctx, resourceGroupName, *VMParameters.Name, "")
 	if err != nil {
 		// Since machine creation failed, delete any infra resources created
-		deleteErr := d.deleteVMNicDisks(ctx, clients, resourceGroupName, vmName, nicName, diskName, dataDiskNames)
-		if deleteErr != nil {
-			klog.Errorf("Error occurred during resource clean up: %s", deleteErr)
+		d.rollbackVMNicDisks(ctx, clients, resourceGroupName, vmName, networkInterfaces, diskName, dataDiskNames)
+
+		return nil, spi.OnARMAPIErrorFail(prometheusServiceVM, err, "VM.Get failed for %s", *VMParameters.Name)
+	}
+	spi.OnARMAPISuccess(prometheusServiceVM, "VM.CreateOrUpdate")
+
+	if canary {
+		if err := d.validateImageBoot(ctx, clients, resourceGroupName, vmName, image); err != nil {
+			// Since machine creation failed, delete any infra resources created
+			d.rollbackVMNicDisks(ctx, clients, resourceGroupName, vmName, networkInterfaces, diskName, dataDiskNames)
+
+			return nil, err
 		}
+	}
+
+	if err := d.initializeVM(ctx, clients, resourceGroupName, vmName); err != nil {
+		// Since machine creation failed, delete any infra resources created
+		d.rollbackVMNicDisks(ctx, clients, resourceGroupName, vmName, networkInterfaces, diskName, dataDiskNames)
 
 		return nil, err
 	}
 
-	/*
-		VM creation
-	*/
-	startTime := time.Now()
-	imageRefClass := d.AzureProviderSpec.Properties.StorageProfile.ImageReference
-	// if ID is not set the image is referenced using a URN
-	if imageRefClass.ID == "" {
+	return &VM, nil
+}
+
+// initializeVM performs the steps after the creation of the VM. It optionally waits for the guest agent of the VM to
+// become ready. The installation of the VM extensions is idempotent, hence it can be retried until it succeeds.
+func (d *MachinePlugin) initializeVM(ctx context.Context, clients spi.AzureDriverClientsInterface, resourceGroupName, vmName string) error {
+	if err := d.waitForGuestAgent(ctx, clients, resourceGroupName, vmName); err != nil {
+		return err
+	}
+	if err := d.installVMExtensions(ctx, clients, resourceGroupName, vmName); err != nil {
+		return err
+	}
 
-		imageReference := getImageReference(d)
-		vmImage, err := clients.GetImages().Get(
-			ctx,
-			d.AzureProviderSpec.Location,
-			*imageReference.Publisher,
-			*imageReference.Offer,
-			*imageReference.Sku,
-			*imageReference.Version)
+	// Disks created along with the VM don't inherit its tags, hence they are tagged explicitly
+	// so that they can be identified, e.g. by the orphan collector, once they get detached.
+	naming := namingStrategyOf(d.AzureProviderSpec)
+	diskNames := []string{naming.OSDiskName(vmName)}
+	if storageProfile := d.AzureProviderSpec.Properties.StorageProfile; len(storageProfile.DataDisks) > 0 {
+		diskNames = append(diskNames, getAzureDataDiskNames(naming, storageProfile.DataDisks, storageProfile.DataDiskLunOffset, vmName)...)
+	}
+	if err := d.tagDisks(ctx, clients, resourceGroupName, diskNames); err != nil {
+		spi.WarningS(ctx, "Could not tag disks of VM", "vm", vmName, "err", err)
+	}
+	return nil
+}
 
-		if err != nil {
-			//Since machine creation failed, delete any infra resources created
-			deleteErr := d.deleteVMNicDisks(ctx, clients, resourceGroupName, vmName, nicName, diskName, dataDiskNames)
-			if deleteErr != nil {
-				klog.Errorf("Error occurred during resource clean up: %s", deleteErr)
-			}
+// getVMImage returns the marketplace image of the VM and accepts the agreement of its plan for the subscription if
+// necessary. It returns nil for images referenced by their ID.
+func (d *MachinePlugin) getVMImage(ctx context.Context, clients spi.AzureDriverClientsInterface, machine *v1alpha1.Machine, machineClassName string) (*compute.VirtualMachineImage, error) {
+	// if ID is not set the image is referenced using a URN
+	if d.AzureProviderSpec.Properties.StorageProfile.ImageReference.ID != "" {
+		return nil, nil
+	}
+
+	imageReference := getImageReference(d)
+	vmImage, err := clients.GetImages().Get(
+		ctx,
+		d.AzureProviderSpec.Location,
+		*imageReference.Publisher,
+		*imageReference.Offer,
+		*imageReference.Sku,
+		*imageReference.Version)
+	if err != nil {
+		return nil, spi.OnARMAPIErrorFail(prometheusServiceVM, err, "VirtualMachineImagesclientutils.Get failed for %s", machineClassName)
+	}
 
-			return nil, spi.OnARMAPIErrorFail(prometheusServiceVM, err, "VirtualMachineImagesclientutils.Get failed for %s", req.MachineClass.Name)
+	if vmImage.Plan != nil {
+		if err := d.ensureMarketplaceAgreement(ctx, clients, machine, &vmImage, machineClassName); err != nil {
+			return nil, err
 		}
+	}
 
-		if vmImage.Plan != nil {
-			// If VMImage.Plan exists, check if agreement is accepted and if not accept it for the subscription
+	return &vmImage, nil
+}
 
-			agreement, err := clients.GetMarketplace().Get(
-				ctx,
-				*vmImage.Plan.Publisher,
-				*vmImage.Plan.Product,
-				*vmImage.Plan.Name,
-			)
+// createNICs creates the network interfaces of the VM in parallel and returns the references to attach them to the VM
+func (d *MachinePlugin) createNICs(ctx context.Context, clients spi.AzureDriverClientsInterface, resourceGroupName string, networkInterfaces []networkInterface) ([]compute.NetworkInterfaceReference, error) {
+	var (
+		references = make([]compute.NetworkInterfaceReference, len(networkInterfaces))
+		creators   []func() error
+	)
 
+	for i, nic := range networkInterfaces {
+		i, nic := i, nic
+		creators = append(creators, func() error {
+			nicID, err := d.createNIC(ctx, clients, resourceGroupName, nic)
 			if err != nil {
-				//Since machine creation failed, delete any infra resources created
-				deleteErr := d.deleteVMNicDisks(ctx, clients, resourceGroupName, vmName, nicName, diskName, dataDiskNames)
-				if deleteErr != nil {
-					klog.Errorf("Error occurred during resource clean up: %s", deleteErr)
-				}
-
-				return nil, spi.OnARMAPIErrorFail(prometheusServiceVM, err, "MarketplaceAgreementsclient.Get failed for %s", req.MachineClass.Name)
+				return err
 			}
-
-			if agreement.Accepted == nil || *agreement.Accepted == false {
-				// Need to accept the terms at least once for the subscription
-				klog.V(2).Info("Accepting terms for subscription to make use of the plan")
-
-				agreement.Accepted = to.BoolPtr(true)
-				_, err = clients.GetMarketplace().Create(
-					ctx,
-					*vmImage.Plan.Publisher,
-					*vmImage.Plan.Product,
-					*vmImage.Plan.Name,
-					agreement,
-				)
-
-				if err != nil {
-					//Since machine creation failed, delete any infra resources created
-					deleteErr := d.deleteVMNicDisks(ctx, clients, resourceGroupName, vmName, nicName, diskName, dataDiskNames)
-					if deleteErr != nil {
-						klog.Errorf("Error occurred during resource clean up: %s", deleteErr)
-					}
-
-					return nil, spi.OnARMAPIErrorFail(prometheusServiceVM, err, "MarketplaceAgreementsclientutils.Create failed for %s", req.MachineClass.Name)
-				}
+			references[i] = compute.NetworkInterfaceReference{
+				ID: &nicID,
+				NetworkInterfaceReferenceProperties: &compute.NetworkInterfaceReferenceProperties{
+					Primary: to.BoolPtr(nic.primary),
+				},
 			}
-		}
+			return nil
+		})
+	}
 
-		vmImageRef = &vmImage
+	if err := spi.RunInParallel(creators); err != nil {
+		return nil, err
 	}
+	return references, nil
+}
 
-	// Creating VMParameters for new VM creation request
-	VMParameters := d.getVMParameters(vmName, vmImageRef, *NIC.ID)
+// createOrUpdateNIC creates the network interface with the given private IP address and waits for its completion.
+// An empty address lets Azure allocate one dynamically.
+func (d *MachinePlugin) createOrUpdateNIC(ctx context.Context, clients spi.AzureDriverClientsInterface, resourceGroupName string, nic networkInterface, subnet, ipv6Subnet *network.Subnet, privateIPAddress string, publicIP *network.PublicIPAddress) error {
+	// Creating NICParameters for new NIC creation request
+	NICParameters := d.getNICParameters(nic, subnet, ipv6Subnet, privateIPAddress, publicIP)
+
+	ctx, cancel := withOperationTimeout(ctx, d.nicCreateTimeout)
+	defer cancel()
+
+	// NIC creation request
+	NICFuture, err := clients.GetNic().CreateOrUpdate(ctx, nic.resourceGroupName(resourceGroupName), nic.name, NICParameters)
+	if err != nil {
+		return operationTimeoutError(ctx, spi.OnARMAPIErrorFail(prometheusServiceNIC, err, "NIC.CreateOrUpdate failed for %s", nic.name))
+	}
+
+	// Wait until NIC is created
+	if err := NICFuture.WaitForCompletionRef(ctx, clients.GetClient()); err != nil {
+		return operationTimeoutError(ctx, spi.OnARMAPIErrorFail(prometheusServiceNIC, err, "NIC.WaitForCompletionRef failed for %s", nic.name))
+	}
+	spi.OnARMAPISuccess(prometheusServiceNIC, "NIC.CreateOrUpdate")
+	return nil
+}
 
-	// VM creation request
-	VMFuture, err := clients.GetVM().CreateOrUpdate(ctx, resourceGroupName, *VMParameters.Name, VMParameters)
+// createPublicIP creates the public IP address of the network interface and returns a reference to it
+func (d *MachinePlugin) createPublicIP(ctx context.Context, clients spi.AzureDriverClientsInterface, resourceGroupName string, nic networkInterface) (*network.PublicIPAddress, error) {
+	publicIPParameters, err := d.getPublicIPParameters(nic)
 	if err != nil {
-		//Since machine creation failed, delete any infra resources created
-		deleteErr := d.deleteVMNicDisks(ctx, clients, resourceGroupName, vmName, nicName, diskName, dataDiskNames)
-		if deleteErr != nil {
-			klog.Errorf("Error occurred during resource clean up: %s", deleteErr)
+		return nil, err
+	}
+
+	future, err := clients.GetPublicIP().CreateOrUpdate(ctx, nic.resourceGroupName(resourceGroupName), nic.publicIPName, publicIPParameters)
+	if err != nil {
+		return nil, spi.OnARMAPIErrorFail(prometheusServicePublicIP, err, "PublicIP.CreateOrUpdate failed for %s", nic.publicIPName)
+	}
+	if err := future.WaitForCompletionRef(ctx, clients.GetClient()); err != nil {
+		return nil, spi.OnARMAPIErrorFail(prometheusServicePublicIP, err, "PublicIP.WaitForCompletionRef failed for %s", nic.publicIPName)
+	}
+	spi.OnARMAPISuccess(prometheusServicePublicIP, "PublicIP.CreateOrUpdate")
+
+	publicIP, err := clients.GetPublicIP().Get(ctx, nic.resourceGroupName(resourceGroupName), nic.publicIPName, "")
+	if err != nil {
+		return nil, spi.OnARMAPIErrorFail(prometheusServicePublicIP, err, "PublicIP.Get failed for %s", nic.publicIPName)
+	}
+	return &network.PublicIPAddress{ID: publicIP.ID}, nil
+}
+
+// deletePublicIP deletes the public IP address, if it exists
+func deletePublicIP(ctx context.Context, clients spi.AzureDriverClientsInterface, resourceGroupName, publicIPName string) error {
+	future, err := clients.GetPublicIP().Delete(ctx, resourceGroupName, publicIPName)
+	if err != nil {
+		if spi.NotFound(err) {
+			return nil
 		}
+		return spi.OnARMAPIErrorFail(prometheusServicePublicIP, err, "PublicIP.Delete failed for %s", publicIPName)
+	}
+	if err := future.WaitForCompletionRef(ctx, clients.GetClient()); err != nil {
+		return spi.OnARMAPIErrorFail(prometheusServicePublicIP, err, "PublicIP.WaitForCompletionRef failed for %s", publicIPName)
+	}
+	spi.OnARMAPISuccess(prometheusServicePublicIP, "PublicIP deletion was successful for %s", publicIPName)
+	return nil
+}
+
+// isPrivateIPAddressInUse returns true if the error indicates that the requested static private IP address is taken
+func isPrivateIPAddressInUse(err error) bool {
+	code := serviceErrorCode(err)
+	return code == "PrivateIPAddressInUse" || code == "PrivateIPAddressIsAllocated"
+}
+
+// serviceErrorCode returns the code of the Azure service error wrapped in the error, if any
+func serviceErrorCode(err error) string {
+	serviceErr := serviceError(err)
+	if serviceErr == nil {
+		return ""
+	}
+	return serviceErr.Code
+}
 
-		return nil, spi.OnARMAPIErrorFail(prometheusServiceVM, err, "GetVM().CreateOrUpdate failed for %s", *VMParameters.Name)
+// serviceError returns the Azure service error of the error of an Azure API request, or nil if there is none
+func serviceError(err error) *azure.ServiceError {
+	detailedErr, ok := err.(autorest.DetailedError)
+	if !ok {
+		return nil
 	}
 
-	// Wait until VM is created
-	err = VMFuture.WaitForCompletionRef(ctx, clients.GetClient())
+	switch original := detailedErr.Original.(type) {
+	case *azure.RequestError:
+		return original.ServiceError
+	case *azure.ServiceError:
+		return original
+	}
+	return nil
+}
+
+// createNIC creates a network interface in its subnet and returns its ID
+func (d *MachinePlugin) createNIC(ctx context.Context, clients spi.AzureDriverClientsInterface, resourceGroupName string, nic networkInterface) (string, error) {
+	var (
+		vnetName          = nic.subnetInfo.VnetName
+		vnetResourceGroup = resourceGroupName
+		subnetName        = nic.subnetInfo.SubnetName
+	)
+
+	// Check if the NIC should be assigned to a vnet in a different resource group.
+	if nic.subnetInfo.VnetResourceGroup != nil {
+		vnetResourceGroup = *nic.subnetInfo.VnetResourceGroup
+	}
+
+	// Getting the subnet object for subnetName
+	// The vnet may be in another subscription, e.g. the central networking subscription of a hub-and-spoke topology
+	subnet, err := clients.GetSubnetOfSubscription(to.String(nic.subnetInfo.VnetSubscriptionID)).Get(
+		ctx,
+		vnetResourceGroup,
+		vnetName,
+		subnetName,
+		"",
+	)
 	if err != nil {
-		// Since machine creation failed, delete any infra resources created
-		deleteErr := d.deleteVMNicDisks(ctx, clients, resourceGroupName, vmName, nicName, diskName, dataDiskNames)
-		if deleteErr != nil {
-			klog.Errorf("Error occurred during resource clean up: %s", deleteErr)
+		return "", spi.OnARMAPIErrorFail(prometheusServiceSubnet, err, "Subnet.Get failed for %s due to %s", subnetName, err)
+	}
+	spi.OnARMAPISuccess(prometheusServiceSubnet, "subnet.Get")
+	if err := checkSubnet(subnet, nic); err != nil {
+		return "", err
+	}
+	ipv6Subnet, err := d.getIPv6Subnet(ctx, clients, vnetResourceGroup, nic, &subnet)
+	if err != nil {
+		return "", err
+	}
+
+	var publicIP *network.PublicIPAddress
+	if nic.publicIP != nil {
+		if publicIP, err = d.createPublicIP(ctx, clients, resourceGroupName, nic); err != nil {
+			return "", err
 		}
+	}
 
-		return nil, spi.OnARMAPIErrorFail(prometheusServiceVM, err, "VMFuture.WaitForCompletionRef failed for %s", *VMParameters.Name)
+	// Static private IP addresses are tried in order until one is not in use
+	privateIPAddresses := []string{""}
+	if nic.staticPrivateIP {
+		if len(nic.privateIPAddresses) == 0 {
+			return "", status.Error(codes.InvalidArgument, fmt.Sprintf("No private IP address is given for the static allocation of NIC %s", nic.name))
+		}
+		privateIPAddresses = nic.privateIPAddresses
 	}
-	klog.Infof("VM Created in %d", time.Now().Sub(startTime))
 
-	// Fetch VM details
-	VM, err := VMFuture.Result(clients.GetVM().(compute.VirtualMachinesClient))
+	for i, privateIPAddress := range privateIPAddresses {
+		err = d.createOrUpdateNIC(ctx, clients, resourceGroupName, nic, &subnet, ipv6Subnet, privateIPAddress, publicIP)
+		if err == nil {
+			break
+		}
+		if !isPrivateIPAddressInUse(err) {
+			return "", err
+		}
+		if i == len(privateIPAddresses)-1 {
+			return "", status.Error(codes.ResourceExhausted, fmt.Sprintf("All private IP addresses %v of NIC %s are in use", privateIPAddresses, nic.name))
+		}
+		spi.V(2).InfoS(ctx, "Private IP address of NIC is in use, trying the next one", "privateIPAddress", privateIPAddress, "nic", nic.name)
+	}
+
+	// Fetch NIC details
+	NIC, err := clients.GetNic().Get(ctx, nic.resourceGroupName(resourceGroupName), nic.name, "")
 	if err != nil {
-		// Since machine creation failed, delete any infra resources created
-		deleteErr := d.deleteVMNicDisks(ctx, clients, resourceGroupName, vmName, nicName, diskName, dataDiskNames)
-		if deleteErr != nil {
-			klog.Errorf("Error occurred during resource clean up: %s", deleteErr)
+		return "", spi.OnARMAPIErrorFail(prometheusServiceNIC, err, "NIC.Get failed for %s", nic.name)
+	}
+	return *NIC.ID, nil
+}
+
+// tagDisks applies the tags of the provider spec to the given disks
+func (d *MachinePlugin) tagDisks(ctx context.Context, clients spi.AzureDriverClientsInterface, resourceGroupName string, diskNames []string) error {
+	// The disks are marked as created by the provider, to distinguish them from the disks of persistent volumes
+	tags := getAzureTags(d.AzureProviderSpec.Tags)
+	tags[spi.DiskManagedByTagKey] = to.StringPtr(spi.DiskManagedByTagValue)
+
+	var taggers []func() error
+	for _, diskName := range diskNames {
+		diskName := diskName
+		taggers = append(taggers, func() error {
+			return spi.UpdateDiskTags(ctx, clients, resourceGroupName, diskName, tags)
+		})
+	}
+	return spi.RunInParallel(taggers)
+}
+
+// vmResources returns the NICs and data disks of the VM model in addition to the ones derived from the naming
+// convention, so that VMs created by older provider versions or adopted VMs are fully cleaned up. The OS disk of the
+// model is returned as a data disk if it is named differently. NICs in other resource groups than the ones of the VM
+// and the given NICs are skipped, as are disks in other resource groups, data disks attached to the VM, e.g. the disks
+// of persistent volumes, and disks retained in the context.
+func vmResources(ctx context.Context, vm compute.VirtualMachine, resourceGroupName string, networkInterfaces []networkInterface, diskName string, dataDiskNames []string) ([]networkInterface, []string) {
+	if vm.VirtualMachineProperties == nil {
+		return networkInterfaces, dataDiskNames
+	}
+
+	var (
+		knownNICs         = map[string]bool{}
+		nicResourceGroups = map[string]bool{strings.ToLower(resourceGroupName): true}
+		knownDisks        = map[string]bool{strings.ToLower(diskName): true}
+		vmName            = to.String(vm.Name)
+	)
+	for _, nic := range networkInterfaces {
+		knownNICs[strings.ToLower(nic.name)] = true
+		nicResourceGroups[strings.ToLower(nic.resourceGroupName(resourceGroupName))] = true
+	}
+	for _, name := range dataDiskNames {
+		knownDisks[strings.ToLower(name)] = true
+	}
+
+	addDisk := func(managedDisk *compute.ManagedDiskParameters) {
+		if managedDisk == nil || managedDisk.ID == nil {
+			return
+		}
+		resource, err := azure.ParseResourceID(*managedDisk.ID)
+		if err != nil || !strings.EqualFold(resource.ResourceGroup, resourceGroupName) || knownDisks[strings.ToLower(resource.ResourceName)] || isRetainedDisk(ctx, resource.ResourceName) {
+			return
 		}
+		knownDisks[strings.ToLower(resource.ResourceName)] = true
+		dataDiskNames = append(dataDiskNames, resource.ResourceName)
+		spi.InfoS(ctx, "Disk of VM does not follow the naming convention, deleting it as well", "disk", resource.ResourceName)
+	}
 
-		return nil, spi.OnARMAPIErrorFail(prometheusServiceVM, err, "VMFuture.Result failed for %s", *VMParameters.Name)
+	if vm.StorageProfile != nil {
+		if vm.StorageProfile.OsDisk != nil {
+			addDisk(vm.StorageProfile.OsDisk.ManagedDisk)
+		}
+		if vm.StorageProfile.DataDisks != nil {
+			for _, dataDisk := range *vm.StorageProfile.DataDisks {
+				if dataDisk.CreateOption != compute.DiskCreateOptionTypesAttach {
+					addDisk(dataDisk.ManagedDisk)
+				}
+			}
+		}
 	}
-	spi.OnARMAPISuccess(prometheusServiceVM, "VM.CreateOrUpdate")
 
-	return &VM, nil
+	if vm.NetworkProfile != nil && vm.NetworkProfile.NetworkInterfaces != nil {
+		for _, reference := range *vm.NetworkProfile.NetworkInterfaces {
+			if reference.ID == nil {
+				continue
+			}
+			resource, err := azure.ParseResourceID(*reference.ID)
+			if err != nil || !nicResourceGroups[strings.ToLower(resource.ResourceGroup)] || knownNICs[strings.ToLower(resource.ResourceName)] {
+				continue
+			}
+			knownNICs[strings.ToLower(resource.ResourceName)] = true
+			nic := networkInterface{name: resource.ResourceName, vmName: vmName}
+			if !strings.EqualFold(resource.ResourceGroup, resourceGroupName) {
+				nic.resourceGroup = resource.ResourceGroup
+			}
+			networkInterfaces = append(networkInterfaces, nic)
+			spi.InfoS(ctx, "NIC of VM does not follow the naming convention, deleting it as well", "nic", resource.ResourceName)
+		}
+	}
+
+	return networkInterfaces, dataDiskNames
+}
+
+// rollbackVMNicDisks deletes the resources created by a failed machine creation. The deletion is detached from the
+// context of the creation, which may be cancelled or timed out already, and bounded by its own timeout instead.
+func (d *MachinePlugin) rollbackVMNicDisks(ctx context.Context, clients spi.AzureDriverClientsInterface, resourceGroupName string, vmName string, networkInterfaces []networkInterface, diskName string, dataDiskNames []string) {
+	timeout := d.deleteTimeout
+	if timeout <= 0 {
+		timeout = rollbackTimeout
+	}
+	ctx, cancel := context.WithTimeout(detachedContext{ctx}, timeout)
+	defer cancel()
+
+	if err := d.deleteVMNicDisks(ctx, clients, resourceGroupName, vmName, networkInterfaces, diskName, dataDiskNames); err != nil {
+		spi.ErrorS(ctx, err, "Error occurred during resource clean up")
+	}
 }
 
 // deleteVMNicDisks deletes the VM and associated Disks and NIC
-func (d *MachinePlugin) deleteVMNicDisks(ctx context.Context, clients spi.AzureDriverClientsInterface, resourceGroupName string, VMName string, nicName string, diskName string, dataDiskNames []string) error {
+func (d *MachinePlugin) deleteVMNicDisks(ctx context.Context, clients spi.AzureDriverClientsInterface, resourceGroupName string, VMName string, networkInterfaces []networkInterface, diskName string, dataDiskNames []string) error {
 
 	// We try to fetch the VM, detach its data disks and finally delete it
 	if vm, vmErr := clients.GetVM().Get(ctx, resourceGroupName, VMName, ""); vmErr == nil {
+		networkInterfaces, dataDiskNames = vmResources(ctx, vm, resourceGroupName, networkInterfaces, diskName, dataDiskNames)
+
+		// The graceful shutdown is bounded by its own timeout, so that it does not consume the one of the deletion
+		d.shutDownVM(ctx, clients, resourceGroupName, VMName)
 
-		spi.WaitForDataDiskDetachment(ctx, clients, resourceGroupName, vm)
-		if deleteErr := spi.DeleteVM(ctx, clients, resourceGroupName, VMName); deleteErr != nil {
-			return deleteErr
+		vmCtx, cancel := withOperationTimeout(ctx, d.deleteTimeout)
+		defer cancel()
+		spi.WaitForDataDiskDetachment(vmCtx, clients, resourceGroupName, vm)
+		if deleteErr := spi.DeleteVM(vmCtx, clients, resourceGroupName, VMName); deleteErr != nil {
+			return operationTimeoutError(vmCtx, deleteErr)
 		}
 
 		spi.OnARMAPISuccess(prometheusServiceVM, "VM Get was successful for %s", *vm.Name)
@@ -501,25 +1310,17 @@ func (d *MachinePlugin) deleteVMNicDisks(ctx context.Context, clients spi.AzureD
 		return spi.OnARMAPIErrorFail(prometheusServiceVM, vmErr, "vm.Get")
 	}
 
-	// Fetch the NIC and deleted it
-	nicDeleter := func() error {
-		if vmHoldingNic, err := spi.FetchAttachedVMfromNIC(ctx, clients, resourceGroupName, nicName); err != nil {
-			if spi.NotFound(err) {
-				// Resource doesn't exist, no need to delete
-				return nil
-			}
-			return err
-		} else if vmHoldingNic != "" {
-			return fmt.Errorf("Cannot delete NIC %s because it is attached to VM %s", nicName, vmHoldingNic)
-		}
-
-		return spi.DeleteNIC(ctx, clients, resourceGroupName, nicName)
-	}
+	// The disks and NICs are deleted in parallel, so that their deletion shares the timeout
+	ctx, cancelDeletion := withOperationTimeout(ctx, d.deleteTimeout)
+	defer cancelDeletion()
 
 	// Fetch the system disk and delete it
-	diskDeleter := spi.GetDeleterForDisk(ctx, clients, resourceGroupName, diskName)
+	deleters := []func() error{spi.GetDeleterForDisk(ctx, clients, resourceGroupName, diskName)}
 
-	deleters := []func() error{nicDeleter, diskDeleter}
+	// Fetch the NICs and delete them together with their public IPs
+	for _, nic := range networkInterfaces {
+		deleters = append(deleters, d.getDeleterForNIC(ctx, clients, resourceGroupName, nic))
+	}
 
 	if dataDiskNames != nil {
 		for _, dataDiskName := range dataDiskNames {
@@ -528,25 +1329,66 @@ func (d *MachinePlugin) deleteVMNicDisks(ctx context.Context, clients spi.AzureD
 		}
 	}
 
-	return spi.RunInParallel(deleters)
+	return operationTimeoutError(ctx, spi.RunInParallel(deleters))
 }
 
+// getDeleterForNIC returns a function deleting the NIC and afterwards its public IP, unless the NIC is still attached to a VM
+func (d *MachinePlugin) getDeleterForNIC(ctx context.Context, clients spi.AzureDriverClientsInterface, resourceGroupName string, nic networkInterface) func() error {
+	resourceGroupName = nic.resourceGroupName(resourceGroupName)
+	return func() error {
+		if NIC, err := clients.GetNic().Get(ctx, resourceGroupName, nic.name, ""); err != nil {
+			if !spi.NotFound(err) {
+				return err
+			}
+			// NIC doesn't exist, no need to delete
+			d.forgetReservedNIC(resourceGroupName, nic.name)
+		} else if NIC.VirtualMachine != nil {
+			return fmt.Errorf("Cannot delete NIC %s because it is attached to VM %s", nic.name, *NIC.VirtualMachine.ID)
+		} else if err := removeForeignReferences(ctx, clients, resourceGroupName, NIC, nic); err != nil {
+			return err
+		} else if err := d.deleteReservedNIC(ctx, clients, resourceGroupName, nic.name, func() error {
+			return spi.DeleteNIC(ctx, clients, resourceGroupName, nic.name)
+		}); err != nil {
+			return err
+		}
+
+		// The public IP can only be deleted once it is no longer attached to the NIC. It is deleted even if the provider
+		// spec does not configure one anymore, as it may have been created before the public IP was switched off.
+		if nic.publicIPName == "" {
+			return nil
+		}
+		return deletePublicIP(ctx, clients, resourceGroupName, nic.publicIPName)
+	}
+}
+
+// fillUpMachineClass fills up the machine class with the provider spec, secret references and metadata of the
+// AzureMachineClass. The labels and annotations of both classes are merged, with the ones of the AzureMachineClass
+// taking precedence. The defaults are filled into the generated provider spec, which is validated without the secret.
 func fillUpMachineClass(azureMachineClass *v1alpha1.AzureMachineClass, machineClass *v1alpha1.MachineClass) error {
 	var (
-		err        error
 		properties api.AzureVirtualMachineProperties
 		subnetInfo api.AzureSubnetInfo
 	)
 
 	// Extract the Properties object from the AzureMachineClass
 	// to fill it up in the MachineClass
-	data, _ := json.Marshal(azureMachineClass.Spec.Properties)
-	err = json.Unmarshal(data, &properties)
+	data, err := json.Marshal(azureMachineClass.Spec.Properties)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	if err := json.Unmarshal(data, &properties); err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
 
 	// Extract the Subnet Info object form the AzureMachineClass
 	// to fill it up in the MachineClass
-	data, _ = json.Marshal(azureMachineClass.Spec.SubnetInfo)
-	err = json.Unmarshal(data, &subnetInfo)
+	data, err = json.Marshal(azureMachineClass.Spec.SubnetInfo)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	if err := json.Unmarshal(data, &subnetInfo); err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
 
 	providerSpec := &api.AzureProviderSpec{
 		Location:      azureMachineClass.Spec.Location,
@@ -555,6 +1397,10 @@ func fillUpMachineClass(azureMachineClass *v1alpha1.AzureMachineClass, machineCl
 		ResourceGroup: azureMachineClass.Spec.ResourceGroup,
 		SubnetInfo:    subnetInfo,
 	}
+	defaults.SetDefaults(providerSpec)
+	if errs := validation.ValidateAzureProviderSpec(providerSpec); len(errs) > 0 {
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("Error while validating ProviderSpec %v", errs))
+	}
 
 	// Marshal providerSpec into Raw Bytes
 	providerSpecMarshal, err := json.Marshal(providerSpec)
@@ -565,12 +1411,28 @@ func fillUpMachineClass(azureMachineClass *v1alpha1.AzureMachineClass, machineCl
 	machineClass.SecretRef = azureMachineClass.Spec.SecretRef
 	machineClass.CredentialsSecretRef = azureMachineClass.Spec.CredentialsSecretRef
 	machineClass.Name = azureMachineClass.Name
-	machineClass.Labels = azureMachineClass.Labels
-	machineClass.Annotations = azureMachineClass.Annotations
+	machineClass.Labels = mergeStringMaps(machineClass.Labels, azureMachineClass.Labels)
+	machineClass.Annotations = mergeStringMaps(machineClass.Annotations, azureMachineClass.Annotations)
 	machineClass.Finalizers = azureMachineClass.Finalizers
+	machineClass.Provider = ProviderName
 	machineClass.ProviderSpec = runtime.RawExtension{
 		Raw: providerSpecMarshal,
 	}
 
-	return err
+	return nil
+}
+
+// mergeStringMaps returns a new map with the entries of all given maps, with later maps taking precedence, or nil if
+// all maps are empty
+func mergeStringMaps(maps ...map[string]string) map[string]string {
+	var result map[string]string
+	for _, m := range maps {
+		for key, value := range m {
+			if result == nil {
+				result = map[string]string{}
+			}
+			result[key] = value
+		}
+	}
+	return result
 }
@@ -0,0 +1,463 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-08-01/compute"
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-04-01/network"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/go-autorest/autorest/to"
+	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/mock"
+	"github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/codes"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/status"
+	gomock "github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var _ = Describe("Utils", func() {
+
+	Describe("#applyPrivateIPAddressPool", func() {
+		var (
+			providerSpec *api.AzureProviderSpec
+			machine      *v1alpha1.Machine
+		)
+
+		BeforeEach(func() {
+			providerSpec = &api.AzureProviderSpec{}
+			providerSpec.Properties.NetworkProfile.PrivateIPAllocationMethod = api.PrivateIPAllocationMethodStatic
+			providerSpec.Properties.NetworkProfile.PrivateIPAddress = to.StringPtr("10.250.0.4")
+			machine = &v1alpha1.Machine{}
+			machine.Name = "machine"
+		})
+
+		It("should keep the address of the provider spec without annotation", func() {
+			networkInterfaces := getNetworkInterfaces(providerSpec, machine.Name)
+
+			Expect(applyPrivateIPAddressPool(networkInterfaces, machine)).To(Succeed())
+			Expect(networkInterfaces[0].staticPrivateIP).To(BeTrue())
+			Expect(networkInterfaces[0].privateIPAddresses).To(Equal([]string{"10.250.0.4"}))
+		})
+
+		It("should prefer the address pool of the machine", func() {
+			machine.Annotations = map[string]string{api.MachineAnnotationPrivateIPAddressPool: "10.250.0.10, 10.250.0.11"}
+			networkInterfaces := getNetworkInterfaces(providerSpec, machine.Name)
+
+			Expect(applyPrivateIPAddressPool(networkInterfaces, machine)).To(Succeed())
+			Expect(networkInterfaces[0].privateIPAddresses).To(Equal([]string{"10.250.0.10", "10.250.0.11"}))
+		})
+
+		It("should reject invalid addresses", func() {
+			machine.Annotations = map[string]string{api.MachineAnnotationPrivateIPAddressPool: "10.250.0.10,foo"}
+
+			Expect(applyPrivateIPAddressPool(getNetworkInterfaces(providerSpec, machine.Name), machine)).NotTo(Succeed())
+		})
+	})
+
+	Describe("#getNetworkInterfaces", func() {
+		It("should inherit the vnet subscription of the subnet info of the provider spec", func() {
+			providerSpec := &api.AzureProviderSpec{SubnetInfo: api.AzureSubnetInfo{VnetName: "hub", VnetSubscriptionID: to.StringPtr("networking")}}
+			providerSpec.Properties.NetworkProfile.Interfaces = []api.AzureNetworkInterface{
+				{Primary: true, SubnetInfo: api.AzureSubnetInfo{SubnetName: "nodes"}},
+				{Name: "secondary", SubnetInfo: api.AzureSubnetInfo{VnetName: "spoke", SubnetName: "nodes"}},
+			}
+			networkInterfaces := getNetworkInterfaces(providerSpec, "machine-0")
+
+			Expect(networkInterfaces[0].subnetInfo.VnetSubscriptionID).To(Equal(to.StringPtr("networking")))
+			Expect(networkInterfaces[1].subnetInfo.VnetSubscriptionID).To(BeNil())
+		})
+	})
+
+	Describe("#nicResourceGroupOf", func() {
+		It("should default to the resource group of the VMs", func() {
+			providerSpec := &api.AzureProviderSpec{ResourceGroup: "rg"}
+			Expect(nicResourceGroupOf(providerSpec)).To(Equal("rg"))
+			Expect(getNetworkInterfaces(providerSpec, "machine-0")[0].resourceGroupName("rg")).To(Equal("rg"))
+
+			providerSpec.NICResourceGroup = to.StringPtr("network-rg")
+			Expect(nicResourceGroupOf(providerSpec)).To(Equal("network-rg"))
+			Expect(getNetworkInterfaces(providerSpec, "machine-0")[0].resourceGroupName("rg")).To(Equal("network-rg"))
+		})
+	})
+
+	Describe("#getNICParameters", func() {
+		It("should associate the security groups with the NIC", func() {
+			providerSpec := &api.AzureProviderSpec{Location: "westeurope"}
+			providerSpec.Properties.NetworkProfile.NetworkSecurityGroup = &api.AzureSubResource{ID: "nsg-id"}
+			providerSpec.Properties.NetworkProfile.ApplicationSecurityGroups = []api.AzureSubResource{{ID: "asg-id"}}
+			driver := &MachinePlugin{AzureProviderSpec: providerSpec}
+
+			nic := driver.getNICParameters(getNetworkInterfaces(providerSpec, "machine-0")[0], &network.Subnet{}, nil, "", nil)
+			Expect(*nic.NetworkSecurityGroup.ID).To(Equal("nsg-id"))
+			Expect(*(*(*nic.IPConfigurations)[0].ApplicationSecurityGroups)[0].ID).To(Equal("asg-id"))
+		})
+
+		It("should add the IPv4 IP configuration to the backend pools", func() {
+			providerSpec := &api.AzureProviderSpec{Location: "westeurope"}
+			providerSpec.Properties.NetworkProfile.LoadBalancerBackendAddressPools = []api.AzureSubResource{{ID: "lb-pool"}}
+			providerSpec.Properties.NetworkProfile.ApplicationGatewayBackendAddressPools = []api.AzureSubResource{{ID: "agw-pool"}}
+			providerSpec.Properties.NetworkProfile.IPv6 = &api.AzureIPv6Configuration{}
+			driver := &MachinePlugin{AzureProviderSpec: providerSpec}
+
+			nic := driver.getNICParameters(getNetworkInterfaces(providerSpec, "machine-0")[0], &network.Subnet{}, &network.Subnet{}, "", nil)
+			ipv4, ipv6 := (*nic.IPConfigurations)[0], (*nic.IPConfigurations)[1]
+			Expect(*ipv4.LoadBalancerBackendAddressPools).To(Equal([]network.BackendAddressPool{{ID: to.StringPtr("lb-pool")}}))
+			Expect(*ipv4.ApplicationGatewayBackendAddressPools).To(Equal([]network.ApplicationGatewayBackendAddressPool{{ID: to.StringPtr("agw-pool")}}))
+			Expect(ipv6.LoadBalancerBackendAddressPools).To(BeNil())
+		})
+
+		It("should render the IPv6 IP configuration of dual-stack interfaces", func() {
+			providerSpec := &api.AzureProviderSpec{Location: "westeurope"}
+			providerSpec.Properties.NetworkProfile.IPv6 = &api.AzureIPv6Configuration{}
+			driver := &MachinePlugin{AzureProviderSpec: providerSpec}
+
+			nic := driver.getNICParameters(getNetworkInterfaces(providerSpec, "machine-0")[0], &network.Subnet{ID: to.StringPtr("ipv4")}, &network.Subnet{ID: to.StringPtr("ipv6")}, "", nil)
+			Expect(*nic.IPConfigurations).To(HaveLen(2))
+			ipv6 := (*nic.IPConfigurations)[1]
+			Expect(*ipv6.Name).To(Equal("machine-0-nic-ipv6"))
+			Expect(ipv6.PrivateIPAddressVersion).To(Equal(network.IPv6))
+			Expect(ipv6.PrivateIPAllocationMethod).To(Equal(network.Dynamic))
+			Expect(*ipv6.Subnet.ID).To(Equal("ipv6"))
+			Expect((*nic.IPConfigurations)[0].PrivateIPAddressVersion).To(BeEmpty())
+		})
+
+		It("should enable IP forwarding unless disabled by the interface", func() {
+			providerSpec := &api.AzureProviderSpec{Location: "westeurope"}
+			providerSpec.Properties.NetworkProfile.Interfaces = []api.AzureNetworkInterface{
+				{Primary: true},
+				{Name: "secondary", EnableIPForwarding: to.BoolPtr(false)},
+			}
+			driver := &MachinePlugin{AzureProviderSpec: providerSpec}
+			networkInterfaces := getNetworkInterfaces(providerSpec, "machine-0")
+
+			Expect(*driver.getNICParameters(networkInterfaces[0], &network.Subnet{}, nil, "", nil).EnableIPForwarding).To(BeTrue())
+			Expect(*driver.getNICParameters(networkInterfaces[1], &network.Subnet{}, nil, "", nil).EnableIPForwarding).To(BeFalse())
+		})
+	})
+
+	Describe("#generateDataDisks", func() {
+		It("should assign LUNs starting at the offset to data disks without LUN", func() {
+			dataDisks := []api.AzureDataDisk{{Name: "data"}, {Name: "logs", Lun: to.Int32Ptr(10)}, {}}
+
+			disks := (&MachinePlugin{}).generateDataDisks("machine", dataDisks, 4)
+			Expect(disks).To(HaveLen(3))
+			Expect(*disks[0].Lun).To(Equal(int32(4)))
+			Expect(*disks[1].Lun).To(Equal(int32(10)))
+			Expect(*disks[2].Lun).To(Equal(int32(6)))
+			Expect(getAzureDataDiskNames(suffixNamingStrategy{}, dataDisks, 4, "machine")).To(Equal([]string{*disks[0].Name, *disks[1].Name, *disks[2].Name}))
+		})
+
+		It("should attach existing disks and create disks from the image", func() {
+			diskID := "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Compute/disks/existing"
+			dataDisks := []api.AzureDataDisk{
+				{Name: "data", Lun: to.Int32Ptr(0), DiskSizeGB: 10, StorageAccountType: "Premium_LRS"},
+				{Lun: to.Int32Ptr(1), CreateOption: api.DataDiskCreateOptionAttach, ManagedDiskID: to.StringPtr(diskID)},
+				{Name: "image", Lun: to.Int32Ptr(2), CreateOption: api.DataDiskCreateOptionFromImage},
+			}
+			driver := &MachinePlugin{AzureProviderSpec: &api.AzureProviderSpec{}}
+
+			disks := driver.generateDataDisks("machine", dataDisks, 0)
+			Expect(disks[0].CreateOption).To(Equal(compute.DiskCreateOptionTypesEmpty))
+			Expect(disks[1].CreateOption).To(Equal(compute.DiskCreateOptionTypesAttach))
+			Expect(*disks[1].Name).To(Equal("existing"))
+			Expect(disks[1].ManagedDisk).To(Equal(&compute.ManagedDiskParameters{ID: to.StringPtr(diskID)}))
+			Expect(disks[1].DiskSizeGB).To(BeNil())
+			Expect(disks[2].CreateOption).To(Equal(compute.DiskCreateOptionTypesFromImage))
+			Expect(disks[2].DiskSizeGB).To(BeNil())
+			Expect(disks[2].ManagedDisk.StorageAccountType).To(BeEmpty())
+
+			Expect(getAzureDataDiskNames(suffixNamingStrategy{}, dataDisks, 0, "machine")).To(Equal([]string{*disks[0].Name, *disks[2].Name}))
+		})
+	})
+
+	Describe("#vmResources", func() {
+		id := func(resourceGroup, provider, name string) *string {
+			return to.StringPtr("/subscriptions/sub/resourceGroups/" + resourceGroup + "/providers/Microsoft." + provider + "/" + name)
+		}
+
+		It("should add the NICs and disks of the VM model which do not follow the naming convention", func() {
+			vm := compute.VirtualMachine{
+				Name: to.StringPtr("machine"),
+				VirtualMachineProperties: &compute.VirtualMachineProperties{
+					StorageProfile: &compute.StorageProfile{
+						OsDisk: &compute.OSDisk{ManagedDisk: &compute.ManagedDiskParameters{ID: id("RG", "Compute", "disks/legacy-os")}},
+						DataDisks: &[]compute.DataDisk{
+							{CreateOption: compute.DiskCreateOptionTypesEmpty, ManagedDisk: &compute.ManagedDiskParameters{ID: id("rg", "Compute", "disks/machine-data-disk")}},
+							{CreateOption: compute.DiskCreateOptionTypesEmpty, ManagedDisk: &compute.ManagedDiskParameters{ID: id("rg", "Compute", "disks/legacy-data")}},
+							{CreateOption: compute.DiskCreateOptionTypesAttach, ManagedDisk: &compute.ManagedDiskParameters{ID: id("rg", "Compute", "disks/pv")}},
+							{CreateOption: compute.DiskCreateOptionTypesEmpty, ManagedDisk: &compute.ManagedDiskParameters{ID: id("other", "Compute", "disks/foreign")}},
+						},
+					},
+					NetworkProfile: &compute.NetworkProfile{NetworkInterfaces: &[]compute.NetworkInterfaceReference{
+						{ID: id("rg", "Network", "networkInterfaces/Machine-NIC")},
+						{ID: id("rg", "Network", "networkInterfaces/legacy-nic")},
+						{ID: id("other", "Network", "networkInterfaces/foreign-nic")},
+					}},
+				},
+			}
+
+			networkInterfaces, dataDiskNames := vmResources(context.Background(), vm, "rg", []networkInterface{{name: "machine-nic", vmName: "machine", primary: true}}, "machine-os-disk", []string{"machine-data-disk"})
+			Expect(networkInterfaces).To(Equal([]networkInterface{{name: "machine-nic", vmName: "machine", primary: true}, {name: "legacy-nic", vmName: "machine"}}))
+			Expect(dataDiskNames).To(Equal([]string{"machine-data-disk", "legacy-os", "legacy-data"}))
+		})
+
+		It("should return the NICs of the VM model in the resource group of the NICs", func() {
+			vm := compute.VirtualMachine{
+				Name: to.StringPtr("machine"),
+				VirtualMachineProperties: &compute.VirtualMachineProperties{
+					NetworkProfile: &compute.NetworkProfile{NetworkInterfaces: &[]compute.NetworkInterfaceReference{
+						{ID: id("network-rg", "Network", "networkInterfaces/machine-nic")},
+						{ID: id("Network-RG", "Network", "networkInterfaces/legacy-nic")},
+						{ID: id("other", "Network", "networkInterfaces/foreign-nic")},
+					}},
+				},
+			}
+
+			networkInterfaces, _ := vmResources(context.Background(), vm, "rg", []networkInterface{{name: "machine-nic", vmName: "machine", primary: true, resourceGroup: "network-rg"}}, "machine-os-disk", nil)
+			Expect(networkInterfaces).To(Equal([]networkInterface{
+				{name: "machine-nic", vmName: "machine", primary: true, resourceGroup: "network-rg"},
+				{name: "legacy-nic", vmName: "machine", resourceGroup: "Network-RG"},
+			}))
+		})
+
+		It("should return the resources of the naming convention if the VM model is empty", func() {
+			networkInterfaces, dataDiskNames := vmResources(context.Background(), compute.VirtualMachine{}, "rg", []networkInterface{{name: "machine-nic"}}, "machine-os-disk", nil)
+			Expect(networkInterfaces).To(Equal([]networkInterface{{name: "machine-nic"}}))
+			Expect(dataDiskNames).To(BeEmpty())
+		})
+	})
+
+	Describe("#resolveDataDiskLunOffset", func() {
+		var (
+			ctx       = context.Background()
+			clients   *mock.AzureDriverClients
+			naming    = suffixNamingStrategy{}
+			dataDisks = []api.AzureDataDisk{{Name: "logs"}, {Name: "fixed", Lun: to.Int32Ptr(0)}, {Name: "cache"}}
+		)
+
+		BeforeEach(func() {
+			sp := mock.NewMockPluginSPIImpl(gomock.NewController(GinkgoT()))
+			_, secret := newProviderSpecCacheFixtures()
+			driverClients, err := sp.Setup(secret, nil)
+			Expect(err).NotTo(HaveOccurred())
+			clients = driverClients.(*mock.AzureDriverClients)
+		})
+
+		vmWithDataDisks := func(offset int32) compute.VirtualMachine {
+			return compute.VirtualMachine{VirtualMachineProperties: &compute.VirtualMachineProperties{StorageProfile: &compute.StorageProfile{DataDisks: &[]compute.DataDisk{
+				{Lun: to.Int32Ptr(0), Name: to.StringPtr(naming.DataDiskName("machine", "fixed", 0))},
+				{Lun: to.Int32Ptr(offset), Name: to.StringPtr(naming.DataDiskName("machine", "logs", offset))},
+				{Lun: to.Int32Ptr(offset + 2), Name: to.StringPtr(naming.DataDiskName("machine", "cache", offset+2))},
+			}}}}
+		}
+
+		It("should resolve the offset the data disks of the VM were created with", func() {
+			clients.VM.EXPECT().Get(ctx, "rg", "machine", compute.InstanceViewTypes("")).Return(vmWithDataDisks(4), nil)
+
+			offset, err := resolveDataDiskLunOffset(ctx, clients, "rg", "machine", naming, dataDisks, 8)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(offset).To(Equal(int32(4)))
+			Expect(getAzureDataDiskNames(naming, dataDisks, offset, "machine")).To(ContainElement("machine-logs-4-data-disk"))
+		})
+
+		It("should keep the offset of the machine class if it matches the VM or the VM is gone", func() {
+			clients.VM.EXPECT().Get(ctx, "rg", "machine", compute.InstanceViewTypes("")).Return(vmWithDataDisks(8), nil)
+			Expect(resolveDataDiskLunOffset(ctx, clients, "rg", "machine", naming, dataDisks, 8)).To(Equal(int32(8)))
+
+			clients.VM.EXPECT().Get(ctx, "rg", "machine", compute.InstanceViewTypes("")).Return(compute.VirtualMachine{}, autorest.DetailedError{Response: &http.Response{StatusCode: http.StatusNotFound}})
+			Expect(resolveDataDiskLunOffset(ctx, clients, "rg", "machine", naming, dataDisks, 8)).To(Equal(int32(8)))
+		})
+
+		It("should not read the VM if all data disks have a LUN", func() {
+			Expect(resolveDataDiskLunOffset(ctx, clients, "rg", "machine", naming, []api.AzureDataDisk{{Name: "fixed", Lun: to.Int32Ptr(0)}}, 8)).To(Equal(int32(8)))
+		})
+
+		It("should fail if the VM cannot be read", func() {
+			clients.VM.EXPECT().Get(ctx, "rg", "machine", compute.InstanceViewTypes("")).Return(compute.VirtualMachine{}, errors.New("failed"))
+
+			_, err := resolveDataDiskLunOffset(ctx, clients, "rg", "machine", naming, dataDisks, 8)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("#getPublicIPParameters", func() {
+		It("should default to a static standard public IP with the rendered DNS label", func() {
+			providerSpec := &api.AzureProviderSpec{Location: "westeurope"}
+			providerSpec.Properties.NetworkProfile.PublicIP = &api.AzurePublicIP{DNSLabelTemplate: to.StringPtr("dev-{{ .MachineName }}")}
+			driver := &MachinePlugin{AzureProviderSpec: providerSpec}
+
+			publicIP, err := driver.getPublicIPParameters(getNetworkInterfaces(providerSpec, "machine-0")[0])
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*publicIP.Name).To(Equal("machine-0-pip"))
+			Expect(publicIP.Sku.Name).To(Equal(network.PublicIPAddressSkuNameStandard))
+			Expect(publicIP.PublicIPAllocationMethod).To(Equal(network.Static))
+			Expect(*publicIP.DNSSettings.DomainNameLabel).To(Equal("dev-machine-0"))
+		})
+	})
+
+	Describe("#renderDNSLabel", func() {
+		It("should reject labels which Azure does not accept", func() {
+			for _, template := range []string{"{{ .MachineName }}.dev", "0-{{ .MachineName }}", "{{ .MachineName }}-", "a" + strings.Repeat("b", 63)} {
+				_, err := renderDNSLabel(template, "machine-0")
+				Expect(err).To(HaveOccurred(), template)
+				Expect(err.(*status.Status).Code()).To(Equal(codes.InvalidArgument))
+			}
+		})
+	})
+
+	Describe("#getDiskEncryptionSet", func() {
+		It("should reference the disk encryption set", func() {
+			id := "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Compute/diskEncryptionSets/des"
+			Expect(*getDiskEncryptionSet(&id).ID).To(Equal(id))
+		})
+
+		It("should keep platform-managed keys without an ID", func() {
+			Expect(getDiskEncryptionSet(nil)).To(BeNil())
+		})
+	})
+
+	Describe("#getSSHPublicKeys", func() {
+		It("should render a single key object and a list of keys", func() {
+			var single, list api.AzureOSProfile
+			Expect(json.Unmarshal([]byte(`{"adminUsername":"core","linuxConfiguration":{"ssh":{"publicKeys":{"keyData":"old"}}}}`), &single)).To(Succeed())
+			Expect(json.Unmarshal([]byte(`{"adminUsername":"core","linuxConfiguration":{"ssh":{"publicKeys":[{"keyData":"old"},{"keyData":"new"}]}}}`), &list)).To(Succeed())
+
+			Expect(*getSSHPublicKeys(single, nil)).To(Equal([]compute.SSHPublicKey{
+				{Path: to.StringPtr("/home/core/.ssh/authorized_keys"), KeyData: to.StringPtr("old")},
+			}))
+			Expect(*getSSHPublicKeys(list, nil)).To(Equal([]compute.SSHPublicKey{
+				{Path: to.StringPtr("/home/core/.ssh/authorized_keys"), KeyData: to.StringPtr("old")},
+				{Path: to.StringPtr("/home/core/.ssh/authorized_keys"), KeyData: to.StringPtr("new")},
+			}))
+		})
+
+		It("should keep the paths of additional keys", func() {
+			var osProfile api.AzureOSProfile
+			Expect(json.Unmarshal([]byte(`{"adminUsername":"core","linuxConfiguration":{"ssh":{"publicKeys":[{"keyData":"inline"}],"additionalPublicKeys":[{"path":"/home/core/.ssh/break_glass_keys","keyData":"break-glass"}]}}}`), &osProfile)).To(Succeed())
+
+			Expect(*getSSHPublicKeys(osProfile, nil)).To(Equal([]compute.SSHPublicKey{
+				{Path: to.StringPtr("/home/core/.ssh/authorized_keys"), KeyData: to.StringPtr("inline")},
+				{Path: to.StringPtr("/home/core/.ssh/break_glass_keys"), KeyData: to.StringPtr("break-glass")},
+			}))
+		})
+
+		It("should add the keys of the machine class secret", func() {
+			var osProfile api.AzureOSProfile
+			Expect(json.Unmarshal([]byte(`{"adminUsername":"core","linuxConfiguration":{"ssh":{"publicKeys":[{"keyData":"inline"}],"publicKeysSecretKey":"sshKeys"}}}`), &osProfile)).To(Succeed())
+			secret := &corev1.Secret{Data: map[string][]byte{"sshKeys": []byte("# rotated\nssh-rsa first\n\nssh-ed25519 second\ninline\n")}}
+
+			Expect(*getSSHPublicKeys(osProfile, secret)).To(Equal([]compute.SSHPublicKey{
+				{Path: to.StringPtr("/home/core/.ssh/authorized_keys"), KeyData: to.StringPtr("inline")},
+				{Path: to.StringPtr("/home/core/.ssh/authorized_keys"), KeyData: to.StringPtr("ssh-rsa first")},
+				{Path: to.StringPtr("/home/core/.ssh/authorized_keys"), KeyData: to.StringPtr("ssh-ed25519 second")},
+			}))
+		})
+	})
+
+	Describe("#getVMParameters", func() {
+		var plugin *MachinePlugin
+
+		BeforeEach(func() {
+			providerSpec := &api.AzureProviderSpec{}
+			Expect(json.Unmarshal(mock.AzureProviderSpec, providerSpec)).To(Succeed())
+			plugin = &MachinePlugin{AzureProviderSpec: providerSpec}
+		})
+
+		It("should keep the defaults of the VM agent and extension operations", func() {
+			vm := plugin.getVMParameters(context.Background(), "machine", nil, nil, nil, nil)
+			Expect(vm.OsProfile.AllowExtensionOperations).To(BeNil())
+			Expect(vm.OsProfile.LinuxConfiguration.ProvisionVMAgent).To(BeNil())
+		})
+
+		It("should disable the VM agent and extension operations", func() {
+			plugin.AzureProviderSpec.Properties.OsProfile.ProvisionVMAgent = to.BoolPtr(false)
+			plugin.AzureProviderSpec.Properties.OsProfile.AllowExtensionOperations = to.BoolPtr(false)
+
+			vm := plugin.getVMParameters(context.Background(), "machine", nil, nil, nil, nil)
+			Expect(*vm.OsProfile.AllowExtensionOperations).To(BeFalse())
+			Expect(*vm.OsProfile.LinuxConfiguration.ProvisionVMAgent).To(BeFalse())
+		})
+
+		It("should take the admin password of Windows VMs from the secret of the request", func() {
+			plugin.Secret = &corev1.Secret{Data: map[string][]byte{api.WindowsAdminPasswordSecretKey: []byte("other")}}
+			plugin.AzureProviderSpec.Properties.OsProfile.OSType = api.OSTypeWindows
+
+			vm := plugin.getVMParameters(context.Background(), "machine", nil, nil, nil, &corev1.Secret{Data: map[string][]byte{api.WindowsAdminPasswordSecretKey: []byte("password")}})
+			Expect(*vm.OsProfile.AdminPassword).To(Equal("password"))
+		})
+	})
+
+	Describe("#isPrivateIPAddressInUse", func() {
+		It("should detect the in-use error code", func() {
+			err := autorest.DetailedError{Original: &azure.RequestError{ServiceError: &azure.ServiceError{Code: "PrivateIPAddressInUse"}}}
+			Expect(isPrivateIPAddressInUse(err)).To(BeTrue())
+		})
+
+		It("should ignore other errors", func() {
+			Expect(isPrivateIPAddressInUse(autorest.DetailedError{Original: &azure.ServiceError{Code: "SubnetIsFull"}})).To(BeFalse())
+			Expect(isPrivateIPAddressInUse(errors.New("foo"))).To(BeFalse())
+		})
+	})
+
+	Describe("#createOrUpdateNIC", func() {
+		It("should fail with deadline exceeded if the creation times out", func() {
+			var (
+				ctx     = context.Background()
+				driver  = &MachinePlugin{AzureProviderSpec: &api.AzureProviderSpec{}, nicCreateTimeout: time.Millisecond}
+				clients = mock.NewMockPluginSPIImpl(gomock.NewController(GinkgoT()))
+			)
+			driverClients, err := clients.Setup(&corev1.Secret{}, nil)
+			Expect(err).NotTo(HaveOccurred())
+			driverClients.(*mock.AzureDriverClients).NIC.EXPECT().CreateOrUpdate(gomock.Any(), "rg", "machine-nic", gomock.Any()).DoAndReturn(
+				func(ctx context.Context, _, _ string, _ network.Interface) (network.InterfacesCreateOrUpdateFuture, error) {
+					<-ctx.Done()
+					return network.InterfacesCreateOrUpdateFuture{}, ctx.Err()
+				})
+
+			err = driver.createOrUpdateNIC(ctx, driverClients, "rg", networkInterface{name: "machine-nic"}, &network.Subnet{ID: to.StringPtr("subnet")}, nil, "", nil)
+			Expect(err).To(BeAssignableToTypeOf(&status.Status{}))
+			Expect(err.(*status.Status).Code()).To(Equal(codes.DeadlineExceeded))
+		})
+	})
+
+	Describe("#rollbackVMNicDisks", func() {
+		It("should delete the resources even if the context of the creation is cancelled", func() {
+			var (
+				driver  = &MachinePlugin{AzureProviderSpec: &api.AzureProviderSpec{}, deleteTimeout: time.Minute}
+				clients = mock.NewMockPluginSPIImpl(gomock.NewController(GinkgoT()))
+			)
+			driverClients, err := clients.Setup(&corev1.Secret{}, nil)
+			Expect(err).NotTo(HaveOccurred())
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			notFound := autorest.DetailedError{Response: &http.Response{StatusCode: http.StatusNotFound}}
+			driverClients.(*mock.AzureDriverClients).VM.EXPECT().Get(gomock.Any(), "rg", "machine", compute.InstanceViewTypes("")).DoAndReturn(
+				func(ctx context.Context, _, _ string, _ compute.InstanceViewTypes) (compute.VirtualMachine, error) {
+					Expect(ctx.Err()).NotTo(HaveOccurred())
+					deadline, ok := ctx.Deadline()
+					Expect(ok).To(BeTrue())
+					Expect(deadline).To(BeTemporally("~", time.Now().Add(time.Minute), time.Second))
+					return compute.VirtualMachine{}, notFound
+				})
+			driverClients.(*mock.AzureDriverClients).Disk.EXPECT().Get(gomock.Any(), "rg", "machine-os-disk").Return(compute.Disk{}, notFound)
+
+			driver.rollbackVMNicDisks(ctx, driverClients, "rg", "machine", nil, "machine-os-disk", nil)
+		})
+	})
+})
@@ -0,0 +1,231 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/spi"
+)
+
+const (
+	// activityLogAPIVersion is the API version of the Azure Activity Log used to watch the VMs
+	activityLogAPIVersion = "2015-04-01"
+	// activityLogDelay is the time by which every poll overlaps the previous one, as Azure makes events available in
+	// the Activity Log with a delay. The delay usually is a few minutes, but it is not bounded. Events which become
+	// available later than the overlap are missed, the VMs are then only refreshed once their inventory entry expires.
+	activityLogDelay = 15 * time.Minute
+	// watchedResourceGroupExpiry is the duration after which a resource group is no longer polled if none of its
+	// machine classes has been listed, e.g. because the machine classes were deleted. The machine controller manager
+	// lists the machines of every machine class well within this duration.
+	watchedResourceGroupExpiry = 2 * time.Hour
+
+	vmOperationPrefix = "microsoft.compute/virtualmachines/"
+)
+
+type activityLogEvents struct {
+	Value []struct {
+		EventDataID    string    `json:"eventDataId"`
+		EventTimestamp time.Time `json:"eventTimestamp"`
+		ResourceID     string    `json:"resourceId"`
+		OperationName  struct {
+			Value string `json:"value"`
+		} `json:"operationName"`
+		Status struct {
+			Value string `json:"value"`
+		} `json:"status"`
+	} `json:"value"`
+	NextLink string `json:"nextLink"`
+}
+
+// vmEvent is a completed operation on a VM reported by the Activity Log
+type vmEvent struct {
+	id        string
+	timestamp time.Time
+	vmName    string
+	operation string
+}
+
+// vmWatcher pushes the state changes of VMs into the VM inventory, so that the inventory can be kept for a longer time.
+// It polls the Activity Log of all resource groups whose machines have been listed and removes the VMs of completed
+// operations, e.g. deletions, deallocations or updates, from the inventory, so that their status is looked up directly.
+// Out-of-band changes are detected once Azure makes them available in the Activity Log, which usually takes a few
+// minutes in addition to the poll interval.
+type vmWatcher struct {
+	inventory *vmInventory
+
+	mutex          sync.Mutex
+	resourceGroups map[string]*watchedResourceGroup
+}
+
+// watchedResourceGroup is a resource group whose Activity Log is polled
+type watchedResourceGroup struct {
+	client                  autorest.Client
+	resourceManagerEndpoint string
+	subscriptionID          string
+	resourceGroup           string
+
+	// listed is the time the machines of the resource group were last listed
+	listed time.Time
+	// since is the time of the last poll
+	since time.Time
+	// seen are the IDs of the events of the overlap of the polls, which have been applied already, with their time
+	seen map[string]time.Time
+}
+
+func newVMWatcher(inventory *vmInventory) *vmWatcher {
+	return &vmWatcher{
+		inventory:      inventory,
+		resourceGroups: map[string]*watchedResourceGroup{},
+	}
+}
+
+// watch registers the resource group of the inventory key for polling. The client is used to read the Activity Log.
+func (w *vmWatcher) watch(key, subscriptionID, resourceGroup string, client autorest.Client, resourceManagerEndpoint string) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	now := time.Now()
+	if entry, ok := w.resourceGroups[key]; ok {
+		// The client is replaced, as the credentials of the secret may have been rotated
+		entry.client = client
+		entry.listed = now
+		return
+	}
+	w.resourceGroups[key] = &watchedResourceGroup{
+		client:                  client,
+		resourceManagerEndpoint: resourceManagerEndpoint,
+		subscriptionID:          subscriptionID,
+		resourceGroup:           resourceGroup,
+		listed:                  now,
+		since:                   now,
+		seen:                    map[string]time.Time{},
+	}
+}
+
+// sync polls the Activity Log of all watched resource groups and applies the VM events to the inventory. Resource
+// groups which have not been listed within the expiry are dropped instead.
+func (w *vmWatcher) sync(ctx context.Context) {
+	w.mutex.Lock()
+	entries := make(map[string]*watchedResourceGroup, len(w.resourceGroups))
+	for key, entry := range w.resourceGroups {
+		if time.Since(entry.listed) > watchedResourceGroupExpiry {
+			spi.InfoS(ctx, "Activity Log of resource group is no longer polled, as its machines have not been listed", spi.LogKeyResourceGroup, entry.resourceGroup)
+			delete(w.resourceGroups, key)
+			continue
+		}
+		entries[key] = entry
+	}
+	w.mutex.Unlock()
+
+	for key, entry := range entries {
+		if err := w.poll(ctx, key, entry); err != nil {
+			spi.WarningS(ctx, "Activity Log of resource group could not be polled", spi.LogKeyResourceGroup, entry.resourceGroup, "err", err)
+		}
+	}
+}
+
+func (w *vmWatcher) poll(ctx context.Context, key string, entry *watchedResourceGroup) error {
+	w.mutex.Lock()
+	var (
+		client = entry.client
+		since  = entry.since.Add(-activityLogDelay)
+		now    = time.Now()
+	)
+	w.mutex.Unlock()
+
+	events, err := fetchVMEvents(ctx, client, entry.resourceManagerEndpoint, entry.subscriptionID, entry.resourceGroup, since)
+	if err != nil {
+		return err
+	}
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	for _, event := range events {
+		if _, ok := entry.seen[event.id]; ok {
+			continue
+		}
+		entry.seen[event.id] = event.timestamp
+		spi.V(3).InfoS(ctx, "VM changed by Activity Log operation", spi.LogKeyResourceGroup, entry.resourceGroup, "vm", event.vmName, "activityLogOperation", event.operation)
+		w.inventory.remove(key, event.vmName)
+	}
+	// Events before the start of the next poll are not returned again
+	for id, timestamp := range entry.seen {
+		if timestamp.Before(now.Add(-activityLogDelay)) {
+			delete(entry.seen, id)
+		}
+	}
+	entry.since = now
+	return nil
+}
+
+// fetchVMEvents returns the succeeded VM operations of the resource group since the given time from the Activity Log
+func fetchVMEvents(ctx context.Context, client autorest.Client, resourceManagerEndpoint, subscriptionID, resourceGroup string, since time.Time) ([]vmEvent, error) {
+	var (
+		events     []vmEvent
+		filter     = fmt.Sprintf("eventTimestamp ge '%s' and resourceGroupName eq '%s' and resourceProvider eq 'Microsoft.Compute'", since.UTC().Format(time.RFC3339), resourceGroup)
+		decorators = []autorest.PrepareDecorator{
+			autorest.AsGet(),
+			autorest.WithBaseURL(resourceManagerEndpoint),
+			autorest.WithPathParameters("/subscriptions/{subscriptionId}/providers/Microsoft.Insights/eventtypes/management/values", map[string]interface{}{"subscriptionId": autorest.Encode("path", subscriptionID)}),
+			autorest.WithQueryParameters(map[string]interface{}{
+				"api-version": activityLogAPIVersion,
+				"$filter":     autorest.Encode("query", filter),
+				"$select":     "eventDataId,eventTimestamp,resourceId,operationName,status",
+			}),
+		}
+	)
+
+	for {
+		req, err := autorest.Prepare((&http.Request{}).WithContext(ctx), append(decorators, client.WithAuthorization())...)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := client.Send(req)
+		if err != nil {
+			return nil, err
+		}
+
+		var result activityLogEvents
+		if err := autorest.Respond(resp,
+			autorest.WithErrorUnlessStatusCode(http.StatusOK),
+			autorest.ByUnmarshallingJSON(&result),
+			autorest.ByClosing()); err != nil {
+			return nil, err
+		}
+
+		for _, value := range result.Value {
+			operation := strings.ToLower(value.OperationName.Value)
+			if !strings.HasPrefix(operation, vmOperationPrefix) || !strings.EqualFold(value.Status.Value, "Succeeded") {
+				continue
+			}
+			// The resource ID of VM operations ends with the VM name, also for operations like restart or deallocate
+			segments := strings.Split(value.ResourceID, "/")
+			if len(segments) < 2 || !strings.EqualFold(segments[len(segments)-2], "virtualMachines") {
+				continue
+			}
+			events = append(events, vmEvent{
+				id:        value.EventDataID,
+				timestamp: value.EventTimestamp,
+				vmName:    segments[len(segments)-1],
+				operation: operation,
+			})
+		}
+
+		if result.NextLink == "" {
+			return events, nil
+		}
+		decorators = []autorest.PrepareDecorator{autorest.AsGet(), autorest.WithBaseURL(result.NextLink)}
+	}
+}
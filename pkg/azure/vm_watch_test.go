@@ -0,0 +1,104 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-08-01/compute"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("VMWatcher", func() {
+	var (
+		ctx = context.Background()
+		key = "sub/rg"
+
+		server    *httptest.Server
+		requests  int
+		inventory *vmInventory
+		watcher   *vmWatcher
+	)
+
+	BeforeEach(func() {
+		requests = 0
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			Expect(r.URL.Path).To(Equal("/subscriptions/sub/providers/Microsoft.Insights/eventtypes/management/values"))
+			Expect(r.URL.Query().Get("$filter")).To(ContainSubstring("resourceGroupName eq 'rg'"))
+
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintf(w, `{"value": [
+				{"eventDataId": "1", "eventTimestamp": "%[1]s", "resourceId": "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Compute/virtualMachines/machine-0", "operationName": {"value": "Microsoft.Compute/virtualMachines/delete"}, "status": {"value": "Succeeded"}},
+				{"eventDataId": "2", "eventTimestamp": "%[1]s", "resourceId": "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Compute/virtualMachines/machine-1", "operationName": {"value": "Microsoft.Compute/virtualMachines/delete"}, "status": {"value": "Started"}},
+				{"eventDataId": "3", "eventTimestamp": "%[1]s", "resourceId": "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Compute/disks/machine-1-os-disk", "operationName": {"value": "Microsoft.Compute/disks/delete"}, "status": {"value": "Succeeded"}}
+			]}`, time.Now().UTC().Format(time.RFC3339))
+		}))
+
+		inventory = newVMInventory(time.Hour)
+		inventory.update(key, []compute.VirtualMachine{
+			{Name: to.StringPtr("machine-0"), Location: to.StringPtr("westeurope")},
+			{Name: to.StringPtr("machine-1"), Location: to.StringPtr("westeurope")},
+		})
+		watcher = newVMWatcher(inventory)
+		watcher.watch(key, "sub", "rg", autorest.NewClientWithUserAgent(""), server.URL)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should remove the VMs of succeeded operations from the inventory", func() {
+		watcher.sync(ctx)
+
+		Expect(requests).To(Equal(1))
+		_, ok, err := inventory.get(ctx, nil, key, "rg", nil, "machine-0")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeFalse())
+		_, ok, err = inventory.get(ctx, nil, key, "rg", nil, "machine-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+	})
+
+	It("should apply the events of the poll overlap only once", func() {
+		watcher.sync(ctx)
+		inventory.update(key, []compute.VirtualMachine{{Name: to.StringPtr("machine-0"), Location: to.StringPtr("westeurope")}})
+		watcher.sync(ctx)
+
+		Expect(requests).To(Equal(2))
+		_, ok, err := inventory.get(ctx, nil, key, "rg", nil, "machine-0")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+	})
+
+	It("should forget the applied events once they are before the next poll", func() {
+		watcher.resourceGroups[key].seen["0"] = time.Now().Add(-activityLogDelay - time.Minute)
+		watcher.sync(ctx)
+
+		Expect(watcher.resourceGroups[key].seen).NotTo(HaveKey("0"))
+		Expect(watcher.resourceGroups[key].seen).To(HaveKey("1"))
+	})
+
+	It("should stop polling resource groups whose machines have not been listed", func() {
+		watcher.resourceGroups[key].listed = time.Now().Add(-watchedResourceGroupExpiry - time.Minute)
+		watcher.sync(ctx)
+
+		Expect(requests).To(BeZero())
+		Expect(watcher.resourceGroups).To(BeEmpty())
+
+		watcher.watch(key, "sub", "rg", autorest.NewClientWithUserAgent(""), server.URL)
+		watcher.sync(ctx)
+		Expect(requests).To(Equal(1))
+	})
+})
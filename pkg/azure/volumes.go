@@ -0,0 +1,85 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/Azure/go-autorest/autorest/azure"
+	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/spi"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// volumeSessions remembers the secret and cloud configuration of the latest machine class per subscription, so that
+// GetVolumeIDs, whose request carries no credentials, can read the tags of the disks of the subscription
+type volumeSessions struct {
+	mutex    sync.Mutex
+	sessions map[string]volumeSession
+}
+
+type volumeSession struct {
+	secret             *corev1.Secret
+	cloudConfiguration *api.CloudConfiguration
+}
+
+func newVolumeSessions() *volumeSessions {
+	return &volumeSessions{sessions: map[string]volumeSession{}}
+}
+
+// record remembers the secret and cloud configuration for the subscription of the secret
+func (v *volumeSessions) record(secret *corev1.Secret, cloudConfiguration *api.CloudConfiguration) {
+	if v == nil || secret == nil {
+		return
+	}
+	id := strings.ToLower(subscriptionID(secret))
+	if id == "" {
+		return
+	}
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	v.sessions[id] = volumeSession{secret: secret, cloudConfiguration: cloudConfiguration}
+}
+
+func (v *volumeSessions) get(subscriptionID string) (volumeSession, bool) {
+	if v == nil {
+		return volumeSession{}, false
+	}
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	session, ok := v.sessions[strings.ToLower(subscriptionID)]
+	return session, ok
+}
+
+// isMachineDisk returns true if the managed disk with the given resource ID carries the managed-by tag of the disks
+// created by the provider along with a VM. Disks which cannot be read, e.g. as no machine class of their subscription
+// was seen yet, are not considered machine disks, so that the drain waits for their detachment as for any volume.
+func (d *MachinePlugin) isMachineDisk(ctx context.Context, diskID string) bool {
+	resource, err := azure.ParseResourceID(diskID)
+	if err != nil || !strings.EqualFold(resource.Provider, "Microsoft.Compute") || !strings.EqualFold(resource.ResourceType, "disks") {
+		return false
+	}
+	session, ok := d.volumeSessions.get(resource.SubscriptionID)
+	if !ok {
+		return false
+	}
+	clients, err := d.SPI.Setup(session.secret, session.cloudConfiguration)
+	if err != nil {
+		spi.WarningS(ctx, "Tags of the disk of a volume could not be read", "disk", resource.ResourceName, "err", err)
+		return false
+	}
+	disk, err := clients.GetDisk().Get(ctx, resource.ResourceGroup, resource.ResourceName)
+	if err != nil {
+		if !spi.NotFound(err) {
+			spi.WarningS(ctx, "Tags of the disk of a volume could not be read", "disk", resource.ResourceName, "err", err)
+		}
+		return false
+	}
+	return spi.IsManagedByProvider(disk.Tags)
+}
@@ -0,0 +1,69 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-08-01/compute"
+	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/codes"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/status"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// windowsComputerNameMaxLength is the maximum length of the computer name of Windows VMs
+const windowsComputerNameMaxLength = 15
+
+// isWindows returns true if the OS profile describes a Windows VM
+func isWindows(osProfile api.AzureOSProfile) bool {
+	return osProfile.OSType == api.OSTypeWindows
+}
+
+// checkWindowsComputerName rejects computer names of Windows VMs which are too long before any resource is created
+func checkWindowsComputerName(osProfile api.AzureOSProfile, computerName string) error {
+	if isWindows(osProfile) && len(computerName) > windowsComputerNameMaxLength {
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("Computer name %q of Windows VM exceeds %d characters, configure a computer name template to shorten it", computerName, windowsComputerNameMaxLength))
+	}
+	return nil
+}
+
+// getWindowsAdminPassword returns the password of the admin user of Windows VMs from the machine class secret
+func getWindowsAdminPassword(osProfile api.AzureOSProfile, secret *corev1.Secret) string {
+	key := api.WindowsAdminPasswordSecretKey
+	if osProfile.WindowsConfiguration != nil && osProfile.WindowsConfiguration.AdminPasswordSecretKey != "" {
+		key = osProfile.WindowsConfiguration.AdminPasswordSecretKey
+	}
+	if secret == nil {
+		return ""
+	}
+	return string(secret.Data[key])
+}
+
+// getWindowsConfiguration returns the Windows configuration of the VM parameters
+func getWindowsConfiguration(osProfile api.AzureOSProfile) *compute.WindowsConfiguration {
+	windowsConfiguration := &compute.WindowsConfiguration{
+		ProvisionVMAgent: osProfile.ProvisionVMAgent,
+	}
+	if osProfile.WindowsConfiguration == nil {
+		return windowsConfiguration
+	}
+
+	windowsConfiguration.EnableAutomaticUpdates = osProfile.WindowsConfiguration.EnableAutomaticUpdates
+	windowsConfiguration.TimeZone = osProfile.WindowsConfiguration.TimeZone
+	if len(osProfile.WindowsConfiguration.WinRM) > 0 {
+		listeners := make([]compute.WinRMListener, 0, len(osProfile.WindowsConfiguration.WinRM))
+		for _, listener := range osProfile.WindowsConfiguration.WinRM {
+			listeners = append(listeners, compute.WinRMListener{
+				Protocol:       compute.ProtocolTypes(listener.Protocol),
+				CertificateURL: listener.CertificateURL,
+			})
+		}
+		windowsConfiguration.WinRM = &compute.WinRMConfiguration{Listeners: &listeners}
+	}
+	return windowsConfiguration
+}
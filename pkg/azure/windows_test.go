@@ -0,0 +1,56 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-08-01/compute"
+	"github.com/Azure/go-autorest/autorest/to"
+	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var _ = Describe("Windows", func() {
+	var osProfile api.AzureOSProfile
+
+	BeforeEach(func() {
+		osProfile = api.AzureOSProfile{
+			OSType: api.OSTypeWindows,
+			WindowsConfiguration: &api.AzureWindowsConfiguration{
+				AdminPasswordSecretKey: "windowsPassword",
+				EnableAutomaticUpdates: to.BoolPtr(false),
+				TimeZone:               to.StringPtr("W. Europe Standard Time"),
+				WinRM:                  []api.AzureWinRMListener{{Protocol: api.WinRMProtocolHTTPS, CertificateURL: to.StringPtr("https://vault/secrets/winrm")}},
+			},
+		}
+	})
+
+	It("should reject computer names exceeding 15 characters for Windows VMs only", func() {
+		Expect(checkWindowsComputerName(osProfile, "shoot-worker-a1")).To(Succeed())
+		Expect(checkWindowsComputerName(osProfile, "shoot-worker-a12")).NotTo(Succeed())
+		Expect(checkWindowsComputerName(api.AzureOSProfile{}, "shoot-worker-a12")).To(Succeed())
+	})
+
+	It("should read the admin password from the configured secret key", func() {
+		secret := &corev1.Secret{Data: map[string][]byte{"windowsPassword": []byte("secret"), api.WindowsAdminPasswordSecretKey: []byte("default")}}
+		Expect(getWindowsAdminPassword(osProfile, secret)).To(Equal("secret"))
+
+		osProfile.WindowsConfiguration = nil
+		Expect(getWindowsAdminPassword(osProfile, secret)).To(Equal("default"))
+	})
+
+	It("should map the Windows configuration", func() {
+		Expect(getWindowsConfiguration(osProfile)).To(Equal(&compute.WindowsConfiguration{
+			EnableAutomaticUpdates: to.BoolPtr(false),
+			TimeZone:               to.StringPtr("W. Europe Standard Time"),
+			WinRM: &compute.WinRMConfiguration{Listeners: &[]compute.WinRMListener{
+				{Protocol: compute.HTTPS, CertificateURL: to.StringPtr("https://vault/secrets/winrm")},
+			}},
+		}))
+	})
+})
@@ -0,0 +1,32 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"hash/fnv"
+
+	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+)
+
+// zoneTagKey is the key of the tag recording the zone chosen for a VM spread across zones
+const zoneTagKey = "machine-controller-manager-zone"
+
+// getZone returns the zone of the VM, or nil if it is not zonal. VMs spread across zones are assigned to a zone by a
+// hash of their name, so that a recreated VM lands in the same zone and the machines of a deployment spread evenly.
+func getZone(properties api.AzureVirtualMachineProperties, vmName string) *int {
+	if properties.Zone != nil {
+		return properties.Zone
+	}
+	if len(properties.Zones) == 0 {
+		return nil
+	}
+
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(vmName))
+	zone := properties.Zones[hash.Sum32()%uint32(len(properties.Zones))]
+	return &zone
+}
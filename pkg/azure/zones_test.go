@@ -0,0 +1,65 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/Azure/go-autorest/autorest/to"
+	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Zones", func() {
+	It("should use the single zone", func() {
+		Expect(getZone(api.AzureVirtualMachineProperties{Zone: to.IntPtr(2)}, "machine")).To(Equal(to.IntPtr(2)))
+		Expect(getZone(api.AzureVirtualMachineProperties{}, "machine")).To(BeNil())
+	})
+
+	It("should spread machines deterministically across all zones", func() {
+		properties := api.AzureVirtualMachineProperties{Zones: []int{1, 2, 3}}
+
+		counts := map[int]int{}
+		for i := 0; i < 300; i++ {
+			name := fmt.Sprintf("shoot--project--worker-z-%d", i)
+			zone := getZone(properties, name)
+			Expect(zone).NotTo(BeNil())
+			Expect(getZone(properties, name)).To(Equal(zone))
+			counts[*zone]++
+		}
+		Expect(counts).To(HaveLen(3))
+		for _, count := range counts {
+			Expect(count).To(BeNumerically(">", 50))
+		}
+	})
+
+	It("should accept regional machines without zone, availability set or machine set", func() {
+		machineClass, secret := newProviderSpecCacheFixtures()
+		providerSpec := UnmarshalProviderSpec(machineClass.ProviderSpec.Raw)
+
+		var err error
+		providerSpec.Properties.Zone = nil
+		machineClass.ProviderSpec.Raw, err = json.Marshal(providerSpec)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = decodeProviderSpecAndSecret(machineClass, secret)
+		Expect(err).To(MatchError(ContainSubstring("or be regional")))
+
+		providerSpec.Properties.Regional = true
+		machineClass.ProviderSpec.Raw, err = json.Marshal(providerSpec)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = decodeProviderSpecAndSecret(machineClass, secret)
+		Expect(err).NotTo(HaveOccurred())
+
+		providerSpec.Properties.Zones = []int{1, 2}
+		machineClass.ProviderSpec.Raw, err = json.Marshal(providerSpec)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = decodeProviderSpecAndSecret(machineClass, secret)
+		Expect(err).To(MatchError(ContainSubstring("properties.regional")))
+	})
+})
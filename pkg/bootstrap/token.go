@@ -0,0 +1,143 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package bootstrap issues short-lived bootstrap tokens which machines use to register their kubelet
+package bootstrap
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	bootstraptokenapi "k8s.io/cluster-bootstrap/token/api"
+	bootstraptokenutil "k8s.io/cluster-bootstrap/token/util"
+)
+
+const (
+	// TokenPlaceholder is the placeholder in the user data which is replaced by the bootstrap token of the machine
+	TokenPlaceholder = "<<BOOTSTRAP_TOKEN>>"
+
+	// tokenGroup is the extra group of the issued bootstrap tokens
+	tokenGroup = bootstraptokenapi.BootstrapDefaultGroup + ":machine-controller-manager"
+)
+
+// TokenIssuer issues bootstrap tokens for machines in the target cluster
+type TokenIssuer interface {
+	// Issue creates or renews the bootstrap token of the machine and returns it
+	Issue(ctx context.Context, machineName string) (string, error)
+	// Revoke deletes the bootstrap token of the machine
+	Revoke(ctx context.Context, machineName string) error
+}
+
+type tokenIssuer struct {
+	client kubernetes.Interface
+	ttl    time.Duration
+}
+
+// NewTokenIssuer returns a TokenIssuer which stores the bootstrap tokens as secrets in the kube-system
+// namespace of the target cluster. The tokens expire after the given ttl.
+func NewTokenIssuer(client kubernetes.Interface, ttl time.Duration) TokenIssuer {
+	return &tokenIssuer{client: client, ttl: ttl}
+}
+
+// Issue creates or renews the bootstrap token of the machine and returns it.
+// The token id is derived from the machine name, so that repeated creation attempts renew the same token.
+func (t *tokenIssuer) Issue(ctx context.Context, machineName string) (string, error) {
+	token, err := bootstraptokenutil.GenerateBootstrapToken()
+	if err != nil {
+		return "", err
+	}
+
+	var (
+		tokenID     = tokenIDForMachine(machineName)
+		tokenSecret = token[len(token)-bootstraptokenapi.BootstrapTokenSecretBytes:]
+		secret      = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      bootstraptokenutil.BootstrapTokenSecretName(tokenID),
+				Namespace: metav1.NamespaceSystem,
+			},
+			Type: bootstraptokenapi.SecretTypeBootstrapToken,
+			StringData: map[string]string{
+				bootstraptokenapi.BootstrapTokenIDKey:               tokenID,
+				bootstraptokenapi.BootstrapTokenSecretKey:           tokenSecret,
+				bootstraptokenapi.BootstrapTokenDescriptionKey:      fmt.Sprintf("Bootstrap token for machine %q", machineName),
+				bootstraptokenapi.BootstrapTokenExpirationKey:       time.Now().Add(t.ttl).UTC().Format(time.RFC3339),
+				bootstraptokenapi.BootstrapTokenExtraGroupsKey:      tokenGroup,
+				bootstraptokenapi.BootstrapTokenUsageAuthentication: "true",
+				bootstraptokenapi.BootstrapTokenUsageSigningKey:     "true",
+			},
+		}
+		secrets = t.client.CoreV1().Secrets(metav1.NamespaceSystem)
+	)
+
+	err = withContext(ctx, func() error {
+		existing, err := secrets.Get(secret.Name, metav1.GetOptions{})
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		switch {
+		case apierrors.IsNotFound(err):
+			_, err = secrets.Create(secret)
+		case err == nil:
+			existing.Type = secret.Type
+			existing.Data = nil
+			existing.StringData = secret.StringData
+			_, err = secrets.Update(existing)
+		}
+		return err
+	})
+	if err != nil {
+		return "", fmt.Errorf("Could not store bootstrap token of machine %q: %v", machineName, err)
+	}
+
+	return bootstraptokenutil.TokenFromIDAndSecret(tokenID, tokenSecret), nil
+}
+
+// Revoke deletes the bootstrap token of the machine
+func (t *tokenIssuer) Revoke(ctx context.Context, machineName string) error {
+	name := bootstraptokenutil.BootstrapTokenSecretName(tokenIDForMachine(machineName))
+	err := withContext(ctx, func() error {
+		return t.client.CoreV1().Secrets(metav1.NamespaceSystem).Delete(name, &metav1.DeleteOptions{})
+	})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("Could not revoke bootstrap token of machine %q: %v", machineName, err)
+	}
+	return nil
+}
+
+// withContext runs the given calls of the client, which doesn't take a context in this version, bounded by the
+// context. The calls are not started once the context is done, and the caller stops waiting for them on cancellation.
+func withContext(ctx context.Context, calls func() error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- calls()
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// RenderUserData replaces the token placeholder in the user data with the given token
+func RenderUserData(userData []byte, token string) []byte {
+	return bytes.Replace(userData, []byte(TokenPlaceholder), []byte(token), -1)
+}
+
+// tokenIDForMachine derives a valid bootstrap token id from the machine name
+func tokenIDForMachine(machineName string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(machineName)))[:bootstraptokenapi.BootstrapTokenIDBytes]
+}
@@ -0,0 +1,80 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bootstrap
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	bootstraptokenutil "k8s.io/cluster-bootstrap/token/util"
+)
+
+var _ = Describe("TokenIssuer", func() {
+
+	var (
+		client *fake.Clientset
+		issuer TokenIssuer
+	)
+
+	BeforeEach(func() {
+		client = fake.NewSimpleClientset()
+		issuer = NewTokenIssuer(client, time.Hour)
+	})
+
+	It("should issue a valid bootstrap token stored in the kube-system namespace", func() {
+		token, err := issuer.Issue(context.Background(), "machine-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(bootstraptokenutil.IsValidBootstrapToken(token)).To(BeTrue())
+
+		secret, err := client.CoreV1().Secrets(metav1.NamespaceSystem).Get(bootstraptokenutil.BootstrapTokenSecretName(token[:6]), metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(secret.Type).To(Equal(corev1.SecretType("bootstrap.kubernetes.io/token")))
+		Expect(secret.StringData).To(HaveKeyWithValue("token-secret", token[7:]))
+	})
+
+	It("should renew the token of a machine on repeated issuing", func() {
+		first, err := issuer.Issue(context.Background(), "machine-1")
+		Expect(err).NotTo(HaveOccurred())
+		second, err := issuer.Issue(context.Background(), "machine-1")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(second[:6]).To(Equal(first[:6]))
+		Expect(second).NotTo(Equal(first))
+	})
+
+	It("should revoke the token of a machine", func() {
+		token, err := issuer.Issue(context.Background(), "machine-1")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(issuer.Revoke(context.Background(), "machine-1")).To(Succeed())
+		Expect(issuer.Revoke(context.Background(), "machine-1")).To(Succeed())
+
+		_, err = client.CoreV1().Secrets(metav1.NamespaceSystem).Get(bootstraptokenutil.BootstrapTokenSecretName(token[:6]), metav1.GetOptions{})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should not store a token for a cancelled request", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := issuer.Issue(ctx, "machine-1")
+		Expect(err).To(MatchError(ContainSubstring(context.Canceled.Error())))
+
+		secrets, err := client.CoreV1().Secrets(metav1.NamespaceSystem).List(metav1.ListOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(secrets.Items).To(BeEmpty())
+	})
+
+	It("should render the token into the user data", func() {
+		Expect(string(RenderUserData([]byte("token: <<BOOTSTRAP_TOKEN>>"), "abcdef.0123456789abcdef"))).To(Equal("token: abcdef.0123456789abcdef"))
+	})
+})
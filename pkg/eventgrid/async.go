@@ -0,0 +1,76 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package eventgrid
+
+import (
+	"context"
+	"errors"
+)
+
+// maxBatchSize is the maximum number of queued events sent in a single request
+const maxBatchSize = 100
+
+// ErrQueueFull is returned by the asynchronous publisher if its queue is full and the events are dropped
+var ErrQueueFull = errors.New("Event Grid queue is full, the events are dropped")
+
+// asyncPublisher is the Publisher queueing the events and sending them in the background, so that machine operations
+// do not wait for Event Grid. Events are dropped if the queue is full, e.g. while the topic is unreachable.
+type asyncPublisher struct {
+	publisher Publisher
+	queue     chan Event
+	onError   func(error)
+}
+
+// NewAsyncPublisher returns a Publisher queueing up to queueSize events and sending them in batches with the given
+// publisher until the stop channel is closed. Errors of the background publishing are passed to onError.
+func NewAsyncPublisher(publisher Publisher, queueSize int, stopCh <-chan struct{}, onError func(error)) Publisher {
+	p := &asyncPublisher{
+		publisher: publisher,
+		queue:     make(chan Event, queueSize),
+		onError:   onError,
+	}
+	go p.run(stopCh)
+	return p
+}
+
+// Publish queues the given events without waiting for their publishing. It returns ErrQueueFull if the queue cannot
+// take all events, in which case the remaining events are dropped.
+func (p *asyncPublisher) Publish(_ context.Context, events ...Event) error {
+	for _, event := range events {
+		select {
+		case p.queue <- event:
+		default:
+			return ErrQueueFull
+		}
+	}
+	return nil
+}
+
+// run publishes the queued events until the stop channel is closed
+func (p *asyncPublisher) run(stopCh <-chan struct{}) {
+	for {
+		select {
+		case <-stopCh:
+			return
+		case event := <-p.queue:
+			batch := append(make([]Event, 0, maxBatchSize), event)
+		drain:
+			for len(batch) < maxBatchSize {
+				select {
+				case event := <-p.queue:
+					batch = append(batch, event)
+				default:
+					break drain
+				}
+			}
+			// The requests are bounded by the timeout of the publisher
+			if err := p.publisher.Publish(context.Background(), batch...); err != nil && p.onError != nil {
+				p.onError(err)
+			}
+		}
+	}
+}
@@ -0,0 +1,138 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package eventgrid implements a minimal publisher for Azure Event Grid custom topics
+package eventgrid
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// EventTypeMachineCreated is the event type published after a machine has been created
+	EventTypeMachineCreated = "Gardener.MachineControllerManager.Azure.MachineCreated"
+	// EventTypeMachineDeleted is the event type published after a machine has been deleted
+	EventTypeMachineDeleted = "Gardener.MachineControllerManager.Azure.MachineDeleted"
+	// EventTypeMachineFailed is the event type published when a machine operation has failed
+	EventTypeMachineFailed = "Gardener.MachineControllerManager.Azure.MachineFailed"
+
+	// apiVersion is the Event Grid data plane API version used to publish events
+	apiVersion = "2018-01-01"
+	// dataVersion is the schema version of the MachineEventData
+	dataVersion = "1.0"
+	// sasKeyHeader is the header carrying the access key of the topic
+	sasKeyHeader = "aeg-sas-key"
+)
+
+// Event is an event in the Event Grid event schema
+type Event struct {
+	ID          string      `json:"id"`
+	Subject     string      `json:"subject"`
+	EventType   string      `json:"eventType"`
+	EventTime   time.Time   `json:"eventTime"`
+	Data        interface{} `json:"data,omitempty"`
+	DataVersion string      `json:"dataVersion"`
+}
+
+// MachineEventData is the payload of the machine lifecycle events
+type MachineEventData struct {
+	MachineName   string `json:"machineName"`
+	MachineClass  string `json:"machineClass,omitempty"`
+	ResourceGroup string `json:"resourceGroup,omitempty"`
+	Location      string `json:"location,omitempty"`
+	ProviderID    string `json:"providerID,omitempty"`
+	Operation     string `json:"operation"`
+	Error         string `json:"error,omitempty"`
+}
+
+// Publisher publishes events to an Event Grid topic
+type Publisher interface {
+	Publish(ctx context.Context, events ...Event) error
+}
+
+// NewMachineEvent returns a new event of the given type for the machine described by data
+func NewMachineEvent(eventType string, data MachineEventData) Event {
+	return Event{
+		ID:          newEventID(),
+		Subject:     fmt.Sprintf("machines/%s", data.MachineName),
+		EventType:   eventType,
+		EventTime:   time.Now().UTC(),
+		Data:        data,
+		DataVersion: dataVersion,
+	}
+}
+
+// topicPublisher is the Publisher sending events to a custom topic endpoint authenticated with an access key
+type topicPublisher struct {
+	endpoint string
+	key      string
+	client   *http.Client
+}
+
+// NewTopicPublisher returns a Publisher for the custom topic at the given endpoint
+func NewTopicPublisher(endpoint, key string, timeout time.Duration) Publisher {
+	return &topicPublisher{
+		endpoint: endpoint,
+		key:      key,
+		client:   &http.Client{Timeout: timeout},
+	}
+}
+
+// Publish sends the given events to the topic in a single request
+func (p *topicPublisher) Publish(ctx context.Context, events ...Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	body, err := json.Marshal(events)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, p.topicURL(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(sasKeyHeader, p.key)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		message, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("Publishing %d event(s) to Event Grid topic failed with status %d: %s", len(events), resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+	return nil
+}
+
+func (p *topicPublisher) topicURL() string {
+	if strings.Contains(p.endpoint, "?") {
+		return p.endpoint
+	}
+	return fmt.Sprintf("%s?api-version=%s", p.endpoint, apiVersion)
+}
+
+func newEventID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("%d", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,136 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package eventgrid
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// recordingPublisher records the published batches and blocks until released if a release channel is set
+type recordingPublisher struct {
+	mutex   sync.Mutex
+	batches [][]Event
+	release chan struct{}
+	err     error
+}
+
+func (p *recordingPublisher) Publish(_ context.Context, events ...Event) error {
+	if p.release != nil {
+		<-p.release
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.batches = append(p.batches, events)
+	return p.err
+}
+
+func (p *recordingPublisher) published() int {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	count := 0
+	for _, batch := range p.batches {
+		count += len(batch)
+	}
+	return count
+}
+
+var _ = Describe("EventGrid", func() {
+	var (
+		ctx   = context.Background()
+		event = NewMachineEvent(EventTypeMachineCreated, MachineEventData{MachineName: "machine", Operation: "create"})
+	)
+
+	Describe("#topicPublisher", func() {
+		var (
+			server   *httptest.Server
+			status   int
+			received []Event
+			request  *http.Request
+		)
+
+		BeforeEach(func() {
+			status, received = http.StatusOK, nil
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				request = r
+				Expect(json.NewDecoder(r.Body).Decode(&received)).To(Succeed())
+				w.WriteHeader(status)
+				_, _ = w.Write([]byte("topic rejected the events"))
+			}))
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("should send the events to the topic with its access key", func() {
+			Expect(NewTopicPublisher(server.URL, "key", time.Second).Publish(ctx, event)).To(Succeed())
+			Expect(request.Header.Get(sasKeyHeader)).To(Equal("key"))
+			Expect(request.URL.Query().Get("api-version")).To(Equal(apiVersion))
+			Expect(received).To(HaveLen(1))
+			Expect(received[0].ID).To(Equal(event.ID))
+			Expect(received[0].Subject).To(Equal("machines/machine"))
+		})
+
+		It("should return an error if the topic rejects the events", func() {
+			status = http.StatusUnauthorized
+			err := NewTopicPublisher(server.URL, "key", time.Second).Publish(ctx, event)
+			Expect(err).To(MatchError(ContainSubstring("status 401: topic rejected the events")))
+		})
+	})
+
+	Describe("#asyncPublisher", func() {
+		var (
+			stopCh    chan struct{}
+			publisher *recordingPublisher
+		)
+
+		BeforeEach(func() {
+			stopCh = make(chan struct{})
+			publisher = &recordingPublisher{}
+		})
+
+		AfterEach(func() {
+			close(stopCh)
+		})
+
+		It("should publish the queued events in the background", func() {
+			async := NewAsyncPublisher(publisher, 10, stopCh, nil)
+			Expect(async.Publish(ctx, event, event)).To(Succeed())
+			Eventually(publisher.published).Should(Equal(2))
+		})
+
+		It("should not wait for the publishing and drop the events exceeding the queue", func() {
+			publisher.release = make(chan struct{})
+			async := NewAsyncPublisher(publisher, 1, stopCh, nil)
+
+			// The first event is taken by the blocked worker, the second fills the queue
+			Expect(async.Publish(ctx, event)).To(Succeed())
+			Eventually(func() int { return len(async.(*asyncPublisher).queue) }).Should(BeZero())
+			Expect(async.Publish(ctx, event)).To(Succeed())
+			Expect(async.Publish(ctx, event)).To(MatchError(ErrQueueFull))
+
+			close(publisher.release)
+			Eventually(publisher.published).Should(Equal(2))
+		})
+
+		It("should pass the errors of the background publishing to the error handler", func() {
+			publisher.err = errors.New("topic unreachable")
+			errs := make(chan error, 1)
+			async := NewAsyncPublisher(publisher, 10, stopCh, func(err error) { errs <- err })
+
+			Expect(async.Publish(ctx, event)).To(Succeed())
+			Eventually(errs).Should(Receive(MatchError("topic unreachable")))
+		})
+	})
+})
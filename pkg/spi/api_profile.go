@@ -0,0 +1,77 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package spi
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/Azure/go-autorest/autorest"
+	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+)
+
+// resourceGroupsProvider is the resource provider of the resource group requests, whose paths name no provider
+const resourceGroupsProvider = "microsoft.resources"
+
+// apiProfileInspector returns the prepare decorator replacing the API versions of the vendored clients with the ones
+// of the API profile, e.g. to talk to an Azure Stack Hub which lags behind the API versions of Azure. Requests of
+// resource providers which are not part of the profile are left unchanged.
+func apiProfileInspector(profile string) autorest.PrepareDecorator {
+	versions := api.APIProfileVersions[profile]
+	return func(p autorest.Preparer) autorest.Preparer {
+		return autorest.PreparerFunc(func(r *http.Request) (*http.Request, error) {
+			r, err := p.Prepare(r)
+			if err != nil || len(versions) == 0 {
+				return r, err
+			}
+
+			provider, resourceType := resourceProviderOf(r.URL.Path)
+			version, ok := versions[provider+"/"+resourceType]
+			if !ok {
+				version, ok = versions[provider]
+			}
+			if !ok {
+				return r, nil
+			}
+			query := r.URL.Query()
+			query.Set("api-version", version)
+			r.URL.RawQuery = query.Encode()
+			return r, nil
+		})
+	}
+}
+
+// resourceProviderOf returns the lowercase resource provider and resource type of the request path, which are the
+// segments following the last providers segment
+func resourceProviderOf(path string) (string, string) {
+	segments := strings.Split(strings.ToLower(strings.Trim(path, "/")), "/")
+	for i := len(segments) - 2; i >= 0; i-- {
+		if segments[i] != "providers" {
+			continue
+		}
+		if i+2 < len(segments) {
+			return segments[i+1], segments[i+2]
+		}
+		return segments[i+1], ""
+	}
+	return resourceGroupsProvider, ""
+}
+
+// applyAPIProfile makes all Azure clients send their requests with the API versions of the API profile
+func (clients *azureDriverClients) applyAPIProfile(profile string) {
+	if profile == "" {
+		return
+	}
+	inspector := apiProfileInspector(profile)
+	for _, client := range clients.autorestClients() {
+		if client.RequestInspector == nil {
+			client.RequestInspector = inspector
+			continue
+		}
+		client.RequestInspector = chainPrepareDecorators(inspector, client.RequestInspector)
+	}
+}
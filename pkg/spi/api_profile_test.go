@@ -0,0 +1,84 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package spi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("APIProfile", func() {
+	var (
+		server  *httptest.Server
+		clients *azureDriverClients
+		queries map[string]url.Values
+	)
+
+	BeforeEach(func() {
+		queries = map[string]url.Values{}
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			queries[r.URL.Path] = r.URL.Query()
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		clients = newClientsWithAuthorizer("sub", server.URL, autorest.NullAuthorizer{}, nil)
+		clients.applyAPIProfile(api.APIProfile20200901Hybrid)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should send the requests with the API versions of the profile", func() {
+		ctx := context.Background()
+		_, err := clients.vm.Delete(ctx, "rg", "vm", nil)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = clients.disk.Delete(ctx, "rg", "disk")
+		Expect(err).NotTo(HaveOccurred())
+		_, err = clients.nic.Delete(ctx, "rg", "nic")
+		Expect(err).NotTo(HaveOccurred())
+		_, err = clients.group.CheckExistence(ctx, "rg")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(queries["/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Compute/virtualMachines/vm"].Get("api-version")).To(Equal("2020-06-01"))
+		Expect(queries["/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Compute/disks/disk"].Get("api-version")).To(Equal("2019-07-01"))
+		Expect(queries["/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/networkInterfaces/nic"].Get("api-version")).To(Equal("2018-11-01"))
+		Expect(queries["/subscriptions/sub/resourcegroups/rg"].Get("api-version")).To(Equal("2019-10-01"))
+	})
+
+	It("should keep forcing the deletion of VMs", func() {
+		ctx := WithForceDeletion(context.Background())
+		_, err := clients.vm.Delete(ctx, "rg", "vm", ForceDeletionParameter(ctx))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(queries["/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Compute/virtualMachines/vm"].Get("forceDeletion")).To(Equal("true"))
+	})
+
+	It("should take the endpoints of Azure Stack Hubs from the cloud configuration", func() {
+		env, err := GetEnvironment(&api.CloudConfiguration{
+			Name:                    api.CloudNameAzureStackHub,
+			ResourceManagerEndpoint: to.StringPtr("https://management.local.azurestack.external/"),
+			ActiveDirectoryEndpoint: to.StringPtr("https://adfs.local.azurestack.external/adfs/"),
+			TokenAudience:           to.StringPtr("https://management.adfs.azurestack.local/1234"),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(env.ResourceManagerEndpoint).To(Equal("https://management.local.azurestack.external/"))
+		Expect(env.ActiveDirectoryEndpoint).To(Equal("https://adfs.local.azurestack.external/adfs/"))
+		Expect(env.TokenAudience).To(Equal("https://management.adfs.azurestack.local/1234"))
+
+		env, err = GetEnvironment(&api.CloudConfiguration{ResourceManagerEndpoint: to.StringPtr("https://arm.example.com/")})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(env.TokenAudience).To(Equal("https://arm.example.com/"))
+	})
+})
@@ -7,12 +7,13 @@ SPDX-License-Identifier: Apache-2.0
 package spi
 
 import (
+	"fmt"
 	"strings"
 
-	"github.com/Azure/azure-sdk-for-go/profiles/latest/compute/mgmt/compute"
-	"github.com/Azure/azure-sdk-for-go/profiles/latest/marketplaceordering/mgmt/marketplaceordering"
-	"github.com/Azure/azure-sdk-for-go/profiles/latest/network/mgmt/network"
-	"github.com/Azure/azure-sdk-for-go/profiles/latest/resources/mgmt/resources"
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-08-01/compute"
+	"github.com/Azure/azure-sdk-for-go/services/marketplaceordering/mgmt/2015-06-01/marketplaceordering"
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-04-01/network"
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-05-01/resources"
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/adal"
 	"github.com/Azure/go-autorest/autorest/azure"
@@ -22,64 +23,228 @@ import (
 )
 
 // PluginSPIImpl is the real implementation of SPI interface that makes the calls to the Azure SDK.
-type PluginSPIImpl struct{}
+type PluginSPIImpl struct {
+	// LatencyInjection optionally delays all requests of the Azure clients in non-production environments
+	LatencyInjection *LatencyInjection
+	// DryRun optionally suppresses all mutating requests of the Azure clients
+	DryRun *DryRun
+	// UserAgent optionally extends the User-Agent header of all requests of the Azure clients
+	UserAgent *UserAgent
+	// Throttling optionally retries the throttled requests of the Azure clients
+	Throttling *Throttling
+	// ClientCache optionally reuses the Azure clients of sessions with the same credentials and refreshes their AAD
+	// tokens in the background
+	ClientCache *ClientCache
+	// LookupCache optionally caches the subnet and VM image lookups of the Azure clients
+	LookupCache *LookupCache
+}
 
 // Setup starts a new Azure session
-func (ms *PluginSPIImpl) Setup(secret *corev1.Secret) (AzureDriverClientsInterface, error) {
+func (ms *PluginSPIImpl) Setup(secret *corev1.Secret, cloudConfiguration *api.CloudConfiguration) (AzureDriverClientsInterface, error) {
 	var (
 		subscriptionID = extractCredentialsFromData(secret.Data, api.AzureSubscriptionID, api.AzureAlternativeSubscriptionID)
 		tenantID       = extractCredentialsFromData(secret.Data, api.AzureTenantID, api.AzureAlternativeTenantID)
 		clientID       = extractCredentialsFromData(secret.Data, api.AzureClientID, api.AzureAlternativeClientID)
 		clientSecret   = extractCredentialsFromData(secret.Data, api.AzureClientSecret, api.AzureAlternativeClientSecret)
-
-		env = azure.PublicCloud
 	)
-	return newClients(subscriptionID, tenantID, clientID, clientSecret, env)
-}
 
-// newClients returns the authenticated Azure clients
-func newClients(subscriptionID, tenantID, clientID, clientSecret string, env azure.Environment) (*azureDriverClients, error) {
-	oauthConfig, err := adal.NewOAuthConfig(env.ActiveDirectoryEndpoint, tenantID)
+	env, err := GetEnvironment(cloudConfiguration)
 	if err != nil {
 		return nil, err
 	}
 
-	spToken, err := adal.NewServicePrincipalToken(*oauthConfig, clientID, clientSecret, env.ResourceManagerEndpoint)
+	var (
+		token                   = extractCredentialsFromData(secret.Data, api.AzureWorkloadIdentityToken)
+		tokenFile               = extractCredentialsFromData(secret.Data, api.AzureWorkloadIdentityTokenFile)
+		managedIdentityClientID = extractCredentialsFromData(secret.Data, api.AzureManagedIdentityClientID)
+		useManagedIdentity      = extractCredentialsFromData(secret.Data, api.AzureUseManagedIdentity)
+		profile                 = apiProfileOf(cloudConfiguration)
+		cacheKey                = clientCacheKey(subscriptionID, tenantID, clientID, clientSecret, token, tokenFile, managedIdentityClientID, env.ResourceManagerEndpoint, env.ActiveDirectoryEndpoint, env.TokenAudience, profile)
+	)
+	managedIdentity, err := usesManagedIdentity(useManagedIdentity, clientID, clientSecret, token, tokenFile)
 	if err != nil {
 		return nil, err
 	}
+	if clients, ok := ms.ClientCache.get(cacheKey); ok {
+		return clients, nil
+	}
 
+	var spToken *adal.ServicePrincipalToken
+	if managedIdentity {
+		spToken, err = newManagedIdentityToken(managedIdentityClientID, env)
+	} else {
+		var credential adal.ServicePrincipalSecret = &adal.ServicePrincipalTokenSecret{ClientSecret: clientSecret}
+		if clientSecret == "" {
+			// Without a client secret, the workload identity token is exchanged via client assertion
+			credential = &federatedTokenSecret{token: token, tokenFile: tokenFile}
+		}
+		spToken, err = newServicePrincipalToken(tenantID, clientID, credential, env)
+	}
+	if err != nil {
+		return nil, err
+	}
 	authorizer := autorest.NewBearerAuthorizer(spToken)
+	sender := newSender(ms.ClientCache.decorator(cacheKey), ms.DryRun.decorator(), ms.LatencyInjection.decorator(), usageDecorator(), requestLogDecorator(), metricsDecorator(), ms.Throttling.decorator())
+	clients := newClientsWithAuthorizer(subscriptionID, env.ResourceManagerEndpoint, ms.ClientCache.authorizer(cacheKey, authorizer), sender)
+	clients.lookupCache, clients.lookupScope = ms.LookupCache, cacheKey
+	clients.applyAPIProfile(profile)
+	addBuildInfo(clients.autorestClients()...)
+	ms.UserAgent.apply(clients.autorestClients()...)
+	ms.ClientCache.set(cacheKey, clients, spToken)
+	return clients, nil
+}
+
+// newSender returns the sender of the Azure clients with the given decorators, or nil to use the default sender if
+// all decorators are nil. The last decorator is the first to handle a request.
+func newSender(decorators ...autorest.SendDecorator) autorest.Sender {
+	var active []autorest.SendDecorator
+	for _, decorator := range decorators {
+		if decorator != nil {
+			active = append(active, decorator)
+		}
+	}
+	if len(active) == 0 {
+		return nil
+	}
+	return autorest.CreateSender(active...)
+}
+
+// newServicePrincipalToken returns the token of the service principal, which is refreshed when it expires
+func newServicePrincipalToken(tenantID, clientID string, credential adal.ServicePrincipalSecret, env azure.Environment) (*adal.ServicePrincipalToken, error) {
+	oauthConfig, err := adal.NewOAuthConfig(env.ActiveDirectoryEndpoint, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	return adal.NewServicePrincipalTokenWithSecret(*oauthConfig, clientID, env.TokenAudience, credential)
+}
 
-	subnetClient := network.NewSubnetsClient(subscriptionID)
+// NewClients returns the Azure clients of the resource manager endpoint, which authorize their requests with the given
+// authorizer and send them with the given sender, e.g. to talk to a fake of the Azure API in simulations. The default
+// sender is used if the given sender is nil.
+func NewClients(subscriptionID, resourceManagerEndpoint string, authorizer autorest.Authorizer, sender autorest.Sender) AzureDriverClientsInterface {
+	return newClientsWithAuthorizer(subscriptionID, resourceManagerEndpoint, authorizer, sender)
+}
+
+func newClientsWithAuthorizer(subscriptionID, resourceManagerEndpoint string, authorizer autorest.Authorizer, sender autorest.Sender) *azureDriverClients {
+	subnetClient := network.NewSubnetsClientWithBaseURI(resourceManagerEndpoint, subscriptionID)
 	subnetClient.Authorizer = authorizer
+	subnetClient.Sender = sender
 
-	interfacesClient := network.NewInterfacesClient(subscriptionID)
+	virtualNetworksClient := network.NewVirtualNetworksClientWithBaseURI(resourceManagerEndpoint, subscriptionID)
+	virtualNetworksClient.Authorizer = authorizer
+	virtualNetworksClient.Sender = sender
+
+	interfacesClient := network.NewInterfacesClientWithBaseURI(resourceManagerEndpoint, subscriptionID)
 	interfacesClient.Authorizer = authorizer
+	interfacesClient.Sender = sender
 
-	vmClient := compute.NewVirtualMachinesClient(subscriptionID)
+	vmClient := compute.NewVirtualMachinesClientWithBaseURI(resourceManagerEndpoint, subscriptionID)
 	vmClient.Authorizer = authorizer
+	vmClient.Sender = sender
 
-	vmImagesClient := compute.NewVirtualMachineImagesClient(subscriptionID)
+	vmImagesClient := compute.NewVirtualMachineImagesClientWithBaseURI(resourceManagerEndpoint, subscriptionID)
 	vmImagesClient.Authorizer = authorizer
+	vmImagesClient.Sender = sender
 
-	diskClient := compute.NewDisksClient(subscriptionID)
+	diskClient := compute.NewDisksClientWithBaseURI(resourceManagerEndpoint, subscriptionID)
 	diskClient.Authorizer = authorizer
+	diskClient.Sender = sender
 
 	// deploymentsClient := resources.NewDeploymentsClient(subscriptionID)
 	// deploymentsClient.Authorizer = authorizer
 
-	groupClient := resources.NewGroupsClient(subscriptionID)
+	groupClient := resources.NewGroupsClientWithBaseURI(resourceManagerEndpoint, subscriptionID)
 	groupClient.Authorizer = authorizer
+	groupClient.Sender = sender
 
-	marketplaceClient := marketplaceordering.NewMarketplaceAgreementsClient(subscriptionID)
+	marketplaceClient := marketplaceordering.NewMarketplaceAgreementsClientWithBaseURI(resourceManagerEndpoint, subscriptionID)
 	marketplaceClient.Authorizer = authorizer
+	marketplaceClient.Sender = sender
+
+	skusClient := compute.NewResourceSkusClientWithBaseURI(resourceManagerEndpoint, subscriptionID)
+	skusClient.Authorizer = authorizer
+	skusClient.Sender = sender
+
+	publicIPClient := network.NewPublicIPAddressesClientWithBaseURI(resourceManagerEndpoint, subscriptionID)
+	publicIPClient.Authorizer = authorizer
+	publicIPClient.Sender = sender
+
+	resourcesClient := resources.NewClientWithBaseURI(resourceManagerEndpoint, subscriptionID)
+	resourcesClient.Authorizer = authorizer
+	resourcesClient.Sender = sender
+
+	extensionsClient := compute.NewVirtualMachineExtensionsClientWithBaseURI(resourceManagerEndpoint, subscriptionID)
+	extensionsClient.Authorizer = authorizer
+	extensionsClient.Sender = sender
 
-	return &azureDriverClients{subnet: subnetClient, nic: interfacesClient, vm: vmClient, disk: diskClient, group: groupClient, images: vmImagesClient, marketplace: marketplaceClient}, nil
+	availabilitySetsClient := compute.NewAvailabilitySetsClientWithBaseURI(resourceManagerEndpoint, subscriptionID)
+	availabilitySetsClient.Authorizer = authorizer
+	availabilitySetsClient.Sender = sender
+
+	usageClient := compute.NewUsageClientWithBaseURI(resourceManagerEndpoint, subscriptionID)
+	usageClient.Authorizer = authorizer
+	usageClient.Sender = sender
+
+	return &azureDriverClients{subnet: subnetClient, virtualNetworks: virtualNetworksClient, nic: interfacesClient, vm: vmClient, disk: diskClient, group: groupClient, images: vmImagesClient, marketplace: marketplaceClient, skus: skusClient, publicIP: publicIPClient, resources: resourcesClient, extensions: extensionsClient, availabilitySets: availabilitySetsClient, usage: usageClient}
 
 	// return &azureDriverClients{subnet: subnetClient, nic: interfacesClient, vm: vmClient, disk: diskClient, deployments: deploymentsClient, group: groupClient, images: vmImagesClient, marketplace: marketplaceClient}, nil
 }
 
+// autorestClients returns the underlying clients of all Azure clients
+func (clients *azureDriverClients) autorestClients() []*autorest.Client {
+	return []*autorest.Client{
+		&clients.subnet.Client, &clients.virtualNetworks.Client, &clients.nic.Client, &clients.vm.Client, &clients.disk.Client, &clients.group.Client, &clients.images.Client,
+		&clients.marketplace.Client, &clients.skus.Client, &clients.publicIP.Client, &clients.resources.Client, &clients.extensions.Client,
+		&clients.availabilitySets.Client, &clients.usage.Client,
+	}
+}
+
+// GetEnvironment returns the Azure environment for the given cloud configuration.
+// The Azure public cloud is used if no cloud configuration is given. The token audience of the environment is the
+// resource manager endpoint unless configured otherwise.
+func GetEnvironment(cloudConfiguration *api.CloudConfiguration) (azure.Environment, error) {
+	if cloudConfiguration == nil {
+		return azure.PublicCloud, nil
+	}
+
+	var env azure.Environment
+	switch strings.ToLower(cloudConfiguration.Name) {
+	case "", strings.ToLower(api.CloudNameAzurePublic):
+		env = azure.PublicCloud
+	case strings.ToLower(api.CloudNameAzureChina):
+		env = azure.ChinaCloud
+	case strings.ToLower(api.CloudNameAzureGovernment):
+		env = azure.USGovernmentCloud
+	case strings.ToLower(api.CloudNameAzureStackHub):
+		// The endpoints of Azure Stack Hubs are specific to every installation and taken from the cloud configuration
+		env = azure.Environment{Name: api.CloudNameAzureStackHub}
+	default:
+		return env, fmt.Errorf("Unknown cloud configuration name %q", cloudConfiguration.Name)
+	}
+
+	if cloudConfiguration.ResourceManagerEndpoint != nil {
+		env.ResourceManagerEndpoint = *cloudConfiguration.ResourceManagerEndpoint
+	}
+	if cloudConfiguration.ActiveDirectoryEndpoint != nil {
+		env.ActiveDirectoryEndpoint = *cloudConfiguration.ActiveDirectoryEndpoint
+	}
+	env.TokenAudience = env.ResourceManagerEndpoint
+	if cloudConfiguration.TokenAudience != nil {
+		env.TokenAudience = *cloudConfiguration.TokenAudience
+	}
+	return env, nil
+}
+
+// apiProfileOf returns the API profile of the cloud configuration, which is empty if the API versions of the provider
+// are used
+func apiProfileOf(cloudConfiguration *api.CloudConfiguration) string {
+	if cloudConfiguration == nil {
+		return ""
+	}
+	return cloudConfiguration.APIProfile
+}
+
 // extractCredentialsFromData extracts and trims a value from the given data map. The first key that exists is being
 // returned, otherwise, the next key is tried, etc. If no key exists then an empty string is returned.
 func extractCredentialsFromData(data map[string][]byte, keys ...string) string {
@@ -0,0 +1,186 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package spi
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/adal"
+)
+
+// MaxTokenRefreshBefore bounds the duration before their expiry in which the AAD tokens of cached clients are
+// refreshed. It is the minimum configurable lifetime of AAD access tokens, as tokens whose lifetime is shorter than
+// the duration would be refreshed on every use.
+const MaxTokenRefreshBefore = 10 * time.Minute
+
+// ClientCache caches the Azure clients of a session by its credentials, so that the clients and their AAD token are
+// reused across requests instead of acquiring a new token for every request, e.g. during mass reconciliations which
+// are otherwise throttled by AAD. The tokens of the cached clients are optionally refreshed in the background before
+// they expire, so that requests do not wait for their refresh.
+type ClientCache struct {
+	ttl           time.Duration
+	refreshBefore time.Duration
+
+	mutex   sync.Mutex
+	entries map[string]*cachedClients
+}
+
+// cachedClients are the clients of a session along with their token and expiry
+type cachedClients struct {
+	clients *azureDriverClients
+	token   *adal.ServicePrincipalToken
+	expiry  time.Time
+}
+
+// NewClientCache returns a client cache which keeps the clients for the given duration and refreshes their tokens the
+// given duration before they expire, or only when they are used if zero
+func NewClientCache(ttl, refreshBefore time.Duration) *ClientCache {
+	return &ClientCache{
+		ttl:           ttl,
+		refreshBefore: refreshBefore,
+		entries:       map[string]*cachedClients{},
+	}
+}
+
+// Enabled returns true if clients are cached
+func (c *ClientCache) Enabled() bool {
+	return c != nil && c.ttl > 0
+}
+
+// clientCacheKey returns the cache key of the given credentials and endpoints. The key is a hash, so that the
+// credentials are not kept in memory beyond the lifetime of the clients.
+func clientCacheKey(values ...string) string {
+	hash := sha256.Sum256([]byte(strings.Join(values, "\x00")))
+	return hex.EncodeToString(hash[:])
+}
+
+// get returns the cached clients of the key if they are not expired yet
+func (c *ClientCache) get(key string) (*azureDriverClients, bool) {
+	if !c.Enabled() {
+		return nil, false
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok || time.Now().After(entry.expiry) {
+		return nil, false
+	}
+	return entry.clients, true
+}
+
+// set caches the clients of the key along with their token and drops all expired clients
+func (c *ClientCache) set(key string, clients *azureDriverClients, token *adal.ServicePrincipalToken) {
+	if !c.Enabled() {
+		return
+	}
+	if token != nil && c.refreshBefore > 0 {
+		token.SetRefreshWithin(c.refreshBefore)
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	now := time.Now()
+	for k, entry := range c.entries {
+		if now.After(entry.expiry) {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = &cachedClients{clients: clients, token: token, expiry: now.Add(c.ttl)}
+}
+
+// Refresh refreshes the tokens of the cached clients which expire within the refresh duration. Clients whose token
+// cannot be refreshed are dropped, so that the next session acquires a new token.
+func (c *ClientCache) Refresh(ctx context.Context) {
+	if !c.Enabled() || c.refreshBefore <= 0 {
+		return
+	}
+	c.mutex.Lock()
+	now := time.Now()
+	tokens := map[string]*adal.ServicePrincipalToken{}
+	for key, entry := range c.entries {
+		if entry.token != nil && !now.After(entry.expiry) {
+			tokens[key] = entry.token
+		}
+	}
+	c.mutex.Unlock()
+
+	for key, token := range tokens {
+		if err := token.EnsureFreshWithContext(ctx); err != nil {
+			WarningS(ctx, "AAD token of cached Azure clients could not be refreshed, the clients are dropped", "err", err)
+			c.invalidate(ctx, key)
+		}
+	}
+}
+
+// invalidate drops the cached clients of the key, so that the next session builds new clients with a new token
+func (c *ClientCache) invalidate(ctx context.Context, key string) {
+	if !c.Enabled() {
+		return
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if _, ok := c.entries[key]; ok {
+		V(2).InfoS(ctx, "Cached Azure clients are invalidated after an authentication failure")
+		delete(c.entries, key)
+	}
+}
+
+// decorator returns the decorator invalidating the cached clients of the key if Azure Resource Manager rejects the
+// token, or nil if caching is disabled
+func (c *ClientCache) decorator(key string) autorest.SendDecorator {
+	if !c.Enabled() {
+		return nil
+	}
+	return func(s autorest.Sender) autorest.Sender {
+		return autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+			resp, err := s.Do(r)
+			if resp != nil && resp.StatusCode == http.StatusUnauthorized {
+				c.invalidate(r.Context(), key)
+			}
+			return resp, err
+		})
+	}
+}
+
+// authorizer returns the authorizer invalidating the cached clients of the key if the token cannot be refreshed, or
+// the given authorizer if caching is disabled
+func (c *ClientCache) authorizer(key string, authorizer autorest.Authorizer) autorest.Authorizer {
+	if !c.Enabled() {
+		return authorizer
+	}
+	return &invalidatingAuthorizer{cache: c, key: key, authorizer: authorizer}
+}
+
+// invalidatingAuthorizer invalidates the cached clients of the key if the wrapped authorizer fails to refresh the token
+type invalidatingAuthorizer struct {
+	cache      *ClientCache
+	key        string
+	authorizer autorest.Authorizer
+}
+
+// WithAuthorization is a method of the interface autorest.Authorizer
+func (a *invalidatingAuthorizer) WithAuthorization() autorest.PrepareDecorator {
+	return func(p autorest.Preparer) autorest.Preparer {
+		return autorest.PreparerFunc(func(r *http.Request) (*http.Request, error) {
+			ctx := r.Context()
+			r, err := a.authorizer.WithAuthorization()(p).Prepare(r)
+			if detailedErr, ok := err.(autorest.DetailedError); ok && detailedErr.PackageType == "azure.BearerAuthorizer" {
+				a.cache.invalidate(ctx, a.key)
+			}
+			return r, err
+		})
+	}
+}
@@ -0,0 +1,155 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package spi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/adal"
+	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func expiresIn(d time.Duration) json.Number {
+	return json.Number(strconv.FormatInt(time.Now().Add(d).Unix(), 10))
+}
+
+var _ = Describe("ClientCache", func() {
+	var (
+		impl   *PluginSPIImpl
+		secret = func(clientSecret string) *corev1.Secret {
+			return &corev1.Secret{Data: map[string][]byte{
+				api.AzureSubscriptionID: []byte("sub"),
+				api.AzureTenantID:       []byte("tenant"),
+				api.AzureClientID:       []byte("client"),
+				api.AzureClientSecret:   []byte(clientSecret),
+			}}
+		}
+	)
+
+	BeforeEach(func() {
+		impl = &PluginSPIImpl{ClientCache: NewClientCache(time.Hour, 0)}
+	})
+
+	It("should reuse the clients of the same credentials", func() {
+		clients, err := impl.Setup(secret("secret"), nil)
+		Expect(err).NotTo(HaveOccurred())
+		cached, err := impl.Setup(secret("secret"), nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cached).To(BeIdenticalTo(clients))
+
+		rotated, err := impl.Setup(secret("rotated"), nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rotated).NotTo(BeIdenticalTo(clients))
+	})
+
+	It("should not reuse expired clients", func() {
+		impl.ClientCache = NewClientCache(time.Nanosecond, 0)
+
+		clients, err := impl.Setup(secret("secret"), nil)
+		Expect(err).NotTo(HaveOccurred())
+		time.Sleep(time.Millisecond)
+		Expect(impl.Setup(secret("secret"), nil)).NotTo(BeIdenticalTo(clients))
+	})
+
+	It("should drop the clients once their token is rejected", func() {
+		clients, err := impl.Setup(secret("secret"), nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		var (
+			status = http.StatusOK
+			key    string
+		)
+		for k := range impl.ClientCache.entries {
+			key = k
+		}
+		sender := autorest.DecorateSender(autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: status, Request: r}, nil
+		}), impl.ClientCache.decorator(key))
+		req, _ := http.NewRequest(http.MethodGet, "https://management.azure.com", nil)
+
+		_, _ = sender.Do(req)
+		Expect(impl.Setup(secret("secret"), nil)).To(BeIdenticalTo(clients))
+
+		status = http.StatusUnauthorized
+		_, _ = sender.Do(req)
+		Expect(impl.Setup(secret("secret"), nil)).NotTo(BeIdenticalTo(clients))
+	})
+
+	It("should not cache clients if disabled", func() {
+		impl.ClientCache = nil
+
+		clients, err := impl.Setup(secret("secret"), nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(impl.Setup(secret("secret"), nil)).NotTo(BeIdenticalTo(clients))
+	})
+
+	Context("with token refresh", func() {
+		var (
+			refreshed int
+			refresh   adal.TokenRefresh
+			newToken  = func() *adal.ServicePrincipalToken {
+				config, err := adal.NewOAuthConfig("https://login.microsoftonline.com/", "tenant")
+				Expect(err).NotTo(HaveOccurred())
+				token, err := adal.NewServicePrincipalTokenFromManualToken(*config, "client", "https://management.azure.com/", adal.Token{AccessToken: "token", ExpiresOn: expiresIn(2 * time.Minute)})
+				Expect(err).NotTo(HaveOccurred())
+				token.SetCustomRefreshFunc(func(ctx context.Context, resource string) (*adal.Token, error) {
+					return refresh(ctx, resource)
+				})
+				return token
+			}
+		)
+
+		BeforeEach(func() {
+			impl.ClientCache = NewClientCache(time.Hour, 5*time.Minute)
+			refreshed = 0
+			refresh = func(context.Context, string) (*adal.Token, error) {
+				refreshed++
+				return &adal.Token{AccessToken: "refreshed", ExpiresOn: expiresIn(time.Hour)}, nil
+			}
+		})
+
+		It("should refresh the tokens of cached clients which expire within the refresh duration", func() {
+			token := newToken()
+			impl.ClientCache.set("key", &azureDriverClients{}, token)
+
+			impl.ClientCache.Refresh(context.Background())
+			Expect(refreshed).To(Equal(1))
+			Expect(token.OAuthToken()).To(Equal("refreshed"))
+
+			impl.ClientCache.Refresh(context.Background())
+			Expect(refreshed).To(Equal(1))
+		})
+
+		It("should drop the clients whose token cannot be refreshed", func() {
+			refresh = func(context.Context, string) (*adal.Token, error) {
+				return nil, errors.New("invalid client secret")
+			}
+			impl.ClientCache.set("key", &azureDriverClients{}, newToken())
+
+			impl.ClientCache.Refresh(context.Background())
+			_, ok := impl.ClientCache.get("key")
+			Expect(ok).To(BeFalse())
+		})
+
+		It("should not refresh the tokens of expired clients", func() {
+			impl.ClientCache.set("key", &azureDriverClients{}, newToken())
+			impl.ClientCache.entries["key"].expiry = time.Now().Add(-time.Minute)
+
+			impl.ClientCache.Refresh(context.Background())
+			Expect(refreshed).To(BeZero())
+		})
+	})
+})
@@ -13,18 +13,17 @@ import (
 	"strings"
 	"sync"
 
-	"github.com/Azure/azure-sdk-for-go/profiles/latest/compute/mgmt/compute"
-	"github.com/Azure/azure-sdk-for-go/profiles/latest/marketplaceordering/mgmt/marketplaceordering"
-	"github.com/Azure/azure-sdk-for-go/profiles/latest/network/mgmt/network"
-	"github.com/Azure/azure-sdk-for-go/profiles/latest/resources/mgmt/resources"
-	computeapi "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2019-12-01/compute/computeapi"
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-08-01/compute"
+	computeapi "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-08-01/compute/computeapi"
+	"github.com/Azure/azure-sdk-for-go/services/marketplaceordering/mgmt/2015-06-01/marketplaceordering"
 	marketplaceorderingapi "github.com/Azure/azure-sdk-for-go/services/marketplaceordering/mgmt/2015-06-01/marketplaceordering/marketplaceorderingapi"
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-04-01/network"
 	networkapi "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-04-01/network/networkapi"
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-05-01/resources"
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/gardener/machine-controller-manager-provider-azure/pkg/spi/resourcesapi"
 	"github.com/gardener/machine-controller-manager/pkg/util/provider/metrics"
 	"github.com/prometheus/client_golang/prometheus"
-	"k8s.io/klog"
 )
 
 const (
@@ -41,6 +40,14 @@ type AzureDriverClientsInterface interface {
 	// GetSubnet() is the getter for the Azure Subnets Client
 	GetSubnet() networkapi.SubnetsClientAPI
 
+	// GetSubnetOfSubscription() is the getter for the Azure Subnets Client of the given subscription, which is the
+	// subscription of the session if empty
+	GetSubnetOfSubscription(subscriptionID string) networkapi.SubnetsClientAPI
+
+	// GetVirtualNetworksOfSubscription() is the getter for the Azure Virtual Networks Client of the given subscription,
+	// which is the subscription of the session if empty
+	GetVirtualNetworksOfSubscription(subscriptionID string) networkapi.VirtualNetworksClientAPI
+
 	// GetNic() is the getter for the Azure Interfaces Client
 	GetNic() networkapi.InterfacesClientAPI
 
@@ -62,19 +69,48 @@ type AzureDriverClientsInterface interface {
 	// GetMarketplace() is the getter for the Azure Marketplace Agreement Client
 	GetMarketplace() marketplaceorderingapi.MarketplaceAgreementsClientAPI
 
+	// GetResourceSkus() is the getter for the Azure Resource SKUs Client
+	GetResourceSkus() computeapi.ResourceSkusClientAPI
+
+	// GetPublicIP() is the getter for the Azure Public IP Addresses Client
+	GetPublicIP() networkapi.PublicIPAddressesClientAPI
+
+	// GetResources() is the getter for the Azure generic resources Client
+	GetResources() resourcesapi.ResourcesClientAPI
+
+	// GetVMExtensions() is the getter for the Azure Virtual Machine Extensions Client
+	GetVMExtensions() computeapi.VirtualMachineExtensionsClientAPI
+
+	// GetAvailabilitySets() is the getter for the Azure Availability Sets Client
+	GetAvailabilitySets() computeapi.AvailabilitySetsClientAPI
+
+	// GetUsage() is the getter for the Azure compute Usage Client
+	GetUsage() computeapi.UsageClientAPI
+
 	// GetClient() is the getter of the Azure autorest client
 	GetClient() autorest.Client
 }
 
 // azureDriverClients . . .
 type azureDriverClients struct {
-	subnet      network.SubnetsClient
-	nic         network.InterfacesClient
-	vm          compute.VirtualMachinesClient
-	disk        compute.DisksClient
-	images      compute.VirtualMachineImagesClient
-	group       resources.GroupsClient
-	marketplace marketplaceordering.MarketplaceAgreementsClient
+	subnet           network.SubnetsClient
+	virtualNetworks  network.VirtualNetworksClient
+	nic              network.InterfacesClient
+	vm               compute.VirtualMachinesClient
+	disk             compute.DisksClient
+	images           compute.VirtualMachineImagesClient
+	group            resources.GroupsClient
+	marketplace      marketplaceordering.MarketplaceAgreementsClient
+	skus             compute.ResourceSkusClient
+	publicIP         network.PublicIPAddressesClient
+	resources        resources.Client
+	extensions       compute.VirtualMachineExtensionsClient
+	availabilitySets compute.AvailabilitySetsClient
+	usage            compute.UsageClient
+
+	// lookupCache optionally caches the subnet and VM image lookups in the scope of the credentials of the clients
+	lookupCache *LookupCache
+	lookupScope string
 
 	// commenting the below deployments attribute as I do not see an active usage of it in the core
 	// deployments resources.DeploymentsClient
@@ -93,7 +129,7 @@ func (clients *azureDriverClients) GetDisk() computeapi.DisksClientAPI {
 
 // GetImages is the getter for the Virtual Machines Images Client from the AzureDriverClients
 func (clients *azureDriverClients) GetImages() computeapi.VirtualMachineImagesClientAPI {
-	return clients.images
+	return clients.lookupCache.images(clients.lookupScope, clients.images)
 }
 
 // GetNic is the getter for the  Network Interfaces Client from the AzureDriverClients
@@ -103,7 +139,35 @@ func (clients *azureDriverClients) GetNic() networkapi.InterfacesClientAPI {
 
 // GetSubnet is the getter for the Network Subnets Client from the AzureDriverClients
 func (clients *azureDriverClients) GetSubnet() networkapi.SubnetsClientAPI {
-	return clients.subnet
+	return clients.lookupCache.subnets(clients.lookupScope, clients.subnet)
+}
+
+// GetSubnetOfSubscription is the getter for the Network Subnets Client of the given subscription from the
+// AzureDriverClients, e.g. of the central networking subscription of a hub-and-spoke topology. The client is a copy of
+// the subnets client of the session, so that it sends its requests with the same credentials and decorators.
+func (clients *azureDriverClients) GetSubnetOfSubscription(subscriptionID string) networkapi.SubnetsClientAPI {
+	if subscriptionID == "" || strings.EqualFold(subscriptionID, clients.subnet.SubscriptionID) {
+		return clients.GetSubnet()
+	}
+	subnet := clients.subnet
+	subnet.SubscriptionID = subscriptionID
+	return clients.lookupCache.subnets(clients.lookupScope+"/"+strings.ToLower(subscriptionID), subnet)
+}
+
+// GetVirtualNetworksOfSubscription is the getter for the Virtual Networks Client of the given subscription from the
+// AzureDriverClients. The client is a copy of the virtual networks client of the session, like the subnets client of
+// another subscription.
+func (clients *azureDriverClients) GetVirtualNetworksOfSubscription(subscriptionID string) networkapi.VirtualNetworksClientAPI {
+	virtualNetworks := clients.virtualNetworks
+	if subscriptionID != "" {
+		virtualNetworks.SubscriptionID = subscriptionID
+	}
+	return virtualNetworks
+}
+
+// GetPublicIP is the getter for the Public IP Addresses Client from the AzureDriverClients
+func (clients *azureDriverClients) GetPublicIP() networkapi.PublicIPAddressesClientAPI {
+	return clients.publicIP
 }
 
 // GetDeployments is the getter for the resources deployment from the AzureDriverClients
@@ -121,6 +185,31 @@ func (clients *azureDriverClients) GetMarketplace() marketplaceorderingapi.Marke
 	return clients.marketplace
 }
 
+// GetResourceSkus is the getter for the resource SKUs client from the AzureDriverClients
+func (clients *azureDriverClients) GetResourceSkus() computeapi.ResourceSkusClientAPI {
+	return clients.skus
+}
+
+// GetResources is the getter for the generic resources client from the AzureDriverClients
+func (clients *azureDriverClients) GetResources() resourcesapi.ResourcesClientAPI {
+	return clients.resources
+}
+
+// GetVMExtensions is the getter for the Virtual Machine Extensions Client from the AzureDriverClients
+func (clients *azureDriverClients) GetVMExtensions() computeapi.VirtualMachineExtensionsClientAPI {
+	return clients.extensions
+}
+
+// GetAvailabilitySets is the getter for the Availability Sets Client from the AzureDriverClients
+func (clients *azureDriverClients) GetAvailabilitySets() computeapi.AvailabilitySetsClientAPI {
+	return clients.availabilitySets
+}
+
+// GetUsage is the getter for the compute Usage Client from the AzureDriverClients
+func (clients *azureDriverClients) GetUsage() computeapi.UsageClientAPI {
+	return clients.usage
+}
+
 // GetClient is the getter for the autorest Client from the AzureDriverClients
 func (clients *azureDriverClients) GetClient() autorest.Client {
 	return clients.GetVM().(compute.VirtualMachinesClient).BaseClient.Client
@@ -128,10 +217,10 @@ func (clients *azureDriverClients) GetClient() autorest.Client {
 
 // DeleteVM is the helper function to acknowledge the VM deletion
 func DeleteVM(ctx context.Context, clients AzureDriverClientsInterface, resourceGroupName string, vmName string) error {
-	klog.V(2).Infof("VM deletion has began for %q", vmName)
-	defer klog.V(2).Infof("VM deleted for %q", vmName)
+	V(2).InfoS(ctx, "VM deletion has began", "vm", vmName)
+	defer V(2).InfoS(ctx, "VM deleted", "vm", vmName)
 
-	future, err := clients.GetVM().Delete(ctx, resourceGroupName, vmName)
+	future, err := clients.GetVM().Delete(ctx, resourceGroupName, vmName, ForceDeletionParameter(ctx))
 	if err != nil {
 		return OnARMAPIErrorFail(prometheusServiceVM, err, "vm.Delete")
 	}
@@ -145,8 +234,8 @@ func DeleteVM(ctx context.Context, clients AzureDriverClientsInterface, resource
 
 // WaitForDataDiskDetachment is functin that ensures all the data disks are detached from the VM
 func WaitForDataDiskDetachment(ctx context.Context, clients AzureDriverClientsInterface, resourceGroupName string, vm compute.VirtualMachine) error {
-	klog.V(2).Infof("Data disk detachment began for %q", *vm.Name)
-	defer klog.V(2).Infof("Data disk detached for %q", *vm.Name)
+	V(2).InfoS(ctx, "Data disk detachment began", "vm", vm.Name)
+	defer V(2).InfoS(ctx, "Data disk detached", "vm", vm.Name)
 
 	if len(*vm.StorageProfile.DataDisks) > 0 {
 		// There are disks attached hence need to detach them
@@ -180,8 +269,8 @@ func FetchAttachedVMfromNIC(ctx context.Context, clients AzureDriverClientsInter
 
 // DeleteNIC function deletes the attached Network Interface Card
 func DeleteNIC(ctx context.Context, clients AzureDriverClientsInterface, resourceGroupName string, nicName string) error {
-	klog.V(2).Infof("NIC delete started for %q", nicName)
-	defer klog.V(2).Infof("NIC deleted for %q", nicName)
+	V(2).InfoS(ctx, "NIC delete started", "nic", nicName)
+	defer V(2).InfoS(ctx, "NIC deleted", "nic", nicName)
 
 	future, err := clients.GetNic().Delete(ctx, resourceGroupName, nicName)
 	if err != nil {
@@ -194,20 +283,40 @@ func DeleteNIC(ctx context.Context, clients AzureDriverClientsInterface, resourc
 	return nil
 }
 
-func fetchAttachedVMfromDisk(ctx context.Context, clients AzureDriverClientsInterface, resourceGroupName, diskName string) (string, error) {
-	disk, err := clients.GetDisk().Get(ctx, resourceGroupName, diskName)
-	if err != nil {
-		return "", err
+const (
+	// DiskManagedByTagKey is the key of the tag marking the disks created by the provider
+	DiskManagedByTagKey = "managed-by"
+	// DiskManagedByTagValue is the value of the tag marking the disks created by the provider
+	DiskManagedByTagValue = "mcm-provider-azure"
+)
+
+// csiDiskTagKeys are the keys of the tags the Azure Disk CSI driver and the in-tree volume plugin set on the disks
+// they create for persistent volumes
+var csiDiskTagKeys = []string{"kubernetes.io-created-for-pv-name", "k8s-azure-created-by"}
+
+// IsManagedByProvider returns true if the disk with the given tags was created by the provider along with a VM
+func IsManagedByProvider(tags map[string]*string) bool {
+	value, ok := tags[DiskManagedByTagKey]
+	return ok && value != nil && *value == DiskManagedByTagValue
+}
+
+// IsPersistentVolumeDisk returns true if the disk with the given tags was created for a persistent volume, e.g. by the
+// Azure Disk CSI driver, and not by the provider. Such disks must never be deleted by the provider.
+func IsPersistentVolumeDisk(tags map[string]*string) bool {
+	if IsManagedByProvider(tags) {
+		return false
 	}
-	if disk.ManagedBy == nil {
-		return "", nil
+	for _, key := range csiDiskTagKeys {
+		if _, ok := tags[key]; ok {
+			return true
+		}
 	}
-	return *disk.ManagedBy, nil
+	return false
 }
 
 func deleteDisk(ctx context.Context, clients AzureDriverClientsInterface, resourceGroupName string, diskName string) error {
-	klog.V(2).Infof("Disk delete started for %q", diskName)
-	defer klog.V(2).Infof("Disk deleted for %q", diskName)
+	V(2).InfoS(ctx, "Disk delete started", "disk", diskName)
+	defer V(2).InfoS(ctx, "Disk deleted", "disk", diskName)
 
 	future, err := clients.GetDisk().Delete(ctx, resourceGroupName, diskName)
 	if err != nil {
@@ -220,17 +329,33 @@ func deleteDisk(ctx context.Context, clients AzureDriverClientsInterface, resour
 	return nil
 }
 
+// UpdateDiskTags replaces the tags of the given disk
+func UpdateDiskTags(ctx context.Context, clients AzureDriverClientsInterface, resourceGroupName string, diskName string, tags map[string]*string) error {
+	future, err := clients.GetDisk().Update(ctx, resourceGroupName, diskName, compute.DiskUpdate{Tags: tags})
+	if err != nil {
+		return OnARMAPIErrorFail(prometheusServiceDisk, err, "disk.Update")
+	}
+	if err = future.WaitForCompletionRef(ctx, clients.GetClient()); err != nil {
+		return OnARMAPIErrorFail(prometheusServiceDisk, err, "disk.Update")
+	}
+	OnARMAPISuccess(prometheusServiceDisk, "Disk update was successful for %s", diskName)
+	return nil
+}
+
 // GetDeleterForDisk executes the deletion of the attached disk
 func GetDeleterForDisk(ctx context.Context, clients AzureDriverClientsInterface, resourceGroupName string, diskName string) func() error {
 	return func() error {
-		if vmHoldingDisk, err := fetchAttachedVMfromDisk(ctx, clients, resourceGroupName, diskName); err != nil {
+		if disk, err := clients.GetDisk().Get(ctx, resourceGroupName, diskName); err != nil {
 			if NotFound(err) {
 				// Resource doesn't exist, no need to delete
 				return nil
 			}
 			return err
-		} else if vmHoldingDisk != "" {
-			return fmt.Errorf("Cannot delete disk %s because it is attached to VM %s", diskName, vmHoldingDisk)
+		} else if IsPersistentVolumeDisk(disk.Tags) {
+			WarningS(ctx, "Disk is not deleted, as it was created for a persistent volume", "disk", diskName)
+			return nil
+		} else if disk.ManagedBy != nil {
+			return fmt.Errorf("Cannot delete disk %s because it is attached to VM %s", diskName, *disk.ManagedBy)
 		}
 
 		return deleteDisk(ctx, clients, resourceGroupName, diskName)
@@ -265,12 +390,7 @@ func RetrieveRequestID(err error) (bool, string, *autorest.DetailedError) {
 // OnErrorFail prints a failure message and exits the program if err is not nil.
 func OnErrorFail(err error, format string, v ...interface{}) error {
 	if err != nil {
-		message := fmt.Sprintf(format, v...)
-		if hasRequestID, requestID, detailedErr := RetrieveRequestID(err); hasRequestID {
-			klog.Errorf("Azure ARM API call with x-ms-request-id=%s failed. %s: %s\n", requestID, message, *detailedErr)
-		} else {
-			klog.Errorf("%s: %s\n", message, err)
-		}
+		ErrorS(context.TODO(), err, "Azure ARM API call failed", "message", fmt.Sprintf(format, v...))
 	}
 	return err
 }
@@ -315,12 +435,22 @@ func RunInParallel(funcs []func() error) error {
 
 	wg.Wait()
 
-	var trimmedErrorMessages []string
+	var (
+		trimmedErrorMessages []string
+		firstErr             error
+	)
 	for _, e := range errors {
 		if e != nil {
+			if firstErr == nil {
+				firstErr = e
+			}
 			trimmedErrorMessages = append(trimmedErrorMessages, e.Error())
 		}
 	}
+	// A single error is returned as it is, so that its type, e.g. its status code, is kept
+	if len(trimmedErrorMessages) == 1 {
+		return firstErr
+	}
 	if len(trimmedErrorMessages) > 0 {
 		return fmt.Errorf(strings.Join(trimmedErrorMessages, "\n"))
 	}
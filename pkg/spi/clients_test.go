@@ -0,0 +1,60 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package spi
+
+import (
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-04-01/network"
+	"github.com/Azure/go-autorest/autorest/to"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Clients", func() {
+	Describe("#IsPersistentVolumeDisk", func() {
+		It("should detect disks created for persistent volumes", func() {
+			Expect(IsPersistentVolumeDisk(map[string]*string{"kubernetes.io-created-for-pv-name": to.StringPtr("pv-0")})).To(BeTrue())
+			Expect(IsPersistentVolumeDisk(map[string]*string{"k8s-azure-created-by": to.StringPtr("kubernetes-azure-dd")})).To(BeTrue())
+		})
+
+		It("should not detect disks created by the provider", func() {
+			Expect(IsPersistentVolumeDisk(nil)).To(BeFalse())
+			Expect(IsPersistentVolumeDisk(map[string]*string{
+				DiskManagedByTagKey:                 to.StringPtr(DiskManagedByTagValue),
+				"kubernetes.io-created-for-pv-name": to.StringPtr("pv-0"),
+			})).To(BeFalse())
+		})
+	})
+
+	Describe("#GetSubnetOfSubscription", func() {
+		It("should return a subnets client of the given subscription", func() {
+			clients := newClientsWithAuthorizer("vm-subscription", "https://management.azure.com/", nil, nil)
+
+			Expect(clients.GetSubnetOfSubscription("").(network.SubnetsClient).SubscriptionID).To(Equal("vm-subscription"))
+			Expect(clients.GetSubnetOfSubscription("networking").(network.SubnetsClient).SubscriptionID).To(Equal("networking"))
+			Expect(clients.GetSubnet().(network.SubnetsClient).SubscriptionID).To(Equal("vm-subscription"))
+		})
+	})
+
+	Describe("#RunInParallel", func() {
+		It("should return a single error as it is", func() {
+			err := fmt.Errorf("failed")
+			Expect(RunInParallel([]func() error{
+				func() error { return nil },
+				func() error { return err },
+			})).To(BeIdenticalTo(err))
+		})
+
+		It("should join multiple errors", func() {
+			Expect(RunInParallel([]func() error{
+				func() error { return fmt.Errorf("first") },
+				func() error { return fmt.Errorf("second") },
+			})).To(MatchError("first\nsecond"))
+		})
+	})
+})
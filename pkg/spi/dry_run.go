@@ -0,0 +1,216 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package spi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+)
+
+const (
+	// dryRunResourceTTL is the duration for which the resources created or deleted by the dry run are remembered
+	dryRunResourceTTL = time.Hour
+	// dryRunMaxResources caps the number of remembered resources, the ones remembered first are forgotten beyond it
+	dryRunMaxResources = 10000
+)
+
+// DryRun configures a read-only mode of the Azure clients. Mutating requests are logged and answered with a synthetic
+// response instead of being sent, while reading requests are sent as usual. It allows shadow deployments and
+// game days against production machine classes without changing any resource.
+// Resources created or deleted by the dry run are remembered in memory for an hour, so that subsequent reads observe
+// them.
+type DryRun struct {
+	// ErrorCode is the Azure error code of the synthetic failure returned for mutating requests. Mutating requests
+	// succeed if it is empty.
+	ErrorCode string
+
+	mutex sync.Mutex
+	// resources are the resources created or deleted by the dry run, by their lower case ID
+	resources map[string]dryRunResource
+}
+
+// dryRunResource is a resource remembered by the dry run along with its expiry
+type dryRunResource struct {
+	// body is the body of the created resource, which is nil if the resource was deleted
+	body      []byte
+	expiresAt time.Time
+}
+
+// decorator returns the decorator answering mutating requests with synthetic responses, or nil if the dry run is
+// disabled
+func (d *DryRun) decorator() autorest.SendDecorator {
+	if d == nil {
+		return nil
+	}
+	return func(s autorest.Sender) autorest.Sender {
+		return autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+			if r.Method == http.MethodGet || r.Method == http.MethodHead {
+				if resp, ok := d.read(r); ok {
+					return resp, nil
+				}
+				return s.Do(r)
+			}
+			InfoS(r.Context(), "Dry run: suppressed Azure API request", "method", r.Method, "path", r.URL.Path)
+			return d.respond(r)
+		})
+	}
+}
+
+// read answers reading requests of resources which were created or deleted by the dry run
+func (d *DryRun) read(r *http.Request) (*http.Response, bool) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	resource, ok := d.resources[strings.ToLower(r.URL.Path)]
+	if !ok || time.Now().After(resource.expiresAt) {
+		return nil, false
+	}
+	body := resource.body
+	if body == nil {
+		return newResponse(r, http.StatusNotFound, []byte(`{"error":{"code":"ResourceNotFound","message":"The resource was deleted by the dry run"}}`)), true
+	}
+	return newResponse(r, http.StatusOK, body), true
+}
+
+// remember records the body of the created resource, or nil if it was deleted. Expired resources are pruned and the
+// resource expiring first is forgotten if the cap is reached.
+func (d *DryRun) remember(id string, body []byte) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.resources == nil {
+		d.resources = map[string]dryRunResource{}
+	}
+	now := time.Now()
+	for key, resource := range d.resources {
+		if now.After(resource.expiresAt) {
+			delete(d.resources, key)
+		}
+	}
+	key := strings.ToLower(id)
+	if _, ok := d.resources[key]; !ok && len(d.resources) >= dryRunMaxResources {
+		var oldest string
+		for key, resource := range d.resources {
+			if oldest == "" || resource.expiresAt.Before(d.resources[oldest].expiresAt) {
+				oldest = key
+			}
+		}
+		delete(d.resources, oldest)
+	}
+	d.resources[key] = dryRunResource{body: body, expiresAt: now.Add(dryRunResourceTTL)}
+}
+
+// respond returns the synthetic response of a mutating request. Created or updated resources are echoed with their
+// ID and name derived from the request URL, so that callers can continue with them. Only created resources are
+// remembered, as the body of an update does not describe the full resource.
+func (d *DryRun) respond(r *http.Request) (*http.Response, error) {
+	if d.ErrorCode != "" {
+		body, err := json.Marshal(map[string]interface{}{
+			"error": map[string]string{
+				"code":    d.ErrorCode,
+				"message": fmt.Sprintf("%s %s was suppressed by the dry run", r.Method, r.URL.Path),
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		return newResponse(r, http.StatusBadRequest, body), nil
+	}
+
+	if r.Method == http.MethodDelete {
+		d.remember(r.URL.Path, nil)
+	}
+	if r.Method != http.MethodPut && r.Method != http.MethodPatch {
+		return newResponse(r, http.StatusOK, nil), nil
+	}
+
+	resource := map[string]interface{}{}
+	if r.Body != nil {
+		data, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			return nil, err
+		}
+		if len(data) > 0 {
+			if err := json.Unmarshal(data, &resource); err != nil {
+				return nil, err
+			}
+		}
+	}
+	resource["id"] = r.URL.Path
+	resource["name"] = path.Base(r.URL.Path)
+
+	if properties, ok := resource["properties"].(map[string]interface{}); ok {
+		properties["provisioningState"] = "Succeeded"
+	}
+
+	body, err := json.Marshal(resource)
+	if err != nil {
+		return nil, err
+	}
+	if r.Method == http.MethodPut {
+		d.remember(r.URL.Path, body)
+	}
+	return newResponse(r, http.StatusOK, body), nil
+}
+
+// DryRunTransport wraps the transport of a Kubernetes client, so that its mutating requests, e.g. of events and
+// annotations, are logged and answered with the echoed request body instead of being sent
+func DryRunTransport(rt http.RoundTripper) http.RoundTripper {
+	return roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		if r.Method == http.MethodGet || r.Method == http.MethodHead {
+			return rt.RoundTrip(r)
+		}
+		InfoS(r.Context(), "Dry run: suppressed Kubernetes API request", "method", r.Method, "path", r.URL.Path)
+
+		body := []byte(`{"kind":"Status","apiVersion":"v1","status":"Success"}`)
+		if r.Method != http.MethodDelete && r.Body != nil {
+			data, err := ioutil.ReadAll(r.Body)
+			if err != nil {
+				return nil, err
+			}
+			if len(data) > 0 {
+				body = data
+			}
+		}
+		resp := newResponse(r, http.StatusOK, body)
+		if contentType := r.Header.Get("Content-Type"); contentType != "" && r.Method != http.MethodPatch {
+			resp.Header.Set("Content-Type", contentType)
+		}
+		return resp, nil
+	})
+}
+
+// roundTripperFunc is a function implementing http.RoundTripper
+type roundTripperFunc func(r *http.Request) (*http.Response, error)
+
+// RoundTrip calls the function
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func newResponse(r *http.Request, statusCode int, body []byte) *http.Response {
+	return &http.Response{
+		Status:        http.StatusText(statusCode),
+		StatusCode:    statusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json; charset=utf-8"}},
+		Body:          ioutil.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       r,
+	}
+}
@@ -0,0 +1,132 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package spi
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-04-01/network"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DryRun", func() {
+	var (
+		ctx    = context.Background()
+		server *httptest.Server
+		client network.InterfacesClient
+	)
+
+	BeforeEach(func() {
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"name":"nic","location":"westeurope"}`))
+		}))
+		client = network.NewInterfacesClientWithBaseURI(server.URL, "sub")
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should send reading requests", func() {
+		client.Sender = newSender((&DryRun{}).decorator())
+
+		nic, err := client.Get(ctx, "rg", "nic", "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*nic.Location).To(Equal("westeurope"))
+	})
+
+	It("should answer mutating requests with the echoed resource", func() {
+		client.Sender = newSender((&DryRun{}).decorator())
+
+		future, err := client.CreateOrUpdate(ctx, "rg", "nic", network.Interface{Location: to.StringPtr("westeurope"), InterfacePropertiesFormat: &network.InterfacePropertiesFormat{}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(future.WaitForCompletionRef(ctx, client.Client)).To(Succeed())
+		nic, err := future.Result(client)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*nic.ID).To(Equal("/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/networkInterfaces/nic"))
+		Expect(*nic.Location).To(Equal("westeurope"))
+
+		nic, err = client.Get(ctx, "rg", "nic", "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nic.ProvisioningState).To(Equal(network.Succeeded))
+
+		_, err = client.Delete(ctx, "rg", "nic")
+		Expect(err).NotTo(HaveOccurred())
+		_, err = client.Get(ctx, "rg", "nic", "")
+		Expect(err).To(HaveOccurred())
+		Expect(NotFound(err)).To(BeTrue())
+	})
+
+	It("should fail mutating requests with the configured error code", func() {
+		client.Sender = newSender((&DryRun{ErrorCode: "AllocationFailed"}).decorator())
+
+		_, err := client.CreateOrUpdate(ctx, "rg", "nic", network.Interface{})
+		Expect(err).To(HaveOccurred())
+		detailedErr, ok := err.(autorest.DetailedError)
+		Expect(ok).To(BeTrue())
+		Expect(detailedErr.StatusCode).To(Equal(http.StatusBadRequest))
+	})
+
+	It("should forget expired resources and cap the remembered resources", func() {
+		dryRun := &DryRun{}
+		for i := 0; i < dryRunMaxResources; i++ {
+			dryRun.remember(fmt.Sprintf("/nic-%d", i), nil)
+		}
+		expired := dryRun.resources["/nic-0"]
+		expired.expiresAt = time.Now().Add(-time.Second)
+		dryRun.resources["/nic-0"] = expired
+		_, ok := dryRun.read(httptest.NewRequest(http.MethodGet, "/nic-0", nil))
+		Expect(ok).To(BeFalse())
+
+		dryRun.remember("/nic", nil)
+		Expect(dryRun.resources).To(HaveLen(dryRunMaxResources))
+		Expect(dryRun.resources).NotTo(HaveKey("/nic-0"))
+
+		dryRun.remember("/other-nic", nil)
+		Expect(dryRun.resources).To(HaveLen(dryRunMaxResources))
+		Expect(dryRun.resources).To(HaveKey("/other-nic"))
+	})
+
+	It("should suppress mutating Kubernetes requests", func() {
+		var sent []string
+		transport := DryRunTransport(roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			sent = append(sent, r.Method)
+			return newResponse(r, http.StatusOK, []byte(`{}`)), nil
+		}))
+
+		resp, err := transport.RoundTrip(httptest.NewRequest(http.MethodPost, "/api/v1/namespaces/default/events", strings.NewReader(`{"reason":"VMRestarted"}`)))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		body, err := ioutil.ReadAll(resp.Body)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(body)).To(Equal(`{"reason":"VMRestarted"}`))
+
+		_, err = transport.RoundTrip(httptest.NewRequest(http.MethodGet, "/api/v1/namespaces/default/secrets/userdata", nil))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sent).To(Equal([]string{http.MethodGet}))
+	})
+
+	It("should not decorate the sender if it is disabled", func() {
+		var dryRun *DryRun
+		Expect(dryRun.decorator()).To(BeNil())
+		Expect(newSender(dryRun.decorator(), (&LatencyInjection{}).decorator())).To(BeNil())
+	})
+})
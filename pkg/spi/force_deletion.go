@@ -0,0 +1,47 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package spi
+
+import (
+	"context"
+
+	"github.com/Azure/go-autorest/autorest"
+)
+
+type forceDeletionKey struct{}
+
+// WithForceDeletion returns a context forcing the deletion of the VMs deleted with it, which skips the graceful
+// shutdown and deletes VMs stuck in a failed provisioning state
+func WithForceDeletion(ctx context.Context) context.Context {
+	return context.WithValue(ctx, forceDeletionKey{}, true)
+}
+
+// ForceDeletion returns true if the context forces the deletion of VMs
+func ForceDeletion(ctx context.Context) bool {
+	force, _ := ctx.Value(forceDeletionKey{}).(bool)
+	return force
+}
+
+// ForceDeletionParameter returns the forceDeletion parameter of the VM deletions with the context, which is only set
+// if the context forces the deletion
+func ForceDeletionParameter(ctx context.Context) *bool {
+	if !ForceDeletion(ctx) {
+		return nil
+	}
+	force := true
+	return &force
+}
+
+// chainPrepareDecorators returns the prepare decorator applying the given decorators in order
+func chainPrepareDecorators(decorators ...autorest.PrepareDecorator) autorest.PrepareDecorator {
+	return func(p autorest.Preparer) autorest.Preparer {
+		for _, decorator := range decorators {
+			p = decorator(p)
+		}
+		return p
+	}
+}
@@ -0,0 +1,54 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package spi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-08-01/compute"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ForceDeletion", func() {
+	var (
+		server *httptest.Server
+		client compute.VirtualMachinesClient
+		query  url.Values
+	)
+
+	BeforeEach(func() {
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			query = r.URL.Query()
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		client = compute.NewVirtualMachinesClientWithBaseURI(server.URL, "sub")
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should force the deletion of VMs deleted with a context forcing it", func() {
+		ctx := WithForceDeletion(context.Background())
+		_, err := client.Delete(ctx, "rg", "vm", ForceDeletionParameter(ctx))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(query.Get("forceDeletion")).To(Equal("true"))
+	})
+
+	It("should leave other deletions unchanged", func() {
+		ctx := context.Background()
+		_, err := client.Delete(ctx, "rg", "vm", ForceDeletionParameter(ctx))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(query).NotTo(HaveKey("forceDeletion"))
+	})
+})
@@ -0,0 +1,58 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package spi
+
+import (
+	"math/rand"
+	"net/http"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+)
+
+// LatencyInjection configures an artificial delay of all requests of the Azure clients. It simulates a slow Azure API
+// to validate timeouts and backoffs and must not be used in production environments.
+type LatencyInjection struct {
+	// Latency is the fixed delay of every request
+	Latency time.Duration
+	// Jitter is the upper bound of the random delay added to the latency
+	Jitter time.Duration
+}
+
+// Enabled returns true if requests are delayed
+func (l *LatencyInjection) Enabled() bool {
+	return l != nil && (l.Latency > 0 || l.Jitter > 0)
+}
+
+// delay returns the delay of the next request
+func (l *LatencyInjection) delay() time.Duration {
+	delay := l.Latency
+	if l.Jitter > 0 {
+		delay += time.Duration(rand.Int63n(int64(l.Jitter)))
+	}
+	return delay
+}
+
+// decorator returns the decorator delaying every request before it is sent, or nil if the latency injection is
+// disabled. The delay is aborted if the request is cancelled.
+func (l *LatencyInjection) decorator() autorest.SendDecorator {
+	if !l.Enabled() {
+		return nil
+	}
+	return func(s autorest.Sender) autorest.Sender {
+		return autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+			timer := time.NewTimer(l.delay())
+			defer timer.Stop()
+			select {
+			case <-timer.C:
+			case <-r.Context().Done():
+				return nil, r.Context().Err()
+			}
+			return s.Do(r)
+		})
+	}
+}
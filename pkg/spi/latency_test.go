@@ -0,0 +1,61 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package spi
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LatencyInjection", func() {
+	var sent autorest.Sender
+
+	BeforeEach(func() {
+		sent = autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+			return newResponse(r, http.StatusOK, nil), nil
+		})
+	})
+
+	It("should only be enabled with a latency or jitter", func() {
+		var latencyInjection *LatencyInjection
+		Expect(latencyInjection.Enabled()).To(BeFalse())
+		Expect((&LatencyInjection{}).Enabled()).To(BeFalse())
+		Expect((&LatencyInjection{}).decorator()).To(BeNil())
+		Expect((&LatencyInjection{Latency: time.Second}).Enabled()).To(BeTrue())
+		Expect((&LatencyInjection{Jitter: time.Second}).Enabled()).To(BeTrue())
+	})
+
+	It("should delay requests by the latency and at most the jitter", func() {
+		latencyInjection := &LatencyInjection{Latency: 20 * time.Millisecond, Jitter: 10 * time.Millisecond}
+		for i := 0; i < 10; i++ {
+			Expect(latencyInjection.delay()).To(BeNumerically(">=", 20*time.Millisecond))
+			Expect(latencyInjection.delay()).To(BeNumerically("<", 30*time.Millisecond))
+		}
+
+		request, err := http.NewRequest(http.MethodGet, "https://management.azure.com", nil)
+		Expect(err).NotTo(HaveOccurred())
+		start := time.Now()
+		_, err = latencyInjection.decorator()(sent).Do(request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(time.Since(start)).To(BeNumerically(">=", 20*time.Millisecond))
+	})
+
+	It("should abort the delay if the request is cancelled", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		request, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://management.azure.com", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = (&LatencyInjection{Latency: time.Hour}).decorator()(sent).Do(request)
+		Expect(err).To(MatchError(context.Canceled))
+	})
+})
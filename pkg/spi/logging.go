@@ -0,0 +1,156 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package spi
+
+import (
+	"context"
+	"flag"
+	"net/http"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"k8s.io/klog/v2"
+)
+
+// Log keys of the fields identifying the machine and the Azure request of a log line
+const (
+	LogKeyMachine       = "machine"
+	LogKeyMachineClass  = "machineClass"
+	LogKeyResourceGroup = "resourceGroup"
+	LogKeyOperation     = "operation"
+	LogKeyRequestID     = "requestID"
+	LogKeyCorrelationID = "correlationID"
+)
+
+// requestLogLevel is the verbosity of the log lines of the Azure API requests
+const requestLogLevel = klog.Level(3)
+
+type logFieldsKey struct{}
+
+// WithLogFields returns a context carrying the given key-value pairs in addition to the fields of the given context,
+// which are added to all lines logged with it, e.g. the machine and operation of a request. Fields of the given
+// context with the same key are replaced.
+func WithLogFields(ctx context.Context, keysAndValues ...interface{}) context.Context {
+	fields := append([]interface{}{}, logFields(ctx)...)
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		replaced := false
+		for j := 0; j+1 < len(fields); j += 2 {
+			if fields[j] == keysAndValues[i] {
+				fields[j+1], replaced = keysAndValues[i+1], true
+			}
+		}
+		if !replaced {
+			fields = append(fields, keysAndValues[i], keysAndValues[i+1])
+		}
+	}
+	return context.WithValue(ctx, logFieldsKey{}, fields)
+}
+
+// logFields returns the key-value pairs of the context
+func logFields(ctx context.Context) []interface{} {
+	if ctx == nil {
+		return nil
+	}
+	fields, _ := ctx.Value(logFieldsKey{}).([]interface{})
+	return fields
+}
+
+// InfoS logs the message with the fields of the context and the given key-value pairs
+func InfoS(ctx context.Context, msg string, keysAndValues ...interface{}) {
+	klog.InfoSDepth(1, msg, withLogFields(ctx, keysAndValues)...)
+}
+
+// WarningS logs the warning with the fields of the context and the given key-value pairs. klog/v2 has no structured
+// warnings, so warnings are logged as info messages, as recommended by the Kubernetes structured logging migration.
+func WarningS(ctx context.Context, msg string, keysAndValues ...interface{}) {
+	klog.InfoSDepth(1, msg, withLogFields(ctx, keysAndValues)...)
+}
+
+// ErrorS logs the error with the fields of the context and the given key-value pairs. The request and correlation
+// IDs of failed Azure API requests are added, so that the failure can be looked up by the Azure support.
+func ErrorS(ctx context.Context, err error, msg string, keysAndValues ...interface{}) {
+	if detailedErr, ok := err.(autorest.DetailedError); ok && detailedErr.Response != nil {
+		keysAndValues = append(keysAndValues, responseIDs(detailedErr.Response)...)
+	}
+	klog.ErrorSDepth(1, err, msg, withLogFields(ctx, keysAndValues)...)
+}
+
+// Verbose logs structured messages if its verbosity is enabled
+type Verbose struct {
+	enabled bool
+}
+
+// V returns whether the given verbosity is enabled
+func V(level klog.Level) Verbose {
+	return Verbose{enabled: klog.V(level).Enabled()}
+}
+
+// InfoS logs the message with the fields of the context and the given key-value pairs if the verbosity is enabled
+func (v Verbose) InfoS(ctx context.Context, msg string, keysAndValues ...interface{}) {
+	if v.enabled {
+		klog.InfoSDepth(1, msg, withLogFields(ctx, keysAndValues)...)
+	}
+}
+
+// withLogFields returns the fields of the context followed by the given key-value pairs
+func withLogFields(ctx context.Context, keysAndValues []interface{}) []interface{} {
+	return append(append([]interface{}{}, logFields(ctx)...), keysAndValues...)
+}
+
+// klogFlags are the flags of klog/v2. They are not registered at the command line, which carries the flags of klog
+// used by the machine controller manager, but are synchronized with them by SyncLogFlags.
+var klogFlags = func() *flag.FlagSet {
+	flags := flag.NewFlagSet("klog/v2", flag.ContinueOnError)
+	klog.InitFlags(flags)
+	return flags
+}()
+
+// SyncLogFlags applies the parsed klog flags of the command line, e.g. the verbosity, to klog/v2
+func SyncLogFlags() {
+	flag.CommandLine.VisitAll(func(f *flag.Flag) {
+		if v2Flag := klogFlags.Lookup(f.Name); v2Flag != nil {
+			if err := v2Flag.Value.Set(f.Value.String()); err != nil {
+				klog.ErrorS(err, "Flag could not be applied to klog/v2", "flag", f.Name)
+			}
+		}
+	})
+}
+
+// responseIDs returns the key-value pairs of the request and correlation IDs Azure Resource Manager assigned to the
+// response
+func responseIDs(resp *http.Response) []interface{} {
+	var keysAndValues []interface{}
+	if requestID := resp.Header.Get("X-Ms-Request-Id"); requestID != "" {
+		keysAndValues = append(keysAndValues, LogKeyRequestID, requestID)
+	}
+	if correlationID := resp.Header.Get("X-Ms-Correlation-Request-Id"); correlationID != "" {
+		keysAndValues = append(keysAndValues, LogKeyCorrelationID, correlationID)
+	}
+	return keysAndValues
+}
+
+// requestLogDecorator returns the decorator logging the Azure API requests along with the fields of their context and
+// the IDs Azure assigned to them, so that the requests can be correlated with the machine they were sent for
+func requestLogDecorator() autorest.SendDecorator {
+	return func(s autorest.Sender) autorest.Sender {
+		return autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+			if !klog.V(requestLogLevel).Enabled() {
+				return s.Do(r)
+			}
+			start := time.Now()
+			resp, err := s.Do(r)
+			keysAndValues := []interface{}{"method", r.Method, "resourceType", resourceType(r.URL.Path), "duration", time.Since(start)}
+			if err != nil || resp == nil {
+				klog.InfoSDepth(0, "Azure API request failed", withLogFields(r.Context(), append(keysAndValues, "err", err))...)
+				return resp, err
+			}
+			keysAndValues = append(keysAndValues, "statusCode", resp.StatusCode)
+			klog.InfoSDepth(0, "Azure API request", withLogFields(r.Context(), append(keysAndValues, responseIDs(resp)...))...)
+			return resp, err
+		})
+	}
+}
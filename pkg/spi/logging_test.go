@@ -0,0 +1,67 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package spi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/klog/v2"
+)
+
+var _ = Describe("Logging", func() {
+	var output *bytes.Buffer
+
+	BeforeEach(func() {
+		output = &bytes.Buffer{}
+		Expect(klogFlags.Set("logtostderr", "false")).To(Succeed())
+		klog.SetOutput(output)
+	})
+
+	AfterEach(func() {
+		Expect(klogFlags.Set("logtostderr", "true")).To(Succeed())
+	})
+
+	It("should log the message with the fields of the context and the given key-value pairs", func() {
+		ctx := WithLogFields(context.Background(), LogKeyOperation, "CreateMachine", LogKeyMachine, "machine")
+
+		InfoS(ctx, "VM created", "vm", "machine", "duration", time.Second, "attempt", 2)
+		klog.Flush()
+		Expect(output.String()).To(ContainSubstring(`"VM created" operation="CreateMachine" machine="machine" vm="machine" duration="1s" attempt=2`))
+	})
+
+	It("should log the error with the request and correlation IDs of the response", func() {
+		resp := &http.Response{Header: http.Header{}}
+		resp.Header.Set("X-Ms-Request-Id", "request")
+
+		ErrorS(WithLogFields(context.Background(), LogKeyMachine, "machine"), autorest.DetailedError{Original: errors.New("failed"), Response: resp}, "VM creation failed")
+		klog.Flush()
+		Expect(output.String()).To(MatchRegexp(`"VM creation failed" err=".*Original Error: failed" machine="machine" requestID="request"`))
+	})
+
+	It("should replace fields of the context with the same key", func() {
+		ctx := WithLogFields(context.Background(), LogKeyOperation, "GetMachineStatus", LogKeyMachine, "machine")
+		ctx = WithLogFields(ctx, LogKeyOperation, "ListMachines", LogKeyResourceGroup, "rg")
+
+		Expect(logFields(ctx)).To(Equal([]interface{}{LogKeyOperation, "ListMachines", LogKeyMachine, "machine", LogKeyResourceGroup, "rg"}))
+	})
+
+	It("should return the request and correlation IDs of a response", func() {
+		resp := &http.Response{Header: http.Header{}}
+		resp.Header.Set("X-Ms-Request-Id", "request")
+		resp.Header.Set("X-Ms-Correlation-Request-Id", "correlation")
+
+		Expect(responseIDs(resp)).To(Equal([]interface{}{LogKeyRequestID, "request", LogKeyCorrelationID, "correlation"}))
+		Expect(responseIDs(&http.Response{Header: http.Header{}})).To(BeEmpty())
+	})
+})
@@ -0,0 +1,153 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package spi
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-08-01/compute"
+	computeapi "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-08-01/compute/computeapi"
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-04-01/network"
+	networkapi "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-04-01/network/networkapi"
+)
+
+// LookupCache caches the results of the subnet and VM image lookups, which are identical for all machines of a
+// machine class, so that creating many machines does not exhaust the read quota of Azure Resource Manager. Failed
+// lookups are not cached. Concurrent lookups of the same key share a single request.
+type LookupCache struct {
+	ttl time.Duration
+
+	mutex    sync.Mutex
+	entries  map[string]*lookupCacheEntry
+	inFlight map[string]*lookupCall
+}
+
+// lookupCacheEntry is the result of a lookup along with its expiry
+type lookupCacheEntry struct {
+	result interface{}
+	expiry time.Time
+}
+
+// lookupCall is a lookup in flight, done is closed once its result or error is set
+type lookupCall struct {
+	done   chan struct{}
+	result interface{}
+	err    error
+}
+
+// NewLookupCache returns a lookup cache which keeps the results for the given duration
+func NewLookupCache(ttl time.Duration) *LookupCache {
+	return &LookupCache{
+		ttl:      ttl,
+		entries:  map[string]*lookupCacheEntry{},
+		inFlight: map[string]*lookupCall{},
+	}
+}
+
+// Enabled returns true if lookups are cached
+func (c *LookupCache) Enabled() bool {
+	return c != nil && c.ttl > 0
+}
+
+// lookup returns the cached result of the key if it is not expired yet, otherwise it fetches and caches the result.
+// Callers missing the same key at the same time wait for the fetch of the first one and share its result or error.
+func (c *LookupCache) lookup(key string, fetch func() (interface{}, error)) (interface{}, error) {
+	c.mutex.Lock()
+	if entry, ok := c.entries[key]; ok && time.Now().Before(entry.expiry) {
+		c.mutex.Unlock()
+		return entry.result, nil
+	}
+	if call, ok := c.inFlight[key]; ok {
+		c.mutex.Unlock()
+		<-call.done
+		return call.result, call.err
+	}
+	call := &lookupCall{done: make(chan struct{})}
+	c.inFlight[key] = call
+	c.mutex.Unlock()
+
+	call.result, call.err = fetch()
+
+	c.mutex.Lock()
+	delete(c.inFlight, key)
+	if call.err == nil {
+		now := time.Now()
+		for k, entry := range c.entries {
+			if now.After(entry.expiry) {
+				delete(c.entries, k)
+			}
+		}
+		c.entries[key] = &lookupCacheEntry{result: call.result, expiry: now.Add(c.ttl)}
+	}
+	c.mutex.Unlock()
+	close(call.done)
+
+	if call.err != nil {
+		return nil, call.err
+	}
+	return call.result, nil
+}
+
+// lookupCacheKey returns the cache key of a lookup in the given scope, e.g. the credentials of the session
+func lookupCacheKey(scope, kind string, values ...string) string {
+	return scope + "/" + kind + "/" + strings.ToLower(strings.Join(values, "/"))
+}
+
+// subnets returns the subnets client caching the subnet lookups, or the given client if caching is disabled
+func (c *LookupCache) subnets(scope string, client networkapi.SubnetsClientAPI) networkapi.SubnetsClientAPI {
+	if !c.Enabled() {
+		return client
+	}
+	return &cachingSubnetsClient{SubnetsClientAPI: client, cache: c, scope: scope}
+}
+
+// images returns the VM images client caching the image lookups, or the given client if caching is disabled
+func (c *LookupCache) images(scope string, client computeapi.VirtualMachineImagesClientAPI) computeapi.VirtualMachineImagesClientAPI {
+	if !c.Enabled() {
+		return client
+	}
+	return &cachingImagesClient{VirtualMachineImagesClientAPI: client, cache: c, scope: scope}
+}
+
+// cachingSubnetsClient is a subnets client caching the results of Get
+type cachingSubnetsClient struct {
+	networkapi.SubnetsClientAPI
+	cache *LookupCache
+	scope string
+}
+
+// Get is a method of the interface networkapi.SubnetsClientAPI
+func (c *cachingSubnetsClient) Get(ctx context.Context, resourceGroupName string, virtualNetworkName string, subnetName string, expand string) (network.Subnet, error) {
+	result, err := c.cache.lookup(lookupCacheKey(c.scope, "subnet", resourceGroupName, virtualNetworkName, subnetName, expand), func() (interface{}, error) {
+		return c.SubnetsClientAPI.Get(ctx, resourceGroupName, virtualNetworkName, subnetName, expand)
+	})
+	if err != nil {
+		return network.Subnet{}, err
+	}
+	return result.(network.Subnet), nil
+}
+
+// cachingImagesClient is a VM images client caching the results of Get
+type cachingImagesClient struct {
+	computeapi.VirtualMachineImagesClientAPI
+	cache *LookupCache
+	scope string
+}
+
+// Get is a method of the interface computeapi.VirtualMachineImagesClientAPI
+func (c *cachingImagesClient) Get(ctx context.Context, location string, publisherName string, offer string, skus string, version string) (compute.VirtualMachineImage, error) {
+	result, err := c.cache.lookup(lookupCacheKey(c.scope, "image", location, publisherName, offer, skus, version), func() (interface{}, error) {
+		return c.VirtualMachineImagesClientAPI.Get(ctx, location, publisherName, offer, skus, version)
+	})
+	if err != nil {
+		return compute.VirtualMachineImage{}, err
+	}
+	return result.(compute.VirtualMachineImage), nil
+}
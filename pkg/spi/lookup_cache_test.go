@@ -0,0 +1,120 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package spi
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-04-01/network"
+	networkapi "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-04-01/network/networkapi"
+	"github.com/Azure/go-autorest/autorest/to"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// fakeSubnetsClient counts the subnet lookups and fails them if err is set
+type fakeSubnetsClient struct {
+	networkapi.SubnetsClientAPI
+	calls int
+	err   error
+}
+
+func (f *fakeSubnetsClient) Get(ctx context.Context, resourceGroupName string, virtualNetworkName string, subnetName string, expand string) (network.Subnet, error) {
+	f.calls++
+	if f.err != nil {
+		return network.Subnet{}, f.err
+	}
+	return network.Subnet{Name: to.StringPtr(subnetName)}, nil
+}
+
+var _ = Describe("LookupCache", func() {
+	var (
+		ctx    = context.Background()
+		client *fakeSubnetsClient
+	)
+
+	BeforeEach(func() {
+		client = &fakeSubnetsClient{}
+	})
+
+	It("should reuse the results of identical lookups in the same scope", func() {
+		cache := NewLookupCache(time.Hour)
+
+		for i := 0; i < 3; i++ {
+			subnet, err := cache.subnets("scope", client).Get(ctx, "rg", "vnet", "nodes", "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(subnet.Name).To(Equal(to.StringPtr("nodes")))
+		}
+		Expect(client.calls).To(Equal(1))
+
+		_, _ = cache.subnets("scope", client).Get(ctx, "rg", "vnet", "other", "")
+		_, _ = cache.subnets("other-scope", client).Get(ctx, "rg", "vnet", "nodes", "")
+		Expect(client.calls).To(Equal(3))
+	})
+
+	It("should not cache failed or expired lookups", func() {
+		cache := NewLookupCache(time.Nanosecond)
+		_, _ = cache.subnets("scope", client).Get(ctx, "rg", "vnet", "nodes", "")
+		_, _ = cache.subnets("scope", client).Get(ctx, "rg", "vnet", "nodes", "")
+		Expect(client.calls).To(Equal(2))
+
+		cache = NewLookupCache(time.Hour)
+		client.err = fmt.Errorf("throttled")
+		_, err := cache.subnets("scope", client).Get(ctx, "rg", "vnet", "nodes", "")
+		Expect(err).To(MatchError("throttled"))
+		client.err = nil
+		_, err = cache.subnets("scope", client).Get(ctx, "rg", "vnet", "nodes", "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(client.calls).To(Equal(4))
+	})
+
+	It("should return the clients as they are if caching is disabled", func() {
+		var cache *LookupCache
+		Expect(cache.subnets("scope", client)).To(BeIdenticalTo(client))
+	})
+
+	It("should share a single fetch between concurrent lookups of the same key", func() {
+		var (
+			cache   = NewLookupCache(time.Hour)
+			fetches int32
+			started = make(chan struct{})
+			release = make(chan struct{})
+			wg      sync.WaitGroup
+		)
+		fetch := func() (interface{}, error) {
+			if atomic.AddInt32(&fetches, 1) == 1 {
+				close(started)
+			}
+			<-release
+			return "result", nil
+		}
+
+		results := make([]interface{}, 5)
+		wg.Add(len(results))
+		go func() {
+			defer wg.Done()
+			results[0], _ = cache.lookup("key", fetch)
+		}()
+		<-started
+		for i := 1; i < len(results); i++ {
+			go func(i int) {
+				defer wg.Done()
+				results[i], _ = cache.lookup("key", fetch)
+			}(i)
+		}
+		time.Sleep(50 * time.Millisecond)
+		close(release)
+		wg.Wait()
+
+		Expect(atomic.LoadInt32(&fetches)).To(Equal(int32(1)))
+		Expect(results).To(Equal([]interface{}{"result", "result", "result", "result", "result"}))
+	})
+})
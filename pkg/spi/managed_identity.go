@@ -0,0 +1,44 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package spi
+
+import (
+	"fmt"
+
+	"github.com/Azure/go-autorest/autorest/adal"
+	"github.com/Azure/go-autorest/autorest/azure"
+	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+)
+
+// usesManagedIdentity returns true if the provider authenticates with its own managed identity, which is the case if
+// the secret opts in to it explicitly. The managed identity is never used as a fallback for missing client
+// credentials, as this would grant a misconfigured secret the permissions of the provider.
+func usesManagedIdentity(useManagedIdentity, clientID, clientSecret, token, tokenFile string) (bool, error) {
+	hasCredentials := clientID != "" || clientSecret != "" || token != "" || tokenFile != ""
+	switch {
+	case useManagedIdentity != "true" && !hasCredentials:
+		return false, fmt.Errorf("secret contains no client credentials and does not set %s to true", api.AzureUseManagedIdentity)
+	case useManagedIdentity == "true" && hasCredentials:
+		return false, fmt.Errorf("secret sets %s to true but contains client credentials", api.AzureUseManagedIdentity)
+	}
+	return useManagedIdentity == "true", nil
+}
+
+// newManagedIdentityToken returns the token of the managed identity of the provider, which is acquired from the
+// Instance Metadata Service of the node the provider runs on. The user assigned identity with the given client id is
+// used if it is not empty, otherwise the system assigned identity.
+func newManagedIdentityToken(managedIdentityClientID string, env azure.Environment) (*adal.ServicePrincipalToken, error) {
+	msiEndpoint, err := adal.GetMSIEndpoint()
+	if err != nil {
+		return nil, err
+	}
+
+	if managedIdentityClientID != "" {
+		return adal.NewServicePrincipalTokenFromMSIWithUserAssignedID(msiEndpoint, env.TokenAudience, managedIdentityClientID)
+	}
+	return adal.NewServicePrincipalTokenFromMSI(msiEndpoint, env.TokenAudience)
+}
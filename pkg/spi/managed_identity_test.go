@@ -0,0 +1,43 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package spi
+
+import (
+	"github.com/Azure/go-autorest/autorest"
+	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var _ = Describe("ManagedIdentity", func() {
+	It("should use the managed identity only if the secret opts in without client credentials", func() {
+		Expect(usesManagedIdentity("true", "", "", "", "")).To(BeTrue())
+		Expect(usesManagedIdentity("", "client", "secret", "", "")).To(BeFalse())
+		Expect(usesManagedIdentity("", "client", "", "", "/var/run/token")).To(BeFalse())
+	})
+
+	It("should not fall back to the managed identity without client credentials", func() {
+		_, err := usesManagedIdentity("", "", "", "", "")
+		Expect(err).To(HaveOccurred())
+		_, err = usesManagedIdentity("false", "", "", "", "")
+		Expect(err).To(HaveOccurred())
+		_, err = usesManagedIdentity("true", "client", "secret", "", "")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should set up clients authorized by the managed identity", func() {
+		clients, err := (&PluginSPIImpl{}).Setup(&corev1.Secret{Data: map[string][]byte{
+			api.AzureSubscriptionID:          []byte("sub"),
+			api.AzureTenantID:                []byte("tenant"),
+			api.AzureUseManagedIdentity:      []byte("true"),
+			api.AzureManagedIdentityClientID: []byte("identity"),
+		}}, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(clients.GetClient().Authorizer).To(BeAssignableToTypeOf(&autorest.BearerAuthorizer{}))
+	})
+})
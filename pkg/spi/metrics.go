@@ -0,0 +1,224 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package spi
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// longRunningOperationMaxAge is the age after which a long running operation which was not polled to its end is no
+// longer considered in flight, e.g. as its poller timed out
+const longRunningOperationMaxAge = time.Hour
+
+var (
+	// apiRequestDuration is the latency of the Azure API requests
+	apiRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "mcm",
+		Subsystem: "azure",
+		Name:      "api_request_duration_seconds",
+		Help:      "Latency of the Azure API requests per resource type and HTTP method, including the polling of long running operations.",
+		Buckets:   []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60},
+	}, []string{"service", "operation"})
+
+	// apiErrorsCounter is the number of failed Azure API requests per Azure error code
+	apiErrorsCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "mcm",
+		Subsystem: "azure",
+		Name:      "api_errors_total",
+		Help:      "Number of failed Azure API requests per resource type, HTTP method and Azure error code. Requests without response are counted with the code RequestFailed.",
+	}, []string{"service", "operation", "code"})
+
+	// longRunningOperationsGauge is the number of long running operations which are being polled
+	longRunningOperationsGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "mcm",
+		Subsystem: "azure",
+		Name:      "long_running_operations_in_flight",
+		Help:      "Number of accepted long running Azure operations per resource type and HTTP method which did not complete yet.",
+	}, []string{"service", "operation"})
+
+	// longRunningOperationDuration is the duration of the long running operations until their completion
+	longRunningOperationDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "mcm",
+		Subsystem: "azure",
+		Name:      "long_running_operation_duration_seconds",
+		Help:      "Duration of the long running Azure operations per resource type and HTTP method until their completion was polled.",
+		Buckets:   []float64{1, 5, 10, 30, 60, 120, 300, 600, 1200, 1800},
+	}, []string{"service", "operation"})
+)
+
+func init() {
+	prometheus.MustRegister(apiRequestDuration, apiErrorsCounter, longRunningOperationsGauge, longRunningOperationDuration)
+}
+
+// longRunningOperation is an accepted long running operation, which is polled via its polling URL
+type longRunningOperation struct {
+	service   string
+	operation string
+	start     time.Time
+	// asyncOperation is true if the polling URL is the Azure-AsyncOperation URL, which reports the status in its body
+	asyncOperation bool
+}
+
+// longRunningOperations tracks the long running operations in flight by their polling URL
+type longRunningOperations struct {
+	mutex      sync.Mutex
+	operations map[string]longRunningOperation
+}
+
+// lroTracker tracks the long running operations of all Azure clients
+var lroTracker = &longRunningOperations{operations: map[string]longRunningOperation{}}
+
+// start tracks the operation accepted with the response, if the response is the start of a long running operation
+func (l *longRunningOperations) start(r *http.Request, resp *http.Response, service string, now time.Time) {
+	if r.Method == http.MethodGet || (resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusAccepted) {
+		return
+	}
+	operation := longRunningOperation{service: service, operation: r.Method, start: now, asyncOperation: true}
+	pollingURL := resp.Header.Get("Azure-AsyncOperation")
+	if pollingURL == "" {
+		pollingURL, operation.asyncOperation = resp.Header.Get("Location"), false
+	}
+	if pollingURL == "" {
+		return
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	for url, op := range l.operations {
+		if now.Sub(op.start) > longRunningOperationMaxAge {
+			delete(l.operations, url)
+			longRunningOperationsGauge.WithLabelValues(op.service, op.operation).Dec()
+		}
+	}
+	if _, ok := l.operations[pollingURL]; !ok {
+		l.operations[pollingURL] = operation
+		longRunningOperationsGauge.WithLabelValues(operation.service, operation.operation).Inc()
+	}
+}
+
+// poll ends the tracking of the long running operation polled with the request if the response reports its end
+func (l *longRunningOperations) poll(r *http.Request, resp *http.Response, now time.Time) {
+	if r.Method != http.MethodGet {
+		return
+	}
+	pollingURL := r.URL.String()
+
+	l.mutex.Lock()
+	operation, ok := l.operations[pollingURL]
+	l.mutex.Unlock()
+	if !ok || !operationDone(resp, operation.asyncOperation) {
+		return
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if _, ok := l.operations[pollingURL]; ok {
+		delete(l.operations, pollingURL)
+		longRunningOperationsGauge.WithLabelValues(operation.service, operation.operation).Dec()
+		longRunningOperationDuration.WithLabelValues(operation.service, operation.operation).Observe(now.Sub(operation.start).Seconds())
+	}
+}
+
+// inFlight returns the number of tracked long running operations
+func (l *longRunningOperations) inFlight() int {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return len(l.operations)
+}
+
+// operationDone returns true if the polling response reports the end of the long running operation. Azure-AsyncOperation
+// URLs report the status in the body, Location URLs respond with 202 until the operation ends.
+func operationDone(resp *http.Response, asyncOperation bool) bool {
+	if resp.StatusCode >= http.StatusBadRequest {
+		return true
+	}
+	if resp.StatusCode == http.StatusAccepted {
+		return false
+	}
+	if !asyncOperation {
+		return true
+	}
+	var body struct {
+		Status string `json:"status"`
+	}
+	if err := json.Unmarshal(readResponseBody(resp), &body); err != nil {
+		return false
+	}
+	switch strings.ToLower(body.Status) {
+	case "succeeded", "failed", "canceled":
+		return true
+	}
+	return false
+}
+
+// metricsDecorator returns the decorator recording the latency and errors of the Azure API requests and tracking the
+// long running operations they start
+func metricsDecorator() autorest.SendDecorator {
+	return func(s autorest.Sender) autorest.Sender {
+		return autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+			service := resourceType(r.URL.Path)
+			start := time.Now()
+			resp, err := s.Do(r)
+			now := time.Now()
+			apiRequestDuration.WithLabelValues(service, r.Method).Observe(now.Sub(start).Seconds())
+
+			if err != nil || resp == nil {
+				apiErrorsCounter.WithLabelValues(service, r.Method, "RequestFailed").Inc()
+				return resp, err
+			}
+			if resp.StatusCode >= http.StatusBadRequest {
+				apiErrorsCounter.WithLabelValues(service, r.Method, responseErrorCode(resp)).Inc()
+			}
+			lroTracker.start(r, resp, service, now)
+			lroTracker.poll(r, resp, now)
+			return resp, err
+		})
+	}
+}
+
+// resourceType returns the resource type addressed by the path of an Azure Resource Manager request, e.g.
+// Microsoft.Compute/virtualMachines or Microsoft.Network/virtualNetworks/subnets. The names of the resources are
+// omitted to keep the cardinality of the metrics low.
+func resourceType(path string) string {
+	i := strings.LastIndex(strings.ToLower(path), "/providers/")
+	if i < 0 {
+		if strings.Contains(strings.ToLower(path), "/resourcegroups/") {
+			return "resourceGroups"
+		}
+		return "other"
+	}
+
+	segments := strings.Split(strings.Trim(path[i+len("/providers/"):], "/"), "/")
+	types := []string{segments[0]}
+	for j := 1; j < len(segments); j += 2 {
+		types = append(types, segments[j])
+	}
+	return strings.Join(types, "/")
+}
+
+// responseErrorCode returns the Azure error code of the error response, or its status code if the body contains none.
+// The body is restored after it has been read.
+func responseErrorCode(resp *http.Response) string {
+	var body struct {
+		Error struct {
+			Code string `json:"code"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(readResponseBody(resp), &body); err == nil && body.Error.Code != "" {
+		return body.Error.Code
+	}
+	return strconv.Itoa(resp.StatusCode)
+}
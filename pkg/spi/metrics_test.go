@@ -0,0 +1,75 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package spi
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Metrics", func() {
+	DescribeTable("#resourceType",
+		func(path, expected string) {
+			Expect(resourceType(path)).To(Equal(expected))
+		},
+		Entry("VM", "/subscriptions/s/resourceGroups/rg/providers/Microsoft.Compute/virtualMachines/vm", "Microsoft.Compute/virtualMachines"),
+		Entry("VM action", "/subscriptions/s/resourceGroups/rg/providers/Microsoft.Compute/virtualMachines/vm/start", "Microsoft.Compute/virtualMachines/start"),
+		Entry("subnet", "/subscriptions/s/resourceGroups/rg/providers/Microsoft.Network/virtualNetworks/vnet/subnets/nodes", "Microsoft.Network/virtualNetworks/subnets"),
+		Entry("operation", "/subscriptions/s/providers/Microsoft.Compute/locations/westeurope/operations/1234", "Microsoft.Compute/locations/operations"),
+		Entry("resource group", "/subscriptions/s/resourcegroups/rg", "resourceGroups"),
+		Entry("other", "/subscriptions/s", "other"),
+	)
+
+	It("should return the Azure error code of error responses and restore their body", func() {
+		resp := &http.Response{StatusCode: http.StatusConflict, Body: ioutil.NopCloser(strings.NewReader(`{"error":{"code":"QuotaExceeded"}}`))}
+		Expect(responseErrorCode(resp)).To(Equal("QuotaExceeded"))
+		body, _ := ioutil.ReadAll(resp.Body)
+		Expect(string(body)).To(Equal(`{"error":{"code":"QuotaExceeded"}}`))
+
+		Expect(responseErrorCode(&http.Response{StatusCode: http.StatusBadGateway})).To(Equal("502"))
+	})
+
+	It("should track long running operations until their completion is polled", func() {
+		var status string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPut {
+				w.Header().Set("Azure-AsyncOperation", "http://"+r.Host+"/subscriptions/s/providers/Microsoft.Compute/locations/westeurope/operations/1234")
+				w.WriteHeader(http.StatusCreated)
+				return
+			}
+			_, _ = w.Write([]byte(`{"status":"` + status + `"}`))
+		}))
+		defer server.Close()
+
+		sender := newSender(metricsDecorator())
+		send := func(method, path string) {
+			req, err := http.NewRequest(method, server.URL+path, nil)
+			Expect(err).NotTo(HaveOccurred())
+			resp, err := sender.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+			resp.Body.Close()
+		}
+		inFlight := lroTracker.inFlight()
+
+		send(http.MethodPut, "/subscriptions/s/resourceGroups/rg/providers/Microsoft.Compute/virtualMachines/vm")
+		Expect(lroTracker.inFlight()).To(Equal(inFlight + 1))
+
+		status = "InProgress"
+		send(http.MethodGet, "/subscriptions/s/providers/Microsoft.Compute/locations/westeurope/operations/1234")
+		Expect(lroTracker.inFlight()).To(Equal(inFlight + 1))
+
+		status = "Succeeded"
+		send(http.MethodGet, "/subscriptions/s/providers/Microsoft.Compute/locations/westeurope/operations/1234")
+		Expect(lroTracker.inFlight()).To(Equal(inFlight))
+	})
+})
@@ -8,10 +8,15 @@ package resourcesapi
 import (
 	"context"
 
-	"github.com/Azure/azure-sdk-for-go/profiles/latest/resources/mgmt/resources"
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2019-05-01/resources"
 )
 
 // GroupsClientAPI TODO
 type GroupsClientAPI interface {
 	Get(ctx context.Context, resourceGroupName string) (result resources.Group, err error)
 }
+
+// ResourcesClientAPI is the interface of the generic resources client
+type ResourcesClientAPI interface {
+	GetByID(ctx context.Context, resourceID string, APIVersion string) (result resources.GenericResource, err error)
+}
@@ -7,11 +7,12 @@ SPDX-License-Identifier: Apache-2.0
 package spi
 
 import (
+	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
 	corev1 "k8s.io/api/core/v1"
 )
 
 // SessionProviderInterface provides an interface to deal with cloud provider session
 // Example interfaces are listed below.
 type SessionProviderInterface interface {
-	Setup(cloudConfig *corev1.Secret) (AzureDriverClientsInterface, error)
+	Setup(secret *corev1.Secret, cloudConfiguration *api.CloudConfiguration) (AzureDriverClientsInterface, error)
 }
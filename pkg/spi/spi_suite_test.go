@@ -0,0 +1,19 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package spi
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestSPI(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "SPI Suite")
+}
@@ -0,0 +1,137 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package spi
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+)
+
+// throttledErrorCode is the Azure error code of throttled requests
+const throttledErrorCode = "TooManyRequests"
+
+// Throttling configures the retries of the requests of the Azure clients which are throttled by Azure Resource Manager,
+// e.g. by the subscription-wide limit of write requests during large scale-outs.
+type Throttling struct {
+	// MaxRetries is the maximum number of retries of a throttled request
+	MaxRetries int
+	// MinBackoff is the backoff of the first retry if Azure does not send a Retry-After header. It is doubled for
+	// every further retry.
+	MinBackoff time.Duration
+	// MaxBackoff caps the backoff of the retries, including the ones requested by a Retry-After header
+	MaxBackoff time.Duration
+}
+
+// Enabled returns true if throttled requests are retried
+func (t *Throttling) Enabled() bool {
+	return t != nil && t.MaxRetries > 0
+}
+
+// backoff returns the backoff before the given retry of the throttled response. The Retry-After header is honored,
+// otherwise the backoff grows exponentially with a random jitter, so that throttled clients don't retry in lockstep.
+func (t *Throttling) backoff(retry int, resp *http.Response) time.Duration {
+	backoff := t.MinBackoff << uint(retry)
+	if retryAfter, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && retryAfter >= 0 {
+		backoff = time.Duration(retryAfter) * time.Second
+	} else if backoff > 0 {
+		backoff = backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))
+	}
+	if t.MaxBackoff > 0 && (backoff > t.MaxBackoff || backoff < 0) {
+		backoff = t.MaxBackoff
+	}
+	return backoff
+}
+
+// decorator returns the decorator retrying throttled requests, or nil if the retries are disabled. The last throttled
+// response is returned once the retries are exhausted. The backoff is aborted if the request is cancelled.
+func (t *Throttling) decorator() autorest.SendDecorator {
+	if !t.Enabled() {
+		return nil
+	}
+	return func(s autorest.Sender) autorest.Sender {
+		return autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+			rr := autorest.NewRetriableRequest(r)
+			for retry := 0; ; retry++ {
+				if err := rr.Prepare(); err != nil {
+					return nil, err
+				}
+				resp, err := s.Do(rr.Request())
+				if err != nil || retry >= t.MaxRetries || !isThrottledResponse(resp) {
+					return resp, err
+				}
+
+				backoff := t.backoff(retry, resp)
+				V(2).InfoS(r.Context(), "Azure API request was throttled", "method", r.Method, "path", r.URL.Path, "backoff", backoff)
+				_ = autorest.Respond(resp, autorest.ByDiscardingBody(), autorest.ByClosing())
+
+				timer := time.NewTimer(backoff)
+				select {
+				case <-timer.C:
+				case <-r.Context().Done():
+					timer.Stop()
+					return nil, r.Context().Err()
+				}
+			}
+		})
+	}
+}
+
+// isThrottledResponse returns true if the response has the status code 429 or the error code TooManyRequests. The body
+// of error responses is restored after it has been read.
+func isThrottledResponse(resp *http.Response) bool {
+	if resp == nil || resp.StatusCode < http.StatusBadRequest {
+		return false
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	var serviceErr struct {
+		Error azure.ServiceError `json:"error"`
+	}
+	return json.Unmarshal(readResponseBody(resp), &serviceErr) == nil && serviceErr.Error.Code == throttledErrorCode
+}
+
+// readResponseBody returns the body of the response and restores it, so that it can be read again. It returns nil if
+// the response has no body or the body cannot be read.
+func readResponseBody(resp *http.Response) []byte {
+	if resp.Body == nil {
+		return nil
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return nil
+	}
+	return body
+}
+
+// IsThrottled returns true if the error of an Azure API request indicates that it was throttled
+func IsThrottled(err error) bool {
+	detailedErr, ok := err.(autorest.DetailedError)
+	if !ok {
+		return false
+	}
+	if detailedErr.Response != nil && detailedErr.Response.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	switch original := detailedErr.Original.(type) {
+	case *azure.RequestError:
+		return original.ServiceError != nil && original.ServiceError.Code == throttledErrorCode
+	case *azure.ServiceError:
+		return original.Code == throttledErrorCode
+	}
+	return false
+}
@@ -0,0 +1,111 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package spi
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Throttling", func() {
+	var (
+		server    *httptest.Server
+		bodies    []string
+		responses []func(w http.ResponseWriter)
+	)
+
+	BeforeEach(func() {
+		bodies = nil
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := ioutil.ReadAll(r.Body)
+			bodies = append(bodies, string(body))
+			respond := responses[0]
+			responses = responses[1:]
+			respond(w)
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	send := func(throttling *Throttling) (*http.Response, error) {
+		req, err := http.NewRequest(http.MethodPut, server.URL, strings.NewReader(`{"location":"westeurope"}`))
+		Expect(err).NotTo(HaveOccurred())
+		return newSender(throttling.decorator()).Do(req)
+	}
+
+	It("should retry throttled requests with their body", func() {
+		responses = []func(w http.ResponseWriter){
+			func(w http.ResponseWriter) {
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(http.StatusTooManyRequests)
+			},
+			func(w http.ResponseWriter) {
+				w.WriteHeader(http.StatusConflict)
+				_, _ = w.Write([]byte(`{"error":{"code":"TooManyRequests","message":"throttled"}}`))
+			},
+			func(w http.ResponseWriter) { w.WriteHeader(http.StatusOK) },
+		}
+
+		resp, err := send(&Throttling{MaxRetries: 3, MinBackoff: time.Millisecond})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		Expect(bodies).To(Equal([]string{`{"location":"westeurope"}`, `{"location":"westeurope"}`, `{"location":"westeurope"}`}))
+	})
+
+	It("should return the throttled response once the retries are exhausted", func() {
+		responses = []func(w http.ResponseWriter){
+			func(w http.ResponseWriter) { w.WriteHeader(http.StatusTooManyRequests) },
+			func(w http.ResponseWriter) { w.WriteHeader(http.StatusTooManyRequests) },
+		}
+
+		resp, err := send(&Throttling{MaxRetries: 1, MinBackoff: time.Millisecond})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusTooManyRequests))
+		Expect(bodies).To(HaveLen(2))
+	})
+
+	It("should not retry other errors", func() {
+		responses = []func(w http.ResponseWriter){
+			func(w http.ResponseWriter) {
+				w.WriteHeader(http.StatusConflict)
+				_, _ = w.Write([]byte(`{"error":{"code":"Conflict"}}`))
+			},
+		}
+
+		resp, err := send(&Throttling{MaxRetries: 3, MinBackoff: time.Millisecond})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusConflict))
+		body, _ := ioutil.ReadAll(resp.Body)
+		Expect(string(body)).To(Equal(`{"error":{"code":"Conflict"}}`))
+	})
+
+	It("should cap the backoff", func() {
+		throttling := &Throttling{MaxRetries: 10, MinBackoff: time.Second, MaxBackoff: 10 * time.Second}
+		resp := &http.Response{Header: http.Header{}}
+
+		Expect(throttling.backoff(0, resp)).To(BeNumerically("~", 750*time.Millisecond, 250*time.Millisecond))
+		Expect(throttling.backoff(8, resp)).To(Equal(10 * time.Second))
+		resp.Header.Set("Retry-After", "30")
+		Expect(throttling.backoff(0, resp)).To(Equal(10 * time.Second))
+	})
+
+	It("should detect throttled errors", func() {
+		Expect(IsThrottled(autorest.DetailedError{Response: &http.Response{StatusCode: http.StatusTooManyRequests}})).To(BeTrue())
+		Expect(IsThrottled(autorest.DetailedError{Original: &azure.RequestError{ServiceError: &azure.ServiceError{Code: "TooManyRequests"}}})).To(BeTrue())
+		Expect(IsThrottled(autorest.DetailedError{Response: &http.Response{StatusCode: http.StatusConflict}})).To(BeFalse())
+	})
+})
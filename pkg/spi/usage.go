@@ -0,0 +1,51 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package spi
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// machineClassRequestsCounter is the number of Azure API requests attributed to a machine class
+var machineClassRequestsCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "mcm",
+	Subsystem: "azure",
+	Name:      "machine_class_api_requests_total",
+	Help:      "Number of Azure API requests sent on behalf of the machines of a machine class, including the polling of long running operations.",
+}, []string{"machine_class", "method"})
+
+func init() {
+	prometheus.MustRegister(machineClassRequestsCounter)
+}
+
+type machineClassKey struct{}
+
+// WithMachineClass returns a context attributing the Azure API requests sent with it to the machine class. The
+// context is returned unchanged if the machine class is unnamed.
+func WithMachineClass(ctx context.Context, machineClass string) context.Context {
+	if machineClass == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, machineClassKey{}, machineClass)
+}
+
+// usageDecorator returns the decorator counting the requests of the machine class in the request context.
+// Requests without a machine class are not counted.
+func usageDecorator() autorest.SendDecorator {
+	return func(s autorest.Sender) autorest.Sender {
+		return autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+			if machineClass, ok := r.Context().Value(machineClassKey{}).(string); ok {
+				machineClassRequestsCounter.WithLabelValues(machineClass, r.Method).Inc()
+			}
+			return s.Do(r)
+		})
+	}
+}
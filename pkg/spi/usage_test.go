@@ -0,0 +1,57 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package spi
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Azure/go-autorest/autorest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// seriesOf returns the number of series of the collector
+func seriesOf(collector prometheus.Collector) int {
+	metrics := make(chan prometheus.Metric, 1000)
+	collector.Collect(metrics)
+	close(metrics)
+	return len(metrics)
+}
+
+var _ = Describe("Usage", func() {
+	var (
+		sender = autorest.DecorateSender(autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusOK, Request: r}, nil
+		}), usageDecorator())
+		send = func(ctx context.Context, method string) {
+			req, err := http.NewRequest(method, "https://management.azure.com/subscriptions/s", nil)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = sender.Do(req.WithContext(ctx))
+			Expect(err).NotTo(HaveOccurred())
+		}
+	)
+
+	It("should count the requests of the machine class in the context by method", func() {
+		ctx := WithMachineClass(context.Background(), "usage-class")
+		send(ctx, http.MethodGet)
+		send(ctx, http.MethodGet)
+		send(ctx, http.MethodPut)
+
+		Expect(testutil.ToFloat64(machineClassRequestsCounter.WithLabelValues("usage-class", http.MethodGet))).To(Equal(float64(2)))
+		Expect(testutil.ToFloat64(machineClassRequestsCounter.WithLabelValues("usage-class", http.MethodPut))).To(Equal(float64(1)))
+	})
+
+	It("should not count the requests without or with an unnamed machine class", func() {
+		before := seriesOf(machineClassRequestsCounter)
+		send(context.Background(), http.MethodGet)
+		send(WithMachineClass(context.Background(), ""), http.MethodGet)
+		Expect(seriesOf(machineClassRequestsCounter)).To(Equal(before))
+	})
+})
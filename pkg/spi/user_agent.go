@@ -0,0 +1,68 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package spi
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/version"
+)
+
+// guidPattern matches GUIDs like 00000000-0000-0000-0000-000000000000
+var guidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// UserAgent configures the extensions of the User-Agent header of all requests of the Azure clients, which Azure uses
+// to attribute the usage to partners and to triage support requests.
+type UserAgent struct {
+	// Suffix is appended to the User-Agent header as is
+	Suffix string
+	// PartnerID is the GUID of the Microsoft partner the usage is attributed to. It is appended as pid-<GUID>.
+	PartnerID string
+}
+
+// Validate returns an error if the partner ID is not a GUID
+func (u *UserAgent) Validate() error {
+	if u.PartnerID != "" && !guidPattern.MatchString(u.PartnerID) {
+		return fmt.Errorf("partner ID %q is not a GUID", u.PartnerID)
+	}
+	return nil
+}
+
+// extensions returns the extensions of the User-Agent header, or nil if none are configured
+func (u *UserAgent) extensions() []string {
+	if u == nil {
+		return nil
+	}
+	var extensions []string
+	if u.PartnerID != "" {
+		extensions = append(extensions, "pid-"+strings.ToLower(u.PartnerID))
+	}
+	if suffix := strings.TrimSpace(u.Suffix); suffix != "" {
+		extensions = append(extensions, suffix)
+	}
+	return extensions
+}
+
+// apply adds the extensions to the User-Agent header of the given clients
+func (u *UserAgent) apply(clients ...*autorest.Client) {
+	for _, extension := range u.extensions() {
+		for _, client := range clients {
+			_ = client.AddToUserAgent(extension)
+		}
+	}
+}
+
+// addBuildInfo adds the version of the provider to the User-Agent header of the given clients, so that the provider
+// version which created a resource can be told from the Azure activity log
+func addBuildInfo(clients ...*autorest.Client) {
+	for _, client := range clients {
+		_ = client.AddToUserAgent(version.UserAgent())
+	}
+}
@@ -0,0 +1,48 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package spi
+
+import (
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-08-01/compute"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/version"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("UserAgent", func() {
+	It("should append the partner ID and the suffix to the User-Agent header", func() {
+		client := compute.NewVirtualMachinesClient("sub")
+		defaultUserAgent := client.UserAgent
+
+		userAgent := &UserAgent{Suffix: "gardener-seed-1", PartnerID: "0A1B2C3D-0000-1111-2222-333344445555"}
+		Expect(userAgent.Validate()).To(Succeed())
+		userAgent.apply(&client.Client)
+
+		Expect(client.UserAgent).To(Equal(defaultUserAgent + " pid-0a1b2c3d-0000-1111-2222-333344445555 gardener-seed-1"))
+	})
+
+	It("should leave the User-Agent header unchanged if not configured", func() {
+		client := compute.NewVirtualMachinesClient("sub")
+		defaultUserAgent := client.UserAgent
+
+		var userAgent *UserAgent
+		userAgent.apply(&client.Client)
+		Expect(client.UserAgent).To(Equal(defaultUserAgent))
+	})
+
+	It("should append the provider version to the User-Agent header", func() {
+		client := compute.NewVirtualMachinesClient("sub")
+		defaultUserAgent := client.UserAgent
+
+		addBuildInfo(&client.Client)
+		Expect(client.UserAgent).To(Equal(defaultUserAgent + " machine-controller-manager-provider-azure/" + version.Version + "+" + version.GitCommit))
+	})
+
+	It("should reject partner IDs which are not GUIDs", func() {
+		Expect((&UserAgent{PartnerID: "partner"}).Validate()).NotTo(Succeed())
+	})
+})
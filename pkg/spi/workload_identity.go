@@ -0,0 +1,61 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package spi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"strings"
+
+	"github.com/Azure/go-autorest/autorest/adal"
+)
+
+// clientAssertionTypeJWTBearer is the client assertion type of federated identity credentials
+const clientAssertionTypeJWTBearer = "urn:ietf:params:oauth:client-assertion-type:jwt-bearer"
+
+// federatedTokenSecret implements adal.ServicePrincipalSecret for workload identity federation.
+// The projected service account token is exchanged for an AAD token via client assertion.
+// If a token file is configured, it is re-read on every token refresh as the kubelet rotates it.
+type federatedTokenSecret struct {
+	token     string
+	tokenFile string
+}
+
+// SetAuthenticationValues is a method of the interface adal.ServicePrincipalSecret.
+// It will populate the form submitted during oAuth Token Acquisition using the federated token.
+func (s *federatedTokenSecret) SetAuthenticationValues(spt *adal.ServicePrincipalToken, v *url.Values) error {
+	token, err := s.read()
+	if err != nil {
+		return err
+	}
+	v.Set("client_assertion_type", clientAssertionTypeJWTBearer)
+	v.Set("client_assertion", token)
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (s federatedTokenSecret) MarshalJSON() ([]byte, error) {
+	type tokenType struct {
+		Type string `json:"type"`
+	}
+	return json.Marshal(tokenType{
+		Type: "FederatedTokenSecret",
+	})
+}
+
+func (s *federatedTokenSecret) read() (string, error) {
+	if s.tokenFile == "" {
+		return s.token, nil
+	}
+	token, err := ioutil.ReadFile(s.tokenFile)
+	if err != nil {
+		return "", fmt.Errorf("Could not read workload identity token file: %v", err)
+	}
+	return strings.TrimSpace(string(token)), nil
+}
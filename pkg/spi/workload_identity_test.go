@@ -0,0 +1,68 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package spi
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/Azure/go-autorest/autorest"
+	api "github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var _ = Describe("WorkloadIdentity", func() {
+	It("should submit the token of the secret as client assertion", func() {
+		values := url.Values{}
+		Expect((&federatedTokenSecret{token: "jwt"}).SetAuthenticationValues(nil, &values)).To(Succeed())
+		Expect(values.Get("client_assertion_type")).To(Equal(clientAssertionTypeJWTBearer))
+		Expect(values.Get("client_assertion")).To(Equal("jwt"))
+		Expect(values.Get("client_secret")).To(BeEmpty())
+	})
+
+	It("should re-read the token file on every token refresh", func() {
+		dir, err := ioutil.TempDir("", "workload-identity")
+		Expect(err).NotTo(HaveOccurred())
+		defer os.RemoveAll(dir)
+		tokenFile := filepath.Join(dir, "token")
+		secret := &federatedTokenSecret{token: "ignored", tokenFile: tokenFile}
+
+		Expect(ioutil.WriteFile(tokenFile, []byte("first\n"), 0600)).To(Succeed())
+		values := url.Values{}
+		Expect(secret.SetAuthenticationValues(nil, &values)).To(Succeed())
+		Expect(values.Get("client_assertion")).To(Equal("first"))
+
+		Expect(ioutil.WriteFile(tokenFile, []byte("rotated"), 0600)).To(Succeed())
+		Expect(secret.SetAuthenticationValues(nil, &values)).To(Succeed())
+		Expect(values.Get("client_assertion")).To(Equal("rotated"))
+
+		Expect(os.Remove(tokenFile)).To(Succeed())
+		Expect(secret.SetAuthenticationValues(nil, &values)).To(MatchError(ContainSubstring("Could not read workload identity token file")))
+	})
+
+	It("should not marshal the token", func() {
+		data, err := json.Marshal(federatedTokenSecret{token: "jwt"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal(`{"type":"FederatedTokenSecret"}`))
+	})
+
+	It("should set up clients authorized by the workload identity token without client secret", func() {
+		clients, err := (&PluginSPIImpl{}).Setup(&corev1.Secret{Data: map[string][]byte{
+			api.AzureSubscriptionID:        []byte("sub"),
+			api.AzureTenantID:              []byte("tenant"),
+			api.AzureClientID:              []byte("client"),
+			api.AzureWorkloadIdentityToken: []byte("jwt"),
+		}}, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(clients.GetClient().Authorizer).To(BeAssignableToTypeOf(&autorest.BearerAuthorizer{}))
+	})
+})
@@ -0,0 +1,104 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package spot
+
+import (
+	"strconv"
+
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/azure/apis/conversion"
+	"github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
+	"github.com/gardener/machine-controller-manager/pkg/client/clientset/versioned"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// AnnotationSpotPrice is the annotation of machine deployments carrying the spot price per hour in USD
+	AnnotationSpotPrice = "azure.machine.sapcloud.io/spot-price-usd-per-hour"
+	// AnnotationSpotEvictionRate is the annotation of machine deployments carrying the upper bound of the spot eviction rate in percent
+	AnnotationSpotEvictionRate = "azure.machine.sapcloud.io/spot-eviction-rate-percent"
+
+	machineClassKind = "MachineClass"
+)
+
+// Annotator annotates the machine deployments of a namespace with the spot signals of their machine class.
+// It needs permissions to list machineclasses and to list and update machinedeployments in the namespace.
+type Annotator struct {
+	client    versioned.Interface
+	namespace string
+}
+
+// NewAnnotator returns an Annotator for the machine deployments in the given namespace
+func NewAnnotator(client versioned.Interface, namespace string) *Annotator {
+	return &Annotator{client: client, namespace: namespace}
+}
+
+// Annotate sets the spot annotations on all machine deployments whose machine class uses a tracked target
+func (a *Annotator) Annotate(signals map[Target]Signals) error {
+	classes, err := a.client.MachineV1alpha1().MachineClasses(a.namespace).List(metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	targets := map[string]Target{}
+	for _, class := range classes.Items {
+		providerSpec, err := conversion.DecodeProviderSpec(class.ProviderSpec.Raw)
+		if err != nil {
+			continue
+		}
+		targets[class.Name] = newTarget(providerSpec.Properties.HardwareProfile.VMSize, providerSpec.Location)
+	}
+
+	deployments, err := a.client.MachineV1alpha1().MachineDeployments(a.namespace).List(metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	for i := range deployments.Items {
+		deployment := &deployments.Items[i]
+		class := deployment.Spec.Template.Spec.Class
+		if class.Kind != machineClassKind {
+			continue
+		}
+		target, ok := targets[class.Name]
+		if !ok {
+			continue
+		}
+		s, ok := signals[target]
+		if !ok || !setAnnotations(deployment, s) {
+			continue
+		}
+		if _, err := a.client.MachineV1alpha1().MachineDeployments(a.namespace).Update(deployment); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setAnnotations sets the spot annotations of the deployment and returns whether they have changed
+func setAnnotations(deployment *v1alpha1.MachineDeployment, s Signals) bool {
+	annotations := map[string]*float64{
+		AnnotationSpotPrice:        s.PricePerHour,
+		AnnotationSpotEvictionRate: s.EvictionRatePercent,
+	}
+
+	var changed bool
+	for key, value := range annotations {
+		if value == nil {
+			continue
+		}
+		formatted := strconv.FormatFloat(*value, 'f', -1, 64)
+		if deployment.Annotations[key] == formatted {
+			continue
+		}
+		if deployment.Annotations == nil {
+			deployment.Annotations = map[string]string{}
+		}
+		deployment.Annotations[key] = formatted
+		changed = true
+	}
+	return changed
+}
@@ -0,0 +1,89 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package spot
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Azure/go-autorest/autorest"
+)
+
+// resourceGraphAPIVersion is the API version of the Azure Resource Graph used to query the eviction rates
+const resourceGraphAPIVersion = "2021-03-01"
+
+type resourceGraphQuery struct {
+	Query string `json:"query"`
+}
+
+type evictionRates struct {
+	Data []struct {
+		SkuName      string `json:"skuName"`
+		Location     string `json:"location"`
+		EvictionRate string `json:"evictionRate"`
+	} `json:"data"`
+}
+
+// fetchEvictionRate queries the spot eviction rate of the target from the Azure Resource Graph.
+// It returns nil if Azure does not report an eviction rate for the target.
+func fetchEvictionRate(ctx context.Context, client autorest.Client, resourceManagerEndpoint string, target Target) (*float64, error) {
+	query := fmt.Sprintf("SpotResources | where type =~ 'microsoft.compute/skuspotevictionrate/location' | where sku.name =~ %s and location =~ %s | project skuName = tostring(sku.name), location, evictionRate = tostring(properties.evictionRate)", kqlString(target.VMSize), kqlString(target.Region))
+
+	req, err := autorest.Prepare((&http.Request{}).WithContext(ctx),
+		autorest.AsPost(),
+		autorest.AsContentType("application/json; charset=utf-8"),
+		autorest.WithBaseURL(resourceManagerEndpoint),
+		autorest.WithPath("/providers/Microsoft.ResourceGraph/resources"),
+		autorest.WithQueryParameters(map[string]interface{}{"api-version": resourceGraphAPIVersion}),
+		autorest.WithJSON(resourceGraphQuery{Query: query}),
+		client.WithAuthorization())
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Send(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var rates evictionRates
+	if err := autorest.Respond(resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingJSON(&rates),
+		autorest.ByClosing()); err != nil {
+		return nil, err
+	}
+
+	for _, rate := range rates.Data {
+		return parseEvictionRate(rate.EvictionRate)
+	}
+	return nil, nil
+}
+
+// kqlString returns the value as a single-quoted string literal of the Kusto query language
+func kqlString(value string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, "'", `\'`).Replace(value) + "'"
+}
+
+// parseEvictionRate returns the upper bound of an eviction rate range like "0-5" or "10-15".
+// The open range "20+" is mapped to 100.
+func parseEvictionRate(evictionRate string) (*float64, error) {
+	if strings.HasSuffix(evictionRate, "+") {
+		upper := float64(100)
+		return &upper, nil
+	}
+
+	bounds := strings.Split(evictionRate, "-")
+	upper, err := strconv.ParseFloat(strings.TrimSpace(bounds[len(bounds)-1]), 64)
+	if err != nil {
+		return nil, fmt.Errorf("unknown eviction rate %q", evictionRate)
+	}
+	return &upper, nil
+}
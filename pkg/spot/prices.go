@@ -0,0 +1,101 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package spot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// defaultRetailPricesEndpoint is the endpoint of the public Azure retail prices API
+const defaultRetailPricesEndpoint = "https://prices.azure.com/api/retail/prices"
+
+// PriceFetcher fetches the spot price of a VM size in a region
+type PriceFetcher interface {
+	// SpotPrice returns the Linux spot price per hour in USD, or nil if there is no spot offering
+	SpotPrice(ctx context.Context, vmSize, region string) (*float64, error)
+}
+
+type retailPriceFetcher struct {
+	endpoint string
+	client   *http.Client
+}
+
+type retailPrices struct {
+	Items []struct {
+		RetailPrice   float64 `json:"retailPrice"`
+		UnitOfMeasure string  `json:"unitOfMeasure"`
+		SkuName       string  `json:"skuName"`
+		ProductName   string  `json:"productName"`
+	} `json:"Items"`
+	NextPageLink string `json:"NextPageLink"`
+}
+
+// NewRetailPriceFetcher returns a PriceFetcher querying the public Azure retail prices API.
+// An empty endpoint defaults to the public API.
+func NewRetailPriceFetcher(endpoint string, timeout time.Duration) PriceFetcher {
+	if endpoint == "" {
+		endpoint = defaultRetailPricesEndpoint
+	}
+	return &retailPriceFetcher{
+		endpoint: endpoint,
+		client:   &http.Client{Timeout: timeout},
+	}
+}
+
+// SpotPrice returns the Linux spot price per hour in USD, or nil if there is no spot offering
+func (f *retailPriceFetcher) SpotPrice(ctx context.Context, vmSize, region string) (*float64, error) {
+	filter := fmt.Sprintf("serviceName eq 'Virtual Machines' and priceType eq 'Consumption' and armRegionName eq %s and armSkuName eq %s", odataString(region), odataString(vmSize))
+	link := f.endpoint + "?$filter=" + url.QueryEscape(filter)
+
+	for link != "" {
+		prices, err := f.get(ctx, link)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range prices.Items {
+			if strings.HasSuffix(item.SkuName, " Spot") && item.UnitOfMeasure == "1 Hour" && !strings.Contains(item.ProductName, "Windows") {
+				price := item.RetailPrice
+				return &price, nil
+			}
+		}
+		link = prices.NextPageLink
+	}
+	return nil, nil
+}
+
+// odataString returns the value as a single-quoted string literal of an OData filter
+func odataString(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+func (f *retailPriceFetcher) get(ctx context.Context, link string) (*retailPrices, error) {
+	req, err := http.NewRequest(http.MethodGet, link, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := f.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("retail prices API returned status %d", resp.StatusCode)
+	}
+
+	var prices retailPrices
+	if err := json.NewDecoder(resp.Body).Decode(&prices); err != nil {
+		return nil, err
+	}
+	return &prices, nil
+}
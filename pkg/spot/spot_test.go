@@ -0,0 +1,134 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package spot
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Spot", func() {
+
+	Describe("#SpotPrice", func() {
+		It("should return the Linux spot price of the VM size", func() {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				Expect(r.URL.Query().Get("$filter")).To(ContainSubstring("armSkuName eq 'Standard_D2s_v3'"))
+				fmt.Fprint(w, `{"Items":[
+					{"retailPrice":0.1,"unitOfMeasure":"1 Hour","skuName":"D2s v3","productName":"Virtual Machines DSv3 Series"},
+					{"retailPrice":0.03,"unitOfMeasure":"1 Hour","skuName":"D2s v3 Spot","productName":"Virtual Machines DSv3 Series Windows"},
+					{"retailPrice":0.02,"unitOfMeasure":"1 Hour","skuName":"D2s v3 Spot","productName":"Virtual Machines DSv3 Series"}
+				]}`)
+			}))
+			defer server.Close()
+
+			price, err := NewRetailPriceFetcher(server.URL, time.Second).SpotPrice(context.Background(), "Standard_D2s_v3", "westeurope")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(price).NotTo(BeNil())
+			Expect(*price).To(Equal(0.02))
+		})
+	})
+
+	Describe("#SpotPrice filter", func() {
+		It("should escape the quotes of the filter values", func() {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				Expect(r.URL.Query().Get("$filter")).To(ContainSubstring("armSkuName eq 'Standard_D2s_v3'' or ''1'' eq ''1'"))
+				fmt.Fprint(w, `{"Items":[]}`)
+			}))
+			defer server.Close()
+
+			price, err := NewRetailPriceFetcher(server.URL, time.Second).SpotPrice(context.Background(), "Standard_D2s_v3' or '1' eq '1", "westeurope")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(price).To(BeNil())
+		})
+	})
+
+	Describe("#kqlString", func() {
+		It("should escape quotes and backslashes", func() {
+			Expect(kqlString("Standard_D2s_v3")).To(Equal(`'Standard_D2s_v3'`))
+			Expect(kqlString(`x' or '1'=='1`)).To(Equal(`'x\' or \'1\'==\'1'`))
+			Expect(kqlString(`a\`)).To(Equal(`'a\\'`))
+		})
+	})
+
+	Describe("#fetchEvictionRateOf", func() {
+		It("should fall back to the next source if a source fails", func() {
+			failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusForbidden)
+			}))
+			defer failing.Close()
+			succeeding := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, `{"data":[{"skuName":"Standard_D2s_v3","location":"westeurope","evictionRate":"5-10"}]}`)
+			}))
+			defer succeeding.Close()
+
+			sources := []source{
+				{client: autorest.NewClientWithUserAgent(""), resourceManagerEndpoint: failing.URL},
+				{client: autorest.NewClientWithUserAgent(""), resourceManagerEndpoint: succeeding.URL},
+			}
+			rate, err := fetchEvictionRateOf(context.Background(), sources, newTarget("Standard_D2s_v3", "westeurope"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*rate).To(Equal(float64(10)))
+
+			_, err = fetchEvictionRateOf(context.Background(), sources[:1], newTarget("Standard_D2s_v3", "westeurope"))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("#Observe", func() {
+		It("should keep a client per secret", func() {
+			tracker := NewTracker(nil, nil)
+			tracker.Observe("Standard_D2s_v3", "WestEurope", "garden/a", autorest.NewClientWithUserAgent("a"), "https://a")
+			tracker.Observe("Standard_D2s_v3", "westeurope", "garden/b", autorest.NewClientWithUserAgent("b"), "https://b")
+			tracker.Observe("Standard_D2s_v3", "westeurope", "garden/a", autorest.NewClientWithUserAgent("a"), "https://a2")
+
+			sources := tracker.sources[newTarget("Standard_D2s_v3", "westeurope")]
+			Expect(sources).To(HaveLen(2))
+			Expect(sources["garden/a"].resourceManagerEndpoint).To(Equal("https://a2"))
+			Expect(sources["garden/b"].resourceManagerEndpoint).To(Equal("https://b"))
+		})
+	})
+
+	Describe("#parseEvictionRate", func() {
+		It("should return the upper bound of the range", func() {
+			rate, err := parseEvictionRate("5-10")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*rate).To(Equal(float64(10)))
+
+			rate, err = parseEvictionRate("20+")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*rate).To(Equal(float64(100)))
+		})
+
+		It("should fail for unknown eviction rates", func() {
+			_, err := parseEvictionRate("unknown")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("#setAnnotations", func() {
+		It("should only report a change if an annotation differs", func() {
+			var (
+				deployment = &v1alpha1.MachineDeployment{}
+				price      = 0.02
+				signals    = Signals{PricePerHour: &price}
+			)
+
+			Expect(setAnnotations(deployment, signals)).To(BeTrue())
+			Expect(deployment.Annotations).To(HaveKeyWithValue(AnnotationSpotPrice, "0.02"))
+			Expect(deployment.Annotations).NotTo(HaveKey(AnnotationSpotEvictionRate))
+			Expect(setAnnotations(deployment, signals)).To(BeFalse())
+		})
+	})
+})
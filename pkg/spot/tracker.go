@@ -0,0 +1,149 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package spot tracks the price and eviction rate of spot VMs per VM size and region
+package spot
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/gardener/machine-controller-manager-provider-azure/pkg/spi"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	// priceGauge is the spot price of a VM size in a region
+	priceGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "mcm",
+		Subsystem: "azure_spot",
+		Name:      "price_usd_per_hour",
+		Help:      "Retail price of a spot VM per hour in USD.",
+	}, []string{"vm_size", "region"})
+
+	// evictionRateGauge is the upper bound of the spot eviction rate range of a VM size in a region
+	evictionRateGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "mcm",
+		Subsystem: "azure_spot",
+		Name:      "eviction_rate_percent",
+		Help:      "Upper bound of the spot eviction rate range in percent, as reported by Azure. 100 stands for more than 20%.",
+	}, []string{"vm_size", "region"})
+)
+
+func init() {
+	prometheus.MustRegister(priceGauge, evictionRateGauge)
+}
+
+// Target is a VM size in a region whose spot signals are tracked
+type Target struct {
+	VMSize string
+	Region string
+}
+
+// Signals are the spot signals of a target. Missing signals are nil.
+type Signals struct {
+	PricePerHour        *float64
+	EvictionRatePercent *float64
+}
+
+// source is the authenticated client used to query the eviction rates of a target
+type source struct {
+	client                  autorest.Client
+	resourceManagerEndpoint string
+}
+
+// Tracker periodically queries the spot signals of all observed targets and exports them as metrics
+type Tracker struct {
+	prices    PriceFetcher
+	annotator *Annotator
+
+	mutex sync.Mutex
+	// sources are the clients of each target, keyed by the secret they were observed with
+	sources map[Target]map[string]source
+}
+
+// NewTracker returns a Tracker using the given price fetcher. The annotator is optional.
+func NewTracker(prices PriceFetcher, annotator *Annotator) *Tracker {
+	return &Tracker{
+		prices:    prices,
+		annotator: annotator,
+		sources:   map[Target]map[string]source{},
+	}
+}
+
+// Observe registers the VM size and region for tracking. The client of the secret identified by the key is used to
+// query the eviction rates. If a target is observed with several secrets, their clients are tried in turn.
+func (t *Tracker) Observe(vmSize, region, key string, client autorest.Client, resourceManagerEndpoint string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	target := newTarget(vmSize, region)
+	if t.sources[target] == nil {
+		t.sources[target] = map[string]source{}
+	}
+	t.sources[target][key] = source{client: client, resourceManagerEndpoint: resourceManagerEndpoint}
+}
+
+// Sync queries the spot signals of all observed targets, exports them and annotates the machine deployments
+func (t *Tracker) Sync(ctx context.Context) {
+	t.mutex.Lock()
+	sources := make(map[Target][]source, len(t.sources))
+	for target, byKey := range t.sources {
+		keys := make([]string, 0, len(byKey))
+		for key := range byKey {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			sources[target] = append(sources[target], byKey[key])
+		}
+	}
+	t.mutex.Unlock()
+
+	signals := make(map[Target]Signals, len(sources))
+	for target, srcs := range sources {
+		var s Signals
+
+		if price, err := t.prices.SpotPrice(ctx, target.VMSize, target.Region); err != nil {
+			spi.WarningS(ctx, "Could not fetch spot price", "vmSize", target.VMSize, "region", target.Region, "err", err)
+		} else if price != nil {
+			s.PricePerHour = price
+			priceGauge.WithLabelValues(target.VMSize, target.Region).Set(*price)
+		}
+
+		if rate, err := fetchEvictionRateOf(ctx, srcs, target); err != nil {
+			spi.WarningS(ctx, "Could not fetch spot eviction rate", "vmSize", target.VMSize, "region", target.Region, "err", err)
+		} else if rate != nil {
+			s.EvictionRatePercent = rate
+			evictionRateGauge.WithLabelValues(target.VMSize, target.Region).Set(*rate)
+		}
+
+		signals[target] = s
+	}
+
+	if t.annotator != nil {
+		if err := t.annotator.Annotate(signals); err != nil {
+			spi.WarningS(ctx, "Could not annotate machine deployments with spot signals", "err", err)
+		}
+	}
+}
+
+// fetchEvictionRateOf queries the eviction rate of the target with each source until one succeeds
+func fetchEvictionRateOf(ctx context.Context, sources []source, target Target) (rate *float64, err error) {
+	for _, src := range sources {
+		if rate, err = fetchEvictionRate(ctx, src.client, src.resourceManagerEndpoint, target); err == nil {
+			return rate, nil
+		}
+	}
+	return nil, err
+}
+
+func newTarget(vmSize, region string) Target {
+	return Target{VMSize: vmSize, Region: strings.ToLower(region)}
+}
@@ -0,0 +1,163 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package userdata transforms the user data of machines before it is passed to Azure
+package userdata
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/codes"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/status"
+)
+
+const (
+	// MaxSize is the maximum size of the custom data of a VM accepted by Azure
+	MaxSize = 65535
+
+	// Names of the built-in transformers
+	NameVariables  = "variables"
+	NameSecretRefs = "secret-refs"
+	NameGzip       = "gzip"
+	NameSizeGuard  = "size-guard"
+
+	// SecretRefLabel is the label of the secrets which may be referenced by <<SECRET_REF:name/key>> placeholders.
+	// Other secrets, e.g. the cloud credentials, are never embedded into user data.
+	SecretRefLabel = "azure.machine.sapcloud.io/user-data-secret-ref"
+)
+
+// secretRefPattern matches the secret reference placeholders <<SECRET_REF:name/key>>
+var secretRefPattern = regexp.MustCompile(`<<SECRET_REF:([-a-z0-9.]+)/([-._a-zA-Z0-9]+)>>`)
+
+// Machine describes the machine whose user data is transformed
+type Machine struct {
+	Name          string
+	Class         string
+	Location      string
+	ResourceGroup string
+}
+
+// Transformer transforms the user data of a machine
+type Transformer interface {
+	Transform(machine Machine, userData []byte) ([]byte, error)
+}
+
+// TransformerFunc is a function implementing the Transformer interface
+type TransformerFunc func(machine Machine, userData []byte) ([]byte, error)
+
+// Transform calls the function
+func (f TransformerFunc) Transform(machine Machine, userData []byte) ([]byte, error) {
+	return f(machine, userData)
+}
+
+// Chain applies its transformers in order
+type Chain []Transformer
+
+// Transform applies all transformers of the chain to the user data
+func (c Chain) Transform(machine Machine, userData []byte) ([]byte, error) {
+	var err error
+	for _, transformer := range c {
+		if userData, err = transformer.Transform(machine, userData); err != nil {
+			return nil, err
+		}
+	}
+	return userData, nil
+}
+
+// SecretGetter returns the value of a key of a secret. It must only return the values of secrets labelled with
+// SecretRefLabel set to true.
+type SecretGetter func(name, key string) ([]byte, error)
+
+// NewChain returns the chain of the named built-in transformers. The secret getter is only required by the
+// secret-refs transformer.
+func NewChain(names []string, getSecret SecretGetter) (Chain, error) {
+	var chain Chain
+	for _, name := range names {
+		switch strings.TrimSpace(name) {
+		case NameVariables:
+			chain = append(chain, Variables())
+		case NameSecretRefs:
+			if getSecret == nil {
+				return nil, fmt.Errorf("user data transformer %q requires a secret getter", name)
+			}
+			chain = append(chain, SecretRefs(getSecret))
+		case NameGzip:
+			chain = append(chain, Gzip())
+		case NameSizeGuard:
+			chain = append(chain, SizeGuard(MaxSize))
+		default:
+			return nil, fmt.Errorf("unknown user data transformer %q, must be one of %s, %s, %s or %s", name, NameVariables, NameSecretRefs, NameGzip, NameSizeGuard)
+		}
+	}
+	return chain, nil
+}
+
+// Variables replaces the placeholders <<MACHINE_NAME>>, <<MACHINE_CLASS>>, <<LOCATION>> and <<RESOURCE_GROUP>>
+// with the values of the machine
+func Variables() Transformer {
+	return TransformerFunc(func(machine Machine, userData []byte) ([]byte, error) {
+		replacer := strings.NewReplacer(
+			"<<MACHINE_NAME>>", machine.Name,
+			"<<MACHINE_CLASS>>", machine.Class,
+			"<<LOCATION>>", machine.Location,
+			"<<RESOURCE_GROUP>>", machine.ResourceGroup,
+		)
+		return []byte(replacer.Replace(string(userData))), nil
+	})
+}
+
+// SecretRefs replaces the placeholders <<SECRET_REF:name/key>> with the value of the key of the named secret.
+// Unresolvable references are invalid arguments, unless the secret getter reports another code.
+func SecretRefs(getSecret SecretGetter) Transformer {
+	return TransformerFunc(func(_ Machine, userData []byte) ([]byte, error) {
+		var resolveErr error
+		result := secretRefPattern.ReplaceAllFunc(userData, func(match []byte) []byte {
+			groups := secretRefPattern.FindSubmatch(match)
+			value, err := getSecret(string(groups[1]), string(groups[2]))
+			if err != nil && resolveErr == nil {
+				code, message := codes.InvalidArgument, err.Error()
+				if s, ok := err.(*status.Status); ok {
+					code, message = s.Code(), s.Message()
+				}
+				resolveErr = status.Error(code, fmt.Sprintf("could not resolve secret reference %s: %s", match, message))
+			}
+			return value
+		})
+		if resolveErr != nil {
+			return nil, resolveErr
+		}
+		return result, nil
+	})
+}
+
+// Gzip compresses the user data, which cloud-init decompresses transparently
+func Gzip() Transformer {
+	return TransformerFunc(func(_ Machine, userData []byte) ([]byte, error) {
+		var buf bytes.Buffer
+		writer := gzip.NewWriter(&buf)
+		if _, err := writer.Write(userData); err != nil {
+			return nil, err
+		}
+		if err := writer.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	})
+}
+
+// SizeGuard rejects user data larger than the given size in bytes, before Azure rejects the VM
+func SizeGuard(maxSize int) Transformer {
+	return TransformerFunc(func(machine Machine, userData []byte) ([]byte, error) {
+		if len(userData) > maxSize {
+			return nil, fmt.Errorf("user data of machine %q has %d bytes, which exceeds the maximum of %d bytes", machine.Name, len(userData), maxSize)
+		}
+		return userData, nil
+	})
+}
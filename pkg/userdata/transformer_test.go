@@ -0,0 +1,77 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package userdata
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/codes"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/status"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Transformers", func() {
+
+	var (
+		machine = Machine{Name: "machine-0", Class: "class", Location: "westeurope", ResourceGroup: "rg"}
+		secrets = map[string]string{"bootstrap/ca": "certificate"}
+		get     = func(name, key string) ([]byte, error) {
+			if value, ok := secrets[name+"/"+key]; ok {
+				return []byte(value), nil
+			}
+			return nil, fmt.Errorf("not found")
+		}
+	)
+
+	It("should apply the transformers in order", func() {
+		chain, err := NewChain([]string{NameVariables, NameSecretRefs, NameGzip, NameSizeGuard}, get)
+		Expect(err).NotTo(HaveOccurred())
+
+		result, err := chain.Transform(machine, []byte("hostname=<<MACHINE_NAME>> location=<<LOCATION>> ca=<<SECRET_REF:bootstrap/ca>>"))
+		Expect(err).NotTo(HaveOccurred())
+
+		reader, err := gzip.NewReader(bytes.NewReader(result))
+		Expect(err).NotTo(HaveOccurred())
+		decompressed, err := ioutil.ReadAll(reader)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(decompressed)).To(Equal("hostname=machine-0 location=westeurope ca=certificate"))
+	})
+
+	It("should fail for unresolvable secret references", func() {
+		_, err := SecretRefs(get).Transform(machine, []byte("<<SECRET_REF:bootstrap/missing>>"))
+		Expect(err).To(MatchError(ContainSubstring("<<SECRET_REF:bootstrap/missing>>")))
+		Expect(err.(*status.Status).Code()).To(Equal(codes.InvalidArgument))
+	})
+
+	It("should keep the code reported by the secret getter", func() {
+		_, err := SecretRefs(func(name, key string) ([]byte, error) {
+			return nil, status.Error(codes.Unavailable, "timeout")
+		}).Transform(machine, []byte("<<SECRET_REF:bootstrap/ca>>"))
+		Expect(err.(*status.Status).Code()).To(Equal(codes.Unavailable))
+		Expect(err).To(MatchError(ContainSubstring("timeout")))
+	})
+
+	It("should reject user data exceeding the maximum size", func() {
+		_, err := SizeGuard(4).Transform(machine, []byte("12345"))
+		Expect(err).To(HaveOccurred())
+
+		result, err := SizeGuard(4).Transform(machine, []byte("1234"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal([]byte("1234")))
+	})
+
+	It("should reject unknown transformers and missing secret getters", func() {
+		_, err := NewChain([]string{"base64"}, nil)
+		Expect(err).To(HaveOccurred())
+		_, err = NewChain([]string{NameSecretRefs}, nil)
+		Expect(err).To(HaveOccurred())
+	})
+})
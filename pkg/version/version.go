@@ -0,0 +1,45 @@
+/*
+SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package version provides the build metadata of the provider, which is set at build time via
+// -ldflags "-X github.com/gardener/machine-controller-manager-provider-azure/pkg/version.Version=<version>
+// -X github.com/gardener/machine-controller-manager-provider-azure/pkg/version.GitCommit=<commit>"
+package version
+
+import (
+	"runtime"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// product is the name of the provider in the User-Agent header of the Azure API requests
+const product = "machine-controller-manager-provider-azure"
+
+var (
+	// Version is the version of the provider
+	Version = "unknown"
+	// GitCommit is the commit the provider was built from
+	GitCommit = "unknown"
+)
+
+// buildInfo exposes the build metadata of the provider as labels of a constant metric
+var buildInfo = prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+	Namespace:   "mcm",
+	Subsystem:   "azure",
+	Name:        "build_info",
+	Help:        "Build metadata of the provider. The value is always 1.",
+	ConstLabels: prometheus.Labels{"version": Version, "git_commit": GitCommit, "go_version": runtime.Version()},
+}, func() float64 { return 1 })
+
+func init() {
+	prometheus.MustRegister(buildInfo)
+}
+
+// UserAgent returns the product token of the provider for the User-Agent header of the Azure API requests, which
+// identifies the provider version creating a resource in the Azure activity log
+func UserAgent() string {
+	return product + "/" + Version + "+" + GitCommit
+}